@@ -0,0 +1,25 @@
+module github.com/aliyun/alibabacloud-oss-go-sdk-v2/contrib/ossotel
+
+go 1.21
+
+replace github.com/aliyun/alibabacloud-oss-go-sdk-v2 => ../../
+
+require (
+	github.com/aliyun/alibabacloud-oss-go-sdk-v2 v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/time v0.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,106 @@
+// Package ossotel adapts OpenTelemetry tracing to the oss.Tracer interface.
+package ossotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName The name of the instrumentation library
+const InstrumentationName = "github.com/aliyun/alibabacloud-oss-go-sdk-v2/contrib/ossotel"
+
+type Options struct {
+	// The tracer provider to create tracers with. Defaults to the global tracer provider if nil.
+	TracerProvider trace.TracerProvider
+
+	// The propagator to inject the trace context into http headers.
+	// Defaults to the W3C trace context propagator if nil.
+	Propagator propagation.TextMapPropagator
+}
+
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer creates a oss.Tracer which creates spans by OpenTelemetry.
+func NewTracer(optFns ...func(*Options)) oss.Tracer {
+	options := Options{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if options.Propagator == nil {
+		options.Propagator = propagation.TraceContext{}
+	}
+
+	return &tracer{
+		tracer:     options.TracerProvider.Tracer(InstrumentationName),
+		propagator: options.Propagator,
+	}
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attrs ...oss.SpanAttribute) (context.Context, oss.Span) {
+	ctx, s := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(toAttributes(attrs)...),
+	)
+	return ctx, &span{span: s}
+}
+
+func (t *tracer) Inject(ctx context.Context, header http.Header) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttributes(attrs ...oss.SpanAttribute) {
+	s.span.SetAttributes(toAttributes(attrs)...)
+}
+
+func (s *span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.span.End()
+}
+
+func toAttributes(attrs []oss.SpanAttribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		switch v := attr.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(attr.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(attr.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(attr.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(attr.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(attr.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(attr.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
+
+var _ oss.Tracer = (*tracer)(nil)
+var _ oss.Span = (*span)(nil)
@@ -0,0 +1,82 @@
+package ossotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func attrsOf(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range s.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestTracer(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("x-oss-request-id", "id-1234")
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(404)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<Error>
+				<Code>NoSuchKey</Code>
+				<Message>The specified key does not exist.</Message>
+			</Error>`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	cfg := oss.LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithTracer(NewTracer(func(o *Options) {
+			o.TracerProvider = provider
+		}))
+
+	client := oss.NewClient(cfg)
+	_, err := client.GetObject(context.TODO(), &oss.GetObjectRequest{
+		Bucket: oss.Ptr("bucket"),
+		Key:    oss.Ptr("key"),
+	})
+	assert.NotNil(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+
+	attempt, op := spans[0], spans[1]
+	assert.Equal(t, "oss.GetObject", op.Name())
+	assert.Equal(t, trace.SpanKindClient, op.SpanKind())
+	assert.Equal(t, codes.Error, op.Status().Code)
+	opAttrs := attrsOf(op)
+	assert.Equal(t, "GetObject", opAttrs[oss.SpanAttrRPCMethod].AsString())
+	assert.Equal(t, "bucket", opAttrs[oss.SpanAttrBucket].AsString())
+	assert.Equal(t, "key", opAttrs[oss.SpanAttrKey].AsString())
+	assert.Equal(t, int64(404), opAttrs[oss.SpanAttrHTTPStatusCode].AsInt64())
+	assert.Equal(t, "id-1234", opAttrs[oss.SpanAttrRequestID].AsString())
+	assert.Equal(t, "NoSuchKey", opAttrs[oss.SpanAttrErrorCode].AsString())
+
+	assert.Equal(t, "GET", attempt.Name())
+	assert.Equal(t, op.SpanContext().SpanID(), attempt.Parent().SpanID())
+	assert.Equal(t, op.SpanContext().TraceID(), attempt.SpanContext().TraceID())
+	assert.Equal(t, int64(1), attrsOf(attempt)[oss.SpanAttrAttempt].AsInt64())
+
+	// W3C trace context of the attempt span
+	assert.Equal(t, "00-"+attempt.SpanContext().TraceID().String()+"-"+attempt.SpanContext().SpanID().String()+"-01", traceparent)
+}
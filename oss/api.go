@@ -0,0 +1,534 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package oss
+
+import (
+	"context"
+)
+
+// API lists the operations of Client, so that callers can depend on an interface,
+// decorate it or replace it with a mock in tests.
+type API interface {
+	// AbortBucketWorm Deletes an unlocked retention policy for a bucket.
+	AbortBucketWorm(ctx context.Context, request *AbortBucketWormRequest, optFns ...func(*Options)) (*AbortBucketWormResult, error)
+
+	// AbortMultipartUpload Cancels a multipart upload task and deletes the parts uploaded in the task.
+	AbortMultipartUpload(ctx context.Context, request *AbortMultipartUploadRequest, optFns ...func(*Options)) (*AbortMultipartUploadResult, error)
+
+	// AppendFile opens or creates the named file for appending.
+	AppendFile(ctx context.Context, bucket string, key string, optFns ...func(*AppendOptions)) (*AppendOnlyFile, error)
+
+	// AppendObject Uploads an object by appending the object to an existing object.
+	// Objects created by using the AppendObject operation are appendable objects.
+	AppendObject(ctx context.Context, request *AppendObjectRequest, optFns ...func(*Options)) (*AppendObjectResult, error)
+
+	// AsyncProcessObject apply async process on the specified image file.
+	AsyncProcessObject(ctx context.Context, request *AsyncProcessObjectRequest, optFns ...func(*Options)) (*AsyncProcessObjectResult, error)
+
+	// CleanRestoredObject You can call this operation to clean an object restored from Archive or Cold Archive state. After that, the restored object returns to the frozen state.
+	CleanRestoredObject(ctx context.Context, request *CleanRestoredObjectRequest, optFns ...func(*Options)) (*CleanRestoredObjectResult, error)
+
+	// CloseMetaQuery Disables the metadata management feature for an Object Storage Service (OSS) bucket. After the metadata management feature is disabled for a bucket, OSS automatically deletes the metadata index library of the bucket and you cannot perform metadata indexing.
+	CloseMetaQuery(ctx context.Context, request *CloseMetaQueryRequest, optFns ...func(*Options)) (*CloseMetaQueryResult, error)
+
+	// CompleteBucketWorm Locks a retention policy.
+	CompleteBucketWorm(ctx context.Context, request *CompleteBucketWormRequest, optFns ...func(*Options)) (*CompleteBucketWormResult, error)
+
+	// CompleteMultipartUpload Completes the multipart upload task of an object after all parts of the object are uploaded.
+	CompleteMultipartUpload(ctx context.Context, request *CompleteMultipartUploadRequest, optFns ...func(*Options)) (*CompleteMultipartUploadResult, error)
+
+	// CopyObject Copies objects within a bucket or between buckets in the same region
+	CopyObject(ctx context.Context, request *CopyObjectRequest, optFns ...func(*Options)) (*CopyObjectResult, error)
+
+	// CreateAccessPoint Creates an access point.
+	CreateAccessPoint(ctx context.Context, request *CreateAccessPointRequest, optFns ...func(*Options)) (*CreateAccessPointResult, error)
+
+	// CreateAccessPointForObjectProcess Creates an Object FC Access Point.
+	CreateAccessPointForObjectProcess(ctx context.Context, request *CreateAccessPointForObjectProcessRequest, optFns ...func(*Options)) (*CreateAccessPointForObjectProcessResult, error)
+
+	// CreateBucketDataRedundancyTransition Creates a redundancy type conversion task for a bucket.
+	CreateBucketDataRedundancyTransition(ctx context.Context, request *CreateBucketDataRedundancyTransitionRequest, optFns ...func(*Options)) (*CreateBucketDataRedundancyTransitionResult, error)
+
+	// CreateCnameToken Creates a CNAME token to verify the ownership of a domain name.
+	CreateCnameToken(ctx context.Context, request *CreateCnameTokenRequest, optFns ...func(*Options)) (*CreateCnameTokenResult, error)
+
+	// CreateSelectObjectMeta You can call the CreateSelectObjectMeta operation to obtain information about an object, such as the total number of rows and the number of splits.
+	CreateSelectObjectMeta(ctx context.Context, request *CreateSelectObjectMetaRequest, optFns ...func(*Options)) (*CreateSelectObjectMetaResult, error)
+
+	// DeleteAccessPoint Deletes an access point.
+	DeleteAccessPoint(ctx context.Context, request *DeleteAccessPointRequest, optFns ...func(*Options)) (*DeleteAccessPointResult, error)
+
+	// DeleteAccessPointForObjectProcess Deletes an Object FC Access Point.
+	DeleteAccessPointForObjectProcess(ctx context.Context, request *DeleteAccessPointForObjectProcessRequest, optFns ...func(*Options)) (*DeleteAccessPointForObjectProcessResult, error)
+
+	// DeleteAccessPointPolicy Deletes an access point policy.
+	DeleteAccessPointPolicy(ctx context.Context, request *DeleteAccessPointPolicyRequest, optFns ...func(*Options)) (*DeleteAccessPointPolicyResult, error)
+
+	// DeleteAccessPointPolicyForObjectProcess Deletes the policies of an Object FC Access Point.
+	DeleteAccessPointPolicyForObjectProcess(ctx context.Context, request *DeleteAccessPointPolicyForObjectProcessRequest, optFns ...func(*Options)) (*DeleteAccessPointPolicyForObjectProcessResult, error)
+
+	// DeleteAccessPointPublicAccessBlock Deletes the Block Public Access configurations of an access point.
+	DeleteAccessPointPublicAccessBlock(ctx context.Context, request *DeleteAccessPointPublicAccessBlockRequest, optFns ...func(*Options)) (*DeleteAccessPointPublicAccessBlockResult, error)
+
+	// DeleteBucket Deletes a bucket.
+	DeleteBucket(ctx context.Context, request *DeleteBucketRequest, optFns ...func(*Options)) (*DeleteBucketResult, error)
+
+	// DeleteBucketCors Disables the cross-origin resource sharing (CORS) feature and deletes all CORS rules for a bucket.
+	DeleteBucketCors(ctx context.Context, request *DeleteBucketCorsRequest, optFns ...func(*Options)) (*DeleteBucketCorsResult, error)
+
+	// DeleteBucketDataRedundancyTransition Deletes a redundancy type conversion task of a bucket.
+	DeleteBucketDataRedundancyTransition(ctx context.Context, request *DeleteBucketDataRedundancyTransitionRequest, optFns ...func(*Options)) (*DeleteBucketDataRedundancyTransitionResult, error)
+
+	// DeleteBucketEncryption Deletes encryption rules for a bucket.
+	DeleteBucketEncryption(ctx context.Context, request *DeleteBucketEncryptionRequest, optFns ...func(*Options)) (*DeleteBucketEncryptionResult, error)
+
+	// DeleteBucketInventory Deletes an inventory for a bucket.
+	DeleteBucketInventory(ctx context.Context, request *DeleteBucketInventoryRequest, optFns ...func(*Options)) (*DeleteBucketInventoryResult, error)
+
+	// DeleteBucketLifecycle Deletes the lifecycle rules of a bucket.
+	DeleteBucketLifecycle(ctx context.Context, request *DeleteBucketLifecycleRequest, optFns ...func(*Options)) (*DeleteBucketLifecycleResult, error)
+
+	// DeleteBucketLogging Disables the logging feature for a bucket.
+	DeleteBucketLogging(ctx context.Context, request *DeleteBucketLoggingRequest, optFns ...func(*Options)) (*DeleteBucketLoggingResult, error)
+
+	// DeleteBucketOverwriteConfig Delete overwrite configuration rule for the bucket.
+	DeleteBucketOverwriteConfig(ctx context.Context, request *DeleteBucketOverwriteConfigRequest, optFns ...func(*Options)) (*DeleteBucketOverwriteConfigResult, error)
+
+	// DeleteBucketPolicy Deletes a policy for a bucket.
+	DeleteBucketPolicy(ctx context.Context, request *DeleteBucketPolicyRequest, optFns ...func(*Options)) (*DeleteBucketPolicyResult, error)
+
+	// DeleteBucketPublicAccessBlock Deletes the Block Public Access configurations of a bucket.
+	DeleteBucketPublicAccessBlock(ctx context.Context, request *DeleteBucketPublicAccessBlockRequest, optFns ...func(*Options)) (*DeleteBucketPublicAccessBlockResult, error)
+
+	// DeleteBucketReplication Disables data replication for a bucket and deletes the data replication rule configured for the bucket. After you call this operation, all operations performed on the source bucket are not synchronized to the destination bucket.
+	DeleteBucketReplication(ctx context.Context, request *DeleteBucketReplicationRequest, optFns ...func(*Options)) (*DeleteBucketReplicationResult, error)
+
+	// DeleteBucketTags Deletes tags configured for a bucket.
+	DeleteBucketTags(ctx context.Context, request *DeleteBucketTagsRequest, optFns ...func(*Options)) (*DeleteBucketTagsResult, error)
+
+	// DeleteBucketWebsite Disables the static website hosting mode and deletes the redirection rules for a bucket.
+	DeleteBucketWebsite(ctx context.Context, request *DeleteBucketWebsiteRequest, optFns ...func(*Options)) (*DeleteBucketWebsiteResult, error)
+
+	// DeleteCname Deletes a CNAME record that is mapped to a bucket.
+	DeleteCname(ctx context.Context, request *DeleteCnameRequest, optFns ...func(*Options)) (*DeleteCnameResult, error)
+
+	// DeleteMultipleObjects Deletes multiple objects from a bucket.
+	DeleteMultipleObjects(ctx context.Context, request *DeleteMultipleObjectsRequest, optFns ...func(*Options)) (*DeleteMultipleObjectsResult, error)
+
+	// DeleteObject Deletes an object.
+	DeleteObject(ctx context.Context, request *DeleteObjectRequest, optFns ...func(*Options)) (*DeleteObjectResult, error)
+
+	// DeleteObjectTagging You can call this operation to delete the tags of a specified object.
+	DeleteObjectTagging(ctx context.Context, request *DeleteObjectTaggingRequest, optFns ...func(*Options)) (*DeleteObjectTaggingResult, error)
+
+	// DeletePublicAccessBlock Deletes the Block Public Access configurations of OSS resources.
+	DeletePublicAccessBlock(ctx context.Context, request *DeletePublicAccessBlockRequest, optFns ...func(*Options)) (*DeletePublicAccessBlockResult, error)
+
+	// DeleteStyle Deletes an image style from a bucket.
+	DeleteStyle(ctx context.Context, request *DeleteStyleRequest, optFns ...func(*Options)) (*DeleteStyleResult, error)
+
+	// DeleteUserDefinedLogFieldsConfig Deletes the custom configurations of the user_defined_log_fields field in the real-time logs of a bucket.
+	DeleteUserDefinedLogFieldsConfig(ctx context.Context, request *DeleteUserDefinedLogFieldsConfigRequest, optFns ...func(*Options)) (*DeleteUserDefinedLogFieldsConfigResult, error)
+
+	// DescribeRegions Queries the endpoints of all supported regions or the endpoints of a specific region.
+	DescribeRegions(ctx context.Context, request *DescribeRegionsRequest, optFns ...func(*Options)) (*DescribeRegionsResult, error)
+
+	// DoDataPipeLineAction data pipe line related api.
+	DoDataPipeLineAction(ctx context.Context, request *DoDataPipeLineActionRequest, optFns ...func(*Options)) (*DoDataPipeLineActionResult, error)
+
+	// DoMetaQuery Queries the objects in a bucket that meet the specified conditions by using the data indexing feature. The information about the objects is listed based on the specified fields and sorting methods.
+	DoMetaQuery(ctx context.Context, request *DoMetaQueryRequest, optFns ...func(*Options)) (*DoMetaQueryResult, error)
+
+	// DoMetaQueryAction meta query related api.
+	DoMetaQueryAction(ctx context.Context, request *DoMetaQueryActionRequest, optFns ...func(*Options)) (*DoMetaQueryActionResult, error)
+
+	// ExtendBucketWorm Extends the retention period of objects in a bucket for which a retention policy is locked.
+	ExtendBucketWorm(ctx context.Context, request *ExtendBucketWormRequest, optFns ...func(*Options)) (*ExtendBucketWormResult, error)
+
+	// GetAccessPoint Queries the information about an access point.
+	GetAccessPoint(ctx context.Context, request *GetAccessPointRequest, optFns ...func(*Options)) (*GetAccessPointResult, error)
+
+	// GetAccessPointConfigForObjectProcess Queries the configurations of an Object FC Access Point.
+	GetAccessPointConfigForObjectProcess(ctx context.Context, request *GetAccessPointConfigForObjectProcessRequest, optFns ...func(*Options)) (*GetAccessPointConfigForObjectProcessResult, error)
+
+	// GetAccessPointForObjectProcess Queries basic information about an Object FC Access Point.
+	GetAccessPointForObjectProcess(ctx context.Context, request *GetAccessPointForObjectProcessRequest, optFns ...func(*Options)) (*GetAccessPointForObjectProcessResult, error)
+
+	// GetAccessPointPolicy Queries the configurations of an access point policy.
+	GetAccessPointPolicy(ctx context.Context, request *GetAccessPointPolicyRequest, optFns ...func(*Options)) (*GetAccessPointPolicyResult, error)
+
+	// GetAccessPointPolicyForObjectProcess Queries the policies of an Object FC Access Point.
+	GetAccessPointPolicyForObjectProcess(ctx context.Context, request *GetAccessPointPolicyForObjectProcessRequest, optFns ...func(*Options)) (*GetAccessPointPolicyForObjectProcessResult, error)
+
+	// GetAccessPointPublicAccessBlock Queries the Block Public Access configurations of an access point.
+	GetAccessPointPublicAccessBlock(ctx context.Context, request *GetAccessPointPublicAccessBlockRequest, optFns ...func(*Options)) (*GetAccessPointPublicAccessBlockResult, error)
+
+	// GetBucketAccessMonitor Queries the access tracking status of a bucket.
+	GetBucketAccessMonitor(ctx context.Context, request *GetBucketAccessMonitorRequest, optFns ...func(*Options)) (*GetBucketAccessMonitorResult, error)
+
+	// GetBucketAcl You can call this operation to query the ACL of a bucket.
+	GetBucketAcl(ctx context.Context, request *GetBucketAclRequest, optFns ...func(*Options)) (*GetBucketAclResult, error)
+
+	// GetBucketArchiveDirectRead Queries whether real-time access of Archive objects is enabled for a bucket.
+	GetBucketArchiveDirectRead(ctx context.Context, request *GetBucketArchiveDirectReadRequest, optFns ...func(*Options)) (*GetBucketArchiveDirectReadResult, error)
+
+	// GetBucketCors Queries the cross-origin resource sharing (CORS) rules that are configured for a bucket.
+	GetBucketCors(ctx context.Context, request *GetBucketCorsRequest, optFns ...func(*Options)) (*GetBucketCorsResult, error)
+
+	// GetBucketDataRedundancyTransition Queries the redundancy type conversion tasks of a bucket.
+	GetBucketDataRedundancyTransition(ctx context.Context, request *GetBucketDataRedundancyTransitionRequest, optFns ...func(*Options)) (*GetBucketDataRedundancyTransitionResult, error)
+
+	// GetBucketEncryption Queries the encryption rules configured for a bucket.
+	GetBucketEncryption(ctx context.Context, request *GetBucketEncryptionRequest, optFns ...func(*Options)) (*GetBucketEncryptionResult, error)
+
+	// GetBucketHttpsConfig Queries the Transport Layer Security (TLS) version configurations of a bucket.
+	GetBucketHttpsConfig(ctx context.Context, request *GetBucketHttpsConfigRequest, optFns ...func(*Options)) (*GetBucketHttpsConfigResult, error)
+
+	// GetBucketInfo Queries information about a bucket.
+	GetBucketInfo(ctx context.Context, request *GetBucketInfoRequest, optFns ...func(*Options)) (*GetBucketInfoResult, error)
+
+	// GetBucketInventory Queries the inventories that are configured for a bucket.
+	GetBucketInventory(ctx context.Context, request *GetBucketInventoryRequest, optFns ...func(*Options)) (*GetBucketInventoryResult, error)
+
+	// GetBucketLifecycle Queries the lifecycle rules configured for a bucket. Only the owner of a bucket has the permissions to query the lifecycle rules configured for the bucket.
+	GetBucketLifecycle(ctx context.Context, request *GetBucketLifecycleRequest, optFns ...func(*Options)) (*GetBucketLifecycleResult, error)
+
+	// GetBucketLocation Queries the region of an Object Storage Service (OSS) bucket.
+	GetBucketLocation(ctx context.Context, request *GetBucketLocationRequest, optFns ...func(*Options)) (*GetBucketLocationResult, error)
+
+	// GetBucketLogging Queries the configurations of access log collection of a bucket. Only the owner of a bucket can query the configurations of access log collection of the bucket.
+	GetBucketLogging(ctx context.Context, request *GetBucketLoggingRequest, optFns ...func(*Options)) (*GetBucketLoggingResult, error)
+
+	// GetBucketObjectWormConfiguration Queries the object-level retention policy of a bucket.
+	GetBucketObjectWormConfiguration(ctx context.Context, request *GetBucketObjectWormConfigurationRequest, optFns ...func(*Options)) (*GetBucketObjectWormConfigurationResult, error)
+
+	// GetBucketOverwriteConfig Call the GetBucketOverwriteConfig operation to retrieve the overwrite configuration of a bucket.
+	GetBucketOverwriteConfig(ctx context.Context, request *GetBucketOverwriteConfigRequest, optFns ...func(*Options)) (*GetBucketOverwriteConfigResult, error)
+
+	// GetBucketPolicy Queries the policies configured for a bucket.
+	GetBucketPolicy(ctx context.Context, request *GetBucketPolicyRequest, optFns ...func(*Options)) (*GetBucketPolicyResult, error)
+
+	// GetBucketPolicyStatus Checks whether the current bucket policy allows public access.
+	GetBucketPolicyStatus(ctx context.Context, request *GetBucketPolicyStatusRequest, optFns ...func(*Options)) (*GetBucketPolicyStatusResult, error)
+
+	// GetBucketPublicAccessBlock Queries the Block Public Access configurations of a bucket.
+	GetBucketPublicAccessBlock(ctx context.Context, request *GetBucketPublicAccessBlockRequest, optFns ...func(*Options)) (*GetBucketPublicAccessBlockResult, error)
+
+	// GetBucketReferer Queries the hotlink protection configurations for a bucket.
+	GetBucketReferer(ctx context.Context, request *GetBucketRefererRequest, optFns ...func(*Options)) (*GetBucketRefererResult, error)
+
+	// GetBucketReplication Queries the data replication rules configured for a bucket.
+	GetBucketReplication(ctx context.Context, request *GetBucketReplicationRequest, optFns ...func(*Options)) (*GetBucketReplicationResult, error)
+
+	// GetBucketReplicationLocation Queries the regions in which available destination buckets reside. You can determine the region of the destination bucket to which the data in the source bucket are replicated based on the returned response.
+	GetBucketReplicationLocation(ctx context.Context, request *GetBucketReplicationLocationRequest, optFns ...func(*Options)) (*GetBucketReplicationLocationResult, error)
+
+	// GetBucketReplicationProgress Queries the information about the data replication process of a bucket.
+	GetBucketReplicationProgress(ctx context.Context, request *GetBucketReplicationProgressRequest, optFns ...func(*Options)) (*GetBucketReplicationProgressResult, error)
+
+	// GetBucketRequestPayment You can call this operation to obtain pay-by-requester configurations for a bucket.
+	GetBucketRequestPayment(ctx context.Context, request *GetBucketRequestPaymentRequest, optFns ...func(*Options)) (*GetBucketRequestPaymentResult, error)
+
+	// GetBucketResourceGroup Queries the ID of the resource group to which a bucket belongs.
+	GetBucketResourceGroup(ctx context.Context, request *GetBucketResourceGroupRequest, optFns ...func(*Options)) (*GetBucketResourceGroupResult, error)
+
+	// GetBucketStat Queries the storage capacity of a specified bucket and the number of objects that are stored in the bucket.
+	GetBucketStat(ctx context.Context, request *GetBucketStatRequest, optFns ...func(*Options)) (*GetBucketStatResult, error)
+
+	// GetBucketTags Queries the tags of a bucket.
+	GetBucketTags(ctx context.Context, request *GetBucketTagsRequest, optFns ...func(*Options)) (*GetBucketTagsResult, error)
+
+	// GetBucketTransferAcceleration Queries the transfer acceleration configurations of a bucket.
+	GetBucketTransferAcceleration(ctx context.Context, request *GetBucketTransferAccelerationRequest, optFns ...func(*Options)) (*GetBucketTransferAccelerationResult, error)
+
+	// GetBucketVersioning You can call this operation to query the versioning state of a bucket.
+	GetBucketVersioning(ctx context.Context, request *GetBucketVersioningRequest, optFns ...func(*Options)) (*GetBucketVersioningResult, error)
+
+	// GetBucketWebsite Queries the static website hosting status and redirection rules configured for a bucket.
+	GetBucketWebsite(ctx context.Context, request *GetBucketWebsiteRequest, optFns ...func(*Options)) (*GetBucketWebsiteResult, error)
+
+	// GetBucketWorm Queries the retention policy configured for a bucket.
+	GetBucketWorm(ctx context.Context, request *GetBucketWormRequest, optFns ...func(*Options)) (*GetBucketWormResult, error)
+
+	// GetCnameToken Queries the created CNAME tokens.
+	GetCnameToken(ctx context.Context, request *GetCnameTokenRequest, optFns ...func(*Options)) (*GetCnameTokenResult, error)
+
+	// GetMetaQueryStatus Queries the information about the metadata index library of a bucket.
+	GetMetaQueryStatus(ctx context.Context, request *GetMetaQueryStatusRequest, optFns ...func(*Options)) (*GetMetaQueryStatusResult, error)
+
+	GetObject(ctx context.Context, request *GetObjectRequest, optFns ...func(*Options)) (*GetObjectResult, error)
+
+	// GetObjectAcl Queries the access control list (ACL) of an object in a bucket.
+	GetObjectAcl(ctx context.Context, request *GetObjectAclRequest, optFns ...func(*Options)) (*GetObjectAclResult, error)
+
+	// GetObjectLegalHold Queries the object-level legal retention of an object in a bucket.
+	GetObjectLegalHold(ctx context.Context, request *GetObjectLegalHoldRequest, optFns ...func(*Options)) (*GetObjectLegalHoldResult, error)
+
+	// GetObjectMeta Queries the metadata of an object, including ETag, Size, and LastModified.
+	// The content of the object is not returned.
+	GetObjectMeta(ctx context.Context, request *GetObjectMetaRequest, optFns ...func(*Options)) (*GetObjectMetaResult, error)
+
+	// GetObjectRetention query the object-level retention policy of an object in a bucket.
+	GetObjectRetention(ctx context.Context, request *GetObjectRetentionRequest, optFns ...func(*Options)) (*GetObjectRetentionResult, error)
+
+	// GetObjectTagging You can call this operation to query the tags of an object.
+	GetObjectTagging(ctx context.Context, request *GetObjectTaggingRequest, optFns ...func(*Options)) (*GetObjectTaggingResult, error)
+
+	// GetObjectToFile downloads the object into a local file.
+	GetObjectToFile(ctx context.Context, request *GetObjectRequest, filePath string, optFns ...func(*Options)) (*GetObjectResult, error)
+
+	// GetObjectToFileV2 downloads the object into a local file.
+	// Compared to GetObjectToFile, GetObjectToFileV2 provides the following enhancements:
+	//  1. Supports configurable write buffer size via writeBufferSize parameter to reduce disk I/O frequency.
+	//     Pass nil to use unbuffered writes.
+	//  2. Automatic resume on network disconnection. If the download is interrupted due to network instability,
+	//     it will automatically reconnect from the point of disconnection and continue downloading,
+	//     powered by NewRangeReader which transparently handles reconnection.
+	GetObjectToFileV2(ctx context.Context, request *GetObjectRequest, filePath string, writeBufferSize *int, optFns ...func(*Options)) (*GetObjectResult, error)
+
+	// GetPublicAccessBlock Queries the Block Public Access configurations of OSS resources.
+	GetPublicAccessBlock(ctx context.Context, request *GetPublicAccessBlockRequest, optFns ...func(*Options)) (*GetPublicAccessBlockResult, error)
+
+	// GetStyle Queries the information about an image style of a bucket.
+	GetStyle(ctx context.Context, request *GetStyleRequest, optFns ...func(*Options)) (*GetStyleResult, error)
+
+	// GetSymlink Obtains a symbol link. To perform GetSymlink operations, you must have the read permission on the symbol link.
+	GetSymlink(ctx context.Context, request *GetSymlinkRequest, optFns ...func(*Options)) (*GetSymlinkResult, error)
+
+	// GetUserDefinedLogFieldsConfig Queries the custom configurations of the user_defined_log_fields field in the real-time logs of a bucket.
+	GetUserDefinedLogFieldsConfig(ctx context.Context, request *GetUserDefinedLogFieldsConfigRequest, optFns ...func(*Options)) (*GetUserDefinedLogFieldsConfigResult, error)
+
+	// HeadObject Queries information about all objects in a bucket.
+	HeadObject(ctx context.Context, request *HeadObjectRequest, optFns ...func(*Options)) (*HeadObjectResult, error)
+
+	// InitiateBucketWorm Creates a retention policy.
+	InitiateBucketWorm(ctx context.Context, request *InitiateBucketWormRequest, optFns ...func(*Options)) (*InitiateBucketWormResult, error)
+
+	// InitiateMultipartUpload Initiates a multipart upload task before you can upload data in parts to Object Storage Service (OSS).
+	InitiateMultipartUpload(ctx context.Context, request *InitiateMultipartUploadRequest, optFns ...func(*Options)) (*InitiateMultipartUploadResult, error)
+
+	InvokeOperation(ctx context.Context, input *OperationInput, optFns ...func(*Options)) (*OperationOutput, error)
+
+	// IsBucketExist checks if the bucket exists.
+	IsBucketExist(ctx context.Context, bucket string, optFns ...func(*Options)) (bool, error)
+
+	// IsObjectExist checks if the object exists.
+	IsObjectExist(ctx context.Context, bucket string, key string, optFns ...func(*IsObjectExistOptions)) (bool, error)
+
+	// ListAccessPoints Queries the information about user-level or bucket-level access points.
+	ListAccessPoints(ctx context.Context, request *ListAccessPointsRequest, optFns ...func(*Options)) (*ListAccessPointsResult, error)
+
+	// ListAccessPointsForObjectProcess Lists information about Object FC Access Points in an Alibaba Cloud account.
+	ListAccessPointsForObjectProcess(ctx context.Context, request *ListAccessPointsForObjectProcessRequest, optFns ...func(*Options)) (*ListAccessPointsForObjectProcessResult, error)
+
+	// ListBucketDataRedundancyTransition Lists all redundancy type conversion tasks of a bucket.
+	ListBucketDataRedundancyTransition(ctx context.Context, request *ListBucketDataRedundancyTransitionRequest, optFns ...func(*Options)) (*ListBucketDataRedundancyTransitionResult, error)
+
+	// ListBucketInventory Queries all inventories in a bucket at a time.
+	ListBucketInventory(ctx context.Context, request *ListBucketInventoryRequest, optFns ...func(*Options)) (*ListBucketInventoryResult, error)
+
+	// ListBuckets Lists buckets that belong to the current account.
+	ListBuckets(ctx context.Context, request *ListBucketsRequest, optFns ...func(*Options)) (*ListBucketsResult, error)
+
+	// ListCloudBoxes Lists cloud box buckets that belong to the current account.
+	ListCloudBoxes(ctx context.Context, request *ListCloudBoxesRequest, optFns ...func(*Options)) (*ListCloudBoxesResult, error)
+
+	// ListCname Queries all CNAME records that are mapped to a bucket.
+	ListCname(ctx context.Context, request *ListCnameRequest, optFns ...func(*Options)) (*ListCnameResult, error)
+
+	// ListMultipartUploads Lists all multipart upload tasks in progress. The tasks are not completed or canceled.
+	ListMultipartUploads(ctx context.Context, request *ListMultipartUploadsRequest, optFns ...func(*Options)) (*ListMultipartUploadsResult, error)
+
+	// ListObjectVersions Lists the versions of all objects in a bucket, including delete markers.
+	ListObjectVersions(ctx context.Context, request *ListObjectVersionsRequest, optFns ...func(*Options)) (*ListObjectVersionsResult, error)
+
+	// ListObjects Lists the information about all objects in a bucket.
+	ListObjects(ctx context.Context, request *ListObjectsRequest, optFns ...func(*Options)) (*ListObjectsResult, error)
+
+	// ListObjectsV2 Queries information about all objects in a bucket.
+	ListObjectsV2(ctx context.Context, request *ListObjectsV2Request, optFns ...func(*Options)) (*ListObjectsV2Result, error)
+
+	// ListParts Lists all parts that are uploaded by using a specified upload ID.
+	ListParts(ctx context.Context, request *ListPartsRequest, optFns ...func(*Options)) (*ListPartsResult, error)
+
+	// ListStyle Queries all image styles that are created for a bucket.
+	ListStyle(ctx context.Context, request *ListStyleRequest, optFns ...func(*Options)) (*ListStyleResult, error)
+
+	// ListUserDataRedundancyTransition 列举请求者所有的存储冗余转换任务
+	ListUserDataRedundancyTransition(ctx context.Context, request *ListUserDataRedundancyTransitionRequest, optFns ...func(*Options)) (*ListUserDataRedundancyTransitionResult, error)
+
+	// OpenFile opens the named file for reading.
+	OpenFile(ctx context.Context, bucket string, key string, optFns ...func(*OpenOptions)) (*ReadOnlyFile, error)
+
+	// OpenMetaQuery Enables metadata management for a bucket. After you enable the metadata management feature for a bucket, Object Storage Service (OSS) creates a metadata index library for the bucket and creates metadata indexes for all objects in the bucket. After the metadata index library is created, OSS continues to perform quasi-real-time scans on incremental objects in the bucket and creates metadata indexes for the incremental objects.
+	OpenMetaQuery(ctx context.Context, request *OpenMetaQueryRequest, optFns ...func(*Options)) (*OpenMetaQueryResult, error)
+
+	// OptionObject Determines whether to send a cross-origin request. Before a cross-origin request is sent, the browser sends a preflight OPTIONS request that includes a specific origin, HTTP method, and header information to Object Storage Service (OSS) to determine whether to send the cross-origin request.
+	OptionObject(ctx context.Context, request *OptionObjectRequest, optFns ...func(*Options)) (*OptionObjectResult, error)
+
+	// PostObject Uploads a object with an HTML form (multipart/form-data).
+	PostObject(ctx context.Context, request *PostObjectRequest, optFns ...func(*Options)) (*PostObjectResult, error)
+
+	// Presign generates the pre-signed URL of the request of an operation, such as *GetObjectRequest or *ListPartsRequest.
+	Presign(ctx context.Context, request any, optFns ...func(*PresignOptions)) (*PresignResult, error)
+
+	// PresignOperation generates the pre-signed URL of the request of an operation of api, which invokes its operations
+	// by this client, such as the clients of the tables and vectors packages.
+	// The operation is the method of api named after the type of the request without the "Request" suffix,
+	// for example, ListParts for *ListPartsRequest.
+	// Except the requests of the object operations presigned by Presign before, such as *GetObjectRequest,
+	// the operation is invoked to build the input and it stops before the request is sent. So the work which is done
+	// by the operation before that is done by the presigning too, such as reading or hashing the body.
+	// The operations which send other requests than their own can not be presigned.
+	PresignOperation(ctx context.Context, api any, request any, optFns ...func(*PresignOptions)) (*PresignResult, error)
+
+	// PresignPostObject signs the policy of a form upload, so that the browsers can upload the objects directly.
+	// The policy must have a bucket, which is set by WithBucket.
+	PresignPostObject(ctx context.Context, policy *PostPolicy, optFns ...func(*Options)) (*PresignPostObjectResult, error)
+
+	// ProcessObject apply process on the specified image file.
+	ProcessObject(ctx context.Context, request *ProcessObjectRequest, optFns ...func(*Options)) (*ProcessObjectResult, error)
+
+	// PutAccessPointConfigForObjectProcess Changes the configurations of an Object FC Access Point.
+	PutAccessPointConfigForObjectProcess(ctx context.Context, request *PutAccessPointConfigForObjectProcessRequest, optFns ...func(*Options)) (*PutAccessPointConfigForObjectProcessResult, error)
+
+	// PutAccessPointPolicy Configures an access point policy.
+	PutAccessPointPolicy(ctx context.Context, request *PutAccessPointPolicyRequest, optFns ...func(*Options)) (*PutAccessPointPolicyResult, error)
+
+	// PutAccessPointPolicyForObjectProcess Configures policies for an Object FC Access Point.
+	PutAccessPointPolicyForObjectProcess(ctx context.Context, request *PutAccessPointPolicyForObjectProcessRequest, optFns ...func(*Options)) (*PutAccessPointPolicyForObjectProcessResult, error)
+
+	// PutAccessPointPublicAccessBlock Enables or disables Block Public Access for an access point.
+	PutAccessPointPublicAccessBlock(ctx context.Context, request *PutAccessPointPublicAccessBlockRequest, optFns ...func(*Options)) (*PutAccessPointPublicAccessBlockResult, error)
+
+	// PutBucket Creates a bucket.
+	PutBucket(ctx context.Context, request *PutBucketRequest, optFns ...func(*Options)) (*PutBucketResult, error)
+
+	// PutBucketAccessMonitor Modifies the access tracking status of a bucket.
+	PutBucketAccessMonitor(ctx context.Context, request *PutBucketAccessMonitorRequest, optFns ...func(*Options)) (*PutBucketAccessMonitorResult, error)
+
+	// PutBucketAcl You can call this operation to configure or modify the ACL of a bucket.
+	PutBucketAcl(ctx context.Context, request *PutBucketAclRequest, optFns ...func(*Options)) (*PutBucketAclResult, error)
+
+	// PutBucketArchiveDirectRead Enables or disables real-time access of Archive objects for a bucket.
+	PutBucketArchiveDirectRead(ctx context.Context, request *PutBucketArchiveDirectReadRequest, optFns ...func(*Options)) (*PutBucketArchiveDirectReadResult, error)
+
+	// PutBucketCors Configures cross-origin resource sharing (CORS) rules for a bucket.
+	PutBucketCors(ctx context.Context, request *PutBucketCorsRequest, optFns ...func(*Options)) (*PutBucketCorsResult, error)
+
+	// PutBucketEncryption Configures encryption rules for a bucket.
+	PutBucketEncryption(ctx context.Context, request *PutBucketEncryptionRequest, optFns ...func(*Options)) (*PutBucketEncryptionResult, error)
+
+	// PutBucketHttpsConfig Enables or disables Transport Layer Security (TLS) version management for a bucket.
+	PutBucketHttpsConfig(ctx context.Context, request *PutBucketHttpsConfigRequest, optFns ...func(*Options)) (*PutBucketHttpsConfigResult, error)
+
+	// PutBucketInventory Configures an inventory for a bucket.
+	PutBucketInventory(ctx context.Context, request *PutBucketInventoryRequest, optFns ...func(*Options)) (*PutBucketInventoryResult, error)
+
+	// PutBucketLifecycle Configures a lifecycle rule for a bucket. After you configure a lifecycle rule for a bucket, Object Storage Service (OSS) automatically deletes the objects that match the rule or converts the storage type of the objects based on the point in time that is specified in the lifecycle rule.
+	PutBucketLifecycle(ctx context.Context, request *PutBucketLifecycleRequest, optFns ...func(*Options)) (*PutBucketLifecycleResult, error)
+
+	// PutBucketLogging Enables logging for a bucket. After you enable logging for a bucket, Object Storage Service (OSS) generates logs every hour based on the defined naming rule and stores the logs as objects in the specified destination bucket.
+	PutBucketLogging(ctx context.Context, request *PutBucketLoggingRequest, optFns ...func(*Options)) (*PutBucketLoggingResult, error)
+
+	// PutBucketObjectWormConfiguration Enable object retention on the bucket and configure a retention policy.
+	PutBucketObjectWormConfiguration(ctx context.Context, request *PutBucketObjectWormConfigurationRequest, optFns ...func(*Options)) (*PutBucketObjectWormConfigurationResult, error)
+
+	// PutBucketOverwriteConfig Call the PutBucketOverwriteConfig operation to configure overwrite protection for a bucket. This prevents specified objects from being overwritten.
+	PutBucketOverwriteConfig(ctx context.Context, request *PutBucketOverwriteConfigRequest, optFns ...func(*Options)) (*PutBucketOverwriteConfigResult, error)
+
+	// PutBucketPolicy Configures a policy for a bucket.
+	PutBucketPolicy(ctx context.Context, request *PutBucketPolicyRequest, optFns ...func(*Options)) (*PutBucketPolicyResult, error)
+
+	// PutBucketPublicAccessBlock Enables or disables Block Public Access for a bucket.
+	PutBucketPublicAccessBlock(ctx context.Context, request *PutBucketPublicAccessBlockRequest, optFns ...func(*Options)) (*PutBucketPublicAccessBlockResult, error)
+
+	// PutBucketReferer Configures a Referer whitelist for an Object Storage Service (OSS) bucket. You can specify whether to allow the requests whose Referer field is empty or whose query strings are truncated.
+	PutBucketReferer(ctx context.Context, request *PutBucketRefererRequest, optFns ...func(*Options)) (*PutBucketRefererResult, error)
+
+	// PutBucketReplication Configures data replication rules for a bucket. Object Storage Service (OSS) supports cross-region replication (CRR) and same-region replication (SRR).
+	PutBucketReplication(ctx context.Context, request *PutBucketReplicationRequest, optFns ...func(*Options)) (*PutBucketReplicationResult, error)
+
+	// PutBucketRequestPayment You can call this operation to enable pay-by-requester for a bucket.
+	PutBucketRequestPayment(ctx context.Context, request *PutBucketRequestPaymentRequest, optFns ...func(*Options)) (*PutBucketRequestPaymentResult, error)
+
+	// PutBucketResourceGroup Modifies the ID of the resource group to which a bucket belongs.
+	PutBucketResourceGroup(ctx context.Context, request *PutBucketResourceGroupRequest, optFns ...func(*Options)) (*PutBucketResourceGroupResult, error)
+
+	// PutBucketRtc Enables or disables the Replication Time Control (RTC) feature for existing cross-region replication (CRR) rules.
+	PutBucketRtc(ctx context.Context, request *PutBucketRtcRequest, optFns ...func(*Options)) (*PutBucketRtcResult, error)
+
+	// PutBucketTags Adds tags to or modifies the existing tags of a bucket.
+	PutBucketTags(ctx context.Context, request *PutBucketTagsRequest, optFns ...func(*Options)) (*PutBucketTagsResult, error)
+
+	// PutBucketTransferAcceleration Configures transfer acceleration for a bucket. After you enable transfer acceleration for a bucket, the object access speed is accelerated for users worldwide. The transfer acceleration feature is applicable to scenarios where data needs to be transferred over long geographical distances. This feature can also be used to download or upload objects that are gigabytes or terabytes in size.
+	PutBucketTransferAcceleration(ctx context.Context, request *PutBucketTransferAccelerationRequest, optFns ...func(*Options)) (*PutBucketTransferAccelerationResult, error)
+
+	// PutBucketVersioning Configures the versioning state for a bucket.
+	PutBucketVersioning(ctx context.Context, request *PutBucketVersioningRequest, optFns ...func(*Options)) (*PutBucketVersioningResult, error)
+
+	// PutBucketWebsite Enables the static website hosting mode for a bucket and configures redirection rules for the bucket.
+	PutBucketWebsite(ctx context.Context, request *PutBucketWebsiteRequest, optFns ...func(*Options)) (*PutBucketWebsiteResult, error)
+
+	// PutCname Maps a CNAME record to a bucket.
+	PutCname(ctx context.Context, request *PutCnameRequest, optFns ...func(*Options)) (*PutCnameResult, error)
+
+	// PutObject Uploads a object.
+	PutObject(ctx context.Context, request *PutObjectRequest, optFns ...func(*Options)) (*PutObjectResult, error)
+
+	// PutObjectAcl You can call this operation to modify the access control list (ACL) of an object.
+	PutObjectAcl(ctx context.Context, request *PutObjectAclRequest, optFns ...func(*Options)) (*PutObjectAclResult, error)
+
+	// PutObjectFromFile creates a new object from the local file.
+	PutObjectFromFile(ctx context.Context, request *PutObjectRequest, filePath string, optFns ...func(*Options)) (*PutObjectResult, error)
+
+	// PutObjectLegalHold Configure legal retention on Object.
+	PutObjectLegalHold(ctx context.Context, request *PutObjectLegalHoldRequest, optFns ...func(*Options)) (*PutObjectLegalHoldResult, error)
+
+	// PutObjectRetention Configure a retention policy on Object.
+	PutObjectRetention(ctx context.Context, request *PutObjectRetentionRequest, optFns ...func(*Options)) (*PutObjectRetentionResult, error)
+
+	// PutObjectTagging Adds tags to an object or updates the tags added to the object. Each tag added to an object is a key-value pair.
+	PutObjectTagging(ctx context.Context, request *PutObjectTaggingRequest, optFns ...func(*Options)) (*PutObjectTaggingResult, error)
+
+	// PutPublicAccessBlock Enables or disables Block Public Access for Object Storage Service (OSS) resources.
+	PutPublicAccessBlock(ctx context.Context, request *PutPublicAccessBlockRequest, optFns ...func(*Options)) (*PutPublicAccessBlockResult, error)
+
+	// PutStyle Adds an image style to a bucket. An image style contains one or more image processing parameters.
+	PutStyle(ctx context.Context, request *PutStyleRequest, optFns ...func(*Options)) (*PutStyleResult, error)
+
+	// PutSymlink Creates a symbolic link that points to a destination object. You can use the symbolic link to access the destination object.
+	PutSymlink(ctx context.Context, request *PutSymlinkRequest, optFns ...func(*Options)) (*PutSymlinkResult, error)
+
+	// PutUserDefinedLogFieldsConfig Customizes the user_defined_log_fields field in real-time logs by adding custom request headers or query parameters to the field for subsequent analysis of requests.
+	PutUserDefinedLogFieldsConfig(ctx context.Context, request *PutUserDefinedLogFieldsConfigRequest, optFns ...func(*Options)) (*PutUserDefinedLogFieldsConfigResult, error)
+
+	// RestoreObject Restores Archive, Cold Archive, or Deep Cold Archive objects.
+	// You are charged based on tier(restoration priority) when you restore object. For more information, see OSS document.
+	RestoreObject(ctx context.Context, request *RestoreObjectRequest, optFns ...func(*Options)) (*RestoreObjectResult, error)
+
+	// SealAppendObject This operation stops writing to the Appendable Object, after which the user can configure lifecycle rules to change the storage class of the corresponding Appendable Object to Cold Archive or Deep Cold Archive.
+	SealAppendObject(ctx context.Context, request *SealAppendObjectRequest, optFns ...func(*Options)) (*SealAppendObjectResult, error)
+
+	// SelectObject Executes SQL statements to perform operations on an object and obtains the execution results.
+	SelectObject(ctx context.Context, request *SelectObjectRequest, optFns ...func(*Options)) (*SelectObjectResult, error)
+
+	// Sync makes the objects under the prefix the same as the files in the local directory, or the other way around,
+	// like rsync. The files and the objects are compared by their sizes, modification times and CRC-64,
+	// only the changed ones are transferred, and the extraneous ones are deleted if the Delete option is set.
+	// The modification time of an uploaded file is stored in its user metadata "mtime", and the one of a downloaded file
+	// is set to the time of its object. The failures of the entries are reported in the result instead of stopping the others.
+	// The returned error is only for the arguments, the local directory and the listing of the objects.
+	Sync(ctx context.Context, bucket string, prefix string, localDir string, direction SyncDirection, optFns ...func(*SyncOptions)) (*SyncResult, error)
+
+	// UploadPart Call the UploadPart interface to upload data in blocks (parts) based on the specified Object name and uploadId.
+	UploadPart(ctx context.Context, request *UploadPartRequest, optFns ...func(*Options)) (*UploadPartResult, error)
+
+	// UploadPartCopy You can call this operation to copy data from an existing object to upload a part by adding a x-oss-copy-request header to UploadPart.
+	UploadPartCopy(ctx context.Context, request *UploadPartCopyRequest, optFns ...func(*Options)) (*UploadPartCopyResult, error)
+
+	// WriteGetObjectResponse Customize return data and response headers
+	WriteGetObjectResponse(ctx context.Context, request *WriteGetObjectResponseRequest, optFns ...func(*Options)) (*WriteGetObjectResponseResult, error)
+}
+
+var _ API = (*Client)(nil)
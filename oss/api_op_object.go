@@ -134,6 +134,7 @@ func (c *Client) PutObject(ctx context.Context, request *PutObjectRequest, optFn
 		addProgress,
 		c.updateContentType,
 		c.addCrcCheck,
+		c.addStreamingPayload,
 	}
 	unmarshalFns := []func(result any, output *OperationOutput) error{
 		unmarshalHeader,
@@ -696,6 +697,7 @@ func (c *Client) AppendObject(ctx context.Context, request *AppendObjectRequest,
 	marshalFns := []func(any, *OperationInput) error{
 		addProgress,
 		c.updateContentType,
+		c.addStreamingPayload,
 	}
 
 	unmarshalFns := []func(any, *OperationOutput) error{
@@ -1607,6 +1609,7 @@ func (c *Client) UploadPart(ctx context.Context, request *UploadPartRequest, opt
 	marshalFns := []func(any, *OperationInput) error{
 		addProgress,
 		c.addCrcCheck,
+		c.addStreamingPayload,
 	}
 
 	if err = c.marshalInput(request, input, marshalFns...); err != nil {
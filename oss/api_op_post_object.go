@@ -0,0 +1,229 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type PostObjectRequest struct {
+	// The name of the bucket.
+	Bucket *string `input:"host,bucket,required"`
+
+	// The name of the object, which is the key field of the form.
+	Key *string `input:"path,key,required"`
+
+	// The policy of the form. If it is set, the form is signed with it by PresignPostObject, and the bucket of the request
+	// is added to it if it has none,
+	// otherwise the form is sent with the Fields only, which are signed already or are for a public-read-write bucket.
+	Policy *PostPolicy
+
+	// The fields of the form, such as the ones returned by PresignPostObject, Content-Type, x-oss-meta-*,
+	// success_action_status and callback.
+	Fields map[string]string
+
+	// The file name of the file field, the default is the base name of the key.
+	FileName *string
+
+	// The content of the object.
+	Body io.Reader
+
+	RequestCommon
+}
+
+type PostObjectResult struct {
+	// Content-Md5 for the uploaded object.
+	ContentMD5 *string `output:"header,Content-MD5"`
+
+	// Entity tag for the uploaded object.
+	ETag *string `output:"header,ETag"`
+
+	// The 64-bit CRC value of the object.
+	// This value is calculated based on the ECMA-182 standard.
+	HashCRC64 *string `output:"header,x-oss-hash-crc64ecma"`
+
+	// Version of the object.
+	VersionId *string `output:"header,x-oss-version-id"`
+
+	// The URL of the object, which is returned if the success_action_status is 201.
+	Location *string
+
+	CallbackResult map[string]any
+
+	ResultCommon
+}
+
+// PostObject Uploads a object with an HTML form (multipart/form-data).
+func (c *Client) PostObject(ctx context.Context, request *PostObjectRequest, optFns ...func(*Options)) (*PostObjectResult, error) {
+	var err error
+	if request == nil {
+		request = &PostObjectRequest{}
+	}
+	input := &OperationInput{
+		OpName: "PostObject",
+		Method: "POST",
+		Bucket: request.Bucket,
+	}
+	if err = c.marshalInput(request, input); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	if request.Policy != nil {
+		policy := request.Policy
+		if policy.Bucket() == "" {
+			// the bucket of the request, without modifying the policy of the caller
+			p := *policy
+			p.Conditions = append([]any(nil), policy.Conditions...)
+			policy = p.WithBucket(ToString(request.Bucket))
+		}
+		presignResult, err := c.PresignPostObject(ctx, policy, optFns...)
+		if err != nil {
+			return nil, err
+		}
+		fields = presignResult.Fields
+	}
+	for k, v := range request.Fields {
+		fields[k] = v
+	}
+	if err = c.marshalPostObjectForm(request, input, fields); err != nil {
+		return nil, err
+	}
+
+	unmarshalFns := []func(result any, output *OperationOutput) error{
+		unmarshalHeader,
+	}
+	if fields["callback"] != "" {
+		input.OpMetadata.Add(OpMetaKeyResponsHandler, postCallbackErrorResponseHandler)
+		unmarshalFns = append(unmarshalFns, unmarshalCallbackBody)
+	} else {
+		unmarshalFns = append(unmarshalFns, unmarshalPostObjectBody)
+	}
+
+	output, err := c.invokeOperation(ctx, input, optFns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PostObjectResult{}
+	if err = c.unmarshalOutput(result, output, unmarshalFns...); err != nil {
+		return nil, c.toClientError(err, "UnmarshalOutputFail", output)
+	}
+
+	return result, err
+}
+
+// marshalPostObjectForm encodes the fields and the body into a multipart form, the body is the last part.
+// The form is authorized by its signed policy, so the request itself is not signed.
+func (c *Client) marshalPostObjectForm(request *PostObjectRequest, input *OperationInput, fields map[string]string) error {
+	var head bytes.Buffer
+	w := multipart.NewWriter(&head)
+	if err := w.WriteField("key", ToString(request.Key)); err != nil {
+		return err
+	}
+	for _, k := range sortedStringKeys(fields) {
+		if strings.EqualFold(k, "key") || strings.EqualFold(k, "file") {
+			continue
+		}
+		if err := w.WriteField(k, fields[k]); err != nil {
+			return err
+		}
+	}
+	fileName := ToString(request.FileName)
+	if fileName == "" {
+		fileName = path.Base(ToString(request.Key))
+	}
+	if _, err := w.CreateFormFile("file", fileName); err != nil {
+		return err
+	}
+	tail := "\r\n--" + w.Boundary() + "--\r\n"
+
+	body := request.Body
+	if body == nil {
+		body = strings.NewReader("")
+	}
+	input.Body = io.MultiReader(bytes.NewReader(head.Bytes()), body, strings.NewReader(tail))
+	if input.Headers == nil {
+		input.Headers = map[string]string{}
+	}
+	input.Headers[HTTPHeaderContentType] = w.FormDataContentType()
+	if size := GetReaderLen(body); size >= 0 {
+		input.Headers[HTTPHeaderContentLength] = strconv.FormatInt(int64(head.Len())+size+int64(len(tail)), 10)
+		if c.hasFeature(FeatureEnableCRC64CheckUpload) {
+			crc := &formFileCRC{Hash64: NewCRC64(0), offset: int64(head.Len()), size: size}
+			input.OpMetadata.Add(OpMetaKeyRequestBodyTracker, crc)
+			input.OpMetadata.Add(OpMetaKeyResponsHandler, func(response *http.Response) error {
+				return checkResponseHeaderCRC64(fmt.Sprint(crc.Sum64()), response.Header)
+			})
+		}
+	}
+	input.OpMetadata.Set(OpMetaKeyUnsigned, true)
+	return nil
+}
+
+// formFileCRC computes the CRC-64 of the file part of a form, which is at [offset, offset+size) of the body.
+type formFileCRC struct {
+	hash.Hash64
+	offset int64
+	size   int64
+	pos    int64
+}
+
+func (w *formFileCRC) Write(p []byte) (int, error) {
+	start := w.pos
+	w.pos += int64(len(p))
+	lo, hi := w.offset-start, w.offset+w.size-start
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > int64(len(p)) {
+		hi = int64(len(p))
+	}
+	if lo < hi {
+		w.Hash64.Write(p[lo:hi])
+	}
+	return len(p), nil
+}
+
+func (w *formFileCRC) Reset() {
+	w.Hash64.Reset()
+	w.pos = 0
+}
+
+func postCallbackErrorResponseHandler(response *http.Response) error {
+	if response.StatusCode == 203 {
+		return tryConvertServiceError(response)
+	}
+	return nil
+}
+
+func unmarshalPostObjectBody(result any, output *OperationOutput) error {
+	if output.Body == nil {
+		return nil
+	}
+	defer output.Body.Close()
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return err
+	}
+	if output.StatusCode != 201 || len(body) == 0 {
+		return nil
+	}
+	var postResponse struct {
+		XMLName  xml.Name `xml:"PostResponse"`
+		Location *string  `xml:"Location"`
+	}
+	if err = xml.Unmarshal(body, &postResponse); err != nil {
+		return err
+	}
+	result.(*PostObjectResult).Location = postResponse.Location
+	return nil
+}
@@ -0,0 +1,262 @@
+package oss
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodePostPolicy(t *testing.T, policy string) map[string]any {
+	data, err := base64.StdEncoding.DecodeString(policy)
+	assert.Nil(t, err)
+	doc := map[string]any{}
+	assert.Nil(t, json.Unmarshal(data, &doc))
+	return doc
+}
+
+func TestPostPolicy(t *testing.T) {
+	expiration := time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC)
+	policy := NewPostPolicy(expiration).
+		WithBucket("bucket").
+		WithKeyStartsWith("user/").
+		WithContentLengthRange(1, 1024).
+		WithContentType("image/jpeg").
+		WithSuccessActionStatus(201).
+		WithCallback("e30=").
+		WithCondition("in", "$x-oss-meta-a", "1", "2")
+	assert.Equal(t, "bucket", policy.Bucket())
+	assert.Equal(t, map[string]string{
+		"Content-Type":          "image/jpeg",
+		"success_action_status": "201",
+		"callback":              "e30=",
+	}, policy.Fields())
+
+	encoded, err := policy.Encode(map[string]string{"x-oss-date": "20241201T110000Z"})
+	assert.Nil(t, err)
+	data, _ := base64.StdEncoding.DecodeString(encoded)
+	assert.Equal(t, `{"expiration":"2024-12-01T12:00:00.000Z","conditions":[`+
+		`{"bucket":"bucket"},`+
+		`["starts-with","$key","user/"],`+
+		`["content-length-range",1,1024],`+
+		`["eq","$Content-Type","image/jpeg"],`+
+		`["eq","$success_action_status","201"],`+
+		`["eq","$callback","e30="],`+
+		`["in","$x-oss-meta-a",["1","2"]],`+
+		`{"x-oss-date":"20241201T110000Z"}]}`, string(data))
+
+	encoded, err = NewPostPolicy(expiration).Encode(nil)
+	assert.Nil(t, err)
+	data, _ = base64.StdEncoding.DecodeString(encoded)
+	assert.Equal(t, `{"expiration":"2024-12-01T12:00:00.000Z","conditions":[]}`, string(data))
+}
+
+func TestPresignPostObject(t *testing.T) {
+	expiration := time.Now().Add(time.Hour)
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk", "token")).
+		WithRegion("cn-hangzhou").
+		WithEndpoint("oss-cn-hangzhou.aliyuncs.com")
+	client := NewClient(cfg)
+
+	result, err := client.PresignPostObject(context.TODO(), NewPostPolicy(expiration).WithBucket("bucket").WithKey("a.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "https://bucket.oss-cn-hangzhou.aliyuncs.com/", result.URL)
+	assert.Equal(t, expiration, result.Expiration)
+	fields := result.Fields
+	assert.Equal(t, "a.txt", fields["key"])
+	assert.Equal(t, "OSS4-HMAC-SHA256", fields["x-oss-signature-version"])
+	assert.Equal(t, "token", fields["x-oss-security-token"])
+	assert.True(t, strings.HasPrefix(fields["x-oss-credential"], "ak/"))
+	assert.True(t, strings.HasSuffix(fields["x-oss-credential"], "/cn-hangzhou/oss/aliyun_v4_request"))
+	assert.Len(t, fields["x-oss-signature"], 64)
+
+	// the credential fields are signed as conditions
+	conditions := decodePostPolicy(t, fields["policy"])["conditions"].([]any)
+	assert.Contains(t, conditions, map[string]any{"x-oss-credential": fields["x-oss-credential"]})
+	assert.Contains(t, conditions, map[string]any{"x-oss-date": fields["x-oss-date"]})
+	assert.Contains(t, conditions, map[string]any{"x-oss-security-token": "token"})
+
+	verifier := signer.NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		return &credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk", SecurityToken: "token"}, nil
+	})
+	_, err = verifier.VerifyPostPolicy(context.TODO(), fields)
+	assert.Nil(t, err)
+
+	// v1
+	v1Cfg := cfg.Copy()
+	client = NewClient(v1Cfg.WithSignatureVersion(SignatureVersionV1).WithUsePathStyle(true))
+	result, err = client.PresignPostObject(context.TODO(), NewPostPolicy(expiration).WithBucket("bucket"))
+	assert.Nil(t, err)
+	assert.Equal(t, "https://oss-cn-hangzhou.aliyuncs.com/bucket/", result.URL)
+	fields = result.Fields
+	assert.Equal(t, "ak", fields["OSSAccessKeyId"])
+	assert.Equal(t, "token", fields["x-oss-security-token"])
+	assert.NotEmpty(t, fields["Signature"])
+	assert.Empty(t, fields["x-oss-signature"])
+	_, err = verifier.VerifyPostPolicy(context.TODO(), fields)
+	assert.Nil(t, err)
+
+	// invalid arguments
+	_, err = client.PresignPostObject(context.TODO(), nil)
+	assert.Contains(t, err.Error(), "null field, policy")
+	_, err = client.PresignPostObject(context.TODO(), NewPostPolicy(expiration))
+	assert.Contains(t, err.Error(), "invalid field, OperationInput.Bucket")
+	_, err = client.PresignPostObject(context.TODO(), &PostPolicy{})
+	assert.Contains(t, err.Error(), "missing required field, policy.Expiration")
+
+	anonymousCfg := cfg.Copy()
+	client = NewClient(anonymousCfg.WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()))
+	_, err = client.PresignPostObject(context.TODO(), NewPostPolicy(expiration).WithBucket("bucket"))
+	assert.Contains(t, err.Error(), "invalid field, CredentialsProvider")
+}
+
+type receivedPostForm struct {
+	auth   string
+	fields map[string]string
+	file   string
+	name   string
+}
+
+func testSetupPostObjectServer(t *testing.T, statusCode int, headers map[string]string, body string, form *receivedPostForm) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form.auth = r.Header.Get("Authorization")
+		form.fields = map[string]string{}
+		mr, err := r.MultipartReader()
+		assert.Nil(t, err)
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+			data, _ := io.ReadAll(part)
+			if part.FormName() == "file" {
+				form.file = string(data)
+				form.name = part.FileName()
+			} else {
+				form.fields[part.FormName()] = string(data)
+			}
+		}
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestPostObject(t *testing.T) {
+	data := "hello form"
+	crc := NewCRC64(0)
+	crc.Write([]byte(data))
+	form := &receivedPostForm{}
+	server := testSetupPostObjectServer(t, 201, map[string]string{
+		"Content-Type":         "application/xml",
+		"ETag":                 "\"D41D8CD98F00B204E9800998ECF8****\"",
+		"x-oss-hash-crc64ecma": fmt.Sprint(crc.Sum64()),
+	}, `<?xml version="1.0" encoding="UTF-8"?><PostResponse><Bucket>bucket</Bucket><Location>http://bucket.oss-cn-hangzhou.aliyuncs.com/user/a.txt</Location><Key>user/a.txt</Key><ETag>"D41D8CD98F00B204E9800998ECF8****"</ETag></PostResponse>`, form)
+	defer server.Close()
+
+	client := NewClient(LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithUsePathStyle(true))
+
+	policy := NewPostPolicy(time.Now().Add(time.Hour)).WithKeyStartsWith("user/").WithSuccessActionStatus(201)
+	result, err := client.PostObject(context.TODO(), &PostObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("user/a.txt"),
+		Policy: policy,
+		Fields: map[string]string{"x-oss-meta-a": "b"},
+		Body:   strings.NewReader(data),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, result.StatusCode)
+	assert.Equal(t, "http://bucket.oss-cn-hangzhou.aliyuncs.com/user/a.txt", ToString(result.Location))
+	assert.Equal(t, "\"D41D8CD98F00B204E9800998ECF8****\"", ToString(result.ETag))
+
+	// the request is not signed, the form is
+	assert.Empty(t, form.auth)
+	assert.Equal(t, data, form.file)
+	assert.Equal(t, "a.txt", form.name)
+	assert.Equal(t, "user/a.txt", form.fields["key"])
+	assert.Equal(t, "b", form.fields["x-oss-meta-a"])
+	assert.Equal(t, "201", form.fields["success_action_status"])
+	assert.NotEmpty(t, form.fields["x-oss-signature"])
+	conditions := decodePostPolicy(t, form.fields["policy"])["conditions"].([]any)
+	assert.Contains(t, conditions, map[string]any{"bucket": "bucket"})
+	// the policy of the caller is not modified
+	assert.Equal(t, "", policy.Bucket())
+
+	// the crc of the file does not match
+	_, err = client.PostObject(context.TODO(), &PostObjectRequest{
+		Bucket:   Ptr("bucket"),
+		Key:      Ptr("user/a.txt"),
+		Policy:   policy,
+		FileName: Ptr("b.txt"),
+		Body:     strings.NewReader("hello other"),
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "crc is inconsistent")
+	assert.Equal(t, "b.txt", form.name)
+
+	// the crc is not checked if the size is unknown
+	_, err = client.PostObject(context.TODO(), &PostObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("user/a.txt"),
+		Policy: policy,
+		Body:   io.MultiReader(strings.NewReader("hello other")),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello other", form.file)
+
+	// invalid arguments
+	_, err = client.PostObject(context.TODO(), &PostObjectRequest{Bucket: Ptr("bucket")})
+	assert.Contains(t, err.Error(), "missing required field, Key")
+	_, err = client.PostObject(context.TODO(), nil)
+	assert.Contains(t, err.Error(), "missing required field, Bucket")
+}
+
+func TestPostObject_Callback(t *testing.T) {
+	form := &receivedPostForm{}
+	server := testSetupPostObjectServer(t, 200, map[string]string{
+		"Content-Type": "application/json",
+	}, `{"filename":"a.txt","size":"5"}`, form)
+	defer server.Close()
+
+	client := NewClient(LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithUsePathStyle(true))
+
+	// a form signed by the server of a web application
+	callback := base64.StdEncoding.EncodeToString([]byte(`{"callbackUrl":"www.example.com/callback","callbackBody":"filename=${object}&size=${size}"}`))
+	result, err := client.PostObject(context.TODO(), &PostObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("a.txt"),
+		Fields: map[string]string{
+			"policy":         "cG9saWN5",
+			"OSSAccessKeyId": "ak",
+			"Signature":      "signature",
+			"callback":       callback,
+		},
+		Body: strings.NewReader("hello"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"filename": "a.txt", "size": "5"}, result.CallbackResult)
+	assert.Equal(t, "cG9saWN5", form.fields["policy"])
+	assert.Equal(t, callback, form.fields["callback"])
+}
@@ -12,6 +12,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ----- download checkpoint  -----
@@ -367,3 +369,345 @@ func (cp *uploadCheckpoint) dump() error {
 func (cp *uploadCheckpoint) remove() error {
 	return os.Remove(cp.CpFilePath)
 }
+
+// ----- copy checkpoint  -----
+type copyCheckpoint struct {
+	CpDirPath     string // checkpoint dir full path
+	CpFilePath    string // checkpoint file full path
+	Loaded        bool   // If Info.Data.CopyInfo is loaded from checkpoint
+	StaleUploadId string // The upload id of an invalid checkpoint to the same destination, it should be aborted
+
+	Info struct { //checkpoint data
+		Magic string // Magic
+		MD5   string // The Data's MD5
+		Data  struct {
+			// source
+			ObjectInfo struct {
+				Name      string // oss://bucket/key
+				VersionId string
+			}
+			ObjectMeta struct {
+				Size         int64
+				LastModified string
+				ETag         string
+				VersionId    string
+			}
+
+			// destination
+			DestObjectInfo struct {
+				Name string // oss://bucket/key
+			}
+
+			// copy info
+			PartSize int64
+
+			CopyInfo struct {
+				UploadId string
+				Parts    []UploadPart
+			}
+		}
+	}
+}
+
+func newCopyCheckpoint(request *CopyObjectRequest, baseDir string, header http.Header, partSize int64) *copyCheckpoint {
+	srcBucket := request.SourceBucket
+	if srcBucket == nil {
+		srcBucket = request.Bucket
+	}
+	var buf strings.Builder
+	name := fmt.Sprintf("%v/%v", ToString(srcBucket), ToString(request.SourceKey))
+	buf.WriteString("oss://" + escapePath(name, false))
+	buf.WriteString("\n")
+	buf.WriteString(ToString(request.SourceVersionId))
+
+	hashmd5 := md5.New()
+	hashmd5.Write([]byte(buf.String()))
+	srcHash := hex.EncodeToString(hashmd5.Sum(nil))
+
+	destName := fmt.Sprintf("%v/%v", ToString(request.Bucket), ToString(request.Key))
+	hashmd5.Reset()
+	hashmd5.Write([]byte("oss://" + escapePath(destName, false)))
+	destHash := hex.EncodeToString(hashmd5.Sum(nil))
+
+	var dir string
+	if baseDir == "" {
+		dir = os.TempDir()
+	} else {
+		dir = filepath.Dir(baseDir)
+	}
+
+	cpFilePath := filepath.Join(dir, fmt.Sprintf("%v-%v%v", srcHash, destHash, CheckpointFileSuffixCopier))
+
+	cp := &copyCheckpoint{
+		CpFilePath: cpFilePath,
+		CpDirPath:  dir,
+	}
+
+	objectSize, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+
+	cp.Info.Magic = CheckpointMagic
+	cp.Info.Data.ObjectInfo.Name = "oss://" + name
+	cp.Info.Data.ObjectInfo.VersionId = ToString(request.SourceVersionId)
+	cp.Info.Data.ObjectMeta.Size = objectSize
+	cp.Info.Data.ObjectMeta.LastModified = header.Get("Last-Modified")
+	cp.Info.Data.ObjectMeta.ETag = header.Get("ETag")
+	cp.Info.Data.ObjectMeta.VersionId = header.Get("x-oss-version-id")
+	cp.Info.Data.DestObjectInfo.Name = "oss://" + destName
+	cp.Info.Data.PartSize = partSize
+
+	return cp
+}
+
+// load checkpoint from local file
+func (cp *copyCheckpoint) load() error {
+	if !DirExists(cp.CpDirPath) {
+		return fmt.Errorf("Invaid checkpoint dir, %v", cp.CpDirPath)
+	}
+
+	if !FileExists(cp.CpFilePath) {
+		return nil
+	}
+
+	if !cp.valid() {
+		cp.remove()
+		return nil
+	}
+
+	cp.Loaded = true
+
+	return nil
+}
+
+func (cp *copyCheckpoint) valid() bool {
+	// Compare the CP's Magic and the MD5
+	contents, err := os.ReadFile(cp.CpFilePath)
+	if err != nil {
+		return false
+	}
+
+	dcp := copyCheckpoint{}
+
+	if err = json.Unmarshal(contents, &dcp.Info); err != nil {
+		return false
+	}
+
+	js, _ := json.Marshal(dcp.Info.Data)
+	sum := md5.Sum(js)
+	md5sum := hex.EncodeToString(sum[:])
+
+	if CheckpointMagic != dcp.Info.Magic ||
+		md5sum != dcp.Info.MD5 {
+		return false
+	}
+
+	if !reflect.DeepEqual(cp.Info.Data.DestObjectInfo, dcp.Info.Data.DestObjectInfo) {
+		return false
+	}
+
+	// compare, the source must not be changed, otherwise the upload is stale
+	if !reflect.DeepEqual(cp.Info.Data.ObjectInfo, dcp.Info.Data.ObjectInfo) ||
+		!reflect.DeepEqual(cp.Info.Data.ObjectMeta, dcp.Info.Data.ObjectMeta) ||
+		cp.Info.Data.PartSize != dcp.Info.Data.PartSize {
+		cp.StaleUploadId = dcp.Info.Data.CopyInfo.UploadId
+		return false
+	}
+
+	// copy info
+	if len(dcp.Info.Data.CopyInfo.UploadId) == 0 {
+		return false
+	}
+
+	// update
+	cp.Info.Data.CopyInfo = dcp.Info.Data.CopyInfo
+
+	return true
+}
+
+// dump dumps to file
+func (cp *copyCheckpoint) dump() error {
+	// Calculate MD5
+	js, _ := json.Marshal(cp.Info.Data)
+	sum := md5.Sum(js)
+	md5sum := hex.EncodeToString(sum[:])
+	cp.Info.MD5 = md5sum
+
+	// Serialize
+	js, err := json.Marshal(cp.Info)
+	if err != nil {
+		return err
+	}
+
+	// Dump
+	return os.WriteFile(cp.CpFilePath, js, FilePermMode)
+}
+
+func (cp *copyCheckpoint) remove() error {
+	return os.Remove(cp.CpFilePath)
+}
+
+// ----- copy prefix checkpoint  -----
+type copyPrefixCheckpoint struct {
+	CpDirPath  string // checkpoint dir full path
+	CpFilePath string // checkpoint file full path
+	Loaded     bool   // If Info.Data.Completed is loaded from checkpoint
+
+	mu       sync.Mutex
+	dumpedAt time.Time
+
+	Info struct { //checkpoint data
+		Magic string // Magic
+		MD5   string // The Data's MD5
+		Data  struct {
+			// source
+			Source string // oss://bucket/prefix
+
+			// destination
+			Destination string // oss://bucket/prefix
+
+			// copied objects, by the keys of the source objects
+			Completed map[string]copyPrefixObjectInfo
+		}
+	}
+}
+
+type copyPrefixObjectInfo struct {
+	ETag string // The ETag of the source object
+	Key  string // The key of the destination object
+}
+
+func newCopyPrefixCheckpoint(srcBucket, srcPrefix, destBucket, destPrefix string, baseDir string) *copyPrefixCheckpoint {
+	source := "oss://" + escapePath(fmt.Sprintf("%v/%v", srcBucket, srcPrefix), false)
+	hashmd5 := md5.New()
+	hashmd5.Write([]byte(source))
+	srcHash := hex.EncodeToString(hashmd5.Sum(nil))
+
+	destination := "oss://" + escapePath(fmt.Sprintf("%v/%v", destBucket, destPrefix), false)
+	hashmd5.Reset()
+	hashmd5.Write([]byte(destination))
+	destHash := hex.EncodeToString(hashmd5.Sum(nil))
+
+	var dir string
+	if baseDir == "" {
+		dir = os.TempDir()
+	} else {
+		dir = filepath.Dir(baseDir)
+	}
+
+	cpFilePath := filepath.Join(dir, fmt.Sprintf("%v-%v%v", srcHash, destHash, CheckpointFileSuffixCopyPrefix))
+
+	cp := &copyPrefixCheckpoint{
+		CpFilePath: cpFilePath,
+		CpDirPath:  dir,
+	}
+
+	cp.Info.Magic = CheckpointMagic
+	cp.Info.Data.Source = source
+	cp.Info.Data.Destination = destination
+	cp.Info.Data.Completed = map[string]copyPrefixObjectInfo{}
+
+	return cp
+}
+
+// load checkpoint from local file
+func (cp *copyPrefixCheckpoint) load() error {
+	if !DirExists(cp.CpDirPath) {
+		return fmt.Errorf("Invaid checkpoint dir, %v", cp.CpDirPath)
+	}
+
+	if !FileExists(cp.CpFilePath) {
+		return nil
+	}
+
+	if !cp.valid() {
+		cp.remove()
+		return nil
+	}
+
+	cp.Loaded = true
+
+	return nil
+}
+
+func (cp *copyPrefixCheckpoint) valid() bool {
+	// Compare the CP's Magic and the MD5
+	contents, err := os.ReadFile(cp.CpFilePath)
+	if err != nil {
+		return false
+	}
+
+	dcp := copyPrefixCheckpoint{}
+
+	if err = json.Unmarshal(contents, &dcp.Info); err != nil {
+		return false
+	}
+
+	js, _ := json.Marshal(dcp.Info.Data)
+	sum := md5.Sum(js)
+	md5sum := hex.EncodeToString(sum[:])
+
+	if CheckpointMagic != dcp.Info.Magic ||
+		md5sum != dcp.Info.MD5 {
+		return false
+	}
+
+	// compare
+	if cp.Info.Data.Source != dcp.Info.Data.Source ||
+		cp.Info.Data.Destination != dcp.Info.Data.Destination {
+		return false
+	}
+
+	// update
+	for k, v := range dcp.Info.Data.Completed {
+		cp.Info.Data.Completed[k] = v
+	}
+
+	return true
+}
+
+// completed reports whether the source object of the etag is copied to the key
+func (cp *copyPrefixCheckpoint) completed(srcKey, etag, key string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	info, ok := cp.Info.Data.Completed[srcKey]
+	return ok && info.ETag == etag && info.Key == key
+}
+
+// complete records the copied object, the checkpoint file is dumped at most once per second
+func (cp *copyPrefixCheckpoint) complete(srcKey, etag, key string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.Info.Data.Completed[srcKey] = copyPrefixObjectInfo{ETag: etag, Key: key}
+	if time.Since(cp.dumpedAt) >= time.Second {
+		cp.dumpLocked()
+	}
+}
+
+// dump dumps to file
+func (cp *copyPrefixCheckpoint) dump() error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.dumpLocked()
+}
+
+func (cp *copyPrefixCheckpoint) dumpLocked() error {
+	cp.dumpedAt = time.Now()
+
+	// Calculate MD5
+	js, _ := json.Marshal(cp.Info.Data)
+	sum := md5.Sum(js)
+	md5sum := hex.EncodeToString(sum[:])
+	cp.Info.MD5 = md5sum
+
+	// Serialize
+	js, err := json.Marshal(cp.Info)
+	if err != nil {
+		return err
+	}
+
+	// Dump
+	return os.WriteFile(cp.CpFilePath, js, FilePermMode)
+}
+
+func (cp *copyPrefixCheckpoint) remove() error {
+	return os.Remove(cp.CpFilePath)
+}
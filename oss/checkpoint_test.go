@@ -449,3 +449,120 @@ func TestUploadCheckpointValid(t *testing.T) {
 	os.Remove(destFilePath)
 	os.Remove(cp.CpFilePath)
 }
+
+func TestCopyCheckpoint(t *testing.T) {
+	request := &CopyObjectRequest{
+		Bucket:       Ptr("bucket"),
+		Key:          Ptr("key"),
+		SourceBucket: Ptr("src-bucket"),
+		SourceKey:    Ptr("src-key"),
+	}
+	cpDir := t.TempDir() + "/"
+	partSize := DefaultCopyPartSize
+	header := http.Header{}
+	header.Set("Content-Length", "1000")
+	header.Set("Last-Modified", "Fri, 24 Feb 2012 06:07:48 GMT")
+	header.Set("ETag", "\"D41D8CD98F00B204E9800998ECF8427E\"")
+
+	cp := newCopyCheckpoint(request, cpDir, header, partSize)
+	assert.NotNil(t, cp)
+	assert.Equal(t, "oss://src-bucket/src-key", cp.Info.Data.ObjectInfo.Name)
+	assert.Equal(t, "", cp.Info.Data.ObjectInfo.VersionId)
+	assert.Equal(t, int64(1000), cp.Info.Data.ObjectMeta.Size)
+	assert.Equal(t, "Fri, 24 Feb 2012 06:07:48 GMT", cp.Info.Data.ObjectMeta.LastModified)
+	assert.Equal(t, "\"D41D8CD98F00B204E9800998ECF8427E\"", cp.Info.Data.ObjectMeta.ETag)
+	assert.Equal(t, "oss://bucket/key", cp.Info.Data.DestObjectInfo.Name)
+	assert.Equal(t, CheckpointMagic, cp.Info.Magic)
+	assert.Equal(t, partSize, cp.Info.Data.PartSize)
+	assert.True(t, strings.HasSuffix(cp.CpFilePath, CheckpointFileSuffixCopier))
+
+	//check dump
+	cp.Info.Data.CopyInfo.UploadId = "upload-id"
+	cp.Info.Data.CopyInfo.Parts = []UploadPart{{PartNumber: 1, ETag: Ptr("etag-1")}}
+	cp.dump()
+	assert.True(t, FileExists(cp.CpFilePath))
+
+	//check load
+	cp = newCopyCheckpoint(request, cpDir, header, partSize)
+	err := cp.load()
+	assert.Nil(t, err)
+	assert.True(t, cp.Loaded)
+	assert.Equal(t, "upload-id", cp.Info.Data.CopyInfo.UploadId)
+	assert.Len(t, cp.Info.Data.CopyInfo.Parts, 1)
+	assert.Equal(t, "etag-1", ToString(cp.Info.Data.CopyInfo.Parts[0].ETag))
+
+	//the source is changed
+	header.Set("ETag", "\"E41D8CD98F00B204E9800998ECF8427E\"")
+	cp = newCopyCheckpoint(request, cpDir, header, partSize)
+	err = cp.load()
+	assert.Nil(t, err)
+	assert.False(t, cp.Loaded)
+	assert.False(t, FileExists(cp.CpFilePath))
+
+	//without upload id
+	cp.dump()
+	err = cp.load()
+	assert.Nil(t, err)
+	assert.False(t, cp.Loaded)
+	assert.False(t, FileExists(cp.CpFilePath))
+
+	//the source version is a different checkpoint
+	request.SourceVersionId = Ptr("version-id")
+	vcp := newCopyCheckpoint(request, cpDir, header, partSize)
+	assert.NotEqual(t, cp.CpFilePath, vcp.CpFilePath)
+	assert.Equal(t, "version-id", vcp.Info.Data.ObjectInfo.VersionId)
+}
+
+func TestCopyPrefixCheckpoint(t *testing.T) {
+	cpDir := t.TempDir() + "/"
+
+	cp := newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", cpDir)
+	assert.NotNil(t, cp)
+	assert.Equal(t, "oss://bucket/src/", cp.Info.Data.Source)
+	assert.Equal(t, "oss://dest-bucket/dest/", cp.Info.Data.Destination)
+	assert.Equal(t, CheckpointMagic, cp.Info.Magic)
+	assert.True(t, strings.HasSuffix(cp.CpFilePath, CheckpointFileSuffixCopyPrefix))
+
+	//check load without file
+	err := cp.load()
+	assert.Nil(t, err)
+	assert.False(t, cp.Loaded)
+
+	//check complete, the first one is dumped
+	cp.complete("src/a", "etag-a", "dest/a")
+	cp.complete("src/b", "etag-b", "dest/b")
+	assert.True(t, FileExists(cp.CpFilePath))
+	assert.True(t, cp.completed("src/a", "etag-a", "dest/a"))
+	assert.False(t, cp.completed("src/a", "etag-a2", "dest/a"))
+	assert.False(t, cp.completed("src/a", "etag-a", "dest/a2"))
+	assert.False(t, cp.completed("src/c", "etag-c", "dest/c"))
+
+	//check load
+	cp = newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", cpDir)
+	err = cp.load()
+	assert.Nil(t, err)
+	assert.True(t, cp.Loaded)
+	assert.True(t, cp.completed("src/a", "etag-a", "dest/a"))
+	assert.False(t, cp.completed("src/b", "etag-b", "dest/b"))
+
+	//check dump
+	old := newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", cpDir)
+	old.complete("src/b", "etag-b", "dest/b")
+	assert.Nil(t, old.dump())
+	cp = newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", cpDir)
+	assert.Nil(t, cp.load())
+	assert.True(t, cp.completed("src/b", "etag-b", "dest/b"))
+
+	//load not match
+	cp = newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", cpDir)
+	err = os.WriteFile(cp.CpFilePath, []byte(`{"Magic":"92611BED-89E2-46B6-89E5-72F273D4B0A3","MD5":"invalid","Data":{}}`), FilePermMode)
+	assert.Nil(t, err)
+	err = cp.load()
+	assert.Nil(t, err)
+	assert.False(t, cp.Loaded)
+	assert.False(t, FileExists(cp.CpFilePath))
+
+	//invalid dir
+	cp = newCopyPrefixCheckpoint("bucket", "src/", "dest-bucket", "dest/", "/not-exist-dir/cp")
+	assert.NotNil(t, cp.load())
+}
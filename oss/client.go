@@ -54,12 +54,22 @@ type Options struct {
 	AdditionalHeaders []string
 
 	EndpointProvider EndpointProvider
+
+	Middlewares []Middleware
+
+	// The maximum size of a non-seekable request body buffered for retries, 0 disables it.
+	ReplayBufferSize *int64
+
+	// The maximum size of the replay buffer kept in memory, the rest spills to a temporary file.
+	ReplayBufferMemorySize *int64
 }
 
 func (c Options) Copy() Options {
 	to := c
 	to.ResponseHandlers = make([]func(*http.Response) error, len(c.ResponseHandlers))
 	copy(to.ResponseHandlers, c.ResponseHandlers)
+	to.Middlewares = make([]Middleware, len(c.Middlewares))
+	copy(to.Middlewares, c.Middlewares)
 	return to
 }
 
@@ -78,10 +88,18 @@ type innerOptions struct {
 	// Logger
 	Log Logger
 
+	// The headers and queries to redact in logs
+	LogRedactor *logRedactor
+
+	// The endpoints to fail over between
+	EndpointGroup *endpointGroup
+
 	// UserAgent
 	UserAgent string
 }
 
+//go:generate go run ./internal/apigen -type Client -interface API -mock ossmock
+
 type Client struct {
 	options Options
 	inner   innerOptions
@@ -89,18 +107,24 @@ type Client struct {
 
 func NewClient(cfg *Config, optFns ...func(*Options)) *Client {
 	options := Options{
-		Product:             DefaultProduct,
-		Region:              ToString(cfg.Region),
-		RetryMaxAttempts:    cfg.RetryMaxAttempts,
-		Retryer:             cfg.Retryer,
-		CredentialsProvider: cfg.CredentialsProvider,
-		HttpClient:          cfg.HttpClient,
-		FeatureFlags:        FeatureFlagsDefault,
-		AdditionalHeaders:   cfg.AdditionalHeaders,
+		Product:                DefaultProduct,
+		Region:                 ToString(cfg.Region),
+		RetryMaxAttempts:       cfg.RetryMaxAttempts,
+		Retryer:                cfg.Retryer,
+		CredentialsProvider:    cfg.CredentialsProvider,
+		HttpClient:             cfg.HttpClient,
+		FeatureFlags:           FeatureFlagsDefault,
+		AdditionalHeaders:      cfg.AdditionalHeaders,
+		ReplayBufferSize:       cfg.ReplayBufferSize,
+		ReplayBufferMemorySize: cfg.ReplayBufferMemorySize,
 	}
 	inner := innerOptions{
-		Log:       NewLogger(ToInt(cfg.LogLevel), cfg.LogPrinter),
-		UserAgent: buildUserAgent(cfg),
+		Log:         NewLogger(ToInt(cfg.LogLevel), cfg.LogPrinter),
+		LogRedactor: newLogRedactor(cfg.LogRedactHeaders, cfg.LogRedactQueries),
+		UserAgent:   buildUserAgent(cfg),
+	}
+	if cfg.StructuredLogPrinter != nil {
+		inner.Log = NewStructuredLogger(ToInt(cfg.LogLevel), cfg.StructuredLogPrinter)
 	}
 
 	resolveEndpoint(cfg, &options)
@@ -110,11 +134,17 @@ func NewClient(cfg *Config, optFns ...func(*Options)) *Client {
 	resolveUrlStyle(cfg, &options)
 	resolveFeatureFlags(cfg, &options)
 	resolveCloudBox(cfg, &options)
+	resolveCredentialsProvider(cfg, &options)
 
 	for _, fn := range optFns {
 		fn(&options)
 	}
 
+	// depends on the endpoint and product which may be changed by optFns
+	resolveEndpointFailover(cfg, &options, &inner)
+	resolveMetricsCollector(cfg, &options)
+	resolveTracer(cfg, &options)
+
 	client := &Client{
 		options: options,
 		inner:   inner,
@@ -296,6 +326,71 @@ func resolveCloudBox(cfg *Config, o *Options) {
 	o.Product = CloudBoxProduct
 }
 
+// resolveCredentialsProvider uses the default credentials chain if no provider is set.
+func resolveCredentialsProvider(cfg *Config, o *Options) {
+	if cfg.CredentialsProvider == nil {
+		o.CredentialsProvider = credentials.NewDefaultChainProvider()
+	}
+}
+
+func resolveEndpointFailover(cfg *Config, o *Options, inner *innerOptions) {
+	if o.Endpoint == nil || (len(cfg.FailoverEndpoints) == 0 && len(cfg.FailoverEndpointTypes) == 0) {
+		return
+	}
+
+	disableSSL := ToBool(cfg.DisableSSL)
+	endpoints := []*url.URL{o.Endpoint}
+	seen := map[string]bool{o.Endpoint.Host: true}
+	add := func(endpoint string) {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" || seen[u.Host] {
+			return
+		}
+		seen[u.Host] = true
+		endpoints = append(endpoints, u)
+	}
+	for _, e := range cfg.FailoverEndpoints {
+		add(addEndpointScheme(e, disableSSL))
+	}
+	if region := ToString(cfg.Region); isValidRegion(region) {
+		for _, t := range cfg.FailoverEndpointTypes {
+			add(endpointFromRegion(region, disableSSL, t))
+		}
+	}
+
+	if len(endpoints) < 2 {
+		return
+	}
+
+	threshold := DefaultEndpointFailureThreshold
+	if ToInt(cfg.EndpointFailureThreshold) > 0 {
+		threshold = ToInt(cfg.EndpointFailureThreshold)
+	}
+	interval := DefaultEndpointRecoveryInterval
+	if cfg.EndpointRecoveryInterval != nil {
+		interval = *cfg.EndpointRecoveryInterval
+	}
+
+	inner.EndpointGroup = newEndpointGroup(endpoints, threshold, interval, inner.Log)
+	o.Middlewares = append([]Middleware{endpointFailoverMiddleware(inner.EndpointGroup)}, o.Middlewares...)
+}
+
+func resolveTracer(cfg *Config, o *Options) {
+	if cfg.Tracer == nil {
+		return
+	}
+
+	o.Middlewares = append(tracingMiddlewares(cfg.Tracer, o.Product), o.Middlewares...)
+}
+
+func resolveMetricsCollector(cfg *Config, o *Options) {
+	if cfg.MetricsCollector == nil {
+		return
+	}
+
+	o.Middlewares = append(metricsMiddlewares(cfg.MetricsCollector, o.Product), o.Middlewares...)
+}
+
 func buildUserAgent(cfg *Config) string {
 	if cfg.UserAgent == nil {
 		return defaultUserAgent
@@ -305,6 +400,12 @@ func buildUserAgent(cfg *Config) string {
 }
 
 func (c *Client) invokeOperation(ctx context.Context, input *OperationInput, optFns []func(*Options)) (output *OperationOutput, err error) {
+	// the input is built for Presign
+	if capture, ok := ctx.Value(captureInputKey{}).(*inputCapture); ok {
+		capture.inputs = append(capture.inputs, input)
+		return nil, errInputCaptured
+	}
+
 	if c.getLogLevel() >= LogInfo {
 		c.inner.Log.Infof("InvokeOperation Start: input[%p], OpName:%s, Bucket:%s, Key:%s",
 			input, input.OpName,
@@ -329,7 +430,13 @@ func (c *Client) invokeOperation(ctx context.Context, input *OperationInput, opt
 
 	ctx = applyOperationContext(ctx, &options)
 
-	output, err = c.sendRequest(ctx, input, &options)
+	mctx := &MiddlewareContext{Input: input}
+	err = handleMiddlewares(ctx, options.Middlewares, MiddlewareStepInitialize, mctx,
+		func(ctx context.Context, mctx *MiddlewareContext) (err error) {
+			mctx.Output, err = c.sendRequest(ctx, mctx.Input, &options, mctx)
+			return err
+		})
+	output = mctx.Output
 
 	if err != nil {
 		return output, &OperationError{
@@ -340,7 +447,7 @@ func (c *Client) invokeOperation(ctx context.Context, input *OperationInput, opt
 	return output, err
 }
 
-func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *Options) (output *OperationOutput, err error) {
+func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *Options, mctx *MiddlewareContext) (output *OperationOutput, err error) {
 	var request *http.Request
 	var response *http.Response
 	if c.getLogLevel() >= LogInfo {
@@ -363,13 +470,7 @@ func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *O
 		}
 	}
 	// host & path
-	var strUrl string
-	if opts.EndpointProvider != nil {
-		strUrl = opts.EndpointProvider.BuildURL(input)
-	} else {
-		host, path := buildURL(input, opts)
-		strUrl = fmt.Sprintf("%s://%s%s", opts.Endpoint.Scheme, host, path)
-	}
+	strUrl := buildRequestURL(input, opts)
 
 	// querys
 	if len(input.Parameters) > 0 {
@@ -415,7 +516,11 @@ func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *O
 	if length >= 0 {
 		request.ContentLength = length
 	}
-	request.Body = TeeReadNopCloser(body, writers...)
+	tee := TeeReadNopCloser(body, writers...).(*teeReadNopCloser)
+	if c.retryMaxAttempts(opts) > 1 {
+		tee.enableReplay(ToInt64(opts.ReplayBufferSize), replayBufferMemorySize(opts))
+	}
+	request.Body = tee
 
 	//signing context
 	subResource, _ := input.OpMetadata.Get(signer.SubResource).([]string)
@@ -432,6 +537,10 @@ func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *O
 		AdditionalHeaders: opts.AdditionalHeaders,
 	}
 
+	if streaming, _ := input.OpMetadata.Get(OpMetaKeyStreamingPayload).(bool); streaming && c.canStreamPayload(opts, signingCtx) {
+		setStreamingPayloadHeaders(request, length)
+	}
+
 	if date := request.Header.Get(HeaderOssDate); date != "" {
 		signingCtx.Time, _ = http.ParseTime(date)
 	} else if signTime, ok := input.OpMetadata.Get(signer.SignTime).(time.Time); ok {
@@ -439,22 +548,34 @@ func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *O
 	}
 
 	// send http request
-	response, err = c.sendHttpRequest(ctx, signingCtx, opts)
+	mctx.Request = request
+	err = handleMiddlewares(ctx, opts.Middlewares, MiddlewareStepBuild, mctx,
+		func(ctx context.Context, mctx *MiddlewareContext) error {
+			signingCtx.Request = mctx.Request
+			if err := c.sendHttpRequest(ctx, signingCtx, opts, mctx); err != nil {
+				return err
+			}
+
+			// covert http response into output context
+			return handleMiddlewares(ctx, opts.Middlewares, MiddlewareStepDeserialize, mctx,
+				func(_ context.Context, mctx *MiddlewareContext) error {
+					mctx.Output = &OperationOutput{
+						Input:       input,
+						Status:      mctx.Response.Status,
+						StatusCode:  mctx.Response.StatusCode,
+						Body:        mctx.Response.Body,
+						Headers:     mctx.Response.Header,
+						httpRequest: mctx.Request,
+					}
+					return nil
+				})
+		})
+	request, response, output = mctx.Request, mctx.Response, mctx.Output
 
 	if err != nil {
 		return output, err
 	}
 
-	// covert http response into output context
-	output = &OperationOutput{
-		Input:       input,
-		Status:      response.Status,
-		StatusCode:  response.StatusCode,
-		Body:        response.Body,
-		Headers:     response.Header,
-		httpRequest: request,
-	}
-
 	// save other info by Metadata filed, ex. retry detail info
 	//output.OpMetadata.Set(...)
 	if signingCtx.AuthMethodQuery {
@@ -468,19 +589,32 @@ func (c *Client) sendRequest(ctx context.Context, input *OperationInput, opts *O
 	return output, err
 }
 
-func (c *Client) sendHttpRequest(ctx context.Context, signingCtx *signer.SigningContext, opts *Options) (response *http.Response, err error) {
+func (c *Client) sendHttpRequest(ctx context.Context, signingCtx *signer.SigningContext, opts *Options, mctx *MiddlewareContext) (err error) {
 	request := signingCtx.Request
 	retryer := opts.Retryer
 	maxAttempts := c.retryMaxAttempts(opts)
 	body, _ := request.Body.(*teeReadNopCloser)
 	resetTime := signingCtx.Time.IsZero()
+	handler, _ := retryer.(retry.AttemptHandler)
 	body.Mark()
+	if body.replay != nil {
+		defer func() {
+			if body.replay.replays > 0 {
+				c.inner.Log.Infof("Replay buffer released, request[%p], replays:%v, buffered:%v, spilled:%v",
+					request, body.replay.replays, body.replay.size, body.replay.spilled())
+			}
+			body.replay.release()
+		}()
+	}
+	var retriedErr error
 	for tries := 1; tries <= maxAttempts; tries++ {
 		if tries > 1 {
-			delay, err := retryer.RetryDelay(tries, err)
-			if err != nil {
+			delay, derr := retryer.RetryDelay(tries, err)
+			if derr != nil {
+				err = &RetryStoppedError{Err: err, RetryErr: derr}
 				break
 			}
+			retriedErr = err
 
 			if err = sleepWithContext(ctx, delay); err != nil {
 				err = &CanceledError{Err: err}
@@ -491,6 +625,10 @@ func (c *Client) sendHttpRequest(ctx context.Context, signingCtx *signer.Signing
 				break
 			}
 
+			if body.replaying != nil {
+				c.inner.Log.Infof("Replay request body, request[%p], buffered:%v, spilled:%v", request, body.replay.size, body.replay.spilled())
+			}
+
 			if resetTime {
 				signingCtx.Time = time.Time{}
 			}
@@ -498,66 +636,113 @@ func (c *Client) sendHttpRequest(ctx context.Context, signingCtx *signer.Signing
 			c.inner.Log.Infof("Attempt retry, request[%p], tries:%v, retry delay:%v", request, tries, delay)
 		}
 
-		if response, err = c.sendHttpRequestOnce(ctx, signingCtx, opts); err == nil {
+		if handler != nil {
+			if err = handler.BeforeAttempt(ctx); err != nil {
+				err = &CanceledError{Err: err}
+				break
+			}
+		}
+
+		mctx.Attempt = tries
+		err = c.sendHttpRequestOnce(ctx, signingCtx, opts, mctx)
+
+		if handler != nil {
+			handler.AfterAttempt(tries, err, retriedErr)
+		}
+
+		if err == nil {
 			break
 		}
 
-		c.postSendHttpRequestOnce(signingCtx, response, err)
+		c.postSendHttpRequestOnce(signingCtx, mctx.Response, err)
 
 		if isContextError(ctx, &err) {
 			err = &CanceledError{Err: err}
 			break
 		}
 
-		if !body.IsSeekable() {
+		if !retryer.IsErrorRetryable(err) {
 			break
 		}
 
-		if !retryer.IsErrorRetryable(err) {
+		if !body.IsReplayable() {
+			c.logNotReplayable(request, body)
 			break
 		}
 	}
-	return response, err
+	return err
+}
+
+func (c *Client) logNotReplayable(request *http.Request, body *teeReadNopCloser) {
+	switch {
+	case body.replay == nil:
+		c.inner.Log.Infof("Stop retry, request[%p], the request body is not seekable and the replay buffer is disabled", request)
+	case body.replay.err != nil:
+		c.inner.Log.Warnf("Stop retry, request[%p], the replay buffer failed, err:%v", request, body.replay.err)
+	default:
+		c.inner.Log.Infof("Stop retry, request[%p], the request body exceeds the replay buffer size %v", request, body.replay.limit)
+	}
 }
 
-func (c *Client) sendHttpRequestOnce(ctx context.Context, signingCtx *signer.SigningContext, opts *Options) (
-	response *http.Response, err error,
-) {
+func replayBufferMemorySize(opts *Options) int64 {
+	if opts.ReplayBufferMemorySize == nil {
+		return -1
+	}
+	return *opts.ReplayBufferMemorySize
+}
+
+func (c *Client) sendHttpRequestOnce(ctx context.Context, signingCtx *signer.SigningContext, opts *Options, mctx *MiddlewareContext) (err error) {
 	if c.getLogLevel() > LogInfo {
 		c.inner.Log.Infof("sendHttpRequestOnce Start, http.Request[%p]", signingCtx.Request)
 		defer func() {
-			c.inner.Log.Infof("sendHttpRequestOnce End, http.Request[%p], response[%p], err:%v", signingCtx.Request, response, err)
+			c.inner.Log.Infof("sendHttpRequestOnce End, http.Request[%p], response[%p], err:%v", signingCtx.Request, mctx.Response, err)
 		}()
 	}
 
-	if _, anonymous := opts.CredentialsProvider.(*credentials.AnonymousCredentialsProvider); !anonymous {
-		cred, err := opts.CredentialsProvider.GetCredentials(ctx)
-		if err != nil {
-			return response, err
-		}
+	mctx.Response = nil
+	mctx.Request = signingCtx.Request
+	return handleMiddlewares(ctx, opts.Middlewares, MiddlewareStepBeforeSign, mctx,
+		func(ctx context.Context, mctx *MiddlewareContext) error {
+			signingCtx.Request = mctx.Request
+			if _, anonymous := opts.CredentialsProvider.(*credentials.AnonymousCredentialsProvider); !anonymous {
+				cred, err := opts.CredentialsProvider.GetCredentials(ctx)
+				if err != nil {
+					return err
+				}
 
-		signingCtx.Credentials = &cred
-		if err = c.options.Signer.Sign(ctx, signingCtx); err != nil {
-			return response, err
-		}
-		c.inner.Log.Debugf("sendHttpRequestOnce::Sign request[%p], StringToSign:%s", signingCtx.Request, signingCtx.StringToSign)
-	}
+				signingCtx.Credentials = &cred
+				if err = c.options.Signer.Sign(ctx, signingCtx); err != nil {
+					return err
+				}
+				c.logStringToSign(signingCtx)
+			}
 
-	c.logHttpPRequet(signingCtx.Request)
+			return handleMiddlewares(ctx, opts.Middlewares, MiddlewareStepAfterSign, mctx,
+				func(_ context.Context, mctx *MiddlewareContext) (err error) {
+					c.logHttpPRequet(mctx.Request)
 
-	if response, err = opts.HttpClient.Do(signingCtx.Request); err != nil {
-		return response, err
-	}
+					request := mctx.Request
+					if signer.IsStreamingPayload(request.Header.Get(HeaderOssContentSha256)) {
+						if request, err = streamingPayloadRequest(request, signingCtx); err != nil {
+							return err
+						}
+					}
 
-	c.logHttpResponse(signingCtx.Request, response)
+					if mctx.Response, err = opts.HttpClient.Do(request); err != nil {
+						return err
+					}
 
-	for _, fn := range opts.ResponseHandlers {
-		if err = fn(response); err != nil {
-			return response, err
-		}
-	}
+					c.logHttpResponse(mctx.Request, mctx.Response)
 
-	return response, err
+					for _, fn := range opts.ResponseHandlers {
+						if err = fn(mctx.Response); err != nil {
+							return err
+						}
+					}
+
+					return err
+				})
+		})
 }
 
 func (c *Client) postSendHttpRequestOnce(signingCtx *signer.SigningContext, _ *http.Response, err error) {
@@ -575,6 +760,15 @@ func (c *Client) postSendHttpRequestOnce(signingCtx *signer.SigningContext, _ *h
 	}
 }
 
+// buildRequestURL returns the URL of the request without the query.
+func buildRequestURL(input *OperationInput, opts *Options) string {
+	if opts.EndpointProvider != nil {
+		return opts.EndpointProvider.BuildURL(input)
+	}
+	host, path := buildURL(input, opts)
+	return fmt.Sprintf("%s://%s%s", opts.Endpoint.Scheme, host, path)
+}
+
 func buildURL(input *OperationInput, opts *Options) (host string, path string) {
 	if input == nil || opts == nil || opts.Endpoint == nil {
 		return host, path
@@ -749,6 +943,18 @@ func applyOperationOpt(c *Options, op *Options) {
 		c.AuthMethod = op.AuthMethod
 	}
 
+	if len(op.Middlewares) > 0 {
+		c.Middlewares = append(c.Middlewares, op.Middlewares...)
+	}
+
+	if op.ReplayBufferSize != nil {
+		c.ReplayBufferSize = op.ReplayBufferSize
+	}
+
+	if op.ReplayBufferMemorySize != nil {
+		c.ReplayBufferMemorySize = op.ReplayBufferMemorySize
+	}
+
 	//response handler
 	handlers := []func(*http.Response) error{
 		serviceErrorResponseHandler,
@@ -771,6 +977,11 @@ func applyOperationMetadata(input *OperationInput, c *Options) {
 			c.ResponseHandlers = append(c.ResponseHandlers, hh)
 		}
 	}
+
+	// the request is authorized by its body, such as the signed policy of a form upload
+	if input.OpMetadata.Get(OpMetaKeyUnsigned) == true {
+		c.CredentialsProvider = credentials.NewAnonymousCredentialsProvider()
+	}
 }
 
 // fieldInfo holds details for the input/output of a single field.
@@ -1201,6 +1412,10 @@ func unmarshalCallbackBody(result any, output *OperationOutput) error {
 			if err = json.Unmarshal(body, &r.CallbackResult); err != nil {
 				return err
 			}
+		case *PostObjectResult:
+			if err = json.Unmarshal(body, &r.CallbackResult); err != nil {
+				return err
+			}
 		}
 	}
 	return err
@@ -1436,6 +1651,18 @@ func enableNonStream(_ any, input *OperationInput) error {
 	return nil
 }
 
+func (c *Client) addStreamingPayload(_ any, input *OperationInput) error {
+	if !c.hasFeature(FeatureExperimentalStreamingPayloadSign) || input.Body == nil {
+		return nil
+	}
+	_, seekable := input.Body.(io.Seeker)
+	unknownLength := input.Headers[HTTPHeaderContentLength] == "" && GetReaderLen(input.Body) < 0
+	if unknownLength || !seekable {
+		input.OpMetadata.Set(OpMetaKeyStreamingPayload, true)
+	}
+	return nil
+}
+
 func (c *Client) updateContentType(request any, input *OperationInput) error {
 	if !c.hasFeature(FeatureAutoDetectMimeType) {
 		return nil
@@ -1529,24 +1756,32 @@ func (c *Client) logHttpPRequet(request *http.Request) {
 	if c.getLogLevel() < LogDebug {
 		return
 	}
+	redactor := c.getLogRedactor()
+	if l, ok := c.inner.Log.(*structuredLogger); ok {
+		keyvals := []any{"request", fmt.Sprintf("%p", request)}
+		if request != nil {
+			keyvals = append(keyvals,
+				"method", request.Method,
+				"host", request.URL.Host,
+				"path", request.URL.Path,
+				"query", redactor.redactQuery(request.URL.RawQuery),
+				"headers", redactor.redactHeaders(request.Header),
+			)
+		}
+		l.printer.Print(LogDebug, "http request", keyvals...)
+		return
+	}
 	var logBuffer bytes.Buffer
 	logBuffer.WriteString(fmt.Sprintf("http.request[%p]", request))
 	if request != nil {
 		logBuffer.WriteString(fmt.Sprintf("Method:%s\t", request.Method))
 		logBuffer.WriteString(fmt.Sprintf("Host:%s\t", request.URL.Host))
 		logBuffer.WriteString(fmt.Sprintf("Path:%s\t", request.URL.Path))
-		logBuffer.WriteString(fmt.Sprintf("Query:%s\t", request.URL.RawQuery))
+		logBuffer.WriteString(fmt.Sprintf("Query:%s\t", redactor.redactQuery(request.URL.RawQuery)))
 		logBuffer.WriteString(fmt.Sprintf("Header info:"))
 
 		for k, v := range request.Header {
-			var valueBuffer bytes.Buffer
-			for j := 0; j < len(v); j++ {
-				if j > 0 {
-					valueBuffer.WriteString(" ")
-				}
-				valueBuffer.WriteString(v[j])
-			}
-			logBuffer.WriteString(fmt.Sprintf("\t%s:%s", k, valueBuffer.String()))
+			logBuffer.WriteString(fmt.Sprintf("\t%s:%s", k, redactor.redactHeader(k, v)))
 		}
 	}
 
@@ -1558,25 +1793,51 @@ func (c *Client) logHttpResponse(request *http.Request, response *http.Response)
 	if c.getLogLevel() < LogDebug {
 		return
 	}
+	redactor := c.getLogRedactor()
+	if l, ok := c.inner.Log.(*structuredLogger); ok {
+		keyvals := []any{"request", fmt.Sprintf("%p", request), "response", fmt.Sprintf("%p", response)}
+		if response != nil {
+			keyvals = append(keyvals,
+				"status_code", response.StatusCode,
+				"request_id", response.Header.Get(HeaderOssRequestID),
+				"headers", redactor.redactHeaders(response.Header),
+			)
+		}
+		l.printer.Print(LogDebug, "http response", keyvals...)
+		return
+	}
 	var logBuffer bytes.Buffer
 	logBuffer.WriteString(fmt.Sprintf("http.request[%p]|http.response[%p]", request, response))
 	if response != nil {
 		logBuffer.WriteString(fmt.Sprintf("StatusCode:%d\t", response.StatusCode))
 		logBuffer.WriteString(fmt.Sprintf("Header info:"))
 		for k, v := range response.Header {
-			var valueBuffer bytes.Buffer
-			for j := 0; j < len(v); j++ {
-				if j > 0 {
-					valueBuffer.WriteString(" ")
-				}
-				valueBuffer.WriteString(v[j])
-			}
-			logBuffer.WriteString(fmt.Sprintf("\t%s:%s", k, valueBuffer.String()))
+			logBuffer.WriteString(fmt.Sprintf("\t%s:%s", k, redactor.redactHeader(k, v)))
 		}
 	}
 	c.inner.Log.Debugf("%s", logBuffer.String())
 }
 
+// logStringToSign Print the string to sign without the redacted values
+func (c *Client) logStringToSign(signingCtx *signer.SigningContext) {
+	if c.getLogLevel() < LogDebug {
+		return
+	}
+	var securityToken string
+	if signingCtx.Credentials != nil {
+		securityToken = signingCtx.Credentials.SecurityToken
+	}
+	c.inner.Log.Debugf("sendHttpRequestOnce::Sign request[%p], StringToSign:%s", signingCtx.Request,
+		c.getLogRedactor().redactStringToSign(signingCtx.StringToSign, securityToken))
+}
+
+func (c *Client) getLogRedactor() *logRedactor {
+	if c.inner.LogRedactor != nil {
+		return c.inner.LogRedactor
+	}
+	return newLogRedactor(nil, nil)
+}
+
 func (c *Client) getLogLevel() int {
 	if c.inner.Log != nil {
 		return c.inner.Log.Level()
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14945,6 +14946,190 @@ func TestInvokeOperation_RetryMaxAttempts(t *testing.T) {
 	}
 }
 
+type stubAttemptRetryer struct {
+	retry.Retryer
+	beforeErr error
+	before    int
+	after     []error
+	retried   []error
+}
+
+func (r *stubAttemptRetryer) BeforeAttempt(ctx context.Context) error {
+	r.before++
+	return r.beforeErr
+}
+
+func (r *stubAttemptRetryer) AfterAttempt(attempt int, err error, retriedErr error) {
+	r.after = append(r.after, err)
+	r.retried = append(r.retried, retriedErr)
+}
+
+func TestInvokeOperation_AdaptiveRetryer(t *testing.T) {
+	var recv int32
+	server := testSetupMockServer(t, 500,
+		map[string]string{
+			"Content-Type": "application/xml",
+		},
+		[]byte(
+			`<?xml version="1.0" encoding="UTF-8"?>
+			<Error>
+				<Code>InternalError</Code>
+				<Message>Please try again.</Message>
+			</Error>`),
+		func(t *testing.T, r *http.Request) {
+			atomic.AddInt32(&recv, 1)
+		})
+	defer server.Close()
+
+	input := &OperationInput{
+		OpName: "GetObject",
+		Method: "GET",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}
+
+	// the retry quota stops retrying
+	retryer := retry.NewAdaptive(func(ro *retry.AdaptiveOptions) {
+		ro.MaxAttempts = 5
+		ro.Backoff = retry.NewFixedDelayBackoff(0)
+		ro.RetryQuota = 10
+	})
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryer(retryer)
+
+	client := NewClient(cfg)
+	_, err := client.InvokeOperation(context.TODO(), input)
+	assert.NotNil(t, err)
+	var serr *ServiceError
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, "InternalError", serr.Code)
+	assert.True(t, errors.Is(err, retry.ErrRetryQuotaExceeded))
+	var rerr *RetryStoppedError
+	assert.True(t, errors.As(err, &rerr))
+	assert.Contains(t, err.Error(), "retry quota exceeded")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&recv))
+	assert.Equal(t, 0, retryer.AvailableQuota())
+
+	// attempt hooks
+	atomic.StoreInt32(&recv, 0)
+	stub := &stubAttemptRetryer{
+		Retryer: retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.MaxAttempts = 2
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		}),
+	}
+	cfg = LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryer(stub)
+	client = NewClient(cfg)
+	_, err = client.InvokeOperation(context.TODO(), input)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&recv))
+	assert.Equal(t, 2, stub.before)
+	assert.Len(t, stub.after, 2)
+	assert.NotNil(t, stub.after[0])
+	assert.NotNil(t, stub.after[1])
+	assert.Nil(t, stub.retried[0])
+	assert.Equal(t, stub.after[0], stub.retried[1])
+
+	// the attempt is not sent if BeforeAttempt fails
+	atomic.StoreInt32(&recv, 0)
+	stub.beforeErr = context.DeadlineExceeded
+	stub.before = 0
+	stub.after = nil
+	_, err = client.InvokeOperation(context.TODO(), input)
+	assert.NotNil(t, err)
+	var cerr *CanceledError
+	assert.True(t, errors.As(err, &cerr))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&recv))
+	assert.Equal(t, 1, stub.before)
+	assert.Len(t, stub.after, 0)
+}
+
+func TestInvokeOperation_ReplayNonSeekableBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(500)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<Error>
+				<Code>InternalError</Code>
+				<Message>Please try again.</Message>
+			</Error>`))
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	data := strings.Repeat("0123456789", 100)
+	newInput := func() *OperationInput {
+		return &OperationInput{
+			OpName: "PutObject",
+			Method: "PUT",
+			Bucket: Ptr("bucket"),
+			Key:    Ptr("key"),
+			Body:   io.MultiReader(strings.NewReader(data)),
+		}
+	}
+
+	var logs []string
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		})).
+		WithLogLevel(LogInfo).
+		WithLogPrinter(LogPrinterFunc(func(a ...any) {
+			logs = append(logs, fmt.Sprint(a...))
+		}))
+
+	// disabled by default
+	client := NewClient(cfg)
+	_, err := client.InvokeOperation(context.TODO(), newInput())
+	assert.NotNil(t, err)
+	assert.Len(t, bodies, 1)
+	assert.Contains(t, strings.Join(logs, ""), "the replay buffer is disabled")
+
+	// exceeds the replay buffer size
+	bodies, logs = nil, nil
+	_, err = client.InvokeOperation(context.TODO(), newInput(), func(o *Options) {
+		o.ReplayBufferSize = Ptr(int64(100))
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, bodies, 1)
+	assert.Contains(t, strings.Join(logs, ""), "exceeds the replay buffer size 100")
+
+	// replay in memory
+	bodies, logs = nil, nil
+	client = NewClient(cfg.WithReplayBufferSize(1024))
+	output, err := client.InvokeOperation(context.TODO(), newInput())
+	assert.Nil(t, err)
+	assert.Equal(t, 200, output.StatusCode)
+	assert.Equal(t, []string{data, data}, bodies)
+	assert.Contains(t, strings.Join(logs, ""), "buffered:1000, spilled:0")
+
+	// spill to temporary file
+	bodies, logs = nil, nil
+	client = NewClient(cfg.WithReplayBufferMemorySize(100))
+	output, err = client.InvokeOperation(context.TODO(), newInput())
+	assert.Nil(t, err)
+	assert.Equal(t, 200, output.StatusCode)
+	assert.Equal(t, []string{data, data}, bodies)
+	assert.Contains(t, strings.Join(logs, ""), "buffered:1000, spilled:900")
+}
+
 var testMockUserAgentCases = []struct {
 	StatusCode     int
 	Headers        map[string]string
@@ -75,17 +75,21 @@ func (p *ListObjectsPaginator) NextPage(ctx context.Context, optFns ...func(*Opt
 	return result, nil
 }
 
+type ListObjectsV2APIClient interface {
+	ListObjectsV2(ctx context.Context, request *ListObjectsV2Request, optFns ...func(*Options)) (*ListObjectsV2Result, error)
+}
+
 // ListObjectsV2Paginator is a paginator for ListObjectsV2
 type ListObjectsV2Paginator struct {
 	options       PaginatorOptions
-	client        *Client
+	client        ListObjectsV2APIClient
 	request       *ListObjectsV2Request
 	continueToken *string
 	firstPage     bool
 	isTruncated   bool
 }
 
-func (c *Client) NewListObjectsV2Paginator(request *ListObjectsV2Request, optFns ...func(*PaginatorOptions)) *ListObjectsV2Paginator {
+func NewListObjectsV2Paginator(c ListObjectsV2APIClient, request *ListObjectsV2Request, optFns ...func(*PaginatorOptions)) *ListObjectsV2Paginator {
 	if request == nil {
 		request = &ListObjectsV2Request{}
 	}
@@ -107,6 +111,10 @@ func (c *Client) NewListObjectsV2Paginator(request *ListObjectsV2Request, optFns
 	}
 }
 
+func (c *Client) NewListObjectsV2Paginator(request *ListObjectsV2Request, optFns ...func(*PaginatorOptions)) *ListObjectsV2Paginator {
+	return NewListObjectsV2Paginator(c, request, optFns...)
+}
+
 // HasNext Returns true if there’s a next page.
 func (p *ListObjectsV2Paginator) HasNext() bool {
 	return p.firstPage || p.isTruncated
@@ -140,10 +148,14 @@ func (p *ListObjectsV2Paginator) NextPage(ctx context.Context, optFns ...func(*O
 	return result, nil
 }
 
+type ListObjectVersionsAPIClient interface {
+	ListObjectVersions(ctx context.Context, request *ListObjectVersionsRequest, optFns ...func(*Options)) (*ListObjectVersionsResult, error)
+}
+
 // ListObjectVersionsPaginator is a paginator for ListObjectVersions
 type ListObjectVersionsPaginator struct {
 	options         PaginatorOptions
-	client          *Client
+	client          ListObjectVersionsAPIClient
 	request         *ListObjectVersionsRequest
 	keyMarker       *string
 	versionIdMarker *string
@@ -151,7 +163,7 @@ type ListObjectVersionsPaginator struct {
 	isTruncated     bool
 }
 
-func (c *Client) NewListObjectVersionsPaginator(request *ListObjectVersionsRequest, optFns ...func(*PaginatorOptions)) *ListObjectVersionsPaginator {
+func NewListObjectVersionsPaginator(c ListObjectVersionsAPIClient, request *ListObjectVersionsRequest, optFns ...func(*PaginatorOptions)) *ListObjectVersionsPaginator {
 	if request == nil {
 		request = &ListObjectVersionsRequest{}
 	}
@@ -174,6 +186,10 @@ func (c *Client) NewListObjectVersionsPaginator(request *ListObjectVersionsReque
 	}
 }
 
+func (c *Client) NewListObjectVersionsPaginator(request *ListObjectVersionsRequest, optFns ...func(*PaginatorOptions)) *ListObjectVersionsPaginator {
+	return NewListObjectVersionsPaginator(c, request, optFns...)
+}
+
 // HasNext Returns true if there’s a next page.
 func (p *ListObjectVersionsPaginator) HasNext() bool {
 	return p.firstPage || p.isTruncated
@@ -2,11 +2,14 @@ package oss
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
 )
 
@@ -46,7 +49,20 @@ var (
 	}
 )
 
+// Presign generates the pre-signed URL of the request of an operation, such as *GetObjectRequest or *ListPartsRequest.
 func (c *Client) Presign(ctx context.Context, request any, optFns ...func(*PresignOptions)) (*PresignResult, error) {
+	return c.PresignOperation(ctx, c, request, optFns...)
+}
+
+// PresignOperation generates the pre-signed URL of the request of an operation of api, which invokes its operations
+// by this client, such as the clients of the tables and vectors packages.
+// The operation is the method of api named after the type of the request without the "Request" suffix,
+// for example, ListParts for *ListPartsRequest.
+// Except the requests of the object operations presigned by Presign before, such as *GetObjectRequest,
+// the operation is invoked to build the input and it stops before the request is sent. So the work which is done
+// by the operation before that is done by the presigning too, such as reading or hashing the body.
+// The operations which send other requests than their own can not be presigned.
+func (c *Client) PresignOperation(ctx context.Context, api any, request any, optFns ...func(*PresignOptions)) (*PresignResult, error) {
 	options := PresignOptions{}
 
 	if request == nil {
@@ -58,7 +74,7 @@ func (c *Client) Presign(ctx context.Context, request any, optFns ...func(*Presi
 	}
 
 	input := OperationInput{}
-	if err := c.marshalPresignInput(request, &input); err != nil {
+	if err := c.marshalPresignInput(ctx, api, request, &input); err != nil {
 		return nil, err
 	}
 
@@ -90,7 +106,7 @@ func PresignExpiration(value time.Time) func(*PresignOptions) {
 	}
 }
 
-func (c *Client) marshalPresignInput(request any, input *OperationInput) error {
+func (c *Client) marshalPresignInput(ctx context.Context, api any, request any, input *OperationInput) error {
 	switch t := request.(type) {
 	case *GetObjectRequest:
 		input.OpName = "GetObject"
@@ -131,12 +147,65 @@ func (c *Client) marshalPresignInput(request any, input *OperationInput) error {
 		input.Bucket = t.Bucket
 		input.Key = t.Key
 	default:
-		return NewErrParamInvalid(fmt.Sprintf("request %v", reflect.ValueOf(request).Type().String()))
+		return captureOperationInput(ctx, api, request, input)
 	}
 
 	return c.marshalInput(request, input)
 }
 
+type captureInputKey struct{}
+
+// inputCapture holds the inputs which are built by the operation invoked for presigning.
+type inputCapture struct {
+	inputs []*OperationInput
+}
+
+// errInputCaptured stops the operation whose input is captured, before it is sent.
+var errInputCaptured = errors.New("operation input captured")
+
+// captureOperationInput builds the input of the request by invoking the operation of api with a context,
+// which makes invokeOperation return the input instead of sending it.
+// The operation must build exactly one input, which is its own, otherwise another request would be presigned.
+func captureOperationInput(ctx context.Context, api any, request any, input *OperationInput) error {
+	av := reflect.ValueOf(api)
+	if !av.IsValid() || (av.Kind() == reflect.Ptr && av.IsNil()) {
+		return NewErrParamNull("api")
+	}
+	rt := reflect.TypeOf(request)
+	invalid := NewErrParamInvalid(fmt.Sprintf("request %v", rt.String()))
+	if rt.Kind() != reflect.Ptr || !strings.HasSuffix(rt.Elem().Name(), "Request") {
+		return invalid
+	}
+	method := av.MethodByName(strings.TrimSuffix(rt.Elem().Name(), "Request"))
+	if !method.IsValid() {
+		return invalid
+	}
+	mt := method.Type()
+	if mt.NumIn() != 3 || !mt.IsVariadic() || mt.In(1) != rt ||
+		mt.In(2) != reflect.TypeOf([]func(*Options){}) || mt.NumOut() != 2 {
+		return invalid
+	}
+
+	capture := &inputCapture{}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, captureInputKey{}, capture)
+	out := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(request)})
+	if len(capture.inputs) == 0 {
+		if err, ok := out[1].Interface().(error); ok && err != nil {
+			return err
+		}
+		return invalid
+	}
+	opName := strings.TrimSuffix(rt.Elem().Name(), "Request")
+	if len(capture.inputs) > 1 || capture.inputs[0].OpName != opName {
+		return fmt.Errorf("operation %s sends other requests, it can not be presigned", opName)
+	}
+	*input = *capture.inputs[0]
+	return nil
+}
+
 func (c *Client) unmarshalPresignOutput(result *PresignResult, output *OperationOutput) error {
 	if chk, ok := c.options.Signer.(interface{ IsSignedHeader([]string, string) bool }); ok {
 		header := map[string]string{}
@@ -162,3 +231,88 @@ func (c *Client) unmarshalPresignOutput(result *PresignResult, output *Operation
 	}
 	return nil
 }
+
+type PresignPostObjectResult struct {
+	// The URL of the bucket, to which the form is posted.
+	URL string
+
+	// The fields of the form, including the policy, its signature and the fields set by the policy.
+	// The file must be the last field of the form.
+	Fields map[string]string
+
+	// The time after which the form is rejected.
+	Expiration time.Time
+}
+
+// PresignPostObject signs the policy of a form upload, so that the browsers can upload the objects directly.
+// The policy must have a bucket, which is set by WithBucket.
+func (c *Client) PresignPostObject(ctx context.Context, policy *PostPolicy, optFns ...func(*Options)) (*PresignPostObjectResult, error) {
+	if policy == nil {
+		return nil, NewErrParamNull("policy")
+	}
+	if policy.Expiration.IsZero() {
+		return nil, NewErrParamRequired("policy.Expiration")
+	}
+	input := &OperationInput{
+		OpName: "PostObject",
+		Method: "POST",
+		Bucket: Ptr(policy.Bucket()),
+	}
+	if err := validateInput(input); err != nil {
+		return nil, err
+	}
+
+	options := c.options.Copy()
+	opOpt := Options{}
+	for _, fn := range optFns {
+		fn(&opOpt)
+	}
+	applyOperationOpt(&options, &opOpt)
+	if !isValidEndpoint(options.Endpoint) {
+		return nil, NewErrParamInvalid("Endpoint")
+	}
+
+	postSigner, ok := c.options.Signer.(interface {
+		PostPolicyConditions(*signer.SigningContext) (map[string]string, error)
+		SignPostPolicy(*signer.SigningContext, string) (map[string]string, error)
+	})
+	if !ok {
+		return nil, NewErrParamInvalid("Signer")
+	}
+	if _, anonymous := options.CredentialsProvider.(*credentials.AnonymousCredentialsProvider); anonymous {
+		return nil, NewErrParamInvalid("CredentialsProvider")
+	}
+	cred, err := options.CredentialsProvider.GetCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signingCtx := &signer.SigningContext{
+		Product:     Ptr(options.Product),
+		Region:      Ptr(options.Region),
+		Bucket:      input.Bucket,
+		Credentials: &cred,
+		Time:        time.Now().Add(c.inner.ClockOffset),
+	}
+	conditions, err := postSigner.PostPolicyConditions(signingCtx)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := policy.Encode(conditions)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := postSigner.SignPostPolicy(signingCtx, encoded)
+	if err != nil {
+		return nil, err
+	}
+	c.logStringToSign(signingCtx)
+	for k, v := range policy.Fields() {
+		fields[k] = v
+	}
+
+	return &PresignPostObjectResult{
+		URL:        buildRequestURL(input, &options),
+		Fields:     fields,
+		Expiration: policy.Expiration,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -471,12 +472,10 @@ func TestPresignWithError(t *testing.T) {
 	client := NewClient(cfg)
 
 	// unsupport request
-	request := &ListObjectsRequest{
-		Bucket: Ptr("bucket"),
-	}
+	request := &ListObjectsResult{}
 	_, err := client.Presign(context.TODO(), request)
 	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "request *oss.ListObjectsRequest")
+	assert.Contains(t, err.Error(), "request *oss.ListObjectsResult")
 
 	// request is nil
 	_, err = client.Presign(context.TODO(), nil)
@@ -499,12 +498,10 @@ func TestPresignWithError(t *testing.T) {
 	client = NewClient(cfg)
 
 	// unsupport request
-	request = &ListObjectsRequest{
-		Bucket: Ptr("bucket"),
-	}
+	request = &ListObjectsResult{}
 	_, err = client.Presign(context.TODO(), request)
 	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "request *oss.ListObjectsRequest")
+	assert.Contains(t, err.Error(), "request *oss.ListObjectsResult")
 
 	// request is nil
 	_, err = client.Presign(context.TODO(), nil)
@@ -519,3 +516,175 @@ func TestPresignWithError(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "expires should be not greater than 604800(seven days)")
 }
+
+func TestPresignAnyOperation(t *testing.T) {
+	verifier := signer.NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		return &credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}, nil
+	})
+	verify := func(result *PresignResult, bucket, key string) {
+		r, err := http.NewRequest(result.Method, result.URL, nil)
+		assert.Nil(t, err)
+		for k, v := range result.SignedHeaders {
+			r.Header.Set(k, v)
+		}
+		_, err = verifier.Verify(context.TODO(), r, bucket, key)
+		assert.Nil(t, err)
+	}
+
+	for _, version := range []SignatureVersionType{SignatureVersionV1, SignatureVersionV4} {
+		client := NewClient(LoadDefaultConfig().
+			WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+			WithRegion("cn-hangzhou").
+			WithEndpoint("oss-cn-hangzhou.aliyuncs.com").
+			WithSignatureVersion(version))
+
+		result, err := client.Presign(context.TODO(), &DeleteObjectRequest{
+			Bucket:    Ptr("bucket"),
+			Key:       Ptr("key"),
+			VersionId: Ptr("versionId"),
+		}, PresignExpires(10*time.Minute))
+		assert.Nil(t, err)
+		assert.Equal(t, "DELETE", result.Method)
+		assert.Contains(t, result.URL, "bucket.oss-cn-hangzhou.aliyuncs.com/key?")
+		assert.Contains(t, result.URL, "versionId=versionId")
+		assert.NotEmpty(t, result.Expiration)
+		verify(result, "bucket", "key")
+
+		result, err = client.Presign(context.TODO(), &UploadPartCopyRequest{
+			Bucket:     Ptr("bucket"),
+			Key:        Ptr("key"),
+			SourceKey:  Ptr("src"),
+			PartNumber: 1,
+			UploadId:   Ptr("upload-id"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "PUT", result.Method)
+		assert.Contains(t, result.URL, "partNumber=1")
+		assert.Contains(t, result.URL, "uploadId=upload-id")
+		assert.Equal(t, "/bucket/src", result.SignedHeaders["X-Oss-Copy-Source"])
+		verify(result, "bucket", "key")
+
+		result, err = client.Presign(context.TODO(), &ListObjectsV2Request{
+			Bucket: Ptr("bucket"),
+			Prefix: Ptr("dir/"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "GET", result.Method)
+		assert.Contains(t, result.URL, "bucket.oss-cn-hangzhou.aliyuncs.com/?")
+		assert.Contains(t, result.URL, "list-type=2")
+		assert.Contains(t, result.URL, "prefix=dir%2F")
+		verify(result, "bucket", "")
+
+		result, err = client.Presign(context.TODO(), &ListPartsRequest{
+			Bucket:   Ptr("bucket"),
+			Key:      Ptr("key"),
+			UploadId: Ptr("upload-id"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "GET", result.Method)
+		assert.Contains(t, result.URL, "uploadId=upload-id")
+		verify(result, "bucket", "key")
+
+		result, err = client.Presign(context.TODO(), &GetObjectTaggingRequest{
+			Bucket: Ptr("bucket"),
+			Key:    Ptr("key"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "GET", result.Method)
+		assert.Contains(t, result.URL, "tagging")
+		verify(result, "bucket", "key")
+
+		result, err = client.Presign(context.TODO(), &ProcessObjectRequest{
+			Bucket:  Ptr("bucket"),
+			Key:     Ptr("key"),
+			Process: Ptr("image/resize,w_100"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "POST", result.Method)
+		assert.Contains(t, result.URL, "x-oss-process")
+		verify(result, "bucket", "key")
+
+		result, err = client.Presign(context.TODO(), &GetBucketAclRequest{
+			Bucket: Ptr("bucket"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "GET", result.Method)
+		assert.Contains(t, result.URL, "acl")
+		verify(result, "bucket", "")
+
+		// the request is validated by the operation
+		_, err = client.Presign(context.TODO(), &DeleteObjectRequest{
+			Bucket: Ptr("bucket"),
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "missing required field, Key")
+	}
+}
+
+type presignMismatchedApi struct{}
+
+func (presignMismatchedApi) DeleteObject(ctx context.Context, request *DeleteObjectRequest) error {
+	return nil
+}
+
+// presignPrefetchApi sends a HeadObject request before its own operation.
+type presignPrefetchApi struct {
+	client         *Client
+	ignoreHeadFail bool
+}
+
+func (a presignPrefetchApi) DeleteObject(ctx context.Context, request *DeleteObjectRequest, optFns ...func(*Options)) (*DeleteObjectResult, error) {
+	if _, err := a.client.HeadObject(ctx, &HeadObjectRequest{Bucket: request.Bucket, Key: request.Key}); err != nil && !a.ignoreHeadFail {
+		return nil, err
+	}
+	return a.client.DeleteObject(ctx, request, optFns...)
+}
+
+func TestPresignOperationInvalidApi(t *testing.T) {
+	client := NewClient(LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+		WithRegion("cn-hangzhou").
+		WithEndpoint("oss-cn-hangzhou.aliyuncs.com"))
+	request := &DeleteObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}
+
+	// nil api
+	_, err := client.PresignOperation(context.TODO(), nil, request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "null field, api")
+	_, err = client.PresignOperation(context.TODO(), (*Client)(nil), request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "null field, api")
+
+	// the method of the operation has a different signature
+	_, err = client.PresignOperation(context.TODO(), presignMismatchedApi{}, request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid field, request *oss.DeleteObjectRequest")
+
+	// the operation sends another request before its own
+	_, err = client.PresignOperation(context.TODO(), presignPrefetchApi{client: client}, request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "operation DeleteObject sends other requests")
+	_, err = client.PresignOperation(context.TODO(), presignPrefetchApi{client: client, ignoreHeadFail: true}, request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "operation DeleteObject sends other requests")
+
+	// the operation sends its own request only
+	result, err := client.PresignOperation(context.TODO(), client, request)
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE", result.Method)
+
+	// no method of the operation
+	_, err = client.PresignOperation(context.TODO(), struct{}{}, request)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid field, request *oss.DeleteObjectRequest")
+
+	// the requests presigned before do not need the api
+	_, err = client.PresignOperation(context.TODO(), nil, &GetObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.Nil(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/transport"
@@ -837,6 +838,16 @@ func TestUnmarshalOutput_error(t *testing.T) {
 	assert.Contains(t, err.Error(), "XML syntax error on line 1")
 }
 
+func TestResolveCredentialsProvider(t *testing.T) {
+	client := NewClient(LoadDefaultConfig())
+	_, ok := client.options.CredentialsProvider.(*credentials.ChainProvider)
+	assert.True(t, ok)
+
+	provider := credentials.NewStaticCredentialsProvider("ak", "sk")
+	client = NewClient(LoadDefaultConfig().WithCredentialsProvider(provider))
+	assert.Equal(t, provider, client.options.CredentialsProvider)
+}
+
 func TestResolveHTTPClient(t *testing.T) {
 	cfg := NewConfig()
 	opt := &Options{}
@@ -935,6 +946,14 @@ func TestHasFeature(t *testing.T) {
 	assert.True(t, c.hasFeature(FeatureAutoDetectMimeType))
 	assert.True(t, c.hasFeature(FeatureEnableCRC64CheckUpload))
 	assert.True(t, c.hasFeature(FeatureEnableCRC64CheckDownload))
+	assert.False(t, c.hasFeature(FeatureExperimentalStreamingPayloadSign))
+
+	// Enable FeatureExperimentalStreamingPayloadSign
+	c = NewClient(cfg, func(o *Options) {
+		o.FeatureFlags |= FeatureExperimentalStreamingPayloadSign
+	})
+	assert.True(t, c.hasFeature(FeatureExperimentalStreamingPayloadSign))
+	assert.True(t, c.hasFeature(FeatureEnableCRC64CheckUpload))
 }
 
 func TestFeatureCorrectClockSkew(t *testing.T) {
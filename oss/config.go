@@ -21,6 +21,20 @@ type Config struct {
 	// The domain names that other services can use to access OSS.
 	Endpoint *string
 
+	// The endpoints to fail over to in order, when the connection to the Endpoint fails.
+	FailoverEndpoints []string
+
+	// The types of the endpoints to fail over to in order, which are resolved from the region.
+	// They are used after the FailoverEndpoints.
+	FailoverEndpointTypes []EndpointType
+
+	// The number of consecutive connection failures to open the circuit of an endpoint,
+	// the endpoint is skipped until the EndpointRecoveryInterval elapses. Default is 2.
+	EndpointFailureThreshold *int
+
+	// How long an endpoint with an open circuit is skipped before it's tried again. Default is 30s.
+	EndpointRecoveryInterval *time.Duration
+
 	// RetryMaxAttempts specifies the maximum number attempts an API client will call
 	// an operation that fails with a retryable error.
 	RetryMaxAttempts *int
@@ -28,11 +42,20 @@ type Config struct {
 	// Retryer guides how HTTP requests should be retried in case of recoverable failures.
 	Retryer retry.Retryer
 
+	// The maximum size in bytes of a non-seekable request body to buffer, so that the request can be retried.
+	// Default is 0, the requests with non-seekable bodies are not retried.
+	ReplayBufferSize *int64
+
+	// The maximum size in bytes of the replay buffer kept in memory, the rest spills to a temporary file.
+	// Default is the ReplayBufferSize, all buffered bytes are kept in memory.
+	ReplayBufferMemorySize *int64
+
 	// The HTTP client to invoke API calls with. Defaults to client's default HTTP
 	// implementation if nil.
 	HttpClient HTTPClient
 
 	// The credentials provider to use when signing requests.
+	// The client uses credentials.NewDefaultChainProvider if it is not set.
 	CredentialsProvider credentials.CredentialsProvider
 
 	// Allows you to enable the client to use path-style addressing, i.e., https://oss-cn-hangzhou.aliyuncs.com/bucket/key.
@@ -80,6 +103,16 @@ type Config struct {
 	// A interface for the SDK to log messages to.
 	LogPrinter LogPrinter
 
+	// A interface for the SDK to log messages with key/value pairs to.
+	// It takes precedence over LogPrinter.
+	StructuredLogPrinter StructuredLogPrinter
+
+	// The http headers whose values are redacted in logs, in addition to DefaultLogRedactHeaders().
+	LogRedactHeaders []string
+
+	// The query parameters whose values are redacted in logs, in addition to DefaultLogRedactQueries().
+	LogRedactQueries []string
+
 	// DisableSSL forces the endpoint to be resolved as HTTP.
 	DisableSSL *bool
 
@@ -125,6 +158,12 @@ type Config struct {
 
 	// Local address to bind to for outgoing connections.
 	BindAddress net.IP
+
+	// A interface for the SDK to create spans for operations and http attempts.
+	Tracer Tracer
+
+	// A interface for the SDK to report operation metrics to.
+	MetricsCollector MetricsCollector
 }
 
 func NewConfig() *Config {
@@ -160,6 +199,26 @@ func (c *Config) WithEndpoint(endpoint string) *Config {
 	return c
 }
 
+func (c *Config) WithFailoverEndpoints(endpoints ...string) *Config {
+	c.FailoverEndpoints = endpoints
+	return c
+}
+
+func (c *Config) WithFailoverEndpointTypes(types ...EndpointType) *Config {
+	c.FailoverEndpointTypes = types
+	return c
+}
+
+func (c *Config) WithEndpointFailureThreshold(value int) *Config {
+	c.EndpointFailureThreshold = Ptr(value)
+	return c
+}
+
+func (c *Config) WithEndpointRecoveryInterval(value time.Duration) *Config {
+	c.EndpointRecoveryInterval = Ptr(value)
+	return c
+}
+
 func (c *Config) WithRetryMaxAttempts(value int) *Config {
 	c.RetryMaxAttempts = Ptr(value)
 	return c
@@ -225,6 +284,16 @@ func (c *Config) WithProxyFromEnvironment(value bool) *Config {
 	return c
 }
 
+func (c *Config) WithReplayBufferSize(value int64) *Config {
+	c.ReplayBufferSize = Ptr(value)
+	return c
+}
+
+func (c *Config) WithReplayBufferMemorySize(value int64) *Config {
+	c.ReplayBufferMemorySize = Ptr(value)
+	return c
+}
+
 func (c *Config) WithUploadBandwidthlimit(value int64) *Config {
 	c.UploadBandwidthlimit = Ptr(value)
 	return c
@@ -250,6 +319,21 @@ func (c *Config) WithLogPrinter(printer LogPrinter) *Config {
 	return c
 }
 
+func (c *Config) WithStructuredLogPrinter(printer StructuredLogPrinter) *Config {
+	c.StructuredLogPrinter = printer
+	return c
+}
+
+func (c *Config) WithLogRedactHeaders(value []string) *Config {
+	c.LogRedactHeaders = value
+	return c
+}
+
+func (c *Config) WithLogRedactQueries(value []string) *Config {
+	c.LogRedactQueries = value
+	return c
+}
+
 func (c *Config) WithDisableSSL(value bool) *Config {
 	c.DisableSSL = Ptr(value)
 	return c
@@ -309,3 +393,13 @@ func (c *Config) WithBindAddress(value net.IP) *Config {
 	c.BindAddress = value
 	return c
 }
+
+func (c *Config) WithTracer(tracer Tracer) *Config {
+	c.Tracer = tracer
+	return c
+}
+
+func (c *Config) WithMetricsCollector(collector MetricsCollector) *Config {
+	c.MetricsCollector = collector
+	return c
+}
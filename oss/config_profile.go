@@ -0,0 +1,197 @@
+package oss
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/internal/profile"
+)
+
+type LoadProfileOptions struct {
+	// The path of the OSS config file, an INI file, the default is OSS_CONFIG_FILE, then ~/.oss/config.
+	ConfigFile string
+
+	// The path of the Alibaba Cloud CLI config file, the default is ~/.aliyun/config.json.
+	CliConfigFile string
+}
+
+/*
+LoadConfigFromProfile loads the config and the credentials of a named profile.
+If the name is empty, the profile is OSS_PROFILE, then the current profile of the CLI config file, then "default".
+
+The profile is looked up in the OSS config file first, then in the CLI config file.
+The OSS config file is an INI file, whose sections are profiles, named "name" or "profile name":
+
+	[profile dev]
+	region = cn-hangzhou
+	endpoint = oss-cn-hangzhou-internal.aliyuncs.com
+	sign_version = v4
+	use_path_style = false
+	connect_timeout = 10
+	readwrite_timeout = 20
+	mode = RamRoleArn
+	access_key_id = ak
+	access_key_secret = sk
+	ram_role_arn = acs:ram::123456789:role/dev
+
+The credentials keys are the same as the CLI config file, the mode is one of
+AK, StsToken, RamRoleArn, ChainableRamRoleArn, EcsRamRole, External, CredentialsURI and OIDC.
+A profile without credentials uses the default credentials chain. The timeouts are in seconds.
+*/
+func LoadConfigFromProfile(name string, optFns ...func(*LoadProfileOptions)) (*Config, error) {
+	options := LoadProfileOptions{
+		ConfigFile: os.Getenv("OSS_CONFIG_FILE"),
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if options.ConfigFile == "" || options.CliConfigFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		if options.ConfigFile == "" {
+			options.ConfigFile = filepath.Join(home, ".oss", "config")
+		}
+		if options.CliConfigFile == "" {
+			options.CliConfigFile = filepath.Join(home, ".aliyun", "config.json")
+		}
+	}
+	if name == "" {
+		name = os.Getenv("OSS_PROFILE")
+	}
+
+	prof, path, err := findProfile(name, options.ConfigFile, options.CliConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config := LoadDefaultConfig()
+	if err = applyProfile(config, prof); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", prof.Name, err)
+	}
+	if prof.Get("mode") == "" && prof.Get("access_key_id") == "" {
+		// a profile without credentials, such as one which sets the region only
+		config.CredentialsProvider = credentials.NewDefaultChainProvider()
+	} else {
+		config.CredentialsProvider = credentials.NewProfileCredentialsProvider(func(o *credentials.ProfileCredentialsProviderOptions) {
+			o.FilePath = path
+			o.ProfileName = prof.Name
+		})
+	}
+	return config, nil
+}
+
+// findProfile returns the profile and the path of the file which contains it.
+func findProfile(name, configFile, cliConfigFile string) (*profile.Profile, string, error) {
+	var errs []string
+	for _, path := range []string{configFile, cliConfigFile} {
+		f, err := profile.Load(path)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, "", err
+			}
+			errs = append(errs, err.Error())
+			continue
+		}
+		n := name
+		if n == "" {
+			n = f.Current
+		}
+		if n == "" {
+			n = "default"
+		}
+		p, err := f.Profile(n)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return p, path, nil
+	}
+	return nil, "", fmt.Errorf("profile is not found, %s", strings.Join(errs, "; "))
+}
+
+func applyProfile(config *Config, prof *profile.Profile) error {
+	// the CLI config file uses region_id
+	if v := firstValue(prof, "region", "region_id"); v != "" {
+		config.Region = Ptr(v)
+	}
+	if v := prof.Get("endpoint"); v != "" {
+		config.Endpoint = Ptr(v)
+	}
+	if v := prof.Get("sign_version"); v != "" {
+		switch strings.ToLower(v) {
+		case "v1":
+			config.SignatureVersion = Ptr(SignatureVersionV1)
+		case "v4":
+			config.SignatureVersion = Ptr(SignatureVersionV4)
+		default:
+			return fmt.Errorf("invalid sign_version %s, expected v1 or v4", v)
+		}
+	}
+
+	bools := []struct {
+		key   string
+		field **bool
+	}{
+		{"use_path_style", &config.UsePathStyle},
+		{"use_cname", &config.UseCName},
+		{"disable_ssl", &config.DisableSSL},
+		{"insecure_skip_verify", &config.InsecureSkipVerify},
+		{"use_internal_endpoint", &config.UseInternalEndpoint},
+		{"use_accelerate_endpoint", &config.UseAccelerateEndpoint},
+		{"use_dualstack_endpoint", &config.UseDualStackEndpoint},
+	}
+	for _, b := range bools {
+		if v := prof.Get(b.key); v != "" {
+			value, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s %s", b.key, v)
+			}
+			*b.field = Ptr(value)
+		}
+	}
+
+	durations := []struct {
+		keys  []string
+		field **time.Duration
+	}{
+		{[]string{"connect_timeout"}, &config.ConnectTimeout},
+		{[]string{"readwrite_timeout", "read_timeout"}, &config.ReadWriteTimeout},
+	}
+	for _, d := range durations {
+		if v := firstValue(prof, d.keys...); v != "" {
+			seconds, err := strconv.Atoi(v)
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("invalid %s %s", d.keys[0], v)
+			}
+			*d.field = Ptr(time.Duration(seconds) * time.Second)
+		}
+	}
+
+	if v := prof.Get("retry_max_attempts"); v != "" {
+		attempts, err := strconv.Atoi(v)
+		if err != nil || attempts < 0 {
+			return fmt.Errorf("invalid retry_max_attempts %s", v)
+		}
+		if attempts > 0 {
+			config.RetryMaxAttempts = Ptr(attempts)
+		}
+	}
+	return nil
+}
+
+func firstValue(prof *profile.Profile, keys ...string) string {
+	for _, key := range keys {
+		if v := prof.Get(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package oss
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProfileFiles(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+# the OSS config file
+[default]
+region = cn-hangzhou
+mode = AK
+access_key_id = ak
+access_key_secret = sk
+
+[profile dev]
+region = cn-shanghai
+endpoint = oss-cn-shanghai-internal.aliyuncs.com
+sign_version = v1
+use_path_style = true
+use_cname = false
+disable_ssl = true
+connect_timeout = 5
+readwrite_timeout = 30
+retry_max_attempts = 5
+mode = StsToken
+access_key_id = sts-ak
+access_key_secret = sts-sk
+sts_token = token
+
+[region-only]
+region = cn-beijing
+
+[invalid]
+sign_version = v3
+`), 0600))
+
+	cliConfigFile := filepath.Join(dir, "config.json")
+	assert.Nil(t, os.WriteFile(cliConfigFile, []byte(`{
+	"current": "cli",
+	"profiles": [
+		{"name": "cli", "mode": "AK", "access_key_id": "cli-ak", "access_key_secret": "cli-sk", "region_id": "cn-shenzhen", "read_timeout": 15},
+		{"name": "default", "mode": "AK", "access_key_id": "cli-default-ak", "access_key_secret": "cli-default-sk"}
+	]
+}`), 0600))
+	return configFile, cliConfigFile
+}
+
+func TestLoadConfigFromProfile(t *testing.T) {
+	configFile, cliConfigFile := writeProfileFiles(t)
+	withFiles := func(o *LoadProfileOptions) {
+		o.ConfigFile = configFile
+		o.CliConfigFile = cliConfigFile
+	}
+	t.Setenv("OSS_PROFILE", "")
+	ctx := context.Background()
+
+	cfg, err := LoadConfigFromProfile("dev", withFiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-shanghai", ToString(cfg.Region))
+	assert.Equal(t, "oss-cn-shanghai-internal.aliyuncs.com", ToString(cfg.Endpoint))
+	assert.Equal(t, SignatureVersionV1, *cfg.SignatureVersion)
+	assert.True(t, ToBool(cfg.UsePathStyle))
+	assert.False(t, ToBool(cfg.UseCName))
+	assert.True(t, ToBool(cfg.DisableSSL))
+	assert.Equal(t, 5*time.Second, *cfg.ConnectTimeout)
+	assert.Equal(t, 30*time.Second, *cfg.ReadWriteTimeout)
+	assert.Equal(t, 5, *cfg.RetryMaxAttempts)
+	assert.Nil(t, cfg.UseInternalEndpoint)
+	creds, err := cfg.CredentialsProvider.GetCredentials(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "sts-ak", creds.AccessKeyID)
+	assert.Equal(t, "sts-sk", creds.AccessKeySecret)
+	assert.Equal(t, "token", creds.SecurityToken)
+
+	// the default profile of the OSS config file takes precedence over the current profile of the CLI config file
+	cfg, err = LoadConfigFromProfile("", withFiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-hangzhou", ToString(cfg.Region))
+	assert.Nil(t, cfg.Endpoint)
+	assert.Nil(t, cfg.SignatureVersion)
+	creds, err = cfg.CredentialsProvider.GetCredentials(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "ak", creds.AccessKeyID)
+
+	// OSS_PROFILE selects the profile if the name is empty
+	t.Setenv("OSS_PROFILE", "cli")
+	cfg, err = LoadConfigFromProfile("", withFiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-shenzhen", ToString(cfg.Region))
+	assert.Equal(t, 15*time.Second, *cfg.ReadWriteTimeout)
+	creds, err = cfg.CredentialsProvider.GetCredentials(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "cli-ak", creds.AccessKeyID)
+
+	cfg, err = LoadConfigFromProfile("dev", withFiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-shanghai", ToString(cfg.Region))
+	t.Setenv("OSS_PROFILE", "")
+
+	// the current profile of the CLI config file, if there is no OSS config file
+	cfg, err = LoadConfigFromProfile("", func(o *LoadProfileOptions) {
+		o.ConfigFile = configFile + ".missing"
+		o.CliConfigFile = cliConfigFile
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-shenzhen", ToString(cfg.Region))
+
+	// a profile without credentials uses the default chain
+	cfg, err = LoadConfigFromProfile("region-only", withFiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-beijing", ToString(cfg.Region))
+	_, ok := cfg.CredentialsProvider.(*credentials.ChainProvider)
+	assert.True(t, ok)
+
+	_, err = LoadConfigFromProfile("invalid", withFiles)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "profile invalid: invalid sign_version v3")
+
+	_, err = LoadConfigFromProfile("missing", withFiles)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "profile is not found")
+	assert.Contains(t, err.Error(), "profile missing is not found in "+configFile)
+	assert.Contains(t, err.Error(), "profile missing is not found in "+cliConfigFile)
+
+	t.Setenv("OSS_CONFIG_FILE", configFile)
+	cfg, err = LoadConfigFromProfile("dev", func(o *LoadProfileOptions) {
+		o.CliConfigFile = cliConfigFile
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "cn-shanghai", ToString(cfg.Region))
+}
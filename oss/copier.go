@@ -34,6 +34,13 @@ type CopierOptions struct {
 
 	ClientOptions []func(*Options)
 
+	// Specifies whether to record the multipart copy progress in the checkpoint file.
+	EnableCheckpoint bool
+
+	// The path in which the checkpoint file is stored. Example: /local/dir/.
+	// This parameter is valid only if EnableCheckpoint is set to true.
+	CheckpointDir string
+
 	// ShallowCopy Flags
 	NoCheckSSE         bool
 	NoCheckCrossBucket bool
@@ -151,7 +158,16 @@ func (c *Copier) Copy(ctx context.Context, request *CopyObjectRequest, optFns ..
 		return nil, err
 	}
 
-	return delegate.copy()
+	if err = delegate.checkCheckpoint(); err != nil {
+		return nil, err
+	}
+
+	result, err := delegate.copy()
+	if err == nil && delegate.checkpoint != nil {
+		delegate.checkpoint.remove()
+	}
+
+	return result, err
 }
 
 type copierDelegate struct {
@@ -167,6 +183,12 @@ type copierDelegate struct {
 
 	sizeInBytes int64
 	transferred int64
+
+	// for resumable copy
+	uploadId    string
+	copiedParts UploadParts
+
+	checkpoint *copyCheckpoint
 }
 
 func (c *Copier) newDelegate(ctx context.Context, request *CopyObjectRequest, optFns ...func(*CopierOptions)) (*copierDelegate, error) {
@@ -268,6 +290,81 @@ func (d *copierDelegate) applySource() error {
 	return nil
 }
 
+func (d *copierDelegate) checkCheckpoint() error {
+	// only the multipart copy is resumable
+	if !d.options.EnableCheckpoint || d.sizeInBytes <= d.options.MultipartCopyThreshold {
+		return nil
+	}
+
+	d.checkpoint = newCopyCheckpoint(d.request, d.options.CheckpointDir, d.metaProp.Headers, d.options.PartSize)
+	if err := d.checkpoint.load(); err != nil {
+		return err
+	}
+
+	if d.checkpoint.StaleUploadId != "" {
+		d.abortUpload(d.checkpoint.StaleUploadId)
+	}
+
+	if d.checkpoint.Loaded {
+		d.uploadId = d.checkpoint.Info.Data.CopyInfo.UploadId
+		d.copiedParts = d.checkpoint.Info.Data.CopyInfo.Parts
+		d.resumeParts()
+	}
+	d.options.LeavePartsOnError = true
+
+	return nil
+}
+
+// resumeParts keeps the copied parts which are still in the upload,
+// the copy starts over with a new upload if the parts can not be listed.
+func (d *copierDelegate) resumeParts() {
+	paginator := NewListPartsPaginator(d.base.client, &ListPartsRequest{
+		Bucket:       d.request.Bucket,
+		Key:          d.request.Key,
+		UploadId:     Ptr(d.uploadId),
+		RequestPayer: d.request.RequestPayer,
+	})
+
+	uploaded := map[int32]string{}
+	for paginator.HasNext() {
+		page, err := paginator.NextPage(d.context, d.options.ClientOptions...)
+		if err != nil {
+			d.abortUpload(d.uploadId)
+			d.uploadId = ""
+			d.copiedParts = nil
+			return
+		}
+		for _, p := range page.Parts {
+			uploaded[p.PartNumber] = ToString(p.ETag)
+		}
+	}
+
+	var parts UploadParts
+	for _, p := range d.copiedParts {
+		if etag, ok := uploaded[p.PartNumber]; ok && etag == ToString(p.ETag) {
+			parts = append(parts, p)
+			d.transferred += d.partSize(p.PartNumber)
+		}
+	}
+	d.copiedParts = parts
+}
+
+// abortUpload aborts the upload which is not resumed, the error is ignored.
+func (d *copierDelegate) abortUpload(uploadId string) {
+	amRequest := &AbortMultipartUploadRequest{}
+	copyRequest(amRequest, d.request)
+	amRequest.UploadId = Ptr(uploadId)
+	_, _ = d.base.client.AbortMultipartUpload(d.context, amRequest, d.options.ClientOptions...)
+}
+
+func (d *copierDelegate) partSize(partNum int32) int64 {
+	offset := int64(partNum-1) * d.options.PartSize
+	if d.sizeInBytes-offset < d.options.PartSize {
+		return d.sizeInBytes - offset
+	}
+	return d.options.PartSize
+}
+
 func (d *copierDelegate) canUseShallowCopy() bool {
 	if d.options.DisableShallowCopy {
 		return false
@@ -299,7 +396,7 @@ func (d *copierDelegate) canUseShallowCopy() bool {
 func (d *copierDelegate) copy() (*CopyResult, error) {
 	if d.sizeInBytes <= d.options.MultipartCopyThreshold {
 		return d.singleCopy()
-	} else if d.canUseShallowCopy() {
+	} else if d.uploadId == "" && d.canUseShallowCopy() {
 		return d.shallowCopy()
 	}
 	return d.multiCopy()
@@ -361,17 +458,33 @@ func (d *copierDelegate) multiCopy() (*CopyResult, error) {
 		mu       sync.Mutex
 		parts    UploadParts
 		errValue atomic.Value
+		err      error
 	)
 
-	// Init the multipart
-	imRequest, err := d.newInitiateMultipartUpload()
-	if err != nil {
-		return nil, d.wrapErr("", err)
+	// Init the multipart, or resume from upload id
+	initResult := &InitiateMultipartUploadResult{UploadId: Ptr(d.uploadId)}
+	if d.uploadId == "" {
+		var imRequest *InitiateMultipartUploadRequest
+		if imRequest, err = d.newInitiateMultipartUpload(); err != nil {
+			return nil, d.wrapErr("", err)
+		}
+
+		if initResult, err = d.base.client.InitiateMultipartUpload(d.context, imRequest, d.options.ClientOptions...); err != nil {
+			return nil, d.wrapErr("", err)
+		}
 	}
 
-	initResult, err := d.base.client.InitiateMultipartUpload(d.context, imRequest, d.options.ClientOptions...)
-	if err != nil {
-		return nil, d.wrapErr("", err)
+	copied := map[int32]bool{}
+	for _, p := range d.copiedParts {
+		copied[p.PartNumber] = true
+	}
+	parts = append(parts, d.copiedParts...)
+
+	// Update Checkpoint
+	if d.checkpoint != nil {
+		d.checkpoint.Info.Data.CopyInfo.UploadId = ToString(initResult.UploadId)
+		d.checkpoint.Info.Data.CopyInfo.Parts = parts
+		d.checkpoint.dump()
 	}
 
 	saveErrFn := func(e error) {
@@ -430,6 +543,10 @@ func (d *copierDelegate) multiCopy() (*CopyResult, error) {
 					parts = append(parts, UploadPart{ETag: upResult.ETag, PartNumber: data.partNum})
 					d.transferred += data.size
 					d.progressCallback(data.size)
+					if d.checkpoint != nil {
+						d.checkpoint.Info.Data.CopyInfo.Parts = parts
+						d.checkpoint.dump()
+					}
 					mu.Unlock()
 				} else {
 					saveErrFn(err)
@@ -458,7 +575,9 @@ func (d *copierDelegate) multiCopy() (*CopyResult, error) {
 		}
 		//fmt.Printf("send chunk: %d\n", qnum)
 		qnum++
-		ch <- copyChunk{partNum: qnum, size: n, sourceRange: fmt.Sprintf("bytes=%v-%v", readerPos, (readerPos + n - 1))}
+		if !copied[qnum] {
+			ch <- copyChunk{partNum: qnum, size: n, sourceRange: fmt.Sprintf("bytes=%v-%v", readerPos, (readerPos + n - 1))}
+		}
 		readerPos += n
 	}
 
@@ -552,13 +671,8 @@ func (d *copierDelegate) overwirteMetadataProp(imRequest *InitiateMultipartUploa
 			}
 		}
 		// copy meta form source
-		for k, v := range d.metaProp.Headers {
-			lowK := strings.ToLower(k)
-			if strings.HasPrefix(lowK, "x-oss-meta") {
-				imRequest.Headers[lowK] = v[0]
-			} else if _, ok := metadataCopied[lowK]; ok {
-				imRequest.Headers[lowK] = v[0]
-			}
+		for k, v := range copiedMetadataHeaders(d.metaProp.Headers) {
+			imRequest.Headers[k] = v
 		}
 	case "replace":
 		// the metedata has been copied via the copyRequest function before
@@ -0,0 +1,437 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type CopyPrefixOptions struct {
+	// The patterns of the objects to copy, in the syntax of path.Match, such as "*.jpg" or "logs/*.log".
+	// A pattern with a "/" matches the key relative to the source prefix, otherwise it matches the base name.
+	// All the objects are copied by default.
+	Include []string
+
+	// The patterns of the objects not to copy, they take precedence over Include.
+	Exclude []string
+
+	// KeyFn maps the key relative to the source prefix to the key of the destination object.
+	// The default is the destination prefix followed by the relative key.
+	KeyFn func(relKey string) string
+
+	// The number of the objects copied in parallel. The default is the ParallelNum of the copier.
+	ParallelNum int
+
+	// Specifies whether to skip the objects whose destination objects exist with the same size and ETag or CRC-64.
+	SkipUnchanged bool
+
+	// The client to list and read the source objects with, such as the one of another region or account.
+	// The default is the client of the copier.
+	SourceClient CopySourceAPIClient
+
+	// Specifies whether to copy the objects by downloading and uploading them, instead of the server-side copy.
+	// Set it if the source client is of another region, otherwise the server-side copy is tried first,
+	// and the object is downloaded and uploaded if the copy is denied.
+	DisableServerSideCopy bool
+
+	// Specifies whether to record the copied objects in the checkpoint file, so that they are not copied again
+	// when the interrupted job is resumed.
+	EnableCheckpoint bool
+
+	// The path in which the checkpoint file is stored. The default is the temporary directory.
+	CheckpointDir string
+
+	// RequestFn customizes the request of each object, such as its StorageClass or ProgressFn.
+	RequestFn func(request *CopyObjectRequest)
+
+	// The options of the server-side copies of the objects.
+	CopierOptions []func(*CopierOptions)
+
+	// The options of the uploads of the objects, which are copied by downloading and uploading them.
+	UploaderOptions []func(*UploaderOptions)
+}
+
+type CopyPrefixObjectResult struct {
+	// The key of the source object.
+	SourceKey string
+
+	// The key of the destination object.
+	Key string
+
+	// Specifies whether the object is skipped because its destination object is unchanged,
+	// or it is copied in the interrupted job.
+	Skipped bool
+
+	// Specifies whether the object is copied by downloading and uploading it.
+	Streamed bool
+
+	// The result of the copy, nil if the object is skipped or fails.
+	Result *CopyResult
+
+	// The error of the object, nil if it succeeds.
+	Err error
+}
+
+type CopyPrefixResult struct {
+	// The results of the objects, in the lexical order of their source keys.
+	Objects []CopyPrefixObjectResult
+
+	// The number of the objects which are copied, skipped and failed.
+	Copied  int
+	Skipped int
+	Failed  int
+}
+
+// CopyPrefix copies the objects under the source prefix to the destination bucket and prefix.
+// The objects are copied on the server side by Copy if allowed, otherwise they are downloaded from the source
+// and uploaded to the destination, with their metadata and tags. The objects are copied in parallel,
+// and the failures of the objects are reported in the result instead of stopping the others.
+// The returned error is only for the arguments and the listing of the objects.
+func (c *Copier) CopyPrefix(ctx context.Context, srcBucket, srcPrefix, destBucket, destPrefix string, optFns ...func(*CopyPrefixOptions)) (*CopyPrefixResult, error) {
+	options := CopyPrefixOptions{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if srcBucket == "" {
+		return nil, NewErrParamRequired("srcBucket")
+	}
+	if destBucket == "" {
+		return nil, NewErrParamRequired("destBucket")
+	}
+	if err := checkPatterns(options.Include, "options.Include"); err != nil {
+		return nil, err
+	}
+	if err := checkPatterns(options.Exclude, "options.Exclude"); err != nil {
+		return nil, err
+	}
+	srcClient := options.SourceClient
+	if srcClient == nil {
+		client, ok := c.client.(CopySourceAPIClient)
+		if !ok {
+			return nil, fmt.Errorf("the client of the copier does not support listing objects")
+		}
+		srcClient = client
+	}
+	destLister, ok := c.client.(ListObjectsV2APIClient)
+	if !ok && options.SkipUnchanged {
+		return nil, fmt.Errorf("the client of the copier does not support listing objects")
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = c.options.ParallelNum
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = DefaultCopyParallel
+	}
+	keyFn := options.KeyFn
+	if keyFn == nil {
+		keyFn = func(relKey string) string { return destPrefix + relKey }
+	}
+
+	j := &copyPrefixJob{
+		copier:     c,
+		options:    &options,
+		srcBucket:  srcBucket,
+		destBucket: destBucket,
+		srcClient:  srcClient,
+		destClient: c.client,
+		serverSide: !options.DisableServerSideCopy,
+	}
+
+	sources, err := j.listObjects(ctx, j.srcClient, srcBucket, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if options.SkipUnchanged {
+		if j.dests, err = j.listObjects(ctx, destLister, destBucket, destPrefix); err != nil {
+			return nil, err
+		}
+	}
+	if options.EnableCheckpoint {
+		j.checkpoint = newCopyPrefixCheckpoint(srcBucket, srcPrefix, destBucket, destPrefix, options.CheckpointDir)
+		if err = j.checkpoint.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CopyPrefixResult{}
+	var objects []*ObjectProperties
+	for i := range sources {
+		relKey := strings.TrimPrefix(ToString(sources[i].Key), srcPrefix)
+		if !isIncluded(options.Include, options.Exclude, relKey) {
+			continue
+		}
+		objects = append(objects, &sources[i])
+		result.Objects = append(result.Objects, CopyPrefixObjectResult{SourceKey: ToString(sources[i].Key), Key: keyFn(relKey)})
+	}
+
+	ch := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < options.ParallelNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ch {
+				j.copyObject(ctx, objects[i], &result.Objects[i])
+			}
+		}()
+	}
+	for i := range result.Objects {
+		ch <- i
+	}
+	close(ch)
+	wg.Wait()
+
+	for _, object := range result.Objects {
+		switch {
+		case object.Err != nil:
+			result.Failed++
+		case object.Skipped:
+			result.Skipped++
+		default:
+			result.Copied++
+		}
+	}
+	if j.checkpoint != nil {
+		if result.Failed == 0 {
+			j.checkpoint.remove()
+		} else {
+			j.checkpoint.dump()
+		}
+	}
+	return result, nil
+}
+
+type copyPrefixJob struct {
+	copier     *Copier
+	options    *CopyPrefixOptions
+	srcBucket  string
+	destBucket string
+	srcClient  CopySourceAPIClient
+	destClient CopyAPIClient
+	serverSide bool
+
+	// the destination objects by their keys, listed if SkipUnchanged is set
+	dests      []ObjectProperties
+	destsByKey map[string]*ObjectProperties
+	once       sync.Once
+
+	checkpoint *copyPrefixCheckpoint
+}
+
+func (j *copyPrefixJob) listObjects(ctx context.Context, client ListObjectsV2APIClient, bucket, prefix string) ([]ObjectProperties, error) {
+	var objects []ObjectProperties
+	p := NewListObjectsV2Paginator(client, &ListObjectsV2Request{
+		Bucket: Ptr(bucket),
+		Prefix: Ptr(prefix),
+	})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx, j.copier.options.ClientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Contents...)
+	}
+	return objects, nil
+}
+
+func (j *copyPrefixJob) destObject(key string) *ObjectProperties {
+	j.once.Do(func() {
+		j.destsByKey = map[string]*ObjectProperties{}
+		for i := range j.dests {
+			j.destsByKey[ToString(j.dests[i].Key)] = &j.dests[i]
+		}
+	})
+	return j.destsByKey[key]
+}
+
+func (j *copyPrefixJob) copyObject(ctx context.Context, source *ObjectProperties, object *CopyPrefixObjectResult) {
+	if object.Err = ctx.Err(); object.Err != nil {
+		return
+	}
+	etag := ToString(source.ETag)
+	if j.checkpoint != nil && j.checkpoint.completed(object.SourceKey, etag, object.Key) {
+		object.Skipped = true
+		return
+	}
+
+	var head *HeadObjectResult
+	if j.options.SkipUnchanged {
+		object.Skipped, head, object.Err = j.isObjectUnchanged(ctx, source, object.Key)
+		if object.Err != nil {
+			return
+		}
+	}
+	if !object.Skipped {
+		request := &CopyObjectRequest{
+			Bucket:       Ptr(j.destBucket),
+			Key:          Ptr(object.Key),
+			SourceBucket: Ptr(j.srcBucket),
+			SourceKey:    Ptr(object.SourceKey),
+		}
+		if j.options.RequestFn != nil {
+			j.options.RequestFn(request)
+		}
+		if j.serverSide {
+			object.Result, object.Err = j.serverSideCopy(ctx, request, head)
+			if object.Err != nil && j.options.SourceClient != nil && isServerSideCopyDenied(object.Err) {
+				object.Err = nil
+			}
+		}
+		if object.Result == nil && object.Err == nil {
+			object.Streamed = true
+			object.Result, object.Err = j.streamCopy(ctx, request, head)
+		}
+		if object.Err != nil {
+			return
+		}
+	}
+	if j.checkpoint != nil {
+		j.checkpoint.complete(object.SourceKey, etag, object.Key)
+	}
+}
+
+// isObjectUnchanged reports whether the destination object exists with the same size and ETag or CRC-64.
+// The source object is checked by HeadObject if the ETags are different, its result is returned for the copy.
+func (j *copyPrefixJob) isObjectUnchanged(ctx context.Context, source *ObjectProperties, key string) (bool, *HeadObjectResult, error) {
+	dest := j.destObject(key)
+	if dest == nil || dest.Size != source.Size {
+		return false, nil, nil
+	}
+	if ToString(dest.ETag) == ToString(source.ETag) {
+		return true, nil, nil
+	}
+	// the ETags of the objects of the same content are different if they are uploaded in different ways
+	head, err := j.srcClient.HeadObject(ctx, &HeadObjectRequest{Bucket: Ptr(j.srcBucket), Key: source.Key}, j.copier.options.ClientOptions...)
+	if err != nil {
+		return false, nil, err
+	}
+	destHead, err := j.destClient.HeadObject(ctx, &HeadObjectRequest{Bucket: Ptr(j.destBucket), Key: Ptr(key)}, j.copier.options.ClientOptions...)
+	if err != nil {
+		return false, nil, err
+	}
+	crc := ToString(head.HashCRC64)
+	return crc != "" && crc == ToString(destHead.HashCRC64), head, nil
+}
+
+func (j *copyPrefixJob) serverSideCopy(ctx context.Context, request *CopyObjectRequest, head *HeadObjectResult) (*CopyResult, error) {
+	optFns := j.options.CopierOptions
+	if head != nil {
+		optFns = append(optFns[:len(optFns):len(optFns)], func(o *CopierOptions) {
+			o.MetadataProperties = head
+		})
+	}
+	return j.copier.Copy(ctx, request, optFns...)
+}
+
+// streamCopy copies the object by downloading it with the source client and uploading it with the destination client,
+// its metadata and tags are copied unless they are replaced by the request.
+func (j *copyPrefixJob) streamCopy(ctx context.Context, request *CopyObjectRequest, head *HeadObjectResult) (*CopyResult, error) {
+	destClient, ok := j.destClient.(UploadAPIClient)
+	if !ok {
+		return nil, fmt.Errorf("the client of the copier does not support uploading objects")
+	}
+	clientOptions := j.copier.options.ClientOptions
+	if head == nil {
+		var err error
+		if head, err = j.srcClient.HeadObject(ctx, &HeadObjectRequest{Bucket: request.SourceBucket, Key: request.SourceKey}, clientOptions...); err != nil {
+			return nil, err
+		}
+	}
+
+	putRequest := &PutObjectRequest{
+		Bucket:       request.Bucket,
+		Key:          request.Key,
+		StorageClass: request.StorageClass,
+		ProgressFn:   request.ProgressFn,
+		RequestPayer: request.RequestPayer,
+		RequestCommon: RequestCommon{
+			Headers: map[string]string{},
+		},
+	}
+	if strings.EqualFold(ToString(request.MetadataDirective), "replace") {
+		putRequest.CacheControl = request.CacheControl
+		putRequest.ContentDisposition = request.ContentDisposition
+		putRequest.ContentEncoding = request.ContentEncoding
+		putRequest.ContentType = request.ContentType
+		putRequest.Expires = request.Expires
+		putRequest.Metadata = request.Metadata
+	} else {
+		for k, v := range copiedMetadataHeaders(head.Headers) {
+			putRequest.Headers[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+	if strings.EqualFold(ToString(request.TaggingDirective), "replace") {
+		putRequest.Tagging = request.Tagging
+	} else if head.TaggingCount > 0 {
+		tags, err := j.srcClient.GetObjectTagging(ctx, &GetObjectTaggingRequest{Bucket: request.SourceBucket, Key: request.SourceKey}, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		putRequest.Tagging = Ptr(encodeTagging(tags.Tags))
+	}
+
+	// the source is read once, the version is fixed in case the object is overwritten
+	getResult, err := j.srcClient.GetObject(ctx, &GetObjectRequest{
+		Bucket:    request.SourceBucket,
+		Key:       request.SourceKey,
+		VersionId: head.VersionId,
+	}, clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	defer getResult.Body.Close()
+
+	uploader := NewUploader(destClient, j.options.UploaderOptions...)
+	upResult, err := uploader.UploadFrom(ctx, putRequest, getResult.Body)
+	if err != nil {
+		return nil, err
+	}
+	if srcCrc, destCrc := ToString(head.HashCRC64), ToString(upResult.HashCRC64); srcCrc != "" && destCrc != "" && srcCrc != destCrc {
+		return nil, fmt.Errorf("crc is inconsistent, source %s, destination %s", srcCrc, destCrc)
+	}
+	return &CopyResult{
+		UploadId:     upResult.UploadId,
+		ETag:         upResult.ETag,
+		VersionId:    upResult.VersionId,
+		HashCRC64:    upResult.HashCRC64,
+		ResultCommon: upResult.ResultCommon,
+	}, nil
+}
+
+// isServerSideCopyDenied reports whether the server-side copy is impossible, such as the destination can not read
+// the source of another account, or the source bucket is not found by the destination.
+func isServerSideCopyDenied(err error) bool {
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		return serr.StatusCode == 403 || serr.Code == "NoSuchBucket"
+	}
+	return false
+}
+
+// copiedMetadataHeaders returns the headers of the metadata which are copied from the source object,
+// the keys are in lower case.
+func copiedMetadataHeaders(headers http.Header) map[string]string {
+	copied := map[string]string{}
+	for k, v := range headers {
+		lowK := strings.ToLower(k)
+		if strings.HasPrefix(lowK, "x-oss-meta") {
+			copied[lowK] = v[0]
+		} else if _, ok := metadataCopied[lowK]; ok {
+			copied[lowK] = v[0]
+		}
+	}
+	return copied
+}
+
+// encodeTagging encodes the tags as the value of the x-oss-tagging header.
+func encodeTagging(tags []Tag) string {
+	values := make([]string, 0, len(tags))
+	for _, t := range tags {
+		values = append(values, url.QueryEscape(ToString(t.Key))+"="+url.QueryEscape(ToString(t.Value)))
+	}
+	return strings.Join(values, "&")
+}
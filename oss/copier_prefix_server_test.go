@@ -0,0 +1,128 @@
+package oss_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/osstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyPrefix_Server(t *testing.T) {
+	srv, client := newTestServer(t, func(o *osstest.Options) { o.MinPartSize = oss.MinPartSize })
+	ctx := context.TODO()
+	assert.Nil(t, srv.CreateBucket("source"))
+	data := randomData(350 * 1024)
+	put := func(key string, data []byte) {
+		_, err := client.PutObject(ctx, &oss.PutObjectRequest{
+			Bucket:      oss.Ptr("source"),
+			Key:         oss.Ptr(key),
+			Body:        bytes.NewReader(data),
+			ContentType: oss.Ptr("text/plain"),
+			Metadata:    map[string]string{"owner": "alice"},
+			Tagging:     oss.Ptr("team=a&env=dev"),
+		})
+		assert.Nil(t, err)
+	}
+	put("src/a.txt", []byte("a"))
+	put("src/b.log", []byte("b"))
+	put("src/dir/c.txt", data)
+
+	check := func(client *oss.Client, bucket, key string, data []byte) {
+		t.Helper()
+		result, err := client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(bucket), Key: oss.Ptr(key)})
+		if !assert.Nil(t, err) {
+			return
+		}
+		got, _ := io.ReadAll(result.Body)
+		result.Body.Close()
+		assert.Equal(t, data, got)
+		assert.Equal(t, "text/plain", oss.ToString(result.ContentType))
+		assert.Equal(t, "alice", result.Metadata["owner"])
+		tags, err := client.GetObjectTagging(ctx, &oss.GetObjectTaggingRequest{Bucket: oss.Ptr(bucket), Key: oss.Ptr(key)})
+		assert.Nil(t, err)
+		assert.Len(t, tags.Tags, 2)
+	}
+
+	// server-side copy, the large object by UploadPartCopy
+	copier := client.NewCopier(func(co *oss.CopierOptions) {
+		co.PartSize = oss.MinPartSize
+		co.MultipartCopyThreshold = oss.MinPartSize
+	})
+	result, err := copier.CopyPrefix(ctx, "source", "src/", testBucket, "dest/", func(o *oss.CopyPrefixOptions) {
+		o.Exclude = []string{"*.log"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Copied)
+	assert.Equal(t, "dest/a.txt", result.Objects[0].Key)
+	assert.False(t, result.Objects[0].Streamed)
+	assert.NotEmpty(t, oss.ToString(result.Objects[1].Result.UploadId))
+	check(client, testBucket, "dest/a.txt", []byte("a"))
+	check(client, testBucket, "dest/dir/c.txt", data)
+	_, ok := srv.Object(testBucket, "dest/b.log")
+	assert.False(t, ok)
+
+	// the large object is copied by parts, its ETag is different, but the CRC-64 is the same
+	result, err = copier.CopyPrefix(ctx, "source", "src/", testBucket, "dest/", func(o *oss.CopyPrefixOptions) {
+		o.SkipUnchanged = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Copied)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, "src/b.log", result.Objects[1].SourceKey)
+	assert.False(t, result.Objects[1].Skipped)
+
+	// another region, the objects are downloaded and uploaded
+	_, client2 := newTestServer(t, func(o *osstest.Options) { o.Region = "cn-shanghai" })
+	result, err = client2.NewCopier().CopyPrefix(ctx, "source", "src/", testBucket, "", func(o *oss.CopyPrefixOptions) {
+		o.SourceClient = struct{ oss.CopySourceAPIClient }{client}
+		o.DisableServerSideCopy = true
+		o.Include = []string{"*.txt"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Copied)
+	assert.True(t, result.Objects[0].Streamed)
+	check(client2, testBucket, "a.txt", []byte("a"))
+	check(client2, testBucket, "dir/c.txt", data)
+
+	// the same region, but the source bucket is not found by the destination
+	_, client3 := newTestServer(t)
+	dir := t.TempDir()
+	result, err = client3.NewCopier().CopyPrefix(ctx, "source", "src/", testBucket, "", func(o *oss.CopyPrefixOptions) {
+		o.SourceClient = client
+		o.EnableCheckpoint = true
+		o.CheckpointDir = filepath.Join(dir, "cp")
+		o.KeyFn = func(relKey string) string {
+			if relKey == "b.log" {
+				return ""
+			}
+			return relKey
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Copied)
+	assert.Equal(t, 1, result.Failed)
+	assert.True(t, result.Objects[0].Streamed)
+	assert.NotNil(t, result.Objects[1].Err)
+	check(client3, testBucket, "a.txt", []byte("a"))
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+
+	// resume the job, the copied objects are skipped
+	result, err = client3.NewCopier().CopyPrefix(ctx, "source", "src/", testBucket, "", func(o *oss.CopyPrefixOptions) {
+		o.SourceClient = client
+		o.EnableCheckpoint = true
+		o.CheckpointDir = filepath.Join(dir, "cp")
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Copied)
+	assert.Equal(t, 2, result.Skipped)
+	check(client3, testBucket, "b.log", []byte("b"))
+	entries, _ = os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+}
@@ -0,0 +1,86 @@
+package oss
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyPrefix_InvalidArgs(t *testing.T) {
+	copier := NewClient(NewConfig()).NewCopier()
+
+	_, err := copier.CopyPrefix(context.TODO(), "", "src/", "bucket", "dest/")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "missing required field, srcBucket")
+
+	_, err = copier.CopyPrefix(context.TODO(), "bucket", "src/", "", "dest/")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "missing required field, destBucket")
+
+	_, err = copier.CopyPrefix(context.TODO(), "bucket", "src/", "bucket", "dest/", func(o *CopyPrefixOptions) {
+		o.Include = []string{"[a-"}
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "options.Include")
+
+	copier = NewCopier(struct{ CopyAPIClient }{})
+	_, err = copier.CopyPrefix(context.TODO(), "bucket", "src/", "bucket", "dest/")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "does not support listing objects")
+
+	// the objects are listed by the source client, the destination ones are listed if SkipUnchanged is set
+	result, err := copier.CopyPrefix(context.TODO(), "bucket", "src/", "bucket", "dest/", func(o *CopyPrefixOptions) {
+		o.SourceClient = &stubCopySourceAPIClient{}
+	})
+	assert.Nil(t, err)
+	assert.Len(t, result.Objects, 0)
+
+	_, err = copier.CopyPrefix(context.TODO(), "bucket", "src/", "bucket", "dest/", func(o *CopyPrefixOptions) {
+		o.SourceClient = &stubCopySourceAPIClient{}
+		o.SkipUnchanged = true
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "does not support listing objects")
+}
+
+type stubCopySourceAPIClient struct {
+	CopySourceAPIClient
+}
+
+func (c *stubCopySourceAPIClient) ListObjectsV2(ctx context.Context, request *ListObjectsV2Request, optFns ...func(*Options)) (*ListObjectsV2Result, error) {
+	return &ListObjectsV2Result{}, nil
+}
+
+func TestCopiedMetadataHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Content-Type", "text/plain")
+	headers.Set("Cache-Control", "no-cache")
+	headers.Set("X-Oss-Meta-Owner", "alice")
+	headers.Set("Content-Length", "10")
+	headers.Set("ETag", "\"etag\"")
+	headers.Set("X-Oss-Hash-Crc64ecma", "123")
+
+	copied := copiedMetadataHeaders(headers)
+	assert.Equal(t, map[string]string{
+		"content-type":     "text/plain",
+		"cache-control":    "no-cache",
+		"x-oss-meta-owner": "alice",
+	}, copied)
+}
+
+func TestEncodeTagging(t *testing.T) {
+	assert.Equal(t, "", encodeTagging(nil))
+	assert.Equal(t, "k1=v1&k+2=v%262", encodeTagging([]Tag{
+		{Key: Ptr("k1"), Value: Ptr("v1")},
+		{Key: Ptr("k 2"), Value: Ptr("v&2")},
+	}))
+}
+
+func TestIsServerSideCopyDenied(t *testing.T) {
+	assert.True(t, isServerSideCopyDenied(&CopyError{Err: &ServiceError{StatusCode: 403, Code: "AccessDenied"}}))
+	assert.True(t, isServerSideCopyDenied(&ServiceError{StatusCode: 404, Code: "NoSuchBucket"}))
+	assert.False(t, isServerSideCopyDenied(&ServiceError{StatusCode: 404, Code: "NoSuchKey"}))
+	assert.False(t, isServerSideCopyDenied(context.Canceled))
+}
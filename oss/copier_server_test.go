@@ -0,0 +1,137 @@
+package oss_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/osstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopierCheckpoint_Server(t *testing.T) {
+	srv, client := newTestServer(t, func(o *osstest.Options) { o.MinPartSize = oss.MinPartSize })
+	ctx := context.TODO()
+	data := randomData(5*100*1024 + 123)
+	assert.Nil(t, srv.PutObject(testBucket, "src", data))
+	dir := t.TempDir()
+
+	copier := client.NewCopier(func(co *oss.CopierOptions) {
+		co.PartSize = oss.MinPartSize
+		co.MultipartCopyThreshold = oss.MinPartSize
+		co.ParallelNum = 1
+		co.DisableShallowCopy = true
+		co.EnableCheckpoint = true
+		co.CheckpointDir = filepath.Join(dir, "cp")
+	})
+	interrupt := func(after int64) (*oss.CopyResult, error) {
+		cctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		return copier.Copy(cctx, &oss.CopyObjectRequest{
+			Bucket:    oss.Ptr(testBucket),
+			Key:       oss.Ptr("dest"),
+			SourceKey: oss.Ptr("src"),
+			ProgressFn: func(increment, transferred, total int64) {
+				if transferred >= after {
+					cancel()
+				}
+			},
+		})
+	}
+
+	// interrupted after 2 parts
+	_, err := interrupt(2 * oss.MinPartSize)
+	var cerr *oss.CopyError
+	assert.True(t, errors.As(err, &cerr))
+	uploadId := cerr.UploadId
+	assert.NotEmpty(t, uploadId)
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 1)
+
+	// resumed from the 3rd part
+	var first int64
+	_, err = copier.Copy(ctx, &oss.CopyObjectRequest{
+		Bucket:    oss.Ptr(testBucket),
+		Key:       oss.Ptr("dest"),
+		SourceKey: oss.Ptr("src"),
+		ProgressFn: func(increment, transferred, total int64) {
+			if first == 0 {
+				first = transferred
+			}
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3*oss.MinPartSize, first)
+	stored, _ := srv.Object(testBucket, "dest")
+	assert.Equal(t, data, stored)
+	entries, _ = os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+
+	// the source is changed, the copy starts over
+	_, err = interrupt(oss.MinPartSize)
+	assert.True(t, errors.As(err, &cerr))
+	data = randomData(5*100*1024 + 123)
+	assert.Nil(t, srv.PutObject(testBucket, "src", data))
+	result, err := copier.Copy(ctx, &oss.CopyObjectRequest{
+		Bucket:    oss.Ptr(testBucket),
+		Key:       oss.Ptr("dest"),
+		SourceKey: oss.Ptr("src"),
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, cerr.UploadId, oss.ToString(result.UploadId))
+	stored, _ = srv.Object(testBucket, "dest")
+	assert.Equal(t, data, stored)
+
+	// the stale upload is aborted
+	_, err = client.ListParts(ctx, &oss.ListPartsRequest{
+		Bucket:   oss.Ptr(testBucket),
+		Key:      oss.Ptr("dest"),
+		UploadId: oss.Ptr(cerr.UploadId),
+	})
+	var serr *oss.ServiceError
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, "NoSuchUpload", serr.Code)
+
+	// the parts can not be listed, the upload is aborted and the copy starts over
+	_, err = interrupt(oss.MinPartSize)
+	assert.True(t, errors.As(err, &cerr))
+	failClient := oss.NewClient(srv.Config().
+		WithRetryMaxAttempts(1).
+		WithHttpClient(&http.Client{Transport: failListPartsTransport{http.DefaultTransport}}))
+	result, err = failClient.NewCopier(func(co *oss.CopierOptions) {
+		co.PartSize = oss.MinPartSize
+		co.MultipartCopyThreshold = oss.MinPartSize
+		co.DisableShallowCopy = true
+		co.EnableCheckpoint = true
+		co.CheckpointDir = filepath.Join(dir, "cp")
+	}).Copy(ctx, &oss.CopyObjectRequest{
+		Bucket:    oss.Ptr(testBucket),
+		Key:       oss.Ptr("dest"),
+		SourceKey: oss.Ptr("src"),
+	})
+	assert.Nil(t, err)
+	assert.NotEqual(t, cerr.UploadId, oss.ToString(result.UploadId))
+	_, err = client.ListParts(ctx, &oss.ListPartsRequest{
+		Bucket:   oss.Ptr(testBucket),
+		Key:      oss.Ptr("dest"),
+		UploadId: oss.Ptr(cerr.UploadId),
+	})
+	assert.True(t, errors.As(err, &serr))
+	assert.Equal(t, "NoSuchUpload", serr.Code)
+}
+
+// failListPartsTransport fails the ListParts requests.
+type failListPartsTransport struct {
+	http.RoundTripper
+}
+
+func (t failListPartsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == http.MethodGet && r.URL.Query().Has("uploadId") {
+		return nil, errors.New("list parts failed")
+	}
+	return t.RoundTripper.RoundTrip(r)
+}
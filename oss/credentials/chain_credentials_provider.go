@@ -0,0 +1,196 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	envCredentialProcess   = "OSS_CREDENTIAL_PROCESS"
+	envEcsMetadataDisabled = "ALIBABA_CLOUD_ECS_METADATA_DISABLED"
+)
+
+// ChainSource is a named provider of a ChainProvider.
+type ChainSource struct {
+	Name     string
+	Provider CredentialsProvider
+}
+
+// ChainSourceError is the error of a source which is tried by a ChainProvider.
+type ChainSourceError struct {
+	Name string
+	Err  error
+}
+
+// ChainProviderError is returned when none of the sources of a ChainProvider provides credentials.
+type ChainProviderError struct {
+	Errors []ChainSourceError
+}
+
+func (e *ChainProviderError) Error() string {
+	var b strings.Builder
+	b.WriteString("no valid credentials found in the chain")
+	for _, se := range e.Errors {
+		fmt.Fprintf(&b, "; %s: %v", se.Name, se.Err)
+	}
+	return b.String()
+}
+
+// ChainProvider tries the sources in order and remembers the first one which provides credentials,
+// the following calls go to that source only.
+// The sources are tried by one call at a time, without holding the lock, the concurrent calls wait for it.
+type ChainProvider struct {
+	sources []ChainSource
+
+	mu        sync.Mutex
+	current   *ChainSource
+	resolving chan struct{} // closed when the call which tries the sources returns
+}
+
+func NewChainProvider(sources ...ChainSource) *ChainProvider {
+	return &ChainProvider{sources: sources}
+}
+
+func (p *ChainProvider) GetCredentials(ctx context.Context) (Credentials, error) {
+	for {
+		p.mu.Lock()
+		if current := p.current; current != nil {
+			p.mu.Unlock()
+			return current.Provider.GetCredentials(ctx)
+		}
+		if resolving := p.resolving; resolving != nil {
+			p.mu.Unlock()
+			// try the sources again if the other call fails
+			select {
+			case <-resolving:
+				continue
+			case <-ctx.Done():
+				return Credentials{}, ctx.Err()
+			}
+		}
+		resolving := make(chan struct{})
+		p.resolving = resolving
+		p.mu.Unlock()
+
+		creds, source, err := p.resolve(ctx)
+
+		p.mu.Lock()
+		p.current = source
+		p.resolving = nil
+		p.mu.Unlock()
+		close(resolving)
+		return creds, err
+	}
+}
+
+// resolve tries the sources in order, and returns the first one which provides credentials.
+func (p *ChainProvider) resolve(ctx context.Context) (Credentials, *ChainSource, error) {
+	chainErr := &ChainProviderError{}
+	for i := range p.sources {
+		source := &p.sources[i]
+		creds, err := source.Provider.GetCredentials(ctx)
+		if err == nil && !creds.HasKeys() {
+			err = fmt.Errorf("AccessKeyId or AccessKeySecret is empty")
+		}
+		if err != nil {
+			chainErr.Errors = append(chainErr.Errors, ChainSourceError{Name: source.Name, Err: err})
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		return creds, source, nil
+	}
+	return Credentials{}, nil, chainErr
+}
+
+// Source returns the name of the source which provided the credentials, or an empty string.
+func (p *ChainProvider) Source() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.current == nil {
+		return ""
+	}
+	return p.current.Name
+}
+
+type DefaultChainProviderOptions struct {
+	// The options of the profile source.
+	ProfileOptions []func(*ProfileCredentialsProviderOptions)
+
+	// The command of the process source, the default is OSS_CREDENTIAL_PROCESS.
+	// The source is skipped if it is empty.
+	ProcessCommand string
+
+	// The uri of the credentials uri source, the default is ALIBABA_CLOUD_CREDENTIALS_URI.
+	// The source is added only if it is not empty.
+	CredentialsURI string
+
+	// Disable the ECS metadata source, the default is ALIBABA_CLOUD_ECS_METADATA_DISABLED.
+	DisableEcsMetadata bool
+
+	// The options of the ECS metadata source, the timeout is 1 second and there is no retry by default,
+	// so that the chain fails fast outside ECS.
+	EcsRoleOptions []func(*EcsRoleCredentialsProviderOptions)
+}
+
+// NewDefaultChainProvider returns a chain of the environment variables, the profile of the Alibaba Cloud CLI config file,
+// the OIDC role (RRSA), the credentials process, the credentials uri and the ECS instance role.
+func NewDefaultChainProvider(optFns ...func(*DefaultChainProviderOptions)) *ChainProvider {
+	disabled, _ := strconv.ParseBool(os.Getenv(envEcsMetadataDisabled))
+	options := DefaultChainProviderOptions{
+		ProcessCommand:     os.Getenv(envCredentialProcess),
+		CredentialsURI:     os.Getenv(envCredentialsURI),
+		DisableEcsMetadata: disabled,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	sources := []ChainSource{
+		{Name: "Environment", Provider: NewEnvironmentVariableCredentialsProvider()},
+		{Name: "Profile", Provider: NewProfileCredentialsProvider(options.ProfileOptions...)},
+		{Name: "OIDCRole", Provider: oidcChainSource()},
+		{Name: "Process", Provider: processChainSource(options.ProcessCommand)},
+	}
+	if options.CredentialsURI != "" {
+		sources = append(sources, ChainSource{Name: "CredentialsURI", Provider: NewURICredentialsProvider(options.CredentialsURI)})
+	}
+	if !options.DisableEcsMetadata {
+		ecsOptions := append([]func(*EcsRoleCredentialsProviderOptions){
+			func(o *EcsRoleCredentialsProviderOptions) {
+				o.Timeout = time.Second
+				o.Retries = 1
+			},
+		}, options.EcsRoleOptions...)
+		sources = append(sources, ChainSource{Name: "EcsRole", Provider: NewEcsRoleCredentialsProvider(ecsOptions...)})
+	}
+	return NewChainProvider(sources...)
+}
+
+// oidcChainSource is skipped unless the environment variables of RRSA are set.
+func oidcChainSource() CredentialsProvider {
+	if os.Getenv(envOIDCTokenFile) == "" || os.Getenv(envRoleArn) == "" || os.Getenv(envOIDCProviderArn) == "" {
+		return CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+			return Credentials{}, fmt.Errorf("%s, %s or %s is not set", envOIDCTokenFile, envRoleArn, envOIDCProviderArn)
+		})
+	}
+	return NewOIDCRoleCredentialsProvider()
+}
+
+func processChainSource(command string) CredentialsProvider {
+	if command == "" {
+		return CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+			return Credentials{}, fmt.Errorf("%s is not set", envCredentialProcess)
+		})
+	}
+	process := NewProcessCredentialsProvider(command)
+	return NewCredentialsFetcherProvider(CredentialsFetcherFunc(func(ctx context.Context) (Credentials, error) {
+		return process.GetCredentials(ctx)
+	}))
+}
@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -841,3 +844,885 @@ func newStubEcsRoleCredentialsProvider(optFns ...func(*stubEcsRoleCredentialsPro
 	}))
 	return provider
 }
+
+func testSetupStsMockServer(t *testing.T, secrets map[string]string, requests *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Nil(t, r.ParseForm())
+		form := r.PostForm
+		values := map[string]string{}
+		for k := range form {
+			values[k] = form.Get(k)
+		}
+		*requests = append(*requests, values)
+
+		secret, ok := secrets[form.Get("AccessKeyId")]
+		if !ok {
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"RequestId":"id-1","Code":"InvalidAccessKeyId.NotFound","Message":"Specified access key is not found."}`)
+			return
+		}
+		if form.Get("Signature") != stsSignature("POST", form, secret) {
+			w.WriteHeader(400)
+			fmt.Fprint(w, `{"RequestId":"id-2","Code":"SignatureDoesNotMatch","Message":"Specified signature is not matched with our calculation."}`)
+			return
+		}
+		expiration := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05Z")
+		fmt.Fprintf(w, `{"RequestId":"id-3","AssumedRoleUser":{"Arn":"%s/%s","AssumedRoleId":"1234:%s"},`+
+			`"Credentials":{"AccessKeyId":"STS.%s","AccessKeySecret":"sts-secret","SecurityToken":"token-%d","Expiration":"%s"}}`,
+			form.Get("RoleArn"), form.Get("RoleSessionName"), form.Get("RoleSessionName"),
+			form.Get("RoleSessionName"), len(*requests), expiration)
+	}))
+}
+
+func TestAssumeRoleCredentialsProvider(t *testing.T) {
+	var requests []map[string]string
+	server := testSetupStsMockServer(t, map[string]string{"ak": "sk", "STS.first": "sts-secret"}, &requests)
+	defer server.Close()
+
+	provider := NewAssumeRoleCredentialsProviderWithoutRefresh(
+		NewStaticCredentialsProvider("ak", "sk"),
+		"acs:ram::1234:role/test",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Endpoint = server.URL
+			o.RoleSessionName = "session"
+			o.Policy = `{"Version":"1"}`
+			o.Duration = 30 * time.Minute
+			o.ExternalId = "external-id"
+		})
+	creds, err := provider.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "STS.session", creds.AccessKeyID)
+	assert.Equal(t, "sts-secret", creds.AccessKeySecret)
+	assert.Equal(t, "token-1", creds.SecurityToken)
+	assert.NotNil(t, creds.Expires)
+	assert.True(t, creds.Expires.After(time.Now()))
+
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "AssumeRole", requests[0]["Action"])
+	assert.Equal(t, "2015-04-01", requests[0]["Version"])
+	assert.Equal(t, "acs:ram::1234:role/test", requests[0]["RoleArn"])
+	assert.Equal(t, "session", requests[0]["RoleSessionName"])
+	assert.Equal(t, `{"Version":"1"}`, requests[0]["Policy"])
+	assert.Equal(t, "1800", requests[0]["DurationSeconds"])
+	assert.Equal(t, "external-id", requests[0]["ExternalId"])
+	assert.Equal(t, "ak", requests[0]["AccessKeyId"])
+	assert.Equal(t, "", requests[0]["SecurityToken"])
+
+	// the role is assumed with the credentials of another role
+	chained := NewAssumeRoleCredentialsProviderWithoutRefresh(
+		NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "sk"), "acs:ram::1234:role/first",
+			func(o *AssumeRoleCredentialsProviderOptions) {
+				o.Endpoint = server.URL
+				o.RoleSessionName = "first"
+			}),
+		"acs:ram::1234:role/second",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Endpoint = server.URL
+			o.RoleSessionName = "second"
+		})
+	requests = nil
+	creds, err = chained.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "STS.second", creds.AccessKeyID)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "ak", requests[0]["AccessKeyId"])
+	assert.Equal(t, "STS.first", requests[1]["AccessKeyId"])
+	assert.Equal(t, "token-1", requests[1]["SecurityToken"])
+	assert.Equal(t, "3600", requests[1]["DurationSeconds"])
+
+	// wrong secret
+	provider = NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "wrong"), "acs:ram::1234:role/test",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Endpoint = server.URL
+		})
+	_, err = provider.GetCredentials(context.Background())
+	assert.Error(t, err)
+	var stsErr *StsError
+	assert.ErrorAs(t, err, &stsErr)
+	assert.Equal(t, 400, stsErr.StatusCode)
+	assert.Equal(t, "SignatureDoesNotMatch", stsErr.Code)
+	assert.Equal(t, "id-2", stsErr.RequestId)
+
+	// invalid arguments
+	_, err = NewAssumeRoleCredentialsProviderWithoutRefresh(nil, "acs:ram::1234:role/test").GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "source credentials provider is null")
+	_, err = NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "sk"), "").GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "role arn must not be empty")
+	_, err = NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "sk"), "acs:ram::1234:role/test",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Duration = time.Minute
+		}).GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "duration must be at least")
+	_, err = NewAssumeRoleCredentialsProviderWithoutRefresh(NewAnonymousCredentialsProvider(), "acs:ram::1234:role/test").GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "source credentials must have AccessKeyId and AccessKeySecret")
+}
+
+func TestNewAssumeRoleCredentialsProvider(t *testing.T) {
+	p := NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "sk"), "acs:ram::1234:role/test")
+	arp, ok := p.(*AssumeRoleCredentialsProvider)
+	assert.True(t, ok)
+	assert.Equal(t, "https://sts.aliyuncs.com", arp.client.endpoint)
+	assert.Equal(t, time.Hour, arp.options.Duration)
+	assert.Contains(t, arp.options.RoleSessionName, "oss-go-sdk-v2-")
+
+	p = NewAssumeRoleCredentialsProviderWithoutRefresh(NewStaticCredentialsProvider("ak", "sk"), "acs:ram::1234:role/test",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Region = "cn-hangzhou"
+		})
+	assert.Equal(t, "https://sts.cn-hangzhou.aliyuncs.com", p.(*AssumeRoleCredentialsProvider).client.endpoint)
+
+	var requests []map[string]string
+	server := testSetupStsMockServer(t, map[string]string{"ak": "sk"}, &requests)
+	defer server.Close()
+
+	provider := NewAssumeRoleCredentialsProvider(NewStaticCredentialsProvider("ak", "sk"), "acs:ram::1234:role/test",
+		func(o *AssumeRoleCredentialsProviderOptions) {
+			o.Endpoint = server.URL
+		})
+	_, ok = provider.(*CredentialsFetcherProvider)
+	assert.True(t, ok)
+
+	creds, err := provider.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", creds.SecurityToken)
+
+	// the credentials are cached until they are about to expire
+	creds, err = provider.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", creds.SecurityToken)
+	assert.Len(t, requests, 1)
+}
+
+func testSetupOIDCStsMockServer(t *testing.T, requests *[]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		values := map[string]string{}
+		for k := range r.PostForm {
+			values[k] = r.PostForm.Get(k)
+		}
+		*requests = append(*requests, values)
+
+		if values["OIDCToken"] != "token-a" && values["OIDCToken"] != "token-b" {
+			w.WriteHeader(400)
+			fmt.Fprint(w, `{"RequestId":"id-1","Code":"AuthenticationFail.OIDCToken.Invalid","Message":"The oidc token is invalid."}`)
+			return
+		}
+		expiration := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05Z")
+		fmt.Fprintf(w, `{"RequestId":"id-2","Credentials":{"AccessKeyId":"STS.ak","AccessKeySecret":"sts-secret","SecurityToken":"sts-%s","Expiration":"%s"}}`,
+			values["OIDCToken"], expiration)
+	}))
+}
+
+func TestOIDCRoleCredentialsProvider(t *testing.T) {
+	var requests []map[string]string
+	server := testSetupOIDCStsMockServer(t, &requests)
+	defer server.Close()
+
+	tokenFile := t.TempDir() + "/token"
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("token-a\n"), 0600))
+
+	t.Setenv("ALIBABA_CLOUD_ROLE_ARN", "acs:ram::1234:role/pod")
+	t.Setenv("ALIBABA_CLOUD_OIDC_PROVIDER_ARN", "acs:ram::1234:oidc-provider/ack")
+	t.Setenv("ALIBABA_CLOUD_OIDC_TOKEN_FILE", tokenFile)
+	t.Setenv("ALIBABA_CLOUD_ROLE_SESSION_NAME", "pod-session")
+	t.Setenv("ALIBABA_CLOUD_STS_REGION", "")
+	t.Setenv("ALIBABA_CLOUD_VPC_ENDPOINT_ENABLED", "")
+
+	provider := NewOIDCRoleCredentialsProviderWithoutRefresh(func(o *OIDCRoleCredentialsProviderOptions) {
+		o.Endpoint = server.URL
+	})
+	creds, err := provider.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "STS.ak", creds.AccessKeyID)
+	assert.Equal(t, "sts-secret", creds.AccessKeySecret)
+	assert.Equal(t, "sts-token-a", creds.SecurityToken)
+	assert.NotNil(t, creds.Expires)
+
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "AssumeRoleWithOIDC", requests[0]["Action"])
+	assert.Equal(t, "acs:ram::1234:role/pod", requests[0]["RoleArn"])
+	assert.Equal(t, "acs:ram::1234:oidc-provider/ack", requests[0]["OIDCProviderArn"])
+	assert.Equal(t, "token-a", requests[0]["OIDCToken"])
+	assert.Equal(t, "pod-session", requests[0]["RoleSessionName"])
+	assert.Equal(t, "3600", requests[0]["DurationSeconds"])
+	assert.Equal(t, "", requests[0]["AccessKeyId"])
+	assert.Equal(t, "", requests[0]["Signature"])
+
+	// the token file is read again
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("token-b"), 0600))
+	creds, err = provider.GetCredentials(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sts-token-b", creds.SecurityToken)
+
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("expired"), 0600))
+	_, err = provider.GetCredentials(context.Background())
+	var stsErr *StsError
+	assert.ErrorAs(t, err, &stsErr)
+	assert.Equal(t, "AuthenticationFail.OIDCToken.Invalid", stsErr.Code)
+
+	// the options override the environment variables
+	provider = NewOIDCRoleCredentialsProviderWithoutRefresh(func(o *OIDCRoleCredentialsProviderOptions) {
+		o.OIDCTokenFilePath = tokenFile + ".missing"
+	})
+	_, err = provider.GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "failed to read oidc token file")
+
+	t.Setenv("ALIBABA_CLOUD_ROLE_ARN", "")
+	_, err = NewOIDCRoleCredentialsProviderWithoutRefresh().GetCredentials(context.Background())
+	assert.Contains(t, err.Error(), "role arn must not be empty")
+}
+
+func TestNewOIDCRoleCredentialsProvider(t *testing.T) {
+	t.Setenv("ALIBABA_CLOUD_ROLE_SESSION_NAME", "")
+	t.Setenv("ALIBABA_CLOUD_STS_REGION", "cn-beijing")
+	t.Setenv("ALIBABA_CLOUD_VPC_ENDPOINT_ENABLED", "true")
+	p := NewOIDCRoleCredentialsProviderWithoutRefresh().(*OIDCRoleCredentialsProvider)
+	assert.Equal(t, "https://sts-vpc.cn-beijing.aliyuncs.com", p.client.endpoint)
+	assert.Contains(t, p.options.RoleSessionName, "oss-go-sdk-v2-")
+
+	t.Setenv("ALIBABA_CLOUD_VPC_ENDPOINT_ENABLED", "")
+	p = NewOIDCRoleCredentialsProviderWithoutRefresh().(*OIDCRoleCredentialsProvider)
+	assert.Equal(t, "https://sts.cn-beijing.aliyuncs.com", p.client.endpoint)
+
+	var requests []map[string]string
+	server := testSetupOIDCStsMockServer(t, &requests)
+	defer server.Close()
+
+	tokenFile := t.TempDir() + "/token"
+	assert.Nil(t, os.WriteFile(tokenFile, []byte("token-a"), 0600))
+	provider := NewOIDCRoleCredentialsProvider(func(o *OIDCRoleCredentialsProviderOptions) {
+		o.RoleArn = "acs:ram::1234:role/pod"
+		o.OIDCProviderArn = "acs:ram::1234:oidc-provider/ack"
+		o.OIDCTokenFilePath = tokenFile
+		o.Endpoint = server.URL
+	})
+	_, ok := provider.(*CredentialsFetcherProvider)
+	assert.True(t, ok)
+
+	for i := 0; i < 3; i++ {
+		creds, err := provider.GetCredentials(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "sts-token-a", creds.SecurityToken)
+	}
+	assert.Len(t, requests, 1)
+}
+
+func testWriteCliConfig(t *testing.T, content string) string {
+	path := t.TempDir() + "/config.json"
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestProfileCredentialsProvider(t *testing.T) {
+	path := testWriteCliConfig(t, `{
+	"current": "sts",
+	"profiles": [
+		{"name": "default", "mode": "AK", "access_key_id": "ak", "access_key_secret": "sk"},
+		{"name": "sts", "mode": "StsToken", "access_key_id": "ak", "access_key_secret": "sk", "sts_token": "token"},
+		{"name": "role", "mode": "RamRoleArn", "access_key_id": "ak", "access_key_secret": "sk",
+			"ram_role_arn": "acs:ram::1234:role/base", "ram_session_name": "base", "expired_seconds": 900},
+		{"name": "chained", "mode": "ChainableRamRoleArn", "source_profile": "role",
+			"ram_role_arn": "acs:ram::1234:role/chained", "ram_session_name": "chained"},
+		{"name": "cycle-a", "mode": "ChainableRamRoleArn", "source_profile": "cycle-b"},
+		{"name": "cycle-b", "mode": "ChainableRamRoleArn", "source_profile": "cycle-a"},
+		{"name": "empty", "mode": "AK"},
+		{"name": "unknown", "mode": "RsaKeyPair"}
+	]
+}`)
+	profile := func(name string) *ProfileCredentialsProvider {
+		return NewProfileCredentialsProvider(func(o *ProfileCredentialsProviderOptions) {
+			o.FilePath = path
+			o.ProfileName = name
+		}).(*ProfileCredentialsProvider)
+	}
+	ctx := context.Background()
+
+	creds, err := profile("default").GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", creds.AccessKeyID)
+	assert.Equal(t, "sk", creds.AccessKeySecret)
+	assert.Equal(t, "", creds.SecurityToken)
+
+	// the current profile
+	creds, err = profile("").GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", creds.SecurityToken)
+
+	t.Setenv("ALIBABA_CLOUD_PROFILE", "default")
+	creds, err = NewProfileCredentialsProvider(func(o *ProfileCredentialsProviderOptions) {
+		o.FilePath = path
+	}).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "", creds.SecurityToken)
+
+	_, err = profile("empty").GetCredentials(ctx)
+	assert.Contains(t, err.Error(), "profile empty: access_key_id or access_key_secret is empty")
+	_, err = profile("unknown").GetCredentials(ctx)
+	assert.Contains(t, err.Error(), "profile unknown: unsupported mode RsaKeyPair")
+	_, err = profile("cycle-a").GetCredentials(ctx)
+	assert.Contains(t, err.Error(), "source profile cycle-a forms a cycle")
+	_, err = profile("missing").GetCredentials(ctx)
+	assert.Contains(t, err.Error(), "profile missing is not found")
+	_, err = NewProfileCredentialsProvider(func(o *ProfileCredentialsProviderOptions) {
+		o.FilePath = path + ".missing"
+	}).GetCredentials(ctx)
+	assert.Contains(t, err.Error(), "failed to read config file")
+
+	// the roles are assumed on the first GetCredentials, the source of the chained role is the role profile
+	resolved, err := profile("chained").resolve()
+	assert.NoError(t, err)
+	_, ok := resolved.(*CredentialsFetcherProvider)
+	assert.True(t, ok)
+	resolved, err = profile("role").resolve()
+	assert.NoError(t, err)
+	_, ok = resolved.(*CredentialsFetcherProvider)
+	assert.True(t, ok)
+}
+
+func TestChainProvider(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	failing := CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+		calls++
+		return Credentials{}, fmt.Errorf("not configured")
+	})
+	chain := NewChainProvider(
+		ChainSource{Name: "First", Provider: failing},
+		ChainSource{Name: "Empty", Provider: NewAnonymousCredentialsProvider()},
+		ChainSource{Name: "Static", Provider: NewStaticCredentialsProvider("ak", "sk")},
+		ChainSource{Name: "Last", Provider: NewStaticCredentialsProvider("ak1", "sk1")},
+	)
+	assert.Equal(t, "", chain.Source())
+	creds, err := chain.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", creds.AccessKeyID)
+	assert.Equal(t, "Static", chain.Source())
+	assert.Equal(t, 1, calls)
+
+	// the source which succeeded is remembered
+	creds, err = chain.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", creds.AccessKeyID)
+	assert.Equal(t, 1, calls)
+
+	chain = NewChainProvider(
+		ChainSource{Name: "First", Provider: failing},
+		ChainSource{Name: "Empty", Provider: NewAnonymousCredentialsProvider()},
+	)
+	_, err = chain.GetCredentials(ctx)
+	var chainErr *ChainProviderError
+	assert.ErrorAs(t, err, &chainErr)
+	assert.Len(t, chainErr.Errors, 2)
+	assert.Equal(t, "First", chainErr.Errors[0].Name)
+	assert.EqualError(t, chainErr.Errors[0].Err, "not configured")
+	assert.Equal(t, "Empty", chainErr.Errors[1].Name)
+	assert.Equal(t, "no valid credentials found in the chain; First: not configured; Empty: AccessKeyId or AccessKeySecret is empty", err.Error())
+}
+
+func TestChainProvider_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}, nil
+	})
+	chain := NewChainProvider(ChainSource{Name: "Slow", Provider: slow})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		creds, err := chain.GetCredentials(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "ak", creds.AccessKeyID)
+	}()
+	<-started
+
+	// the lock is not held while the sources are tried
+	assert.Equal(t, "", chain.Source())
+
+	// the waiting call honors its context
+	cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	_, err := chain.GetCredentials(cctx)
+	cancel()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the waiting calls use the resolved source
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			creds, err := chain.GetCredentials(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "ak", creds.AccessKeyID)
+		}()
+	}
+	close(release)
+	<-done
+	wg.Wait()
+	assert.Equal(t, "Slow", chain.Source())
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+
+	// the sources are tried again after a failure
+	var failures int32
+	flaky := CredentialsProviderFunc(func(context.Context) (Credentials, error) {
+		if atomic.AddInt32(&failures, 1) == 1 {
+			return Credentials{}, fmt.Errorf("not ready")
+		}
+		return Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}, nil
+	})
+	chain = NewChainProvider(ChainSource{Name: "Flaky", Provider: flaky})
+	_, err = chain.GetCredentials(ctx)
+	assert.Error(t, err)
+	creds, err := chain.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", creds.AccessKeyID)
+}
+
+func TestDefaultChainProvider(t *testing.T) {
+	ctx := context.Background()
+	path := testWriteCliConfig(t, `{"current": "default", "profiles": [{"name": "default", "mode": "AK", "access_key_id": "profile-ak", "access_key_secret": "profile-sk"}]}`)
+	withProfile := func(o *DefaultChainProviderOptions) {
+		o.ProfileOptions = append(o.ProfileOptions, func(po *ProfileCredentialsProviderOptions) {
+			po.FilePath = path
+		})
+	}
+
+	t.Setenv("OSS_ACCESS_KEY_ID", "env-ak")
+	t.Setenv("OSS_ACCESS_KEY_SECRET", "env-sk")
+	t.Setenv("OSS_SESSION_TOKEN", "")
+	t.Setenv("ALIBABA_CLOUD_PROFILE", "")
+	t.Setenv("ALIBABA_CLOUD_OIDC_TOKEN_FILE", "")
+	t.Setenv("OSS_CREDENTIAL_PROCESS", "")
+	t.Setenv("ALIBABA_CLOUD_ECS_METADATA_DISABLED", "true")
+
+	chain := NewDefaultChainProvider(withProfile)
+	assert.Len(t, chain.sources, 4)
+	creds, err := chain.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-ak", creds.AccessKeyID)
+	assert.Equal(t, "Environment", chain.Source())
+
+	t.Setenv("OSS_ACCESS_KEY_ID", "")
+	chain = NewDefaultChainProvider(withProfile)
+	creds, err = chain.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "profile-ak", creds.AccessKeyID)
+	assert.Equal(t, "Profile", chain.Source())
+
+	if runtime.GOOS != "windows" {
+		chain = NewDefaultChainProvider(func(o *DefaultChainProviderOptions) {
+			o.ProfileOptions = []func(*ProfileCredentialsProviderOptions){func(po *ProfileCredentialsProviderOptions) {
+				po.FilePath = path + ".missing"
+			}}
+			o.ProcessCommand = `echo '{"AccessKeyId":"process-ak","AccessKeySecret":"process-sk"}'`
+		})
+		creds, err = chain.GetCredentials(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "process-ak", creds.AccessKeyID)
+		assert.Equal(t, "Process", chain.Source())
+	}
+
+	chain = NewDefaultChainProvider(func(o *DefaultChainProviderOptions) {
+		o.ProfileOptions = []func(*ProfileCredentialsProviderOptions){func(po *ProfileCredentialsProviderOptions) {
+			po.FilePath = path + ".missing"
+		}}
+	})
+	_, err = chain.GetCredentials(ctx)
+	var chainErr *ChainProviderError
+	assert.ErrorAs(t, err, &chainErr)
+	assert.Len(t, chainErr.Errors, 4)
+	assert.Equal(t, "Environment", chainErr.Errors[0].Name)
+	assert.Equal(t, "Profile", chainErr.Errors[1].Name)
+	assert.Contains(t, chainErr.Errors[1].Err.Error(), "failed to read config file")
+	assert.Equal(t, "OIDCRole", chainErr.Errors[2].Name)
+	assert.Equal(t, "Process", chainErr.Errors[3].Name)
+
+	t.Setenv("ALIBABA_CLOUD_ECS_METADATA_DISABLED", "")
+	chain = NewDefaultChainProvider()
+	assert.Len(t, chain.sources, 5)
+	assert.Equal(t, "EcsRole", chain.sources[4].Name)
+}
+
+type ecsMetadataMockServer struct {
+	*httptest.Server
+	supportToken bool
+	requireToken bool
+	ttlHeader    string
+	puts         int32
+	gets         int32
+	validToken   atomic.Value
+}
+
+func testSetupEcsMetadataMockServer(t *testing.T, supportToken, requireToken bool) *ecsMetadataMockServer {
+	s := &ecsMetadataMockServer{supportToken: supportToken, requireToken: requireToken}
+	s.validToken.Store("")
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.Path == "/latest/api/token" {
+			if !s.supportToken {
+				w.WriteHeader(404)
+				return
+			}
+			n := atomic.AddInt32(&s.puts, 1)
+			s.ttlHeader = r.Header.Get("X-aliyun-ecs-metadata-token-ttl-seconds")
+			token := fmt.Sprintf("token-%d", n)
+			s.validToken.Store(token)
+			fmt.Fprint(w, token)
+			return
+		}
+
+		atomic.AddInt32(&s.gets, 1)
+		token := r.Header.Get("X-aliyun-ecs-metadata-token")
+		if token != "" && token != s.validToken.Load().(string) {
+			w.WriteHeader(401)
+			return
+		}
+		if token == "" && s.requireToken {
+			w.WriteHeader(403)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/ram/security-credentials/":
+			fmt.Fprint(w, "EcsRamRoleTest")
+		case "/latest/meta-data/ram/security-credentials/EcsRamRoleTest":
+			expiration := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05Z")
+			fmt.Fprint(w, `{"AccessKeyId":"accessKeyId","AccessKeySecret":"accessKeySecret","SecurityToken":"`+token+`","Expiration":"`+expiration+`","Code":"Success"}`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	return s
+}
+
+func TestEcsRoleCredentialsProvider_MetadataToken(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("ALIBABA_CLOUD_IMDSV1_DISABLED", "")
+
+	server := testSetupEcsMetadataMockServer(t, true, true)
+	defer server.Close()
+	provider := NewEcsRoleCredentialsProviderWithoutRefresh(func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+		o.MetadataTokenTTL = 10 * time.Minute
+	})
+	ecsProvider := provider.(*ecsRoleCredentialsProvider)
+	assert.Equal(t, server.URL+"/latest/meta-data/ram/security-credentials/", ecsProvider.ramCredUrl)
+	assert.False(t, ecsProvider.tokenRequired)
+
+	creds, err := provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "accessKeyId", creds.AccessKeyID)
+	assert.Equal(t, "token-1", creds.SecurityToken)
+	assert.Equal(t, "600", server.ttlHeader)
+
+	// the token is cached
+	_, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.puts))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&server.gets))
+
+	// the token is revoked, a new one is fetched
+	server.validToken.Store("revoked")
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", creds.SecurityToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.puts))
+
+	// the token expires on the client
+	ecsProvider.tokenExpires = time.Now().Add(-time.Second)
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-3", creds.SecurityToken)
+
+	// the token is disabled
+	provider = NewEcsRoleCredentialsProviderWithoutRefresh(EcsRamRole("EcsRamRoleTest"), func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+		o.DisableMetadataToken = true
+	})
+	_, err = provider.GetCredentials(ctx)
+	assert.Error(t, err)
+}
+
+func TestEcsRoleCredentialsProvider_MetadataTokenFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// the metadata service doesn't support the token
+	server := testSetupEcsMetadataMockServer(t, false, false)
+	defer server.Close()
+
+	t.Setenv("ALIBABA_CLOUD_IMDSV1_DISABLED", "")
+	provider := NewEcsRoleCredentialsProviderWithoutRefresh(EcsRamRole("EcsRamRoleTest"), func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+	})
+	creds, err := provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "accessKeyId", creds.AccessKeyID)
+	assert.Equal(t, "", creds.SecurityToken)
+
+	// the hardened mode is required
+	provider = NewEcsRoleCredentialsProviderWithoutRefresh(EcsRamRole("EcsRamRoleTest"), func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+		o.RequireMetadataToken = true
+	})
+	_, err = provider.GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the metadata token is required")
+	assert.Contains(t, err.Error(), "resp.StatusCode:404")
+
+	t.Setenv("ALIBABA_CLOUD_IMDSV1_DISABLED", "true")
+	provider = NewEcsRoleCredentialsProviderWithoutRefresh(EcsRamRole("EcsRamRoleTest"), func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+	})
+	_, err = provider.GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the metadata token is required")
+
+	// the connection errors are not fallen back
+	server.Close()
+	atomic.StoreInt32(&server.gets, 0)
+	t.Setenv("ALIBABA_CLOUD_IMDSV1_DISABLED", "")
+	provider = NewEcsRoleCredentialsProviderWithoutRefresh(EcsRamRole("EcsRamRoleTest"), func(o *EcsRoleCredentialsProviderOptions) {
+		o.MetadataEndpoint = server.URL
+		o.ConnectTimeout = time.Second
+	})
+	_, err = provider.GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&server.gets))
+}
+
+func TestURICredentialsProvider(t *testing.T) {
+	var requests int32
+	var code atomic.Value
+	code.Store("Success")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "GET", r.Method)
+		if r.URL.Path != "/public" && r.Header.Get("Authorization") != "Bearer agent-token" {
+			w.WriteHeader(403)
+			fmt.Fprint(w, "forbidden")
+			return
+		}
+		expiration := time.Now().Add(time.Hour).UTC().Format("2006-01-02T15:04:05Z")
+		fmt.Fprintf(w, `{"Code":"%s","AccessKeyId":"ak-%d","AccessKeySecret":"sk","SecurityToken":"token","Expiration":"%s"}`,
+			code.Load(), n, expiration)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	withToken := func(o *URICredentialsProviderOptions) {
+		o.Headers = map[string]string{"Authorization": "Bearer agent-token"}
+	}
+
+	provider := NewURICredentialsProviderWithoutRefresh(server.URL, withToken)
+	creds, err := provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-1", creds.AccessKeyID)
+	assert.Equal(t, "sk", creds.AccessKeySecret)
+	assert.Equal(t, "token", creds.SecurityToken)
+	assert.NotNil(t, creds.Expires)
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-2", creds.AccessKeyID)
+
+	// the uri of the environment variable
+	t.Setenv("ALIBABA_CLOUD_CREDENTIALS_URI", server.URL)
+	provider = NewURICredentialsProviderWithoutRefresh("", withToken)
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-3", creds.AccessKeyID)
+
+	// the refreshing provider caches the credentials
+	provider = NewURICredentialsProvider(server.URL, withToken)
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	creds, err = provider.GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+
+	_, err = NewURICredentialsProviderWithoutRefresh(server.URL).GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resp.StatusCode:403")
+
+	code.Store("Failed")
+	_, err = NewURICredentialsProviderWithoutRefresh(server.URL, withToken).GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "return code:Failed")
+
+	t.Setenv("ALIBABA_CLOUD_CREDENTIALS_URI", "")
+	_, err = NewURICredentialsProviderWithoutRefresh("").GetCredentials(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials uri must not be empty")
+
+	// the profile and the default chain
+	code.Store("Success")
+	path := testWriteCliConfig(t, `{"profiles": [{"name": "default", "mode": "CredentialsURI", "credentials_uri": "`+server.URL+`/public"}]}`)
+	creds, err = NewProfileCredentialsProvider(func(o *ProfileCredentialsProviderOptions) {
+		o.FilePath = path
+		o.ProfileName = "default"
+	}).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-7", creds.AccessKeyID)
+
+	t.Setenv("ALIBABA_CLOUD_ECS_METADATA_DISABLED", "true")
+	chain := NewDefaultChainProvider(func(o *DefaultChainProviderOptions) {
+		o.CredentialsURI = server.URL
+	})
+	assert.Len(t, chain.sources, 5)
+	assert.Equal(t, "CredentialsURI", chain.sources[4].Name)
+}
+
+func TestCachedProcessCredentialsProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command uses sh")
+	}
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "counter")
+	output := filepath.Join(dir, "output")
+	cmd := fmt.Sprintf("echo run >> %s; cat %s", counter, output)
+	writeOutput := func(ak string, expires time.Time) {
+		assert.Nil(t, os.WriteFile(output, []byte(fmt.Sprintf(`{"AccessKeyId":"%s","AccessKeySecret":"sk","SecurityToken":"token","Expiration":"%s"}`,
+			ak, expires.UTC().Format("2006-01-02T15:04:05Z"))), 0600))
+	}
+	runs := func() int {
+		data, _ := os.ReadFile(counter)
+		return strings.Count(string(data), "run")
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	withCache := func(o *ProcessCredentialsCacheOptions) {
+		o.Dir = cacheDir
+		o.Key = "cache-key"
+	}
+	ctx := context.Background()
+
+	writeOutput("ak-1", time.Now().Add(time.Hour))
+	creds, err := NewCachedProcessCredentialsProvider(cmd, withCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-1", creds.AccessKeyID)
+	assert.Equal(t, 1, runs())
+
+	// the cache file is encrypted and readable by the owner only
+	files, err := filepath.Glob(filepath.Join(cacheDir, "*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	info, err := os.Stat(files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "ak-1")
+	assert.NotContains(t, string(data), "token")
+
+	// another provider, as in another process, reads the cache
+	writeOutput("ak-2", time.Now().Add(time.Hour))
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-1", creds.AccessKeyID)
+	assert.Equal(t, 1, runs())
+
+	// the cache can't be decrypted with another key
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withCache, func(o *ProcessCredentialsCacheOptions) {
+		o.Key = "another-key"
+	}).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-2", creds.AccessKeyID)
+	assert.Equal(t, 2, runs())
+
+	// the credentials which are about to expire are refreshed
+	writeOutput("ak-3", time.Now().Add(5*time.Minute))
+	withFetcher := func(o *ProcessCredentialsCacheOptions) {
+		o.FetcherOptions = []func(*CredentialsFetcherOptions){func(fo *CredentialsFetcherOptions) {
+			fo.ExpiredFactor = 0.999
+			fo.RefreshDuration = time.Second
+		}}
+	}
+	os.Remove(files[0])
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withCache, withFetcher).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-3", creds.AccessKeyID)
+	assert.Equal(t, 3, runs())
+	time.Sleep(time.Second)
+	writeOutput("ak-4", time.Now().Add(time.Hour))
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	assert.Equal(t, 4, runs())
+
+	// a stale lock is left by a crashed process
+	assert.Nil(t, os.WriteFile(files[0]+".lock", nil, 0600))
+	old := time.Now().Add(-time.Minute)
+	assert.Nil(t, os.Chtimes(files[0]+".lock", old, old))
+	os.Remove(files[0])
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	assert.Equal(t, 5, runs())
+	_, err = os.Stat(files[0] + ".lock")
+	assert.True(t, os.IsNotExist(err))
+
+	// a lock younger than the lock timeout is not stale
+	assert.Nil(t, os.WriteFile(files[0]+".lock", nil, 0600))
+	assert.Nil(t, os.Chtimes(files[0]+".lock", old, old))
+	os.Remove(files[0])
+	tctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	_, err = NewCachedProcessCredentialsProvider(cmd, withCache, func(o *ProcessCredentialsCacheOptions) {
+		o.LockTimeout = time.Hour
+	}).GetCredentials(tctx)
+	cancel()
+	assert.Error(t, err)
+	assert.Equal(t, 5, runs())
+	_, err = os.Stat(files[0] + ".lock")
+	assert.NoError(t, err)
+	os.Remove(files[0] + ".lock")
+
+	// concurrent providers run the command once
+	os.Remove(files[0])
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			creds, err := NewCachedProcessCredentialsProvider(cmd, withCache).GetCredentials(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "ak-4", creds.AccessKeyID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 6, runs())
+
+	// without a key, the cache file is not encrypted but readable by the owner only
+	t.Setenv("OSS_CREDENTIAL_CACHE_KEY", "")
+	plainDir := filepath.Join(dir, "plain")
+	withPlainCache := func(o *ProcessCredentialsCacheOptions) {
+		o.Dir = plainDir
+	}
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withPlainCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	assert.Equal(t, 7, runs())
+	files, err = filepath.Glob(filepath.Join(plainDir, "*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	info, err = os.Stat(files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err = os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "ak-4")
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withPlainCache).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ak-4", creds.AccessKeyID)
+	assert.Equal(t, 7, runs())
+
+	// the plain cache is not read with a key
+	creds, err = NewCachedProcessCredentialsProvider(cmd, withPlainCache, func(o *ProcessCredentialsCacheOptions) {
+		o.Key = "cache-key"
+	}).GetCredentials(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, runs())
+}
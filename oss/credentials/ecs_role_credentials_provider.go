@@ -6,20 +6,43 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-const ecs_ram_cred_url = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
+const (
+	ecs_ram_cred_url = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
+
+	ecsMetadataTokenPath      = "/latest/api/token"
+	ecsMetadataTokenHeader    = "X-aliyun-ecs-metadata-token"
+	ecsMetadataTokenTTLHeader = "X-aliyun-ecs-metadata-token-ttl-seconds"
+
+	defaultEcsMetadataTokenTTL = 6 * time.Hour
+
+	envImdsV1Disabled = "ALIBABA_CLOUD_IMDSV1_DISABLED"
+)
 
 type ecsRoleCredentialsProvider struct {
-	ramCredUrl string
-	ramRole    string
-	timeout    time.Duration
-	retries    int
+	ramCredUrl     string
+	ramRole        string
+	timeout        time.Duration
+	connectTimeout time.Duration
+	retries        int
+
+	// the ttl of the metadata token, 0 means the token is not used
+	tokenTTL      time.Duration
+	tokenRequired bool
+
+	tokenMu      sync.Mutex
+	token        string
+	tokenExpires time.Time
 }
 
 type ecsRoleCredentials struct {
@@ -31,16 +54,125 @@ type ecsRoleCredentials struct {
 	Code            string    `json:"Code,omitempty"`
 }
 
-func (p *ecsRoleCredentialsProvider) httpGet(ctx context.Context, url string) (*http.Response, error) {
+func (p *ecsRoleCredentialsProvider) httpClient() *http.Client {
 	c := &http.Client{
 		Timeout: p.timeout,
 	}
+	if p.connectTimeout > 0 {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = (&net.Dialer{Timeout: p.connectTimeout}).DialContext
+		c.Transport = transport
+	}
+	return c
+}
+
+// getMetadataToken returns the cached metadata token or fetches a new one.
+// If the metadata service responds with an error status, such as a service which doesn't support the token,
+// it returns an empty token to fall back to the requests without token, unless the token is required.
+// The errors of the connection are returned as they are, the requests without token would fail as well.
+func (p *ecsRoleCredentialsProvider) getMetadataToken(ctx context.Context) (string, error) {
+	if p.tokenTTL <= 0 {
+		return "", nil
+	}
+
+	p.tokenMu.Lock()
+	defer p.tokenMu.Unlock()
+	if p.token != "" && time.Now().Before(p.tokenExpires) {
+		return p.token, nil
+	}
+
+	token, err := p.fetchMetadataToken(ctx)
+	if err != nil {
+		var statusErr *ecsMetadataStatusError
+		if !errors.As(err, &statusErr) {
+			return "", err
+		}
+		if p.tokenRequired {
+			return "", fmt.Errorf("the metadata token is required: %w", err)
+		}
+		return "", nil
+	}
+
+	// refresh the token before it expires on the server
+	ttl := p.tokenTTL
+	ttl -= ttl / 10
+	p.token = token
+	p.tokenExpires = time.Now().Add(ttl)
+	return token, nil
+}
+
+func (p *ecsRoleCredentialsProvider) fetchMetadataToken(ctx context.Context) (string, error) {
+	u, err := url.Parse(p.ramCredUrl)
+	if err != nil {
+		return "", err
+	}
+	u.Path = ecsMetadataTokenPath
+	req, err := http.NewRequestWithContext(ctx, "PUT", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(ecsMetadataTokenTTLHeader, strconv.FormatInt(int64(p.tokenTTL/time.Second), 10))
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK || len(body) == 0 {
+		return "", &ecsMetadataStatusError{statusCode: resp.StatusCode}
+	}
+	return string(body), nil
+}
+
+type ecsMetadataStatusError struct {
+	statusCode int
+}
+
+func (e *ecsMetadataStatusError) Error() string {
+	return fmt.Sprintf("failed to fetch ecs metadata token, resp.StatusCode:%v", e.statusCode)
+}
+
+func (p *ecsRoleCredentialsProvider) invalidateMetadataToken(token string) {
+	p.tokenMu.Lock()
+	defer p.tokenMu.Unlock()
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+func (p *ecsRoleCredentialsProvider) httpGet(ctx context.Context, url string) (*http.Response, error) {
+	token, err := p.getMetadataToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.doGet(ctx, url, token)
+	if err == nil && token != "" && resp.StatusCode == http.StatusUnauthorized {
+		// the token is expired or revoked, fetch a new one
+		resp.Body.Close()
+		p.invalidateMetadataToken(token)
+		if token, err = p.getMetadataToken(ctx); err != nil {
+			return nil, err
+		}
+		return p.doGet(ctx, url, token)
+	}
+	return resp, err
+}
+
+func (p *ecsRoleCredentialsProvider) doGet(ctx context.Context, url string, token string) (*http.Response, error) {
+	c := p.httpClient()
 	var resp *http.Response
 	var err error
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
+	if token != "" {
+		req.Header.Set(ecsMetadataTokenHeader, token)
+	}
 	for i := 0; i < p.retries; i++ {
 		resp, err = c.Do(req)
 		if err != nil {
@@ -134,22 +266,59 @@ type EcsRoleCredentialsProviderOptions struct {
 	RamRole string
 	Timeout time.Duration
 	Retries int
+
+	// The timeout of establishing the connections to the metadata service, 0 means no limit other than the Timeout.
+	ConnectTimeout time.Duration
+
+	// The address of the metadata service, the default is 100.100.100.200.
+	MetadataEndpoint string
+
+	// The metadata token (security hardening mode) is fetched and cached for the TTL, the default is 6 hours.
+	// If the token can't be fetched, the requests are sent without the token, unless RequireMetadataToken is set.
+	MetadataTokenTTL time.Duration
+
+	// Don't use the metadata token.
+	DisableMetadataToken bool
+
+	// Fail if the metadata token can't be fetched, the default is ALIBABA_CLOUD_IMDSV1_DISABLED.
+	RequireMetadataToken bool
 }
 
 func NewEcsRoleCredentialsProviderWithoutRefresh(optFns ...func(*EcsRoleCredentialsProviderOptions)) CredentialsProvider {
+	required, _ := strconv.ParseBool(os.Getenv(envImdsV1Disabled))
 	options := EcsRoleCredentialsProviderOptions{
-		RamRole: "",
-		Timeout: time.Second * 10,
-		Retries: 3,
+		RamRole:              "",
+		Timeout:              time.Second * 10,
+		Retries:              3,
+		MetadataTokenTTL:     defaultEcsMetadataTokenTTL,
+		RequireMetadataToken: required,
 	}
 	for _, fn := range optFns {
 		fn(&options)
 	}
+
+	ramCredUrl := ecs_ram_cred_url
+	if options.MetadataEndpoint != "" {
+		endpoint := options.MetadataEndpoint
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "http://" + endpoint
+		}
+		ramCredUrl = strings.TrimSuffix(endpoint, "/") + "/latest/meta-data/ram/security-credentials/"
+	}
+
+	tokenTTL := options.MetadataTokenTTL
+	if options.DisableMetadataToken {
+		tokenTTL = 0
+	}
+
 	return &ecsRoleCredentialsProvider{
-		ramCredUrl: ecs_ram_cred_url,
-		ramRole:    options.RamRole,
-		timeout:    options.Timeout,
-		retries:    options.Retries,
+		ramCredUrl:     ramCredUrl,
+		ramRole:        options.RamRole,
+		timeout:        options.Timeout,
+		connectTimeout: options.ConnectTimeout,
+		retries:        options.Retries,
+		tokenTTL:       tokenTTL,
+		tokenRequired:  options.RequireMetadataToken && !options.DisableMetadataToken,
 	}
 }
 
@@ -142,6 +142,11 @@ func (p *CredentialsFetcherProvider) getCreds() *fetcherCredentials {
 }
 
 func (c *CredentialsFetcherProvider) updateCreds(cred *Credentials) {
+	c.credentials.Store(c.newFetcherCredentials(cred))
+}
+
+// newFetcherCredentials computes the expiry window of the credentials which are just fetched.
+func (c *CredentialsFetcherProvider) newFetcherCredentials(cred *Credentials) *fetcherCredentials {
 	fcred := fetcherCredentials{
 		Creds: *cred,
 	}
@@ -153,7 +158,7 @@ func (c *CredentialsFetcherProvider) updateCreds(cred *Credentials) {
 			fcred.ExpiryWindow = duration
 		}
 	}
-	c.credentials.Store(&fcred)
+	return &fcred
 }
 
 func (c *CredentialsFetcherProvider) updateExpiryWindow(fcreds *fetcherCredentials) {
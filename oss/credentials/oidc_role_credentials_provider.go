@@ -0,0 +1,145 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envOIDCTokenFile      = "ALIBABA_CLOUD_OIDC_TOKEN_FILE"
+	envRoleArn            = "ALIBABA_CLOUD_ROLE_ARN"
+	envOIDCProviderArn    = "ALIBABA_CLOUD_OIDC_PROVIDER_ARN"
+	envRoleSessionName    = "ALIBABA_CLOUD_ROLE_SESSION_NAME"
+	envStsRegion          = "ALIBABA_CLOUD_STS_REGION"
+	envVpcEndpointEnabled = "ALIBABA_CLOUD_VPC_ENDPOINT_ENABLED"
+)
+
+type OIDCRoleCredentialsProviderOptions struct {
+	// The ARN of the role to assume, the default is ALIBABA_CLOUD_ROLE_ARN.
+	RoleArn string
+
+	// The ARN of the OIDC identity provider, the default is ALIBABA_CLOUD_OIDC_PROVIDER_ARN.
+	OIDCProviderArn string
+
+	// The path of the OIDC token file, the default is ALIBABA_CLOUD_OIDC_TOKEN_FILE.
+	// The file is read on every request to STS, so that a rotated token is picked up.
+	OIDCTokenFilePath string
+
+	// The name of the role session, the default is ALIBABA_CLOUD_ROLE_SESSION_NAME or oss-go-sdk-v2-{unix timestamp}.
+	RoleSessionName string
+
+	// The policy which further restricts the permissions of the role, in JSON format.
+	Policy string
+
+	// The validity period of the credentials, at least 15 minutes, the default is 1 hour.
+	Duration time.Duration
+
+	// The STS endpoint, a host or a url, the default is sts.aliyuncs.com.
+	Endpoint string
+
+	// The region of the STS endpoint, used only if the Endpoint is not set,
+	// the default is ALIBABA_CLOUD_STS_REGION.
+	Region string
+
+	// Use the VPC endpoint of the region, the default is ALIBABA_CLOUD_VPC_ENDPOINT_ENABLED.
+	UseVpcEndpoint bool
+
+	Timeout    time.Duration
+	HttpClient *http.Client
+}
+
+// OIDCRoleCredentialsProvider gets temporary credentials by assuming a RAM role with an OIDC token,
+// such as the service account token of a pod with RRSA enabled.
+type OIDCRoleCredentialsProvider struct {
+	options OIDCRoleCredentialsProviderOptions
+	client  *stsClient
+}
+
+func (p *OIDCRoleCredentialsProvider) GetCredentials(ctx context.Context) (Credentials, error) {
+	if p.options.RoleArn == "" {
+		return Credentials{}, fmt.Errorf("role arn must not be empty, set it or %s", envRoleArn)
+	}
+	if p.options.OIDCProviderArn == "" {
+		return Credentials{}, fmt.Errorf("oidc provider arn must not be empty, set it or %s", envOIDCProviderArn)
+	}
+	if p.options.OIDCTokenFilePath == "" {
+		return Credentials{}, fmt.Errorf("oidc token file path must not be empty, set it or %s", envOIDCTokenFile)
+	}
+	if p.options.Duration < minAssumeRoleDuration {
+		return Credentials{}, fmt.Errorf("duration must be at least %v, got %v", minAssumeRoleDuration, p.options.Duration)
+	}
+
+	token, err := os.ReadFile(p.options.OIDCTokenFilePath)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read oidc token file: %w", err)
+	}
+	if len(token) == 0 {
+		return Credentials{}, fmt.Errorf("oidc token file %s is empty", p.options.OIDCTokenFilePath)
+	}
+
+	params := map[string]string{
+		"RoleArn":         p.options.RoleArn,
+		"OIDCProviderArn": p.options.OIDCProviderArn,
+		"OIDCToken":       strings.TrimSpace(string(token)),
+		"RoleSessionName": p.options.RoleSessionName,
+		"DurationSeconds": strconv.FormatInt(int64(p.options.Duration/time.Second), 10),
+		"Policy":          p.options.Policy,
+	}
+	// AssumeRoleWithOIDC is authenticated by the token, the request is not signed
+	return p.client.call(ctx, "AssumeRoleWithOIDC", params, nil)
+}
+
+// NewOIDCRoleCredentialsProviderWithoutRefresh returns a provider which calls STS on every GetCredentials.
+func NewOIDCRoleCredentialsProviderWithoutRefresh(optFns ...func(*OIDCRoleCredentialsProviderOptions)) CredentialsProvider {
+	sessionName := os.Getenv(envRoleSessionName)
+	if sessionName == "" {
+		sessionName = fmt.Sprintf("oss-go-sdk-v2-%d", time.Now().Unix())
+	}
+	vpc, _ := strconv.ParseBool(os.Getenv(envVpcEndpointEnabled))
+	options := OIDCRoleCredentialsProviderOptions{
+		RoleArn:           os.Getenv(envRoleArn),
+		OIDCProviderArn:   os.Getenv(envOIDCProviderArn),
+		OIDCTokenFilePath: os.Getenv(envOIDCTokenFile),
+		RoleSessionName:   sessionName,
+		Duration:          defaultAssumeRoleDuration,
+		Region:            os.Getenv(envStsRegion),
+		UseVpcEndpoint:    vpc,
+		Timeout:           time.Second * 10,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	endpoint := options.Endpoint
+	if endpoint == "" && options.Region != "" && options.UseVpcEndpoint {
+		endpoint = fmt.Sprintf("sts-vpc.%s.aliyuncs.com", options.Region)
+	}
+
+	httpClient := options.HttpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: options.Timeout}
+	}
+
+	return &OIDCRoleCredentialsProvider{
+		options: options,
+		client: &stsClient{
+			endpoint:   stsEndpointURL(endpoint, options.Region),
+			httpClient: httpClient,
+		},
+	}
+}
+
+// NewOIDCRoleCredentialsProvider returns a provider which caches the credentials
+// and refreshes them before they expire.
+func NewOIDCRoleCredentialsProvider(optFns ...func(*OIDCRoleCredentialsProviderOptions)) CredentialsProvider {
+	p := NewOIDCRoleCredentialsProviderWithoutRefresh(optFns...)
+	provider := NewCredentialsFetcherProvider(CredentialsFetcherFunc(func(ctx context.Context) (Credentials, error) {
+		return p.GetCredentials(ctx)
+	}))
+	return provider
+}
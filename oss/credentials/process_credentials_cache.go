@@ -0,0 +1,237 @@
+package credentials
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	envCredentialCacheKey = "OSS_CREDENTIAL_CACHE_KEY"
+
+	// the lock timeout if the process has no timeout
+	defaultCredentialCacheLockTimeout = 30 * time.Second
+)
+
+type ProcessCredentialsCacheOptions struct {
+	// The options of the process provider.
+	ProcessOptions []func(*ProcessCredentialsProviderOptions)
+
+	// The options of the expiry, the same as the CredentialsFetcherProvider.
+	FetcherOptions []func(*CredentialsFetcherOptions)
+
+	// The directory of the cache files, the default is {os.UserCacheDir}/oss-go-sdk-v2/credentials.
+	Dir string
+
+	// The key to encrypt the cache files, the default is OSS_CREDENTIAL_CACHE_KEY.
+	// Without a key, the cache files are not encrypted, they are protected by the file permissions (0600) only.
+	Key string
+
+	// How long to wait for another process which is refreshing the cache, the default is the process timeout.
+	// A lock file older than it is left by a crashed process, and is removed.
+	LockTimeout time.Duration
+}
+
+// processCredentialsCache shares the credentials of a command between the processes of a host.
+// The credentials are refreshed by one process at a time, the others wait for it and read the cache.
+type processCredentialsCache struct {
+	process     CredentialsProvider
+	path        string
+	aead        cipher.AEAD // nil if the cache is not encrypted
+	expiry      *CredentialsFetcherProvider
+	lockTimeout time.Duration
+}
+
+// NewCachedProcessCredentialsProvider returns a ProcessCredentialsProvider whose credentials are cached in
+// a file readable by the owner only, so that the processes of a host which run the same command share the credentials.
+// The file is encrypted if a key is set by ProcessCredentialsCacheOptions.Key or OSS_CREDENTIAL_CACHE_KEY.
+// The credentials are cached in memory as well, and are refreshed before they expire.
+// Credentials without an expiration are cached until the cache file is removed.
+func NewCachedProcessCredentialsProvider(command string, optFns ...func(*ProcessCredentialsCacheOptions)) CredentialsProvider {
+	options := ProcessCredentialsCacheOptions{
+		Key: os.Getenv(envCredentialCacheKey),
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	process := NewProcessCredentialsProvider(command, options.ProcessOptions...)
+	cache, err := newProcessCredentialsCache(command, process, options)
+	if err != nil {
+		// the cache is optional, run the command without it
+		return NewCredentialsFetcherProvider(CredentialsFetcherFunc(func(ctx context.Context) (Credentials, error) {
+			return process.GetCredentials(ctx)
+		}), options.FetcherOptions...)
+	}
+	return NewCredentialsFetcherProvider(cache, options.FetcherOptions...)
+}
+
+func newProcessCredentialsCache(command string, process CredentialsProvider, options ProcessCredentialsCacheOptions) (*processCredentialsCache, error) {
+	dir := options.Dir
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(cacheDir, "oss-go-sdk-v2", "credentials")
+	}
+
+	var aead cipher.AEAD
+	if options.Key != "" {
+		sum := sha256.Sum256([]byte(options.Key))
+		block, err := aes.NewCipher(sum[:])
+		if err != nil {
+			return nil, err
+		}
+		if aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	lockTimeout := options.LockTimeout
+	if lockTimeout <= 0 {
+		lockTimeout = process.(*ProcessCredentialsProvider).timeout
+	}
+	if lockTimeout <= 0 {
+		lockTimeout = defaultCredentialCacheLockTimeout
+	}
+
+	name := sha256.Sum256([]byte(command))
+	return &processCredentialsCache{
+		process:     process,
+		path:        filepath.Join(dir, hex.EncodeToString(name[:])),
+		aead:        aead,
+		expiry:      NewCredentialsFetcherProvider(nil, options.FetcherOptions...).(*CredentialsFetcherProvider),
+		lockTimeout: lockTimeout,
+	}, nil
+}
+
+func (c *processCredentialsCache) Fetch(ctx context.Context) (Credentials, error) {
+	if creds, ok := c.load(); ok {
+		return creds, nil
+	}
+
+	unlock, err := c.lock(ctx)
+	if err != nil {
+		// refresh without the cache, rather than fail
+		return c.process.GetCredentials(ctx)
+	}
+	defer unlock()
+
+	// another process may have refreshed the cache
+	if creds, ok := c.load(); ok {
+		return creds, nil
+	}
+
+	creds, err := c.process.GetCredentials(ctx)
+	if err != nil {
+		return creds, err
+	}
+	// the cache is best effort
+	_ = c.store(c.expiry.newFetcherCredentials(&creds))
+	return creds, nil
+}
+
+// load returns the cached credentials if they are not about to expire.
+func (c *processCredentialsCache) load() (Credentials, bool) {
+	plain, err := os.ReadFile(c.path)
+	if err != nil {
+		return Credentials{}, false
+	}
+	if c.aead != nil {
+		size := c.aead.NonceSize()
+		if len(plain) < size {
+			return Credentials{}, false
+		}
+		if plain, err = c.aead.Open(nil, plain[:size], plain[size:], nil); err != nil {
+			return Credentials{}, false
+		}
+	}
+	fcreds := &fetcherCredentials{}
+	if err = json.Unmarshal(plain, fcreds); err != nil || !fcreds.Creds.HasKeys() {
+		return Credentials{}, false
+	}
+	if c.expiry.isSoonExpire(fcreds) {
+		return Credentials{}, false
+	}
+	return fcreds.Creds, true
+}
+
+func (c *processCredentialsCache) store(fcreds *fetcherCredentials) error {
+	data, err := json.Marshal(fcreds)
+	if err != nil {
+		return err
+	}
+	if c.aead != nil {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+			return err
+		}
+		data = c.aead.Seal(nonce, nonce, data, nil)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	// write to a temporary file (0600) then rename it, so that the readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// lock acquires the lock file of the cache, which is shared by the processes.
+func (c *processCredentialsCache) lock(ctx context.Context) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return nil, err
+	}
+	path := c.path + ".lock"
+	deadline := time.Now().Add(c.lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		// a lock older than the lock timeout is left by a crashed process
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > c.lockTimeout {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s", path)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
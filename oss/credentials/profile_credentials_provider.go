@@ -0,0 +1,178 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/internal/profile"
+)
+
+const envProfile = "ALIBABA_CLOUD_PROFILE"
+
+type ProfileCredentialsProviderOptions struct {
+	// The name of the profile, the default is ALIBABA_CLOUD_PROFILE,
+	// then the current profile of the config file.
+	ProfileName string
+
+	// The path of the config file, the default is ~/.aliyun/config.json.
+	// A file without the .json extension is parsed as an INI file, whose keys are the same as the CLI config file.
+	FilePath string
+}
+
+// ProfileCredentialsProvider gets credentials as configured by a profile of the Alibaba Cloud CLI config file.
+// The supported modes are AK, StsToken, RamRoleArn, ChainableRamRoleArn, EcsRamRole, External, CredentialsURI and OIDC.
+// The command of the External mode prints the credentials in the format of ProcessCredentialsProvider.
+// The config file is loaded on the first GetCredentials.
+type ProfileCredentialsProvider struct {
+	options ProfileCredentialsProviderOptions
+
+	mu       sync.Mutex
+	provider CredentialsProvider
+}
+
+func NewProfileCredentialsProvider(optFns ...func(*ProfileCredentialsProviderOptions)) CredentialsProvider {
+	options := ProfileCredentialsProviderOptions{
+		ProfileName: os.Getenv(envProfile),
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	return &ProfileCredentialsProvider{options: options}
+}
+
+func (p *ProfileCredentialsProvider) GetCredentials(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	provider := p.provider
+	if provider == nil {
+		var err error
+		if provider, err = p.resolve(); err != nil {
+			p.mu.Unlock()
+			return Credentials{}, err
+		}
+		p.provider = provider
+	}
+	p.mu.Unlock()
+	return provider.GetCredentials(ctx)
+}
+
+func (p *ProfileCredentialsProvider) resolve() (CredentialsProvider, error) {
+	path := p.options.FilePath
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".aliyun", "config.json")
+	}
+	f, err := profile.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	name := p.options.ProfileName
+	if name == "" {
+		name = f.Current
+	}
+	if name == "" {
+		name = "default"
+	}
+	return profileProvider(f, name, map[string]bool{})
+}
+
+// profileProvider returns the provider of the named profile, visited detects a cycle of source profiles.
+func profileProvider(f *profile.File, name string, visited map[string]bool) (CredentialsProvider, error) {
+	if visited[name] {
+		return nil, fmt.Errorf("source profile %s forms a cycle", name)
+	}
+	visited[name] = true
+
+	prof, err := f.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	accessKeyId, accessKeySecret, stsToken := prof.Get("access_key_id"), prof.Get("access_key_secret"), prof.Get("sts_token")
+	sessionName := prof.Get("ram_session_name")
+	duration := time.Duration(0)
+	if v := prof.Get("expired_seconds"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: invalid expired_seconds %s", name, v)
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	assumeRole := func(source CredentialsProvider) CredentialsProvider {
+		return NewAssumeRoleCredentialsProvider(source, prof.Get("ram_role_arn"), func(o *AssumeRoleCredentialsProviderOptions) {
+			if sessionName != "" {
+				o.RoleSessionName = sessionName
+			}
+			if duration > 0 {
+				o.Duration = duration
+			}
+			o.ExternalId = prof.Get("external_id")
+			o.Policy = prof.Get("policy")
+			o.Region = prof.Get("sts_region")
+		})
+	}
+
+	switch mode := prof.Get("mode"); mode {
+	case "AK", "":
+		if accessKeyId == "" || accessKeySecret == "" {
+			return nil, fmt.Errorf("profile %s: access_key_id or access_key_secret is empty", name)
+		}
+		return NewStaticCredentialsProvider(accessKeyId, accessKeySecret), nil
+	case "StsToken":
+		if accessKeyId == "" || accessKeySecret == "" || stsToken == "" {
+			return nil, fmt.Errorf("profile %s: access_key_id, access_key_secret or sts_token is empty", name)
+		}
+		return NewStaticCredentialsProvider(accessKeyId, accessKeySecret, stsToken), nil
+	case "RamRoleArn":
+		if accessKeyId == "" || accessKeySecret == "" {
+			return nil, fmt.Errorf("profile %s: access_key_id or access_key_secret is empty", name)
+		}
+		return assumeRole(NewStaticCredentialsProvider(accessKeyId, accessKeySecret, stsToken)), nil
+	case "ChainableRamRoleArn":
+		source, err := profileProvider(f, prof.Get("source_profile"), visited)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		return assumeRole(source), nil
+	case "EcsRamRole":
+		return NewEcsRoleCredentialsProvider(EcsRamRole(prof.Get("ram_role_name"))), nil
+	case "External":
+		command := prof.Get("process_command")
+		if command == "" {
+			return nil, fmt.Errorf("profile %s: process_command is empty", name)
+		}
+		process := NewProcessCredentialsProvider(command)
+		return NewCredentialsFetcherProvider(CredentialsFetcherFunc(func(ctx context.Context) (Credentials, error) {
+			return process.GetCredentials(ctx)
+		})), nil
+	case "CredentialsURI":
+		uri := prof.Get("credentials_uri")
+		if uri == "" {
+			return nil, fmt.Errorf("profile %s: credentials_uri is empty", name)
+		}
+		return NewURICredentialsProvider(uri), nil
+	case "OIDC":
+		return NewOIDCRoleCredentialsProvider(func(o *OIDCRoleCredentialsProviderOptions) {
+			o.RoleArn = prof.Get("ram_role_arn")
+			o.OIDCProviderArn = prof.Get("oidc_provider_arn")
+			o.OIDCTokenFilePath = prof.Get("oidc_token_file")
+			if sessionName != "" {
+				o.RoleSessionName = sessionName
+			}
+			if duration > 0 {
+				o.Duration = duration
+			}
+			o.Policy = prof.Get("policy")
+			o.Region = prof.Get("sts_region")
+		}), nil
+	default:
+		return nil, fmt.Errorf("profile %s: unsupported mode %s", name, mode)
+	}
+}
@@ -0,0 +1,175 @@
+package credentials
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultStsEndpoint = "sts.aliyuncs.com"
+	stsApiVersion      = "2015-04-01"
+	stsTimeFormat      = "2006-01-02T15:04:05Z"
+)
+
+// stsClient calls the RPC style operations of the Security Token Service.
+type stsClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+type stsCredentials struct {
+	AccessKeyId     string `json:"AccessKeyId"`
+	AccessKeySecret string `json:"AccessKeySecret"`
+	SecurityToken   string `json:"SecurityToken"`
+	Expiration      string `json:"Expiration"`
+}
+
+type stsResponse struct {
+	RequestId   string          `json:"RequestId"`
+	Code        string          `json:"Code"`
+	Message     string          `json:"Message"`
+	Credentials *stsCredentials `json:"Credentials"`
+}
+
+// StsError is returned when the Security Token Service rejects a request.
+type StsError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestId  string
+}
+
+func (e *StsError) Error() string {
+	return fmt.Sprintf("sts error, http status code: %d, error code: %s, message: %s, request id: %s",
+		e.StatusCode, e.Code, e.Message, e.RequestId)
+}
+
+// stsEndpointURL returns the url of the endpoint, the endpoint may be a host or a url.
+func stsEndpointURL(endpoint, region string) string {
+	if endpoint == "" {
+		endpoint = defaultStsEndpoint
+		if region != "" {
+			endpoint = fmt.Sprintf("sts.%s.aliyuncs.com", region)
+		}
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return endpoint
+}
+
+// call sends the action, the request is signed with the credentials if they are not nil.
+func (c *stsClient) call(ctx context.Context, action string, params map[string]string, creds *Credentials) (Credentials, error) {
+	query := url.Values{}
+	for k, v := range params {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	query.Set("Action", action)
+	query.Set("Format", "JSON")
+	query.Set("Version", stsApiVersion)
+	query.Set("Timestamp", time.Now().UTC().Format(stsTimeFormat))
+
+	if creds != nil {
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			return Credentials{}, err
+		}
+		query.Set("AccessKeyId", creds.AccessKeyID)
+		if creds.SecurityToken != "" {
+			query.Set("SecurityToken", creds.SecurityToken)
+		}
+		query.Set("SignatureMethod", "HMAC-SHA1")
+		query.Set("SignatureVersion", "1.0")
+		query.Set("SignatureNonce", hex.EncodeToString(nonce))
+		query.Set("Signature", stsSignature(http.MethodPost, query, creds.AccessKeySecret))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(query.Encode()))
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var result stsResponse
+	if err = json.Unmarshal(body, &result); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse sts response, http status code: %d, body: '%s'", resp.StatusCode, string(body))
+	}
+	if resp.StatusCode != http.StatusOK || result.Code != "" {
+		return Credentials{}, &StsError{
+			StatusCode: resp.StatusCode,
+			Code:       result.Code,
+			Message:    result.Message,
+			RequestId:  result.RequestId,
+		}
+	}
+	if result.Credentials == nil || result.Credentials.AccessKeyId == "" || result.Credentials.AccessKeySecret == "" {
+		return Credentials{}, fmt.Errorf("AccessKeyId or AccessKeySecret is empty, response body is '%s'", string(body))
+	}
+
+	cred := Credentials{
+		AccessKeyID:     result.Credentials.AccessKeyId,
+		AccessKeySecret: result.Credentials.AccessKeySecret,
+		SecurityToken:   result.Credentials.SecurityToken,
+	}
+	if result.Credentials.Expiration != "" {
+		expires, err := time.Parse(stsTimeFormat, result.Credentials.Expiration)
+		if err != nil {
+			return Credentials{}, err
+		}
+		cred.Expires = &expires
+	}
+	return cred, nil
+}
+
+// stsSignature computes the signature of the RPC style request.
+func stsSignature(method string, query url.Values, secret string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k != "Signature" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, stsPercentEncode(k)+"="+stsPercentEncode(query.Get(k)))
+	}
+	stringToSign := method + "&" + stsPercentEncode("/") + "&" + stsPercentEncode(strings.Join(pairs, "&"))
+
+	h := hmac.New(sha1.New, []byte(secret+"&"))
+	h.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func stsPercentEncode(v string) string {
+	v = url.QueryEscape(v)
+	v = strings.ReplaceAll(v, "+", "%20")
+	v = strings.ReplaceAll(v, "*", "%2A")
+	v = strings.ReplaceAll(v, "%7E", "~")
+	return v
+}
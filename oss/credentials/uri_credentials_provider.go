@@ -0,0 +1,128 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const envCredentialsURI = "ALIBABA_CLOUD_CREDENTIALS_URI"
+
+/*
+the response of the credentials uri
+{
+	"Code": "Success",
+	"AccessKeyId": "ak",
+	"AccessKeySecret": "sk",
+	"SecurityToken": "token",
+	"Expiration": "2023-12-29T07:45:02Z"
+}
+*/
+
+type uriCredentialsResult struct {
+	Code            string     `json:"Code"`
+	AccessKeyId     string     `json:"AccessKeyId"`
+	AccessKeySecret string     `json:"AccessKeySecret"`
+	SecurityToken   string     `json:"SecurityToken"`
+	Expiration      *time.Time `json:"Expiration"`
+}
+
+type URICredentialsProviderOptions struct {
+	Timeout time.Duration
+
+	// The headers to send with the requests, such as the authorization token of the credential agent.
+	Headers map[string]string
+
+	HttpClient *http.Client
+}
+
+// URICredentialsProvider gets credentials from a local HTTP URI, such as a sidecar credential agent.
+type URICredentialsProvider struct {
+	uri     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (p *URICredentialsProvider) GetCredentials(ctx context.Context) (Credentials, error) {
+	if p.uri == "" {
+		return Credentials{}, fmt.Errorf("credentials uri must not be empty")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", p.uri, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Credentials{}, fmt.Errorf("failed to fetch credentials from %s, resp.StatusCode:%v, body:'%s'", p.uri, resp.StatusCode, string(body))
+	}
+
+	result := &uriCredentialsResult{}
+	if err = json.Unmarshal(body, result); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse credentials from %s: %w", p.uri, err)
+	}
+	if result.Code != "" && strings.ToUpper(result.Code) != "SUCCESS" {
+		return Credentials{}, fmt.Errorf("failed to fetch credentials from %s, return code:%s", p.uri, result.Code)
+	}
+
+	creds := Credentials{
+		AccessKeyID:     result.AccessKeyId,
+		AccessKeySecret: result.AccessKeySecret,
+		SecurityToken:   result.SecurityToken,
+		Expires:         result.Expiration,
+	}
+	if !creds.HasKeys() {
+		return creds, fmt.Errorf("AccessKeyId or AccessKeySecret is empty, response body is '%s'", string(body))
+	}
+	return creds, nil
+}
+
+// NewURICredentialsProviderWithoutRefresh returns a provider which requests the uri on every GetCredentials.
+// If the uri is empty, it's ALIBABA_CLOUD_CREDENTIALS_URI.
+func NewURICredentialsProviderWithoutRefresh(uri string, optFns ...func(*URICredentialsProviderOptions)) CredentialsProvider {
+	options := URICredentialsProviderOptions{
+		Timeout: time.Second * 5,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if uri == "" {
+		uri = os.Getenv(envCredentialsURI)
+	}
+
+	client := options.HttpClient
+	if client == nil {
+		client = &http.Client{Timeout: options.Timeout}
+	}
+	return &URICredentialsProvider{
+		uri:     uri,
+		headers: options.Headers,
+		client:  client,
+	}
+}
+
+// NewURICredentialsProvider returns a provider which caches the credentials
+// and refreshes them before they expire.
+func NewURICredentialsProvider(uri string, optFns ...func(*URICredentialsProviderOptions)) CredentialsProvider {
+	p := NewURICredentialsProviderWithoutRefresh(uri, optFns...)
+	provider := NewCredentialsFetcherProvider(CredentialsFetcherFunc(func(ctx context.Context) (Credentials, error) {
+		return p.GetCredentials(ctx)
+	}))
+	return provider
+}
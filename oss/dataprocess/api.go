@@ -0,0 +1,36 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package dataprocess
+
+import (
+	"context"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// API lists the operations of Client, so that callers can depend on an interface,
+// decorate it or replace it with a mock in tests.
+type API interface {
+	// CreateDataset creates a dataset.
+	CreateDataset(ctx context.Context, request *CreateDatasetRequest, optFns ...func(*oss.Options)) (*CreateDatasetResult, error)
+
+	// DeleteDataset deletes a dataset.
+	DeleteDataset(ctx context.Context, request *DeleteDatasetRequest, optFns ...func(*oss.Options)) (*DeleteDatasetResult, error)
+
+	// GetDataset gets the information of a dataset.
+	GetDataset(ctx context.Context, request *GetDatasetRequest, optFns ...func(*oss.Options)) (*GetDatasetResult, error)
+
+	// ListDatasets lists datasets.
+	ListDatasets(ctx context.Context, request *ListDatasetsRequest, optFns ...func(*oss.Options)) (*ListDatasetsResult, error)
+
+	// SemanticQuery queries files in a dataset using natural language.
+	SemanticQuery(ctx context.Context, request *SemanticQueryRequest, optFns ...func(*oss.Options)) (*SemanticQueryResult, error)
+
+	// SimpleQuery queries files in a dataset using structured query language.
+	SimpleQuery(ctx context.Context, request *SimpleQueryRequest, optFns ...func(*oss.Options)) (*SimpleQueryResult, error)
+
+	// UpdateDataset updates a dataset.
+	UpdateDataset(ctx context.Context, request *UpdateDatasetRequest, optFns ...func(*oss.Options)) (*UpdateDatasetResult, error)
+}
+
+var _ API = (*Client)(nil)
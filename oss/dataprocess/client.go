@@ -6,6 +6,8 @@ import (
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
 )
 
+//go:generate go run ../internal/apigen -type Client -interface API -mock dataprocessmock
+
 // Client is the client for accessing OSS Data Process API
 type Client struct {
 	client *oss.Client
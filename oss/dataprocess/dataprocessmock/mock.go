@@ -0,0 +1,136 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package dataprocessmock provides a mock implementation of dataprocess.API which records the calls.
+package dataprocessmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/dataprocess"
+)
+
+// Call is a call of an operation, the context is not recorded.
+type Call struct {
+	Operation string
+	Args      []any
+}
+
+// Client implements dataprocess.API. Each operation calls the function field of the same name with the Func suffix,
+// an operation whose function is not set returns an error.
+type Client struct {
+	mu    sync.Mutex
+	calls []Call
+
+	CreateDatasetFunc func(ctx context.Context, request *dataprocess.CreateDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.CreateDatasetResult, error)
+	DeleteDatasetFunc func(ctx context.Context, request *dataprocess.DeleteDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.DeleteDatasetResult, error)
+	GetDatasetFunc    func(ctx context.Context, request *dataprocess.GetDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.GetDatasetResult, error)
+	ListDatasetsFunc  func(ctx context.Context, request *dataprocess.ListDatasetsRequest, optFns ...func(*oss.Options)) (*dataprocess.ListDatasetsResult, error)
+	SemanticQueryFunc func(ctx context.Context, request *dataprocess.SemanticQueryRequest, optFns ...func(*oss.Options)) (*dataprocess.SemanticQueryResult, error)
+	SimpleQueryFunc   func(ctx context.Context, request *dataprocess.SimpleQueryRequest, optFns ...func(*oss.Options)) (*dataprocess.SimpleQueryResult, error)
+	UpdateDatasetFunc func(ctx context.Context, request *dataprocess.UpdateDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.UpdateDatasetResult, error)
+}
+
+var _ dataprocess.API = (*Client)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of the operation in order.
+func (m *Client) CallsOf(operation string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Client) record(operation string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Operation: operation, Args: args})
+}
+
+func notImplemented(operation string) error {
+	return fmt.Errorf("dataprocessmock: %s is not implemented, set %sFunc", operation, operation)
+}
+
+// CreateDataset records the call and calls CreateDatasetFunc.
+func (m *Client) CreateDataset(ctx context.Context, request *dataprocess.CreateDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.CreateDatasetResult, error) {
+	m.record("CreateDataset", request, optFns)
+	if m.CreateDatasetFunc == nil {
+		return nil, notImplemented("CreateDataset")
+	}
+	return m.CreateDatasetFunc(ctx, request, optFns...)
+}
+
+// DeleteDataset records the call and calls DeleteDatasetFunc.
+func (m *Client) DeleteDataset(ctx context.Context, request *dataprocess.DeleteDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.DeleteDatasetResult, error) {
+	m.record("DeleteDataset", request, optFns)
+	if m.DeleteDatasetFunc == nil {
+		return nil, notImplemented("DeleteDataset")
+	}
+	return m.DeleteDatasetFunc(ctx, request, optFns...)
+}
+
+// GetDataset records the call and calls GetDatasetFunc.
+func (m *Client) GetDataset(ctx context.Context, request *dataprocess.GetDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.GetDatasetResult, error) {
+	m.record("GetDataset", request, optFns)
+	if m.GetDatasetFunc == nil {
+		return nil, notImplemented("GetDataset")
+	}
+	return m.GetDatasetFunc(ctx, request, optFns...)
+}
+
+// ListDatasets records the call and calls ListDatasetsFunc.
+func (m *Client) ListDatasets(ctx context.Context, request *dataprocess.ListDatasetsRequest, optFns ...func(*oss.Options)) (*dataprocess.ListDatasetsResult, error) {
+	m.record("ListDatasets", request, optFns)
+	if m.ListDatasetsFunc == nil {
+		return nil, notImplemented("ListDatasets")
+	}
+	return m.ListDatasetsFunc(ctx, request, optFns...)
+}
+
+// SemanticQuery records the call and calls SemanticQueryFunc.
+func (m *Client) SemanticQuery(ctx context.Context, request *dataprocess.SemanticQueryRequest, optFns ...func(*oss.Options)) (*dataprocess.SemanticQueryResult, error) {
+	m.record("SemanticQuery", request, optFns)
+	if m.SemanticQueryFunc == nil {
+		return nil, notImplemented("SemanticQuery")
+	}
+	return m.SemanticQueryFunc(ctx, request, optFns...)
+}
+
+// SimpleQuery records the call and calls SimpleQueryFunc.
+func (m *Client) SimpleQuery(ctx context.Context, request *dataprocess.SimpleQueryRequest, optFns ...func(*oss.Options)) (*dataprocess.SimpleQueryResult, error) {
+	m.record("SimpleQuery", request, optFns)
+	if m.SimpleQueryFunc == nil {
+		return nil, notImplemented("SimpleQuery")
+	}
+	return m.SimpleQueryFunc(ctx, request, optFns...)
+}
+
+// UpdateDataset records the call and calls UpdateDatasetFunc.
+func (m *Client) UpdateDataset(ctx context.Context, request *dataprocess.UpdateDatasetRequest, optFns ...func(*oss.Options)) (*dataprocess.UpdateDatasetResult, error) {
+	m.record("UpdateDataset", request, optFns)
+	if m.UpdateDatasetFunc == nil {
+		return nil, notImplemented("UpdateDataset")
+	}
+	return m.UpdateDatasetFunc(ctx, request, optFns...)
+}
@@ -1,6 +1,9 @@
 package oss
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 const (
 	MaxUploadParts int32 = 10000
@@ -59,6 +62,12 @@ const (
 	// CheckpointFileSuffixUploader Checkpoint file suffix for Uploader
 	CheckpointFileSuffixUploader = ".ucp"
 
+	// CheckpointFileSuffixCopier Checkpoint file suffix for Copier
+	CheckpointFileSuffixCopier = ".ccp"
+
+	// CheckpointFileSuffixCopyPrefix Checkpoint file suffix for Copier.CopyPrefix
+	CheckpointFileSuffixCopyPrefix = ".pcp"
+
 	// CheckpointMagic Checkpoint file Magic
 	CheckpointMagic = "92611BED-89E2-46B6-89E5-72F273D4B0A3"
 
@@ -76,4 +85,19 @@ const (
 
 	// DefaultOutOfOrderReadThreshold Default out of order read threshold is 3
 	DefaultOutOfOrderReadThreshold int64 = 3
+
+	// DefaultEndpointFailureThreshold The number of consecutive connection failures to open the circuit of an endpoint
+	DefaultEndpointFailureThreshold = 2
+
+	// DefaultEndpointRecoveryInterval How long an endpoint with an open circuit is skipped
+	DefaultEndpointRecoveryInterval = 30 * time.Second
+
+	// DefaultHedgePercentile The percentile of the time to first byte used as the hedge deadline
+	DefaultHedgePercentile = 0.95
+
+	// DefaultHedgeInitialDelay The hedge deadline before enough latencies are observed
+	DefaultHedgeInitialDelay = time.Second
+
+	// DefaultHedgeWindowSize The number of recent latencies to compute the percentile
+	DefaultHedgeWindowSize = 100
 )
@@ -42,6 +42,10 @@ type DownloaderOptions struct {
 	// In memory buffer size for writing. Automatically align to 4K (4*1024 bytes).
 	WriteBufferSize int
 
+	// The policy to hedge the range requests of the parts, nil disables it.
+	// The bytes discarded with the duplicate requests are not reported to the progress, see HedgePolicy.
+	HedgePolicy *HedgePolicy
+
 	ClientOptions []func(*Options)
 }
 
@@ -82,6 +86,12 @@ func NewDownloader(c DownloadAPIClient, optFns ...func(*DownloaderOptions)) *Dow
 
 type DownloadResult struct {
 	Written int64
+
+	// The statistics of the hedged range requests.
+	HedgeStats HedgeStats
+
+	// the headers of the object, from its HeadObject
+	headers http.Header
 }
 
 type DownloadError struct {
@@ -102,11 +112,16 @@ func (m *DownloadError) Unwrap() error {
 }
 
 func (d *Downloader) DownloadFile(ctx context.Context, request *GetObjectRequest, filePath string, optFns ...func(*DownloaderOptions)) (result *DownloadResult, err error) {
+	return d.downloadFile(ctx, request, filePath, nil, optFns...)
+}
+
+func (d *Downloader) downloadFile(ctx context.Context, request *GetObjectRequest, filePath string, budget transferBudget, optFns ...func(*DownloaderOptions)) (result *DownloadResult, err error) {
 	// Downloader wrapper
 	delegate, err := d.newDelegate(ctx, request, optFns...)
 	if err != nil {
 		return nil, err
 	}
+	delegate.budget = budget
 
 	// Source
 	if err = delegate.checkSource(); err != nil {
@@ -174,6 +189,11 @@ type downloaderDelegate struct {
 	checkCRC bool
 
 	checkpoint *downloadCheckpoint
+
+	hedger *Hedger
+
+	// the requests in flight shared with other downloads, nil if unlimited
+	budget transferBudget
 }
 
 type downloaderChunk struct {
@@ -248,6 +268,8 @@ func (d *Downloader) newDelegate(ctx context.Context, request *GetObjectRequest,
 		delegate.options.PartSize = DefaultDownloadPartSize
 	}
 
+	delegate.hedger = newHedger(delegate.options.HedgePolicy)
+
 	if delegate.options.WriteBufferSize > 0 {
 		// align to 4K
 		const alignSize = 4 * 1024
@@ -260,7 +282,11 @@ func (d *Downloader) newDelegate(ctx context.Context, request *GetObjectRequest,
 func (d *downloaderDelegate) checkSource() error {
 	var request HeadObjectRequest
 	copyRequest(&request, d.request)
+	if err := d.budget.acquire(d.context); err != nil {
+		return err
+	}
 	result, err := d.client.HeadObject(d.context, &request, d.options.ClientOptions...)
+	d.budget.release()
 	if err != nil {
 		return err
 	}
@@ -425,7 +451,12 @@ func (d *downloaderDelegate) download() (*DownloadResult, error) {
 				continue
 			}
 
+			if derr := d.budget.acquire(d.context); derr != nil {
+				saveErrFn(derr)
+				continue
+			}
 			dchunk, derr := d.downloadChunk(chunk, hash)
+			d.budget.release()
 
 			if derr != nil && derr != io.EOF {
 				saveErrFn(derr)
@@ -535,7 +566,9 @@ func (d *downloaderDelegate) download() (*DownloadResult, error) {
 	}
 
 	return &DownloadResult{
-		Written: d.written,
+		Written:    d.written,
+		HedgeStats: d.hedger.Stats(),
+		headers:    d.headers,
 	}, nil
 }
 
@@ -549,11 +582,11 @@ func (d *downloaderDelegate) incrWritten(n int64) {
 }
 
 func (d *downloaderDelegate) downloadChunk(chunk downloaderChunk, hash hash.Hash64) (downloadedChunk, error) {
-	// Get the next byte range of data
-	var request GetObjectRequest
-	copyRequest(&request, d.request)
-
 	getFn := func(ctx context.Context, httpRange HTTPRange) (output *ReaderRangeGetOutput, err error) {
+		// Get the next byte range of data
+		var request GetObjectRequest
+		copyRequest(&request, d.request)
+
 		// update range
 		request.Range = nil
 		rangeStr := httpRange.FormatHTTPRange()
@@ -576,6 +609,10 @@ func (d *downloaderDelegate) downloadChunk(chunk downloaderChunk, hash hash.Hash
 		}, nil
 	}
 
+	if d.hedger != nil {
+		getFn = d.hedger.Wrap(getFn)
+	}
+
 	reader, _ := NewRangeReader(d.context, getFn, &HTTPRange{chunk.start, chunk.size}, d.etag)
 	defer reader.Close()
 
@@ -0,0 +1,318 @@
+package oss
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type DownloadDirectoryOptions struct {
+	// The patterns of the objects to download, in the syntax of path.Match, such as "*.jpg" or "logs/*.log".
+	// A pattern with a "/" matches the key relative to the prefix, otherwise it matches the base name.
+	// All the objects are downloaded by default.
+	Include []string
+
+	// The patterns of the objects not to download, they take precedence over Include.
+	Exclude []string
+
+	// PathFn maps the key relative to the prefix to the path of its file relative to the local directory.
+	// The default is the relative key. The paths out of the local directory are rejected.
+	PathFn func(relKey string) string
+
+	// The maximum number of requests in flight, which is shared by the objects and their parts.
+	// The default is the ParallelNum of the downloader.
+	ParallelNum int
+
+	// If it is set, the versions of the objects which are the latest at the time are downloaded,
+	// they are listed by ListObjectVersions. The objects which are deleted at the time are not downloaded.
+	SnapshotTime time.Time
+
+	// Specifies whether to skip the objects whose files exist with the same size and CRC-64.
+	SkipUnchanged bool
+
+	// RequestFn customizes the request of each object, such as its ProgressFn.
+	RequestFn func(request *GetObjectRequest, filePath string)
+
+	// The options of the downloads of the objects.
+	// If the checkpoint is enabled, the interrupted downloads are resumed when the directory is downloaded again.
+	DownloaderOptions []func(*DownloaderOptions)
+}
+
+type DownloadDirectoryFileResult struct {
+	// The key of the object.
+	Key string
+
+	// The version of the object, it is set if the SnapshotTime is set.
+	VersionId *string
+
+	// The local path of the file.
+	Path string
+
+	// Specifies whether the object is skipped because its file is unchanged.
+	Skipped bool
+
+	// The result of the download, nil if the object is skipped or fails.
+	Result *DownloadResult
+
+	// The error of the object, nil if it succeeds.
+	Err error
+}
+
+type DownloadDirectoryResult struct {
+	// The results of the objects, in the lexical order of their keys.
+	Files []DownloadDirectoryFileResult
+
+	// The number of the objects which are downloaded, skipped and failed.
+	Downloaded int
+	Skipped    int
+	Failed     int
+}
+
+// DownloadDirectory downloads the objects under the prefix to the files in the local directory.
+// The directory markers, whose keys end with a "/", are skipped. The objects are downloaded in parallel,
+// and the failures of the objects are reported in the result instead of stopping the others.
+// The returned error is only for the arguments and the listing of the objects.
+func (d *Downloader) DownloadDirectory(ctx context.Context, bucket, prefix, localDir string, optFns ...func(*DownloadDirectoryOptions)) (*DownloadDirectoryResult, error) {
+	options := DownloadDirectoryOptions{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if bucket == "" {
+		return nil, NewErrParamRequired("bucket")
+	}
+	if localDir == "" {
+		return nil, NewErrParamRequired("localDir")
+	}
+	if err := checkPatterns(options.Include, "options.Include"); err != nil {
+		return nil, err
+	}
+	if err := checkPatterns(options.Exclude, "options.Exclude"); err != nil {
+		return nil, err
+	}
+	client := d.listClient()
+	if _, ok := client.(ListObjectsV2APIClient); !ok && options.SnapshotTime.IsZero() {
+		return nil, fmt.Errorf("the client of the downloader does not support listing objects")
+	}
+	if _, ok := client.(ListObjectVersionsAPIClient); !ok && !options.SnapshotTime.IsZero() {
+		return nil, fmt.Errorf("the client of the downloader does not support listing object versions")
+	}
+	localDir, err := filepath.Abs(localDir)
+	if err != nil {
+		return nil, err
+	}
+
+	downloaderOptions := d.options
+	for _, fn := range options.DownloaderOptions {
+		fn(&downloaderOptions)
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = downloaderOptions.ParallelNum
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = DefaultDownloadParallel
+	}
+	pathFn := options.PathFn
+	if pathFn == nil {
+		pathFn = func(relKey string) string { return relKey }
+	}
+
+	var files []DownloadDirectoryFileResult
+	if options.SnapshotTime.IsZero() {
+		files, err = listDirectoryObjects(ctx, client.(ListObjectsV2APIClient), bucket, prefix, downloaderOptions.ClientOptions)
+	} else {
+		files, err = listDirectorySnapshot(ctx, client.(ListObjectVersionsAPIClient), bucket, prefix, options.SnapshotTime, downloaderOptions.ClientOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DownloadDirectoryResult{}
+	for _, file := range files {
+		relKey := strings.TrimPrefix(file.Key, prefix)
+		if strings.HasSuffix(file.Key, "/") || !isIncluded(options.Include, options.Exclude, relKey) {
+			continue
+		}
+		file.Path, file.Err = localFilePath(localDir, pathFn(relKey))
+		result.Files = append(result.Files, file)
+	}
+
+	budget := newTransferBudget(options.ParallelNum)
+	ch := make(chan *DownloadDirectoryFileResult)
+	var wg sync.WaitGroup
+	for i := 0; i < options.ParallelNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range ch {
+				d.downloadDirectoryFile(ctx, bucket, file, &options, &downloaderOptions, budget)
+			}
+		}()
+	}
+	for i := range result.Files {
+		if result.Files[i].Err == nil {
+			ch <- &result.Files[i]
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	for _, file := range result.Files {
+		switch {
+		case file.Err != nil:
+			result.Failed++
+		case file.Skipped:
+			result.Skipped++
+		default:
+			result.Downloaded++
+		}
+	}
+	return result, nil
+}
+
+// listClient returns the client to list the objects with, the EncryptionClient lists by the client it wraps.
+func (d *Downloader) listClient() any {
+	if t, ok := d.client.(*EncryptionClient); ok {
+		return t.Unwrap()
+	}
+	return d.client
+}
+
+func (d *Downloader) downloadDirectoryFile(ctx context.Context, bucket string, file *DownloadDirectoryFileResult,
+	options *DownloadDirectoryOptions, downloaderOptions *DownloaderOptions, budget transferBudget) {
+	if file.Err = ctx.Err(); file.Err != nil {
+		return
+	}
+	request := &GetObjectRequest{
+		Bucket:    Ptr(bucket),
+		Key:       Ptr(file.Key),
+		VersionId: file.VersionId,
+	}
+	if options.SkipUnchanged {
+		file.Skipped, file.Err = d.isObjectUnchanged(ctx, request, file.Path, downloaderOptions.ClientOptions, budget)
+		if file.Skipped || file.Err != nil {
+			return
+		}
+	}
+	if options.RequestFn != nil {
+		options.RequestFn(request, file.Path)
+	}
+	if file.Err = os.MkdirAll(filepath.Dir(file.Path), 0755); file.Err != nil {
+		return
+	}
+	file.Result, file.Err = d.downloadFile(ctx, request, file.Path, budget, options.DownloaderOptions...)
+}
+
+// isObjectUnchanged reports whether the file of the object exists with the same size and CRC-64.
+func (d *Downloader) isObjectUnchanged(ctx context.Context, request *GetObjectRequest, filePath string,
+	clientOptions []func(*Options), budget transferBudget) (bool, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err = budget.acquire(ctx); err != nil {
+		return false, err
+	}
+	head, err := d.client.HeadObject(ctx, &HeadObjectRequest{
+		Bucket:    request.Bucket,
+		Key:       request.Key,
+		VersionId: request.VersionId,
+	}, clientOptions...)
+	budget.release()
+	if err != nil {
+		return false, err
+	}
+	if !info.Mode().IsRegular() || head.ContentLength != info.Size() || head.HashCRC64 == nil {
+		return false, nil
+	}
+	crc, err := fileCRC64(filePath)
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprint(crc) == ToString(head.HashCRC64), nil
+}
+
+// localFilePath returns the path of the file in the local directory, which must not be out of the directory,
+// such as the ones with "../".
+func localFilePath(localDir, relPath string) (string, error) {
+	filePath := filepath.Join(localDir, filepath.FromSlash(relPath))
+	rel, err := filepath.Rel(localDir, filePath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filePath, fmt.Errorf("invalid path %v, it is out of the directory %v", relPath, localDir)
+	}
+	return filePath, nil
+}
+
+func listDirectoryObjects(ctx context.Context, client ListObjectsV2APIClient, bucket, prefix string, clientOptions []func(*Options)) ([]DownloadDirectoryFileResult, error) {
+	var files []DownloadDirectoryFileResult
+	p := NewListObjectsV2Paginator(client, &ListObjectsV2Request{
+		Bucket: Ptr(bucket),
+		Prefix: Ptr(prefix),
+	})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			files = append(files, DownloadDirectoryFileResult{Key: ToString(object.Key)})
+		}
+	}
+	return files, nil
+}
+
+// listDirectorySnapshot lists the versions of the objects which are the latest at the time.
+func listDirectorySnapshot(ctx context.Context, client ListObjectVersionsAPIClient, bucket, prefix string, snapshotTime time.Time, clientOptions []func(*Options)) ([]DownloadDirectoryFileResult, error) {
+	type snapshot struct {
+		versionId    *string
+		lastModified time.Time
+		deleted      bool
+	}
+	var keys []string
+	snapshots := map[string]*snapshot{}
+	update := func(key *string, versionId *string, lastModified *time.Time, deleted bool) {
+		if lastModified == nil || lastModified.After(snapshotTime) {
+			return
+		}
+		s, ok := snapshots[ToString(key)]
+		if !ok {
+			keys = append(keys, ToString(key))
+		} else if !lastModified.After(s.lastModified) {
+			return
+		}
+		snapshots[ToString(key)] = &snapshot{versionId: versionId, lastModified: *lastModified, deleted: deleted}
+	}
+
+	p := NewListObjectVersionsPaginator(client, &ListObjectVersionsRequest{
+		Bucket: Ptr(bucket),
+		Prefix: Ptr(prefix),
+	})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range page.ObjectVersions {
+			update(version.Key, version.VersionId, version.LastModified, false)
+		}
+		for _, marker := range page.ObjectDeleteMarkers {
+			update(marker.Key, marker.VersionId, marker.LastModified, true)
+		}
+	}
+
+	sort.Strings(keys)
+	var files []DownloadDirectoryFileResult
+	for _, key := range keys {
+		if s := snapshots[key]; !s.deleted {
+			files = append(files, DownloadDirectoryFileResult{Key: key, VersionId: s.versionId})
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,70 @@
+package oss_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/osstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadDirectory_Server(t *testing.T) {
+	// the clock of the server is moved by minutes, within the allowed skew of the signatures
+	base := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	var offset int64
+	_, client := newTestServer(t, func(o *osstest.Options) {
+		o.Now = func() time.Time { return base.Add(time.Duration(atomic.LoadInt64(&offset))) }
+	})
+	ctx := context.TODO()
+	_, err := client.PutBucketVersioning(ctx, &oss.PutBucketVersioningRequest{
+		Bucket:                  oss.Ptr(testBucket),
+		VersioningConfiguration: &oss.VersioningConfiguration{Status: oss.VersionEnabled},
+	})
+	assert.Nil(t, err)
+
+	put := func(key, data string) {
+		_, err := client.PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr(key), Body: strings.NewReader(data)})
+		assert.Nil(t, err)
+	}
+	put("dir/a.txt", "a1")
+	put("dir/b.txt", "b1")
+	atomic.AddInt64(&offset, int64(time.Minute))
+	snapshot := base.Add(time.Minute)
+	put("dir/c.txt", "c1")
+	atomic.AddInt64(&offset, int64(time.Minute))
+	put("dir/a.txt", "a2")
+	_, err = client.DeleteObject(ctx, &oss.DeleteObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/b.txt")})
+	assert.Nil(t, err)
+	put("dir/d.txt", "d1")
+
+	downloader := client.NewDownloader()
+	dir := t.TempDir()
+	result, err := downloader.DownloadDirectory(ctx, testBucket, "dir/", dir, func(o *oss.DownloadDirectoryOptions) {
+		o.SnapshotTime = snapshot
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Downloaded)
+	for name, data := range map[string]string{"a.txt": "a1", "b.txt": "b1", "c.txt": "c1"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		assert.Equal(t, data, string(got))
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "d.txt"))
+	assert.NotEmpty(t, oss.ToString(result.Files[0].VersionId))
+
+	// the latest versions
+	dir = t.TempDir()
+	result, err = downloader.DownloadDirectory(ctx, testBucket, "dir/", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Downloaded)
+	got, _ := os.ReadFile(filepath.Join(dir, "a.txt"))
+	assert.Equal(t, "a2", string(got))
+	assert.NoFileExists(t, filepath.Join(dir, "b.txt"))
+	assert.FileExists(t, filepath.Join(dir, "d.txt"))
+}
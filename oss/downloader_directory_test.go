@@ -0,0 +1,187 @@
+package oss
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadDirectory(t *testing.T) {
+	store := newTestObjectStore()
+	server := testSetupObjectStoreServer(t, store)
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	big := strings.Repeat("0123456789", 30*1024)
+	store.put("bucket/dir/a.txt", []byte("hello a"), nil)
+	store.put("bucket/dir/b.jpg", []byte("hello b"), nil)
+	store.put("bucket/dir/big.bin", []byte(big), nil)
+	store.put("bucket/dir/sub/", nil, nil)
+	store.put("bucket/dir/sub/c.txt", []byte("hello c"), nil)
+	store.put("bucket/other/d.txt", []byte("hello d"), nil)
+
+	dir := t.TempDir()
+	downloader := client.NewDownloader(func(do *DownloaderOptions) {
+		do.PartSize = 64 * 1024
+	})
+	var requests int32
+	result, err := downloader.DownloadDirectory(context.TODO(), "bucket", "dir/", dir, func(o *DownloadDirectoryOptions) {
+		o.Exclude = []string{"*.jpg"}
+		o.ParallelNum = 2
+		o.RequestFn = func(request *GetObjectRequest, filePath string) {
+			atomic.AddInt32(&requests, 1)
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Downloaded)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, int32(3), requests)
+	assert.Len(t, result.Files, 3)
+	assert.Equal(t, "dir/a.txt", result.Files[0].Key)
+	assert.Equal(t, filepath.Join(dir, "a.txt"), result.Files[0].Path)
+	assert.Equal(t, int64(7), result.Files[0].Result.Written)
+	assert.Equal(t, "dir/big.bin", result.Files[1].Key)
+	assert.Equal(t, filepath.Join(dir, "sub", "c.txt"), result.Files[2].Path)
+	data, err := os.ReadFile(filepath.Join(dir, "big.bin"))
+	assert.Nil(t, err)
+	assert.Equal(t, big, string(data))
+	data, _ = os.ReadFile(filepath.Join(dir, "sub", "c.txt"))
+	assert.Equal(t, "hello c", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "b.jpg"))
+	// the directory marker is skipped
+	assert.DirExists(t, filepath.Join(dir, "sub"))
+	// the budget is shared by the objects and the parts
+	assert.LessOrEqual(t, atomic.LoadInt32(&store.maxInFlight), int32(2))
+
+	// skip the unchanged files
+	store.put("bucket/dir/a.txt", []byte("hello a, changed"), nil)
+	gets := store.count("GET")
+	result, err = downloader.DownloadDirectory(context.TODO(), "bucket", "dir/", dir, func(o *DownloadDirectoryOptions) {
+		o.Include = []string{"*.txt", "*.bin"}
+		o.SkipUnchanged = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Downloaded)
+	assert.Equal(t, 2, result.Skipped)
+	assert.False(t, result.Files[0].Skipped)
+	assert.True(t, result.Files[1].Skipped)
+	assert.Nil(t, result.Files[1].Result)
+	// three pages of the list and one get request
+	assert.Equal(t, gets+4, store.count("GET"))
+	data, _ = os.ReadFile(filepath.Join(dir, "a.txt"))
+	assert.Equal(t, "hello a, changed", string(data))
+
+	// the failures do not stop the others
+	store.failKeys["bucket/dir/a.txt"] = true
+	store.put("bucket/dir/../evil.txt", []byte("evil"), nil)
+	dir = t.TempDir()
+	result, err = downloader.DownloadDirectory(context.TODO(), "bucket", "dir/", dir, func(o *DownloadDirectoryOptions) {
+		o.Include = []string{"*.txt"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Downloaded)
+	assert.Equal(t, 2, result.Failed)
+	assert.Equal(t, "dir/../evil.txt", result.Files[0].Key)
+	assert.Contains(t, result.Files[0].Err.Error(), "is out of the directory")
+	assert.Equal(t, "dir/a.txt", result.Files[1].Key)
+	var serr *ServiceError
+	assert.ErrorAs(t, result.Files[1].Err, &serr)
+	assert.Equal(t, "AccessDenied", serr.Code)
+	assert.Nil(t, result.Files[2].Err)
+	assert.NoFileExists(t, filepath.Join(dir, "a.txt"))
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "evil.txt"))
+	assert.FileExists(t, filepath.Join(dir, "sub", "c.txt"))
+
+	// the paths are mapped
+	dir = t.TempDir()
+	result, err = downloader.DownloadDirectory(context.TODO(), "bucket", "dir/sub/", dir, func(o *DownloadDirectoryOptions) {
+		o.PathFn = func(relKey string) string { return "mapped/" + strings.ToUpper(relKey) }
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Downloaded)
+	assert.FileExists(t, filepath.Join(dir, "mapped", "C.TXT"))
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = downloader.DownloadDirectory(ctx, "bucket", "dir/", dir)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLocalFilePath(t *testing.T) {
+	dir := t.TempDir()
+	filePath, err := localFilePath(dir, "a/b.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "a", "b.txt"), filePath)
+
+	filePath, err = localFilePath(dir, "a/../b.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "b.txt"), filePath)
+
+	for _, relPath := range []string{"", ".", "..", "../a.txt", "a/../../b.txt", "/../a.txt"} {
+		_, err = localFilePath(dir, relPath)
+		assert.NotNil(t, err, relPath)
+	}
+}
+
+type stubDownloadAPIClient struct {
+	DownloadAPIClient
+}
+
+func TestDownloadDirectory_ArgumentCheck(t *testing.T) {
+	downloader := NewDownloader(&Client{})
+	dir := t.TempDir()
+
+	_, err := downloader.DownloadDirectory(context.TODO(), "", "", dir)
+	assert.Contains(t, err.Error(), "missing required field, bucket")
+
+	_, err = downloader.DownloadDirectory(context.TODO(), "bucket", "", "")
+	assert.Contains(t, err.Error(), "missing required field, localDir")
+
+	_, err = downloader.DownloadDirectory(context.TODO(), "bucket", "", dir, func(o *DownloadDirectoryOptions) {
+		o.Include = []string{"["}
+	})
+	assert.Contains(t, err.Error(), "invalid field, options.Include")
+
+	downloader = NewDownloader(&stubDownloadAPIClient{})
+	_, err = downloader.DownloadDirectory(context.TODO(), "bucket", "", dir)
+	assert.Contains(t, err.Error(), "does not support listing objects")
+}
+
+type stubListObjectsV2Client struct {
+	DownloadAPIClient
+	ListObjectsV2APIClient
+}
+
+func TestDownloadDirectory_ListAPIClient(t *testing.T) {
+	store := newTestObjectStore()
+	server := testSetupObjectStoreServer(t, store)
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	store.put("bucket/dir/a.txt", []byte("hello a"), nil)
+	store.put("bucket/dir/sub/b.txt", []byte("hello b"), nil)
+
+	// any client which implements ListObjectsV2
+	dir := t.TempDir()
+	downloader := NewDownloader(&stubListObjectsV2Client{DownloadAPIClient: client, ListObjectsV2APIClient: client})
+	result, err := downloader.DownloadDirectory(context.TODO(), "bucket", "dir/", dir)
+	assert.Nil(t, err)
+	assert.Len(t, result.Files, 2)
+	data, err := os.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello b", string(data))
+
+	// the snapshot needs ListObjectVersions
+	_, err = downloader.DownloadDirectory(context.TODO(), "bucket", "dir/", dir, func(o *DownloadDirectoryOptions) {
+		o.SnapshotTime = time.Now()
+	})
+	assert.Contains(t, err.Error(), "does not support listing object versions")
+}
@@ -0,0 +1,229 @@
+package oss
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+)
+
+// EndpointState The state of the circuit breaker of an endpoint
+type EndpointState int
+
+const (
+	// The endpoint is healthy and used in order.
+	EndpointStateClosed EndpointState = iota
+
+	// The endpoint failed too many times and is skipped until the recovery interval elapses.
+	EndpointStateOpen
+
+	// The recovery interval elapsed, a trial request is sent to the endpoint.
+	EndpointStateHalfOpen
+)
+
+func (s EndpointState) String() string {
+	switch s {
+	case EndpointStateOpen:
+		return "open"
+	case EndpointStateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// EndpointHealth is a point-in-time copy of the health of an endpoint.
+type EndpointHealth struct {
+	Endpoint string
+
+	State EndpointState
+
+	// The number of consecutive connection failures.
+	Failures int
+
+	// The time when the circuit is opened last time.
+	OpenedAt time.Time
+}
+
+type endpointBreaker struct {
+	endpoint *url.URL
+	state    EndpointState
+	failures int
+	openedAt time.Time
+}
+
+// endpointGroup tracks the health of an ordered list of endpoints,
+// and selects the first healthy one for each attempt.
+type endpointGroup struct {
+	mu        sync.Mutex
+	breakers  []*endpointBreaker
+	threshold int
+	interval  time.Duration
+	now       func() time.Time
+	log       Logger
+}
+
+func newEndpointGroup(endpoints []*url.URL, threshold int, interval time.Duration, log Logger) *endpointGroup {
+	g := &endpointGroup{
+		threshold: threshold,
+		interval:  interval,
+		now:       time.Now,
+		log:       log,
+	}
+	for _, e := range endpoints {
+		g.breakers = append(g.breakers, &endpointBreaker{endpoint: e})
+	}
+	return g
+}
+
+// pick selects the first endpoint whose circuit is closed, or whose recovery interval elapsed.
+// If all circuits are open, the one which opened earliest is used.
+func (g *endpointGroup) pick() *endpointBreaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	var earliest *endpointBreaker
+	for _, b := range g.breakers {
+		switch b.state {
+		case EndpointStateClosed:
+			return b
+		case EndpointStateOpen:
+			if now.Sub(b.openedAt) >= g.interval {
+				b.state = EndpointStateHalfOpen
+				g.log.Infof("Endpoint circuit half-open, endpoint:%v", b.endpoint.Host)
+				return b
+			}
+		}
+		if b.state == EndpointStateOpen && (earliest == nil || b.openedAt.Before(earliest.openedAt)) {
+			earliest = b
+		}
+	}
+	if earliest != nil {
+		return earliest
+	}
+	// a trial request to a half-open endpoint is in flight
+	return g.breakers[0]
+}
+
+func (g *endpointGroup) onSuccess(b *endpointBreaker) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if b.state != EndpointStateClosed {
+		g.log.Infof("Endpoint circuit closed, endpoint:%v", b.endpoint.Host)
+	}
+	b.state = EndpointStateClosed
+	b.failures = 0
+}
+
+func (g *endpointGroup) onFailure(b *endpointBreaker) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b.failures++
+	if b.state == EndpointStateHalfOpen || b.failures >= g.threshold {
+		if b.state != EndpointStateOpen {
+			g.log.Warnf("Endpoint circuit open, endpoint:%v, failures:%v", b.endpoint.Host, b.failures)
+		}
+		b.state = EndpointStateOpen
+		b.openedAt = g.now()
+	}
+}
+
+// onAbort is called when an attempt ends without a response or a connection error, such as the context is canceled.
+// A half-open endpoint is opened again, so that another trial is allowed after the recovery interval.
+func (g *endpointGroup) onAbort(b *endpointBreaker) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if b.state == EndpointStateHalfOpen {
+		g.log.Debugf("Endpoint circuit trial aborted, endpoint:%v", b.endpoint.Host)
+		b.state = EndpointStateOpen
+		b.openedAt = g.now()
+	}
+}
+
+// match returns the prefix of the host, e.g. the bucket name, if the host belongs to one of the endpoints.
+func (g *endpointGroup) match(host string) (prefix string, ok bool) {
+	for _, b := range g.breakers {
+		eh := b.endpoint.Host
+		if host == eh {
+			return "", true
+		}
+		if strings.HasSuffix(host, "."+eh) {
+			return strings.TrimSuffix(host, eh), true
+		}
+	}
+	return "", false
+}
+
+func (g *endpointGroup) health() []EndpointHealth {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := make([]EndpointHealth, 0, len(g.breakers))
+	for _, b := range g.breakers {
+		result = append(result, EndpointHealth{
+			Endpoint: b.endpoint.String(),
+			State:    b.state,
+			Failures: b.failures,
+			OpenedAt: b.openedAt,
+		})
+	}
+	return result
+}
+
+func isConnectionError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	return (&retry.ConnectionErrorRetryable{}).IsErrorRetryable(err)
+}
+
+func endpointFailoverMiddleware(g *endpointGroup) Middleware {
+	return Middleware{
+		Name: "EndpointFailover",
+		Step: MiddlewareStepBeforeSign,
+		Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+			prefix, ok := g.match(mctx.Request.URL.Host)
+			if !ok {
+				// the endpoint is overwritten by the operation
+				return next(ctx, mctx)
+			}
+
+			b := g.pick()
+			u := *mctx.Request.URL
+			u.Scheme = b.endpoint.Scheme
+			u.Host = prefix + b.endpoint.Host
+			if u.Host != mctx.Request.URL.Host {
+				req := mctx.Request.WithContext(ctx)
+				req.URL = &u
+				req.Host = u.Host
+				mctx.Request = req
+			}
+
+			err := next(ctx, mctx)
+			if isConnectionError(ctx, err) {
+				g.onFailure(b)
+			} else if mctx.Response != nil {
+				g.onSuccess(b)
+			} else {
+				g.onAbort(b)
+			}
+			return err
+		},
+	}
+}
+
+// EndpointHealth returns the health of the endpoints in the failover order,
+// or nil if the endpoint failover is not configured.
+func (c *Client) EndpointHealth() []EndpointHealth {
+	if c.inner.EndpointGroup == nil {
+		return nil
+	}
+	return c.inner.EndpointGroup.health()
+}
@@ -0,0 +1,229 @@
+package oss
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointGroup(t *testing.T) {
+	internal, _ := url.Parse("https://oss-cn-hangzhou-internal.aliyuncs.com")
+	public, _ := url.Parse("https://oss-cn-hangzhou.aliyuncs.com")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := newEndpointGroup([]*url.URL{internal, public}, 2, 30*time.Second, NewLogger(LogOff, nil))
+	g.now = func() time.Time { return now }
+
+	// match
+	prefix, ok := g.match("bucket.oss-cn-hangzhou-internal.aliyuncs.com")
+	assert.True(t, ok)
+	assert.Equal(t, "bucket.", prefix)
+	prefix, ok = g.match("oss-cn-hangzhou.aliyuncs.com")
+	assert.True(t, ok)
+	assert.Equal(t, "", prefix)
+	_, ok = g.match("bucket.oss-cn-shanghai.aliyuncs.com")
+	assert.False(t, ok)
+	_, ok = g.match("bucketoss-cn-hangzhou.aliyuncs.com")
+	assert.False(t, ok)
+
+	// the first one is preferred
+	b := g.pick()
+	assert.Equal(t, internal, b.endpoint)
+
+	// opens after consecutive failures
+	g.onFailure(b)
+	assert.Equal(t, internal, g.pick().endpoint)
+	g.onSuccess(b)
+	g.onFailure(b)
+	assert.Equal(t, internal, g.pick().endpoint)
+	g.onFailure(b)
+	assert.Equal(t, public, g.pick().endpoint)
+
+	health := g.health()
+	assert.Len(t, health, 2)
+	assert.Equal(t, EndpointStateOpen, health[0].State)
+	assert.Equal(t, 2, health[0].Failures)
+	assert.Equal(t, now, health[0].OpenedAt)
+	assert.Equal(t, EndpointStateClosed, health[1].State)
+
+	// all open, use the one opened earliest
+	now = now.Add(time.Second)
+	g.onFailure(g.breakers[1])
+	g.onFailure(g.breakers[1])
+	assert.Equal(t, internal, g.pick().endpoint)
+
+	// half-open after the recovery interval, only one trial
+	now = now.Add(30 * time.Second)
+	b = g.pick()
+	assert.Equal(t, internal, b.endpoint)
+	assert.Equal(t, EndpointStateHalfOpen, b.state)
+	assert.Equal(t, public, g.pick().endpoint)
+
+	// the trial fails
+	g.onFailure(b)
+	assert.Equal(t, EndpointStateOpen, b.state)
+	assert.Equal(t, now, b.openedAt)
+
+	// the trial succeeds
+	now = now.Add(30 * time.Second)
+	b = g.pick()
+	assert.Equal(t, internal, b.endpoint)
+	g.onSuccess(b)
+	assert.Equal(t, EndpointStateClosed, b.state)
+	assert.Equal(t, 0, b.failures)
+	assert.Equal(t, internal, g.pick().endpoint)
+
+	assert.Equal(t, "closed", EndpointStateClosed.String())
+	assert.Equal(t, "open", EndpointStateOpen.String())
+	assert.Equal(t, "half-open", EndpointStateHalfOpen.String())
+}
+
+func TestResolveEndpointFailover(t *testing.T) {
+	// not configured
+	client := NewClient(LoadDefaultConfig().
+		WithRegion("cn-hangzhou"))
+	assert.Nil(t, client.EndpointHealth())
+
+	// types from region
+	client = NewClient(LoadDefaultConfig().
+		WithRegion("cn-hangzhou").
+		WithUseInternalEndpoint(true).
+		WithFailoverEndpoints("oss-cn-hangzhou-internal.aliyuncs.com", "http://my-domain.com").
+		WithFailoverEndpointTypes(EndpointPublic, EndpointAccelerate))
+	health := client.EndpointHealth()
+	assert.Len(t, health, 4)
+	assert.Equal(t, "https://oss-cn-hangzhou-internal.aliyuncs.com", health[0].Endpoint)
+	assert.Equal(t, "http://my-domain.com", health[1].Endpoint)
+	assert.Equal(t, "https://oss-cn-hangzhou.aliyuncs.com", health[2].Endpoint)
+	assert.Equal(t, "https://oss-accelerate.aliyuncs.com", health[3].Endpoint)
+	assert.Equal(t, DefaultEndpointFailureThreshold, client.inner.EndpointGroup.threshold)
+	assert.Equal(t, DefaultEndpointRecoveryInterval, client.inner.EndpointGroup.interval)
+	assert.Equal(t, "EndpointFailover", client.options.Middlewares[0].Name)
+
+	// duplicated
+	client = NewClient(LoadDefaultConfig().
+		WithRegion("cn-hangzhou").
+		WithFailoverEndpointTypes(EndpointPublic).
+		WithEndpointFailureThreshold(5).
+		WithEndpointRecoveryInterval(time.Minute))
+	assert.Nil(t, client.EndpointHealth())
+	assert.Len(t, client.options.Middlewares, 0)
+
+	client = NewClient(LoadDefaultConfig().
+		WithRegion("cn-hangzhou").
+		WithFailoverEndpointTypes(EndpointInternal).
+		WithEndpointFailureThreshold(5).
+		WithEndpointRecoveryInterval(time.Minute))
+	assert.Len(t, client.EndpointHealth(), 2)
+	assert.Equal(t, 5, client.inner.EndpointGroup.threshold)
+	assert.Equal(t, time.Minute, client.inner.EndpointGroup.interval)
+}
+
+func TestEndpointFailover_Client(t *testing.T) {
+	var hosts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	// a closed port
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	dead := l.Addr().String()
+	l.Close()
+
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint("http://"+dead).
+		WithFailoverEndpoints(server.URL).
+		WithEndpointFailureThreshold(1).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		}))
+	client := NewClient(cfg)
+
+	input := &OperationInput{
+		OpName: "GetObject",
+		Method: "GET",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}
+
+	// fails over within the retries
+	output, err := client.InvokeOperation(context.TODO(), input)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, output.StatusCode)
+	assert.Equal(t, []string{server.Listener.Addr().String()}, hosts)
+	health := client.EndpointHealth()
+	assert.Equal(t, EndpointStateOpen, health[0].State)
+	assert.Equal(t, EndpointStateClosed, health[1].State)
+
+	// the next operation uses the healthy endpoint directly
+	hosts = nil
+	_, err = client.InvokeOperation(context.TODO(), input, func(o *Options) {
+		o.RetryMaxAttempts = Ptr(1)
+	})
+	assert.Nil(t, err)
+	assert.Len(t, hosts, 1)
+
+	// the endpoint of the operation is not tracked
+	hosts = nil
+	ep, _ := url.Parse("http://localhost:" + server.URL[len("http://127.0.0.1:"):])
+	_, err = client.InvokeOperation(context.TODO(), input, func(o *Options) {
+		o.Endpoint = ep
+		o.RetryMaxAttempts = Ptr(1)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{ep.Host}, hosts)
+
+	// recovers after the interval
+	client.inner.EndpointGroup.now = func() time.Time { return time.Now().Add(time.Hour) }
+	hosts = nil
+	_, err = client.InvokeOperation(context.TODO(), input)
+	assert.Nil(t, err)
+	assert.Len(t, hosts, 1)
+	health = client.EndpointHealth()
+	assert.Equal(t, EndpointStateOpen, health[0].State)
+	assert.Equal(t, 2, health[0].Failures)
+}
+
+func TestEndpointFailover_HalfOpenTrialCanceled(t *testing.T) {
+	internal, _ := url.Parse("https://oss-cn-hangzhou-internal.aliyuncs.com")
+	public, _ := url.Parse("https://oss-cn-hangzhou.aliyuncs.com")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := newEndpointGroup([]*url.URL{internal, public}, 1, 30*time.Second, NewLogger(LogOff, nil))
+	g.now = func() time.Time { return now }
+	g.onFailure(g.breakers[0])
+	assert.Equal(t, EndpointStateOpen, g.breakers[0].state)
+
+	// the trial is canceled without a response
+	now = now.Add(30 * time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequest("GET", "https://bucket.oss-cn-hangzhou-internal.aliyuncs.com/key", nil)
+	mctx := &MiddlewareContext{Request: req}
+	err := endpointFailoverMiddleware(g).Handle(ctx, mctx, func(ctx context.Context, mctx *MiddlewareContext) error {
+		assert.Equal(t, EndpointStateHalfOpen, g.breakers[0].state)
+		return ctx.Err()
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, EndpointStateOpen, g.breakers[0].state)
+	assert.Equal(t, now, g.breakers[0].openedAt)
+	assert.Equal(t, 1, g.breakers[0].failures)
+	assert.Equal(t, public, g.pick().endpoint)
+
+	// another trial after the recovery interval
+	now = now.Add(30 * time.Second)
+	b := g.pick()
+	assert.Equal(t, internal, b.endpoint)
+	assert.Equal(t, EndpointStateHalfOpen, b.state)
+}
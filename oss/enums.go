@@ -217,6 +217,8 @@ const (
 	HeaderOssAllowSameActionOverLap             = "X-Oss-Allow-Same-Action-Overlap"
 	HeaderOssDate                               = "X-Oss-Date"
 	HeaderOssContentSha256                      = "X-Oss-Content-Sha256"
+	HeaderOssDecodedContentLength               = "X-Oss-Decoded-Content-Length"
+	HeaderOssTrailer                            = "X-Oss-Trailer"
 	HeaderOssEC                                 = "X-Oss-Ec"
 	HeaderOssERR                                = "X-Oss-Err"
 )
@@ -301,6 +303,17 @@ const (
 	// This feature takes effect for Downloader.DownloadFile
 	FeatureEnableCRC64CheckDownload
 
+	// FeatureExperimentalStreamingPayloadSign signs the request body chunk by chunk with the signature version 4,
+	// if its length is unknown or it's not seekable. The crc64 of the body is sent as a signed trailing header.
+	// This feature takes effect for PutObject, AppendObject and UploadPart
+	//
+	// EXPERIMENTAL: the x-oss-content-sha256 value STREAMING-OSS4-HMAC-SHA256-PAYLOAD-TRAILER and the headers
+	// x-oss-decoded-content-length and x-oss-trailer are not part of the documented OSS API, so the requests may be
+	// rejected by OSS. The encoding follows the streaming upload of the AWS Signature Version 4,
+	// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html, with the OSS4 algorithm names,
+	// and it is verified by signer.Verifier. It is disabled by default, and may be changed or removed.
+	FeatureExperimentalStreamingPayloadSign
+
 	FeatureFlagsDefault = FeatureCorrectClockSkew + FeatureAutoDetectMimeType +
 		FeatureEnableCRC64CheckUpload + FeatureEnableCRC64CheckDownload
 )
@@ -342,4 +355,6 @@ const (
 	OpMetaKeyResponsHandler     string = "opm-response-handler"
 	OpMetaKeyRequestBodyTracker string = "opm-request-body-tracker"
 	OpMetaKeyIsBucketArn        string = "opm-is-bucket-arn"
+	OpMetaKeyStreamingPayload   string = "opm-streaming-payload"
+	OpMetaKeyUnsigned           string = "opm-unsigned"
 )
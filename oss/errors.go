@@ -2,6 +2,7 @@ package oss
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -43,6 +44,10 @@ func (e *ServiceError) ErrorCode() string {
 	return e.Code
 }
 
+func (e *ServiceError) HttpHeaders() http.Header {
+	return e.Headers
+}
+
 type ClientError struct {
 	Code    string
 	Message string
@@ -111,6 +116,29 @@ func (e *CanceledError) Error() string {
 	return fmt.Sprintf("canceled, %v", e.Err)
 }
 
+// RetryStoppedError is returned when the retryer refuses to retry, e.g. the retry quota is exceeded.
+// Err is the error of the last attempt, and errors.Is and errors.As also match RetryErr.
+type RetryStoppedError struct {
+	Err      error
+	RetryErr error
+}
+
+func (e *RetryStoppedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RetryStoppedError) Is(target error) bool {
+	return errors.Is(e.RetryErr, target)
+}
+
+func (e *RetryStoppedError) As(target interface{}) bool {
+	return errors.As(e.RetryErr, target)
+}
+
+func (e *RetryStoppedError) Error() string {
+	return fmt.Sprintf("retry stopped, %v, last error: %v", e.RetryErr, e.Err)
+}
+
 type InvalidParamError interface {
 	error
 	Field() string
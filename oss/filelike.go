@@ -26,6 +26,10 @@ type OpenOptions struct {
 	RequestPayer      *string
 
 	OutOfOrderReadThreshold int64
+
+	// The policy to hedge the prefetch range requests, nil disables it.
+	// The bytes discarded with the duplicate requests are not reported, see HedgePolicy.
+	HedgePolicy *HedgePolicy
 }
 
 type ReadOnlyFile struct {
@@ -64,6 +68,8 @@ type ReadOnlyFile struct {
 	closed bool // whether we have closed the file
 
 	oooReadThreshold int64
+
+	hedger *Hedger
 }
 
 // NewReadOnlyFile OpenFile opens the named file for reading.
@@ -116,6 +122,7 @@ func NewReadOnlyFile(ctx context.Context, c OpenFileAPIClient, bucket string, ke
 		chunkSize:         options.ChunkSize,
 		prefetchThreshold: options.PrefetchThreshold,
 		oooReadThreshold:  options.OutOfOrderReadThreshold,
+		hedger:            newHedger(options.HedgePolicy),
 	}
 
 	result, err := f.client.HeadObject(f.context, &HeadObjectRequest{
@@ -146,6 +153,11 @@ func NewReadOnlyFile(ctx context.Context, c OpenFileAPIClient, bucket string, ke
 	return f, nil
 }
 
+// HedgeStats returns the statistics of the hedged prefetch range requests.
+func (f *ReadOnlyFile) HedgeStats() HedgeStats {
+	return f.hedger.Stats()
+}
+
 // Close closes the File.
 func (f *ReadOnlyFile) Close() error {
 	if f == nil {
@@ -468,7 +480,7 @@ func (f *ReadOnlyFile) prefetch(offset int64, _ /*needAtLeast*/ int) (err error)
 					request.RangeBehavior = Ptr("standard")
 				}
 				var result *GetObjectResult
-				result, err = f.client.GetObject(f.context, request)
+				result, err = f.client.GetObject(ctx, request)
 				if err != nil {
 					return nil, err
 				}
@@ -481,6 +493,9 @@ func (f *ReadOnlyFile) prefetch(offset int64, _ /*needAtLeast*/ int) (err error)
 				}, nil
 				//fmt.Printf("result.Headers:%#v\n", result.Headers)
 			}
+			if f.hedger != nil {
+				getFn = f.hedger.Wrap(getFn)
+			}
 			ar, err := NewAsyncRangeReader(f.context, getFn, &HTTPRange{off, size}, f.etag, int(cnt))
 			if err != nil {
 				break
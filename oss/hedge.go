@@ -0,0 +1,276 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HedgePolicy controls the hedged range requests. If the first byte of a range request
+// hasn't arrived within the deadline, a duplicate request is sent and the first one to respond is used.
+//
+// The other request is canceled, and its response body is closed without being read. The bytes it has
+// already received are discarded, they are not reported to the progress, and the metrics only count
+// the bytes read by the SDK, that is the first byte at most. So the traffic of a hedged range request
+// can be larger than the progress and the metrics show, by up to the size of the range.
+type HedgePolicy struct {
+	// The percentile of the observed time to first byte used as the deadline, in (0, 1]. Default is 0.95.
+	Percentile float64
+
+	// The deadline before enough latencies are observed. Default is 1s.
+	InitialDelay time.Duration
+
+	// The lower bound of the deadline.
+	MinDelay time.Duration
+
+	// The number of recent latencies to compute the percentile. Default is 100.
+	WindowSize int
+}
+
+// HedgeStats is the statistics of the hedged range requests.
+type HedgeStats struct {
+	// The number of range requests.
+	Requests int64
+
+	// The number of duplicate requests sent.
+	Hedged int64
+
+	// The number of times the duplicate request responded first.
+	Won int64
+}
+
+// the minimum number of latencies to use the percentile
+const hedgeMinSamples = 10
+
+type hedgeAttemptKey struct{}
+
+// hedgeAttempt marks the range requests of a hedged pair, for the metrics.
+type hedgeAttempt struct {
+	hedged    bool
+	abandoned int32
+}
+
+func hedgeAttemptFrom(ctx context.Context) *hedgeAttempt {
+	ha, _ := ctx.Value(hedgeAttemptKey{}).(*hedgeAttempt)
+	return ha
+}
+
+// Hedger sends hedged range requests, and tracks the time to first byte of them.
+// It's safe for concurrent use.
+type Hedger struct {
+	policy HedgePolicy
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+
+	requests int64
+	hedged   int64
+	won      int64
+}
+
+// NewHedger creates a new Hedger instance with the policy.
+func NewHedger(policy HedgePolicy) *Hedger {
+	if policy.Percentile <= 0 || policy.Percentile > 1 {
+		policy.Percentile = DefaultHedgePercentile
+	}
+	if policy.InitialDelay <= 0 {
+		policy.InitialDelay = DefaultHedgeInitialDelay
+	}
+	if policy.WindowSize <= 0 {
+		policy.WindowSize = DefaultHedgeWindowSize
+	}
+	return &Hedger{
+		policy:  policy,
+		samples: make([]time.Duration, 0, policy.WindowSize),
+	}
+}
+
+func newHedger(policy *HedgePolicy) *Hedger {
+	if policy == nil {
+		return nil
+	}
+	return NewHedger(*policy)
+}
+
+// Stats returns the statistics of the hedged range requests.
+func (h *Hedger) Stats() HedgeStats {
+	if h == nil {
+		return HedgeStats{}
+	}
+	return HedgeStats{
+		Requests: atomic.LoadInt64(&h.requests),
+		Hedged:   atomic.LoadInt64(&h.hedged),
+		Won:      atomic.LoadInt64(&h.won),
+	}
+}
+
+// Delay returns the current deadline to send the duplicate request.
+func (h *Hedger) Delay() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	d := h.policy.InitialDelay
+	if len(h.samples) >= hedgeMinSamples {
+		sorted := make([]time.Duration, len(h.samples))
+		copy(sorted, h.samples)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		i := int(float64(len(sorted))*h.policy.Percentile+0.5) - 1
+		if i < 0 {
+			i = 0
+		} else if i >= len(sorted) {
+			i = len(sorted) - 1
+		}
+		d = sorted[i]
+	}
+	if d < h.policy.MinDelay {
+		d = h.policy.MinDelay
+	}
+	return d
+}
+
+func (h *Hedger) observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < h.policy.WindowSize {
+		h.samples = append(h.samples, d)
+		return
+	}
+	h.samples[h.next] = d
+	h.next = (h.next + 1) % h.policy.WindowSize
+}
+
+type hedgeResult struct {
+	output  *ReaderRangeGetOutput
+	err     error
+	hedged  bool
+	latency time.Duration
+	attempt *hedgeAttempt
+	cancel  context.CancelFunc
+}
+
+func (r *hedgeResult) abandon() {
+	atomic.StoreInt32(&r.attempt.abandoned, 1)
+	r.cancel()
+}
+
+func (r *hedgeResult) close() {
+	if r.output != nil && r.output.Body != nil {
+		r.output.Body.Close()
+	}
+	r.cancel()
+}
+
+type hedgeBody struct {
+	io.Reader
+	body   io.Closer
+	cancel context.CancelFunc
+}
+
+func (b *hedgeBody) Close() error {
+	err := b.body.Close()
+	b.cancel()
+	return err
+}
+
+// Wrap returns a ReaderRangeGetFn which hedges the range requests of fn.
+func (h *Hedger) Wrap(fn ReaderRangeGetFn) ReaderRangeGetFn {
+	return func(ctx context.Context, httpRange HTTPRange) (*ReaderRangeGetOutput, error) {
+		atomic.AddInt64(&h.requests, 1)
+		results := make(chan *hedgeResult, 2)
+		var started []*hedgeResult
+
+		send := func(hedged bool) {
+			r := &hedgeResult{hedged: hedged, attempt: &hedgeAttempt{hedged: hedged}}
+			started = append(started, r)
+			var rctx context.Context
+			rctx, r.cancel = context.WithCancel(context.WithValue(ctx, hedgeAttemptKey{}, r.attempt))
+			go func() {
+				start := time.Now()
+				r.output, r.err = h.firstByte(fn(rctx, httpRange))
+				r.latency = time.Since(start)
+				results <- r
+			}()
+		}
+
+		// release the requests which are not used
+		drain := func(winner *hedgeResult, pending int) {
+			for _, r := range started {
+				if r != winner {
+					r.abandon()
+				}
+			}
+			go func() {
+				for i := 0; i < pending; i++ {
+					r := <-results
+					if r != winner {
+						r.close()
+					}
+				}
+			}()
+		}
+
+		send(false)
+		timer := time.NewTimer(h.Delay())
+		defer timer.Stop()
+
+		pending := 1
+		for {
+			select {
+			case r := <-results:
+				pending--
+				if r.err != nil && pending > 0 {
+					// wait for the other one
+					r.cancel()
+					continue
+				}
+				if r.err != nil {
+					r.cancel()
+					drain(r, pending)
+					return nil, r.err
+				}
+				h.observe(r.latency)
+				if r.hedged {
+					atomic.AddInt64(&h.won, 1)
+				}
+				drain(r, pending)
+				r.output.Body = &hedgeBody{Reader: r.output.Body, body: r.output.Body, cancel: r.cancel}
+				return r.output, nil
+			case <-timer.C:
+				if len(started) == 1 {
+					atomic.AddInt64(&h.hedged, 1)
+					send(true)
+					pending++
+				}
+			case <-ctx.Done():
+				drain(nil, pending)
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+// firstByte waits for the first byte of the body.
+func (h *Hedger) firstByte(output *ReaderRangeGetOutput, err error) (*ReaderRangeGetOutput, error) {
+	if err != nil || output == nil || output.Body == nil {
+		return output, err
+	}
+	var p [1]byte
+	n, err := io.ReadFull(output.Body, p[:])
+	if err != nil && err != io.EOF {
+		output.Body.Close()
+		return nil, err
+	}
+	body := output.Body
+	output.Body = &hedgeBody{
+		Reader: io.MultiReader(bytes.NewReader(p[:n]), body),
+		body:   body,
+		cancel: func() {},
+	}
+	return output, nil
+}
@@ -0,0 +1,324 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedger_Delay(t *testing.T) {
+	h := NewHedger(HedgePolicy{})
+	assert.Equal(t, DefaultHedgePercentile, h.policy.Percentile)
+	assert.Equal(t, DefaultHedgeInitialDelay, h.Delay())
+	assert.Equal(t, DefaultHedgeWindowSize, h.policy.WindowSize)
+
+	h = NewHedger(HedgePolicy{
+		Percentile:   0.9,
+		InitialDelay: time.Second,
+		WindowSize:   20,
+	})
+
+	// not enough samples
+	for i := 1; i < hedgeMinSamples; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, time.Second, h.Delay())
+
+	for i := hedgeMinSamples; i <= 20; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, 18*time.Millisecond, h.Delay())
+
+	// the window slides
+	for i := 0; i < 20; i++ {
+		h.observe(100 * time.Millisecond)
+	}
+	assert.Len(t, h.samples, 20)
+	assert.Equal(t, 100*time.Millisecond, h.Delay())
+
+	// lower bound
+	h.policy.MinDelay = 200 * time.Millisecond
+	assert.Equal(t, 200*time.Millisecond, h.Delay())
+
+	// nil policy
+	assert.Nil(t, newHedger(nil))
+	var nh *Hedger
+	assert.Equal(t, HedgeStats{}, nh.Stats())
+}
+
+func TestHedger_Wrap(t *testing.T) {
+	body := func(s string) io.ReadCloser {
+		return io.NopCloser(strings.NewReader(s))
+	}
+
+	// responds in time
+	var calls int32
+	h := NewHedger(HedgePolicy{InitialDelay: time.Second})
+	fn := h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		atomic.AddInt32(&calls, 1)
+		return &ReaderRangeGetOutput{Body: body("hello")}, nil
+	})
+	output, err := fn(context.Background(), HTTPRange{})
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(output.Body)
+	assert.Equal(t, "hello", string(data))
+	assert.Nil(t, output.Body.Close())
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, HedgeStats{Requests: 1}, h.Stats())
+	assert.Len(t, h.samples, 1)
+
+	// the first one is slow, the hedged one wins
+	var canceled int32
+	calls = 0
+	h = NewHedger(HedgePolicy{InitialDelay: 10 * time.Millisecond})
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		ha := hedgeAttemptFrom(ctx)
+		assert.NotNil(t, ha)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			assert.False(t, ha.hedged)
+			select {
+			case <-ctx.Done():
+				atomic.StoreInt32(&canceled, 1)
+				assert.Equal(t, int32(1), atomic.LoadInt32(&ha.abandoned))
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return &ReaderRangeGetOutput{Body: body("slow")}, nil
+			}
+		}
+		assert.True(t, ha.hedged)
+		return &ReaderRangeGetOutput{Body: body("fast")}, nil
+	})
+	output, err = fn(context.Background(), HTTPRange{})
+	assert.Nil(t, err)
+	data, _ = io.ReadAll(output.Body)
+	assert.Equal(t, "fast", string(data))
+	output.Body.Close()
+	assert.Equal(t, HedgeStats{Requests: 1, Hedged: 1, Won: 1}, h.Stats())
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&canceled) == 1 }, time.Second, 5*time.Millisecond)
+
+	// fails before the deadline, not hedged
+	calls = 0
+	h = NewHedger(HedgePolicy{InitialDelay: time.Second})
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("fail")
+	})
+	_, err = fn(context.Background(), HTTPRange{})
+	assert.EqualError(t, err, "fail")
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, HedgeStats{Requests: 1}, h.Stats())
+	assert.Len(t, h.samples, 0)
+
+	// the first one fails after the hedged request sent, wait for the other one
+	calls = 0
+	h = NewHedger(HedgePolicy{InitialDelay: 10 * time.Millisecond})
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			time.Sleep(30 * time.Millisecond)
+			return nil, errors.New("fail")
+		}
+		time.Sleep(50 * time.Millisecond)
+		return &ReaderRangeGetOutput{Body: body("hedged")}, nil
+	})
+	output, err = fn(context.Background(), HTTPRange{})
+	assert.Nil(t, err)
+	data, _ = io.ReadAll(output.Body)
+	assert.Equal(t, "hedged", string(data))
+	assert.Equal(t, HedgeStats{Requests: 1, Hedged: 1, Won: 1}, h.Stats())
+
+	// both fail
+	calls = 0
+	h = NewHedger(HedgePolicy{InitialDelay: 10 * time.Millisecond})
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, fmt.Errorf("fail %v", atomic.AddInt32(&calls, 1))
+	})
+	_, err = fn(context.Background(), HTTPRange{})
+	assert.EqualError(t, err, "fail 2")
+	assert.Equal(t, HedgeStats{Requests: 1, Hedged: 1}, h.Stats())
+
+	// the first byte is late
+	h = NewHedger(HedgePolicy{InitialDelay: 10 * time.Millisecond})
+	calls = 0
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			pr, pw := io.Pipe()
+			go func() {
+				<-ctx.Done()
+				pw.CloseWithError(ctx.Err())
+			}()
+			return &ReaderRangeGetOutput{Body: pr}, nil
+		}
+		return &ReaderRangeGetOutput{Body: body("hedged")}, nil
+	})
+	output, err = fn(context.Background(), HTTPRange{})
+	assert.Nil(t, err)
+	data, _ = io.ReadAll(output.Body)
+	assert.Equal(t, "hedged", string(data))
+
+	// canceled
+	h = NewHedger(HedgePolicy{InitialDelay: 10 * time.Millisecond})
+	fn = h.Wrap(func(ctx context.Context, r HTTPRange) (*ReaderRangeGetOutput, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = fn(ctx, HTTPRange{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+type hedgeMockServer struct {
+	data []byte
+
+	// the GET requests at these offsets are stalled for the first time
+	stall map[int64]bool
+
+	mu       sync.Mutex
+	requests int
+	stalled  int
+}
+
+func (s *hedgeMockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	length := int64(len(s.data))
+	w.Header().Set(HTTPHeaderLastModified, "Fri, 24 Feb 2012 06:07:48 GMT")
+	w.Header().Set(HTTPHeaderETag, "\"fba9dede5f27731c9771645a3986****\"")
+	if r.Method == "HEAD" {
+		w.Header().Set(HTTPHeaderContentLength, fmt.Sprint(length))
+		w.WriteHeader(200)
+		return
+	}
+
+	httpRange, _ := ParseRange(r.Header.Get("Range"))
+	offset, count := httpRange.Offset, length-httpRange.Offset
+	if httpRange.Count > 0 && httpRange.Count < count {
+		count = httpRange.Count
+	}
+
+	s.mu.Lock()
+	s.requests++
+	stall := s.stall[offset]
+	if stall {
+		s.stall[offset] = false
+		s.stalled++
+	}
+	s.mu.Unlock()
+
+	if stall {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		return
+	}
+
+	cr := httpContentRange{Offset: offset, Count: count, Total: length}
+	w.Header().Set("Content-Range", ToString(cr.FormatHTTPContentRange()))
+	w.Header().Set(HTTPHeaderContentLength, fmt.Sprint(count))
+	w.WriteHeader(206)
+	w.Write(s.data[offset : offset+count])
+}
+
+func TestMockDownloader_Hedge(t *testing.T) {
+	partSize := int64(100 * 1024)
+	mock := &hedgeMockServer{
+		data:  []byte(randStr(int(partSize*3 + 123))),
+		stall: map[int64]bool{partSize: true},
+	}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	aggregator := NewMetricsAggregator()
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithMetricsCollector(aggregator)
+
+	client := NewClient(cfg)
+	d := NewDownloader(client, func(do *DownloaderOptions) {
+		do.ParallelNum = 2
+		do.PartSize = partSize
+		do.HedgePolicy = &HedgePolicy{InitialDelay: 50 * time.Millisecond}
+	})
+
+	localFile := randStr(8) + "-hedge"
+	defer os.Remove(localFile)
+	start := time.Now()
+	result, err := d.DownloadFile(context.TODO(), &GetObjectRequest{Bucket: Ptr("bucket"), Key: Ptr("key")}, localFile)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, int64(len(mock.data)), result.Written)
+	assert.Equal(t, int64(4), result.HedgeStats.Requests)
+	assert.Equal(t, int64(1), result.HedgeStats.Hedged)
+	assert.Equal(t, int64(1), result.HedgeStats.Won)
+	assert.Equal(t, 1, mock.stalled)
+	assert.Equal(t, 5, mock.requests)
+
+	data, err := os.ReadFile(localFile)
+	assert.Nil(t, err)
+	assert.Equal(t, mock.data, data)
+
+	// the hedged request is counted, the abandoned one is not an error
+	assert.Eventually(t, func() bool {
+		for _, s := range aggregator.Snapshot() {
+			if s.OpName == "GetObject" {
+				return s.Count == 5
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	for _, s := range aggregator.Snapshot() {
+		if s.OpName == "GetObject" {
+			assert.Equal(t, uint64(1), s.Hedges)
+			assert.Len(t, s.Errors, 0)
+		}
+	}
+}
+
+func TestMockOpenFile_PrefetchRead_Hedge(t *testing.T) {
+	chunkSize := int64(AsyncReadeBufferSize)
+	mock := &hedgeMockServer{
+		data:  []byte(randStr(int(chunkSize*3 + 123))),
+		stall: map[int64]bool{chunkSize: true},
+	}
+	server := httptest.NewServer(mock)
+	defer server.Close()
+
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL)
+
+	client := NewClient(cfg)
+	f, err := client.OpenFile(context.TODO(), "bucket", "key", func(oo *OpenOptions) {
+		oo.EnablePrefetch = true
+		oo.PrefetchThreshold = 0
+		oo.ChunkSize = chunkSize
+		oo.PrefetchNum = 3
+		oo.HedgePolicy = &HedgePolicy{InitialDelay: 50 * time.Millisecond}
+	})
+	assert.Nil(t, err)
+	defer f.Close()
+
+	start := time.Now()
+	data, err := io.ReadAll(f)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, mock.data, data)
+	assert.Equal(t, int64(1), f.HedgeStats().Hedged)
+	assert.Equal(t, int64(1), f.HedgeStats().Won)
+	assert.Equal(t, 1, mock.stalled)
+}
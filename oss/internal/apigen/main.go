@@ -0,0 +1,413 @@
+// Command apigen generates an interface listing the operations of a client type,
+// and a mock implementation of the interface which records the calls.
+//
+// An operation is an exported method whose first parameter is a context.Context
+// and whose last result is an error.
+//
+// Usage, from the directory of the client package:
+//
+//	go run ../internal/apigen -type TablesClient -interface TablesAPI -mock tablesmock
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	typeName      = flag.String("type", "Client", "the name of the client type")
+	interfaceName = flag.String("interface", "API", "the name of the generated interface")
+	output        = flag.String("output", "api.go", "the file of the generated interface")
+	mockPackage   = flag.String("mock", "", "the name of the mock package, which is generated in a sub directory of the same name")
+)
+
+const header = "// Code generated by apigen. DO NOT EDIT.\n\n"
+
+type operation struct {
+	name string
+	doc  []string
+	sig  *types.Signature
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("apigen: ")
+	flag.Parse()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg, files, err := loadPackage(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ops, err := collectOperations(pkg, files, *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = writeSource(filepath.Join(dir, *output), generateInterface(pkg, ops)); err != nil {
+		log.Fatal(err)
+	}
+
+	if *mockPackage != "" {
+		mockDir := filepath.Join(dir, *mockPackage)
+		if err = os.MkdirAll(mockDir, 0755); err != nil {
+			log.Fatal(err)
+		}
+		if err = writeSource(filepath.Join(mockDir, "mock.go"), generateMock(pkg, ops)); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func loadPackage(dir string) (*types.Package, []*ast.File, error) {
+	bpkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bpkg.GoFiles {
+		// skip the previous output, the interface is generated from the methods only
+		if name == *output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, f)
+	}
+
+	// build.ImportDir does not know the import path in module mode
+	path, err := exec.Command("go", "list", "-f", "{{.ImportPath}}", dir).Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("go list: %w", err)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(strings.TrimSpace(string(path)), fset, files, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg, files, nil
+}
+
+func collectOperations(pkg *types.Package, files []*ast.File, name string) ([]operation, error) {
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s is not found in package %s", name, pkg.Path())
+	}
+
+	docs := map[string][]string{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Doc == nil || receiverName(fn) != name {
+				continue
+			}
+			docs[fn.Name.Name] = strings.Split(strings.TrimSpace(fn.Doc.Text()), "\n")
+		}
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+	var ops []operation
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj().(*types.Func)
+		sig := fn.Type().(*types.Signature)
+		if !fn.Exported() || !isOperation(sig) {
+			continue
+		}
+		ops = append(ops, operation{name: fn.Name(), doc: docs[fn.Name()], sig: sig})
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].name < ops[j].name })
+	return ops, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func isOperation(sig *types.Signature) bool {
+	params, results := sig.Params(), sig.Results()
+	if params.Len() == 0 || results.Len() == 0 {
+		return false
+	}
+	return types.TypeString(params.At(0).Type(), nil) == "context.Context" &&
+		types.TypeString(results.At(results.Len()-1).Type(), nil) == "error"
+}
+
+func qualifier(pkg *types.Package, local bool) types.Qualifier {
+	return func(p *types.Package) string {
+		if local && p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+}
+
+// signature returns the parameters and results of the operation,
+// and the arguments to forward the parameters to another function.
+func signature(sig *types.Signature, q types.Qualifier) (params, results, args string) {
+	var ps, as []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		v := sig.Params().At(i)
+		name := v.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("p%d", i)
+		}
+		typ := types.TypeString(v.Type(), q)
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typ = "..." + types.TypeString(v.Type().(*types.Slice).Elem(), q)
+			name += "..."
+			ps = append(ps, strings.TrimSuffix(name, "...")+" "+typ)
+		} else {
+			ps = append(ps, name+" "+typ)
+		}
+		as = append(as, name)
+	}
+
+	var rs []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		rs = append(rs, types.TypeString(sig.Results().At(i).Type(), q))
+	}
+	results = strings.Join(rs, ", ")
+	if len(rs) > 1 {
+		results = "(" + results + ")"
+	}
+	return strings.Join(ps, ", "), results, strings.Join(as, ", ")
+}
+
+func collectImports(pkg *types.Package, ops []operation, local bool) []string {
+	seen := map[string]bool{"context": true}
+	if !local {
+		seen[pkg.Path()] = true
+	}
+	var visit func(t types.Type)
+	visit = func(t types.Type) {
+		switch t := t.(type) {
+		case *types.Named:
+			if p := t.Obj().Pkg(); p != nil && (!local || p != pkg) {
+				seen[p.Path()] = true
+			}
+		case *types.Pointer:
+			visit(t.Elem())
+		case *types.Slice:
+			visit(t.Elem())
+		case *types.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		case *types.Signature:
+			for i := 0; i < t.Params().Len(); i++ {
+				visit(t.Params().At(i).Type())
+			}
+			for i := 0; i < t.Results().Len(); i++ {
+				visit(t.Results().At(i).Type())
+			}
+		}
+	}
+	for _, op := range ops {
+		visit(op.sig)
+	}
+
+	var paths []string
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// writeImports writes the standard library imports first, then the others.
+func writeImports(buf *bytes.Buffer, paths []string) {
+	sort.Strings(paths)
+	var std, others []string
+	for _, p := range paths {
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			others = append(others, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+	buf.WriteString("import (\n")
+	for i, group := range [][]string{std, others} {
+		if i > 0 && len(group) > 0 && len(std) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, p := range group {
+			fmt.Fprintf(buf, "\t%q\n", p)
+		}
+	}
+	buf.WriteString(")\n\n")
+}
+
+func generateInterface(pkg *types.Package, ops []operation) []byte {
+	q := qualifier(pkg, true)
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	writeImports(&buf, collectImports(pkg, ops, true))
+
+	fmt.Fprintf(&buf, "// %s lists the operations of %s, so that callers can depend on an interface,\n", *interfaceName, *typeName)
+	fmt.Fprintf(&buf, "// decorate it or replace it with a mock in tests.\n")
+	fmt.Fprintf(&buf, "type %s interface {\n", *interfaceName)
+	for i, op := range ops {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		for _, line := range op.doc {
+			fmt.Fprintf(&buf, "\t// %s\n", line)
+		}
+		params, results, _ := signature(op.sig, q)
+		fmt.Fprintf(&buf, "\t%s(%s) %s\n", op.name, params, results)
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(&buf, "var _ %s = (*%s)(nil)\n", *interfaceName, *typeName)
+	return buf.Bytes()
+}
+
+func generateMock(pkg *types.Package, ops []operation) []byte {
+	q := qualifier(pkg, false)
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "// Package %s provides a mock implementation of %s.%s which records the calls.\n", *mockPackage, pkg.Name(), *interfaceName)
+	fmt.Fprintf(&buf, "package %s\n\n", *mockPackage)
+	writeImports(&buf, append(collectImports(pkg, ops, false), "fmt", "sync"))
+
+	fmt.Fprintf(&buf, `// Call is a call of an operation, the context is not recorded.
+type Call struct {
+	Operation string
+	Args      []any
+}
+
+// %[1]s implements %[2]s.%[3]s. Each operation calls the function field of the same name with the Func suffix,
+// an operation whose function is not set returns an error.
+type %[1]s struct {
+	mu    sync.Mutex
+	calls []Call
+
+`, *typeName, pkg.Name(), *interfaceName)
+	for _, op := range ops {
+		params, results, _ := signature(op.sig, q)
+		fmt.Fprintf(&buf, "\t%sFunc func(%s) %s\n", op.name, params, results)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(&buf, `var _ %[2]s.%[3]s = (*%[1]s)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *%[1]s) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of the operation in order.
+func (m *%[1]s) CallsOf(operation string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls.
+func (m *%[1]s) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *%[1]s) record(operation string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Operation: operation, Args: args})
+}
+
+func notImplemented(operation string) error {
+	return fmt.Errorf("%[4]s: %%s is not implemented, set %%sFunc", operation, operation)
+}
+`, *typeName, pkg.Name(), *interfaceName, *mockPackage)
+
+	for _, op := range ops {
+		params, results, args := signature(op.sig, q)
+		// the context is not recorded, the variadic parameter is recorded as a slice
+		recorded := strings.Join(strings.Split(args, ", ")[1:], ", ")
+		recorded = strings.TrimSuffix(recorded, "...")
+
+		fmt.Fprintf(&buf, "\n// %s records the call and calls %sFunc.\n", op.name, op.name)
+		fmt.Fprintf(&buf, "func (m *%s) %s(%s) %s {\n", *typeName, op.name, params, results)
+		if recorded != "" {
+			fmt.Fprintf(&buf, "\tm.record(%q, %s)\n", op.name, recorded)
+		} else {
+			fmt.Fprintf(&buf, "\tm.record(%q)\n", op.name)
+		}
+		fmt.Fprintf(&buf, "\tif m.%sFunc == nil {\n", op.name)
+		var zeros []string
+		for i := 0; i < op.sig.Results().Len()-1; i++ {
+			zeros = append(zeros, zeroValue(op.sig.Results().At(i).Type(), q))
+		}
+		zeros = append(zeros, fmt.Sprintf("notImplemented(%q)", op.name))
+		fmt.Fprintf(&buf, "\t\treturn %s\n", strings.Join(zeros, ", "))
+		buf.WriteString("\t}\n")
+		fmt.Fprintf(&buf, "\treturn m.%sFunc(%s)\n", op.name, args)
+		buf.WriteString("}\n")
+	}
+
+	return buf.Bytes()
+}
+
+func zeroValue(t types.Type, q types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		}
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, q) + "{}"
+	}
+	return "nil"
+}
+
+func writeSource(path string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("format %s: %w\n%s", path, err, src)
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
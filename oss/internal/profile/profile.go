@@ -0,0 +1,147 @@
+// Package profile parses the files of named profiles, the config file of the Alibaba Cloud CLI
+// and INI files, into the same form, so that the profiles can be read without regard to the format.
+package profile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Profile is a named profile, the keys of the values are in lower case.
+type Profile struct {
+	Name   string
+	Values map[string]string
+}
+
+// Get returns the value of the key, the key is case-insensitive.
+func (p *Profile) Get(key string) string {
+	return p.Values[strings.ToLower(key)]
+}
+
+// File is a parsed profile file.
+type File struct {
+	Path string
+
+	// The current profile of the CLI config file, empty for INI files.
+	Current string
+
+	Profiles map[string]*Profile
+}
+
+// Profile returns the named profile.
+func (f *File) Profile(name string) (*Profile, error) {
+	if p, ok := f.Profiles[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("profile %s is not found in %s", name, f.Path)
+}
+
+// Load loads the file, a file with the .json extension is parsed as the CLI config file, otherwise as an INI file.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		return parseCli(path, data)
+	}
+	return parseIni(path, data)
+}
+
+/*
+the config file of the Alibaba Cloud CLI
+
+	{
+		"current": "default",
+		"profiles": [
+			{
+				"name": "default",
+				"mode": "AK",
+				"access_key_id": "ak",
+				"access_key_secret": "sk",
+				"region_id": "cn-hangzhou"
+			}
+		]
+	}
+*/
+func parseCli(path string, data []byte) (*File, error) {
+	var cfg struct {
+		Current  string                   `json:"current"`
+		Profiles []map[string]interface{} `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	f := &File{Path: path, Current: cfg.Current, Profiles: map[string]*Profile{}}
+	for _, values := range cfg.Profiles {
+		p := &Profile{Values: map[string]string{}}
+		for k, v := range values {
+			switch v := v.(type) {
+			case nil:
+			case string:
+				p.Values[strings.ToLower(k)] = v
+			case float64, bool:
+				p.Values[strings.ToLower(k)] = fmt.Sprint(v)
+			}
+		}
+		p.Name = p.Values["name"]
+		if p.Name != "" {
+			f.Profiles[p.Name] = p
+		}
+	}
+	return f, nil
+}
+
+/*
+an INI file, a section is a profile, named "name" or "profile name"
+[default]
+mode = AK
+access_key_id = ak
+access_key_secret = sk
+region = cn-hangzhou
+*/
+func parseIni(path string, data []byte) (*File, error) {
+	f := &File{Path: path, Profiles: map[string]*Profile{}}
+	var current *Profile
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("failed to parse config file %s, line %d: invalid section", path, n)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if strings.HasPrefix(name, "profile ") {
+				name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+			}
+			if name == "" {
+				return nil, fmt.Errorf("failed to parse config file %s, line %d: empty section name", path, n)
+			}
+			if current = f.Profiles[name]; current == nil {
+				current = &Profile{Name: name, Values: map[string]string{}}
+				f.Profiles[name] = current
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("failed to parse config file %s, line %d: expected key = value", path, n)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("failed to parse config file %s, line %d: key outside of a section", path, n)
+		}
+		current.Values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
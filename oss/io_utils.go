@@ -1,10 +1,12 @@
 package oss
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"sync"
 	"time"
 )
@@ -758,10 +760,30 @@ type teeReadNopCloser struct {
 	reader  io.Reader
 	writers []io.Writer
 	mark    int64
+
+	// the bytes read from a non-seekable reader, used to replay them on Reset
+	replay *replayBuffer
+
+	// the reader of the replayed bytes, nil if not replaying
+	replaying io.Reader
 }
 
 func (t *teeReadNopCloser) Read(p []byte) (n int, err error) {
-	n, err = t.reader.Read(p)
+	if t.replaying != nil {
+		n, err = t.replaying.Read(p)
+		if err == io.EOF {
+			t.replaying = nil
+			err = nil
+		}
+		if n == 0 && err == nil {
+			return t.Read(p)
+		}
+	} else {
+		n, err = t.reader.Read(p)
+		if n > 0 && t.replay != nil {
+			t.replay.Write(p[:n])
+		}
+	}
 	if n > 0 {
 		for _, w := range t.writers {
 			if nn, err := w.Write(p[:n]); err != nil {
@@ -790,9 +812,30 @@ func (t *teeReadNopCloser) IsSeekable() bool {
 	return ok
 }
 
+// IsReplayable tests if the bytes read from this reader can be read again after Reset,
+// either by seeking or from the replay buffer.
+func (t *teeReadNopCloser) IsReplayable() bool {
+	return t.IsSeekable() || (t.replay != nil && !t.replay.overflow)
+}
+
 // MarkSupported tests if this reader supports the Mark and Reset methods.
 func (t *teeReadNopCloser) MarkSupported() bool {
-	return t.IsSeekable()
+	return t.IsReplayable()
+}
+
+// enableReplay buffers up to limit bytes read from a non-seekable reader so that they can be replayed,
+// at most memLimit bytes are kept in memory and the rest spill to a temporary file.
+func (t *teeReadNopCloser) enableReplay(limit, memLimit int64) {
+	if limit <= 0 || t.IsSeekable() {
+		return
+	}
+	if memLimit < 0 || memLimit > limit {
+		memLimit = limit
+	}
+	t.replay = &replayBuffer{
+		limit:    limit,
+		memLimit: memLimit,
+	}
 }
 
 // Mark marks the current position in this reader. A subsequent call to
@@ -803,6 +846,10 @@ func (t *teeReadNopCloser) Mark() {
 		if pos, err := s.Seek(0, io.SeekCurrent); err == nil {
 			t.mark = pos
 		}
+	} else if t.replay != nil {
+		t.replay.release()
+		t.replay.size = 0
+		t.mark = 0
 	}
 }
 
@@ -817,11 +864,14 @@ func (t *teeReadNopCloser) Reset() error {
 		return fmt.Errorf("Mark is not called yet")
 	}
 
-	// seek to the last marked position
+	// seek to the last marked position, or replay the buffered bytes
 	if s, ok := t.reader.(io.Seeker); ok {
 		if _, err := s.Seek(t.mark, io.SeekStart); err != nil {
 			return err
 		}
+	} else {
+		t.replaying = t.replay.reader()
+		t.replay.replays++
 	}
 
 	// reset writer
@@ -838,6 +888,91 @@ func (t *teeReadNopCloser) Reset() error {
 	return nil
 }
 
+// replayBuffer keeps the bytes read from a non-seekable reader.
+// It stops buffering once the size exceeds the limit, and the bytes can't be replayed any more.
+type replayBuffer struct {
+	limit    int64
+	memLimit int64
+
+	mem  []byte
+	file *os.File
+	size int64
+
+	// the number of times the bytes are replayed
+	replays int
+
+	// the limit is exceeded or the temporary file fails
+	overflow bool
+	err      error
+}
+
+func (b *replayBuffer) Write(p []byte) (int, error) {
+	if b.overflow {
+		return len(p), nil
+	}
+
+	if b.size+int64(len(p)) > b.limit {
+		b.discard(nil)
+		return len(p), nil
+	}
+
+	data := p
+	if room := b.memLimit - int64(len(b.mem)); room > 0 {
+		if int64(len(data)) < room {
+			room = int64(len(data))
+		}
+		b.mem = append(b.mem, data[:room]...)
+		data = data[room:]
+	}
+
+	if len(data) > 0 {
+		if b.file == nil {
+			f, err := os.CreateTemp("", "oss-replay-")
+			if err != nil {
+				b.discard(err)
+				return len(p), nil
+			}
+			b.file = f
+		}
+		if _, err := b.file.Write(data); err != nil {
+			b.discard(err)
+			return len(p), nil
+		}
+	}
+
+	b.size += int64(len(p))
+	return len(p), nil
+}
+
+// spilled returns the number of bytes in the temporary file.
+func (b *replayBuffer) spilled() int64 {
+	return b.size - int64(len(b.mem))
+}
+
+func (b *replayBuffer) reader() io.Reader {
+	r := io.Reader(bytes.NewReader(b.mem))
+	if b.file != nil {
+		r = io.MultiReader(r, io.NewSectionReader(b.file, 0, b.spilled()))
+	}
+	return r
+}
+
+func (b *replayBuffer) discard(err error) {
+	b.overflow = true
+	b.err = err
+	b.release()
+}
+
+// release frees the memory and removes the temporary file.
+func (b *replayBuffer) release() {
+	b.mem = nil
+	if b.file != nil {
+		b.file.Close()
+		os.Remove(b.file.Name())
+		b.file = nil
+	}
+}
+
 type DiscardReadCloser struct {
 	RC      io.ReadCloser
 	Discard int
@@ -1385,3 +1385,84 @@ func TestGetReaderLen(t *testing.T) {
 	n = GetReaderLen(sef)
 	assert.Equal(t, int64(-1), n)
 }
+
+func TestTeeReadNopCloser_Replay(t *testing.T) {
+	data := "0123456789abcdefghij"
+
+	// disabled
+	tee := TeeReadNopCloser(io.MultiReader(strings.NewReader(data))).(*teeReadNopCloser)
+	assert.False(t, tee.IsSeekable())
+	assert.False(t, tee.IsReplayable())
+	tee.Mark()
+	assert.NotNil(t, tee.Reset())
+
+	// seekable reader doesn't use replay buffer
+	tee = TeeReadNopCloser(strings.NewReader(data)).(*teeReadNopCloser)
+	tee.enableReplay(100, -1)
+	assert.Nil(t, tee.replay)
+	assert.True(t, tee.IsReplayable())
+
+	// in memory
+	tracker := &bytes.Buffer{}
+	tee = TeeReadNopCloser(iotest.HalfReader(strings.NewReader(data)), tracker).(*teeReadNopCloser)
+	tee.enableReplay(100, -1)
+	tee.Mark()
+	assert.True(t, tee.IsReplayable())
+	p := make([]byte, 8)
+	n, err := io.ReadFull(tee, p)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, int64(8), tee.replay.size)
+
+	assert.Nil(t, tee.Reset())
+	got, err := io.ReadAll(tee)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(got))
+	assert.Equal(t, int64(20), tee.replay.size)
+	assert.Equal(t, int64(0), tee.replay.spilled())
+	assert.Nil(t, tee.replay.file)
+
+	assert.Nil(t, tee.Reset())
+	got, err = io.ReadAll(tee)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(got))
+	assert.Equal(t, 2, tee.replay.replays)
+	// tracker is reset with the reader
+	assert.Equal(t, data, tracker.String())
+
+	// spill to temporary file
+	tee = TeeReadNopCloser(iotest.OneByteReader(strings.NewReader(data))).(*teeReadNopCloser)
+	tee.enableReplay(100, 5)
+	tee.Mark()
+	got, err = io.ReadAll(tee)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(got))
+	assert.Equal(t, 5, len(tee.replay.mem))
+	assert.Equal(t, int64(15), tee.replay.spilled())
+	assert.NotNil(t, tee.replay.file)
+	name := tee.replay.file.Name()
+	_, err = os.Stat(name)
+	assert.Nil(t, err)
+
+	assert.Nil(t, tee.Reset())
+	got, err = io.ReadAll(tee)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(got))
+
+	tee.replay.release()
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+
+	// exceeds the limit
+	tee = TeeReadNopCloser(io.MultiReader(strings.NewReader(data))).(*teeReadNopCloser)
+	tee.enableReplay(10, 5)
+	tee.Mark()
+	got, err = io.ReadAll(tee)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(got))
+	assert.True(t, tee.replay.overflow)
+	assert.False(t, tee.IsReplayable())
+	assert.Nil(t, tee.replay.mem)
+	assert.Nil(t, tee.replay.file)
+	assert.NotNil(t, tee.Reset())
+}
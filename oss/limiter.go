@@ -2,6 +2,7 @@ package oss
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -40,5 +41,80 @@ func newEmptyTokenBucket(bandwidth int64) *rate.Limiter {
 }
 
 func (tb *BwTokenBucket) LimitBandwidth(n int) {
+	// WaitN fails at once if n exceeds the burst size, so waits for it in bursts
+	burst := tb.Limiter.Burst()
+	for n > burst {
+		tb.Limiter.WaitN(context.Background(), burst)
+		n -= burst
+	}
 	tb.Limiter.WaitN(context.Background(), n)
 }
+
+// NewBwTokenBuckets creates the token buckets to limit the bandwidth of the operations, in kBytes/s.
+// A limit which is not positive is unlimited.
+func NewBwTokenBuckets(uploadBandwidthlimit, downloadBandwidthlimit int64) BwTokenBuckets {
+	var buckets BwTokenBuckets
+	if uploadBandwidthlimit > 0 {
+		buckets[BwTokenBucketSlotTx] = newBwTokenBucket(uploadBandwidthlimit * 1024)
+	}
+	if downloadBandwidthlimit > 0 {
+		buckets[BwTokenBucketSlotRx] = newBwTokenBucket(downloadBandwidthlimit * 1024)
+	}
+	return buckets
+}
+
+// WithBwTokenBuckets limits the bandwidth of the request bodies and the response bodies with the token buckets.
+// The token buckets can be shared by many operations to limit their total bandwidth, such as the parts of the uploads.
+func WithBwTokenBuckets(buckets BwTokenBuckets) func(*Options) {
+	return WithMiddlewares(Middleware{
+		Name: "BandwidthLimit",
+		Step: MiddlewareStepInitialize,
+		Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+			if tb := buckets[BwTokenBucketSlotTx]; tb != nil {
+				// track the request body without changing the caller's input
+				orig := mctx.Input
+				input := *orig
+				input.OpMetadata = input.OpMetadata.Clone()
+				input.OpMetadata.Add(OpMetaKeyRequestBodyTracker, &bwLimitWriter{tb: tb})
+				mctx.Input = &input
+				defer func() {
+					mctx.Input = orig
+					if mctx.Output != nil {
+						mctx.Output.Input = orig
+					}
+				}()
+			}
+			err := next(ctx, mctx)
+			if tb := buckets[BwTokenBucketSlotRx]; tb != nil && mctx.Output != nil && mctx.Output.Body != nil {
+				mctx.Output.Body = &bwLimitReadCloser{body: mctx.Output.Body, tb: tb}
+			}
+			return err
+		},
+	})
+}
+
+type bwLimitWriter struct {
+	tb *BwTokenBucket
+}
+
+func (w *bwLimitWriter) Write(p []byte) (int, error) {
+	w.tb.LimitBandwidth(len(p))
+	return len(p), nil
+}
+
+type bwLimitReadCloser struct {
+	body io.ReadCloser
+	tb   *BwTokenBucket
+}
+
+func (r *bwLimitReadCloser) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if n > 0 {
+		r.tb.LimitBandwidth(n)
+	}
+	return n, err
+}
+
+func (r *bwLimitReadCloser) Close() error {
+	return r.body.Close()
+}
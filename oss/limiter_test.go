@@ -0,0 +1,99 @@
+package oss
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBwTokenBucket_LimitBandwidth(t *testing.T) {
+	// 100 MiB/s, whose burst size is 4 MiB
+	tb := newBwTokenBucket(100 * 1024 * 1024)
+	assert.Equal(t, 4*1024*1024, tb.Limiter.Burst())
+	start := time.Now()
+	tb.LimitBandwidth(6 * 1024 * 1024)
+	assert.True(t, time.Since(start) > 40*time.Millisecond)
+}
+
+func TestNewBwTokenBuckets(t *testing.T) {
+	buckets := NewBwTokenBuckets(0, 0)
+	assert.Nil(t, buckets[BwTokenBucketSlotTx])
+	assert.Nil(t, buckets[BwTokenBucketSlotRx])
+
+	buckets = NewBwTokenBuckets(100, 200)
+	assert.Equal(t, int64(100*1024), buckets[BwTokenBucketSlotTx].Bandwidth)
+	assert.Equal(t, int64(200*1024), buckets[BwTokenBucketSlotRx].Bandwidth)
+}
+
+func TestWithBwTokenBuckets(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 20*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			io.Copy(io.Discard, r.Body)
+			w.WriteHeader(200)
+		case "GET":
+			w.Header().Set(HTTPHeaderContentLength, "204800")
+			w.WriteHeader(200)
+			w.Write(data)
+		}
+	}))
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	// 200 KB at 400 kBytes/s, the token buckets are empty at first
+	buckets := NewBwTokenBuckets(400, 0)
+	start := time.Now()
+	_, err := client.PutObject(context.TODO(), &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   bytes.NewReader(data),
+	}, WithBwTokenBuckets(buckets))
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) > 300*time.Millisecond)
+
+	buckets = NewBwTokenBuckets(0, 400)
+	start = time.Now()
+	result, err := client.GetObject(context.TODO(), &GetObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}, WithBwTokenBuckets(buckets))
+	assert.Nil(t, err)
+	got, err := io.ReadAll(result.Body)
+	assert.Nil(t, err)
+	assert.Nil(t, result.Body.Close())
+	assert.Equal(t, data, got)
+	assert.True(t, time.Since(start) > 300*time.Millisecond)
+
+	// unlimited
+	start = time.Now()
+	_, err = client.PutObject(context.TODO(), &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   bytes.NewReader(data),
+	}, WithBwTokenBuckets(NewBwTokenBuckets(0, 0)))
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 300*time.Millisecond)
+
+	// the input of the caller is unchanged, even if it is reused
+	input := &OperationInput{
+		OpName: "PutObject",
+		Method: "PUT",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   bytes.NewReader(data),
+	}
+	for i := 0; i < 2; i++ {
+		input.Body = bytes.NewReader(data)
+		output, err := client.InvokeOperation(context.TODO(), input, WithBwTokenBuckets(NewBwTokenBuckets(1024, 0)))
+		assert.Nil(t, err)
+		assert.Same(t, input, output.Input)
+		assert.False(t, input.OpMetadata.Has(OpMetaKeyRequestBodyTracker))
+	}
+}
@@ -3,6 +3,7 @@ package oss
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 )
 
@@ -19,6 +20,20 @@ func (f LogPrinterFunc) Print(v ...any) {
 	f(v...)
 }
 
+// A StructuredLogPrinter is a interface for the SDK to log messages with key/value pairs to,
+// e.g. a thin wrapper around log/slog.Logger.
+type StructuredLogPrinter interface {
+	Print(level int, msg string, keyvals ...any)
+}
+
+// A StructuredLogPrinterFunc is a convenience type to wrap it so the StructuredLogPrinter interface can be used.
+type StructuredLogPrinterFunc func(level int, msg string, keyvals ...any)
+
+// Print calls the wrapped function with the arguments provided
+func (f StructuredLogPrinterFunc) Print(level int, msg string, keyvals ...any) {
+	f(level, msg, keyvals...)
+}
+
 // Define the level of the output log
 const (
 	LogOff = iota
@@ -110,6 +125,141 @@ func (l *standardLogger) Level() int {
 	return l.level
 }
 
+// NewStructuredLogger returns a Logger which prints messages through the StructuredLogPrinter.
+func NewStructuredLogger(level int, printer StructuredLogPrinter) Logger {
+	if level <= LogOff || printer == nil {
+		return &nopLogger{}
+	}
+
+	return &structuredLogger{
+		level:   level,
+		printer: printer,
+	}
+}
+
+type structuredLogger struct {
+	level   int
+	printer StructuredLogPrinter
+}
+
+func (l *structuredLogger) printf(level int, format string, v ...any) {
+	if l.level < level {
+		return
+	}
+	l.printer.Print(level, fmt.Sprintf(format, v...))
+}
+
+func (l *structuredLogger) Debugf(format string, v ...any) { l.printf(LogDebug, format, v...) }
+func (l *structuredLogger) Infof(format string, v ...any)  { l.printf(LogInfo, format, v...) }
+func (l *structuredLogger) Warnf(format string, v ...any)  { l.printf(LogWarn, format, v...) }
+func (l *structuredLogger) Errorf(format string, v ...any) { l.printf(LogError, format, v...) }
+func (l *structuredLogger) Level() int                     { return l.level }
+
+// The placeholder of the redacted values in logs
+const logRedactedValue = "******"
+
+// the http headers whose values are never written to logs
+var defaultLogRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"x-oss-security-token",
+	"x-oss-server-side-encryption-customer-key",
+	"x-oss-copy-source-server-side-encryption-customer-key",
+}
+
+// the query parameters whose values are never written to logs
+var defaultLogRedactQueries = []string{
+	"Signature",
+	"security-token",
+	"x-oss-signature",
+	"x-oss-security-token",
+}
+
+// DefaultLogRedactHeaders returns the http headers whose values are never written to logs.
+func DefaultLogRedactHeaders() []string {
+	return append([]string(nil), defaultLogRedactHeaders...)
+}
+
+// DefaultLogRedactQueries returns the query parameters whose values are never written to logs.
+func DefaultLogRedactQueries() []string {
+	return append([]string(nil), defaultLogRedactQueries...)
+}
+
+type logRedactor struct {
+	headerKeys map[string]struct{}
+	queryKeys  map[string]struct{}
+}
+
+func newLogRedactor(headers []string, queries []string) *logRedactor {
+	r := &logRedactor{
+		headerKeys: map[string]struct{}{},
+		queryKeys:  map[string]struct{}{},
+	}
+	for _, list := range [][]string{defaultLogRedactHeaders, headers} {
+		for _, h := range list {
+			r.headerKeys[strings.ToLower(h)] = struct{}{}
+		}
+	}
+	for _, list := range [][]string{defaultLogRedactQueries, queries} {
+		for _, q := range list {
+			r.queryKeys[strings.ToLower(q)] = struct{}{}
+		}
+	}
+	return r
+}
+
+func (r *logRedactor) redactHeader(key string, values []string) string {
+	if _, ok := r.headerKeys[strings.ToLower(key)]; ok {
+		return logRedactedValue
+	}
+	return strings.Join(values, " ")
+}
+
+func (r *logRedactor) redactHeaders(header http.Header) map[string]string {
+	m := make(map[string]string, len(header))
+	for k, v := range header {
+		m[k] = r.redactHeader(k, v)
+	}
+	return m
+}
+
+func (r *logRedactor) redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		if _, ok := r.queryKeys[strings.ToLower(key)]; ok {
+			params[i] = key + "=" + logRedactedValue
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func (r *logRedactor) redactStringToSign(stringToSign string, securityToken string) string {
+	lines := strings.Split(stringToSign, "\n")
+	for i, line := range lines {
+		key, _, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if _, ok := r.headerKeys[strings.ToLower(key)]; ok {
+			lines[i] = key + ":" + logRedactedValue
+		}
+	}
+	stringToSign = strings.Join(lines, "\n")
+	if securityToken != "" {
+		stringToSign = strings.ReplaceAll(stringToSign, securityToken, logRedactedValue)
+	}
+	return stringToSign
+}
+
 func ToLogLevel(s string) int {
 	s = strings.ToLower(s)
 	switch s {
@@ -128,3 +278,4 @@ func ToLogLevel(s string) int {
 
 var _ Logger = (*nopLogger)(nil)
 var _ Logger = (*standardLogger)(nil)
+var _ Logger = (*structuredLogger)(nil)
@@ -2,9 +2,13 @@ package oss
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,3 +98,164 @@ func TestStrToLogLevel(t *testing.T) {
 	assert.Equal(t, LogError, ToLogLevel("err"))
 	assert.Equal(t, LogError, ToLogLevel("eRR"))
 }
+
+func TestStructuredLogger(t *testing.T) {
+	l := NewStructuredLogger(LogDebug, nil)
+	assert.Equal(t, LogOff, l.Level())
+
+	type entry struct {
+		level   int
+		msg     string
+		keyvals []any
+	}
+	var entries []entry
+	l = NewStructuredLogger(LogInfo, StructuredLogPrinterFunc(func(level int, msg string, keyvals ...any) {
+		entries = append(entries, entry{level, msg, keyvals})
+	}))
+	assert.Equal(t, LogInfo, l.Level())
+	l.Debugf("%s", "123")
+	l.Infof("%s", "123")
+	l.Warnf("%s", "123")
+	l.Errorf("%s", "123")
+	assert.Equal(t, []entry{{LogInfo, "123", nil}, {LogWarn, "123", nil}, {LogError, "123", nil}}, entries)
+}
+
+func TestLogRedactor(t *testing.T) {
+	r := newLogRedactor([]string{"X-Custom-Secret"}, []string{"custom-token"})
+
+	assert.Equal(t, logRedactedValue, r.redactHeader("Authorization", []string{"OSS4-HMAC-SHA256 Credential=ak"}))
+	assert.Equal(t, logRedactedValue, r.redactHeader("X-Oss-Security-Token", []string{"token"}))
+	assert.Equal(t, logRedactedValue, r.redactHeader("x-custom-secret", []string{"secret"}))
+	assert.Equal(t, "a b", r.redactHeader("X-Oss-Meta-Key", []string{"a", "b"}))
+
+	h := http.Header{}
+	h.Set("Authorization", "OSS ak:signature")
+	h.Set("Content-Type", "text/plain")
+	assert.Equal(t, map[string]string{
+		"Authorization": logRedactedValue,
+		"Content-Type":  "text/plain",
+	}, r.redactHeaders(h))
+
+	assert.Equal(t, "", r.redactQuery(""))
+	assert.Equal(t, "acl&versionId=123", r.redactQuery("acl&versionId=123"))
+	assert.Equal(t,
+		"x-oss-credential=ak%2F20231115%2Fcn-hangzhou%2Foss%2Faliyun_v4_request&x-oss-security-token="+logRedactedValue+"&x-oss-signature="+logRedactedValue,
+		r.redactQuery("x-oss-credential=ak%2F20231115%2Fcn-hangzhou%2Foss%2Faliyun_v4_request&x-oss-security-token=token&x-oss-signature=abc"))
+	assert.Equal(t,
+		"Expires=1699808204&OSSAccessKeyId=ak&Signature="+logRedactedValue+"&security-token="+logRedactedValue+"&custom-token="+logRedactedValue,
+		r.redactQuery("Expires=1699808204&OSSAccessKeyId=ak&Signature=sig&security-token=token&custom-token=abc"))
+
+	assert.Equal(t,
+		"PUT\n\n\nDate\nx-custom-secret:"+logRedactedValue+"\nx-oss-meta-key:value\nx-oss-security-token:"+logRedactedValue+"\n/bucket/key?security-token="+logRedactedValue,
+		r.redactStringToSign("PUT\n\n\nDate\nx-custom-secret:secret\nx-oss-meta-key:value\nx-oss-security-token:STS.secret\n/bucket/key?security-token=STS.secret", "STS.secret"))
+
+	// the default lists can not be changed by the callers
+	headers := DefaultLogRedactHeaders()
+	assert.Contains(t, headers, "Authorization")
+	headers[0] = "X-Oss-Meta-Key"
+	queries := DefaultLogRedactQueries()
+	assert.Contains(t, queries, "Signature")
+	queries[0] = "versionId"
+	assert.Equal(t, defaultLogRedactHeaders, DefaultLogRedactHeaders())
+	assert.Equal(t, defaultLogRedactQueries, DefaultLogRedactQueries())
+	r = newLogRedactor(nil, nil)
+	assert.Equal(t, logRedactedValue, r.redactHeader("Authorization", []string{"OSS ak:signature"}))
+	assert.Equal(t, "a", r.redactHeader("X-Oss-Meta-Key", []string{"a"}))
+	assert.Equal(t, "Signature="+logRedactedValue+"&versionId=123", r.redactQuery("Signature=sig&versionId=123"))
+}
+
+func TestLogRedaction_Client(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-oss-request-id", "id-1234")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	for _, version := range []SignatureVersionType{SignatureVersionV1, SignatureVersionV4} {
+		buff := bytes.NewBuffer(nil)
+		cfg := LoadDefaultConfig().
+			WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "secret-sk", "secret-token")).
+			WithRegion("cn-hangzhou").
+			WithEndpoint(server.URL).
+			WithSignatureVersion(version).
+			WithLogLevel(LogDebug).
+			WithLogRedactHeaders([]string{"x-oss-meta-secret"}).
+			WithLogPrinter(LogPrinterFunc(func(a ...any) {
+				fmt.Fprintln(buff, a...)
+			}))
+
+		client := NewClient(cfg)
+		_, err := client.InvokeOperation(context.TODO(), &OperationInput{
+			OpName:  "HeadObject",
+			Method:  "HEAD",
+			Bucket:  Ptr("bucket"),
+			Key:     Ptr("key"),
+			Headers: map[string]string{"x-oss-meta-secret": "secret-meta"},
+		})
+		assert.Nil(t, err)
+
+		out := buff.String()
+		assert.Contains(t, out, "StringToSign")
+		assert.Contains(t, out, "Authorization:"+logRedactedValue)
+		assert.Contains(t, out, "X-Oss-Security-Token:"+logRedactedValue)
+		assert.Contains(t, out, "X-Oss-Meta-Secret:"+logRedactedValue)
+		assert.Contains(t, out, "Set-Cookie:"+logRedactedValue)
+		assert.NotContains(t, out, "secret-token")
+		assert.NotContains(t, out, "secret-sk")
+		assert.NotContains(t, out, "secret-meta")
+		assert.NotContains(t, out, "secret-cookie")
+	}
+}
+
+func TestLogRedaction_StructuredClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-oss-request-id", "id-1234")
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	fields := map[string]map[string]any{}
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk", "secret-token")).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithLogLevel(LogDebug).
+		WithStructuredLogPrinter(StructuredLogPrinterFunc(func(level int, msg string, keyvals ...any) {
+			if len(keyvals) == 0 {
+				return
+			}
+			assert.Equal(t, LogDebug, level)
+			m := map[string]any{}
+			for i := 0; i+1 < len(keyvals); i += 2 {
+				m[keyvals[i].(string)] = keyvals[i+1]
+			}
+			fields[msg] = m
+		}))
+
+	client := NewClient(cfg)
+	_, err := client.InvokeOperation(context.TODO(), &OperationInput{
+		OpName:     "GetObjectAcl",
+		Method:     "GET",
+		Bucket:     Ptr("bucket"),
+		Key:        Ptr("key"),
+		Parameters: map[string]string{"acl": ""},
+	}, func(o *Options) {
+		o.AuthMethod = Ptr(AuthMethodQuery)
+	})
+	assert.Nil(t, err)
+
+	req := fields["http request"]
+	assert.NotNil(t, req)
+	assert.Equal(t, "GET", req["method"])
+	assert.Equal(t, "/bucket/key", req["path"])
+	assert.Contains(t, req["query"], "x-oss-signature="+logRedactedValue)
+	assert.Contains(t, req["query"], "x-oss-security-token="+logRedactedValue)
+	assert.NotContains(t, req["query"], "secret-token")
+
+	resp := fields["http response"]
+	assert.NotNil(t, resp)
+	assert.Equal(t, 200, resp["status_code"])
+	assert.Equal(t, "id-1234", resp["request_id"])
+	assert.Equal(t, req["request"], resp["request"])
+}
@@ -0,0 +1,187 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+)
+
+// OperationMetrics holds the measurements of a single operation.
+type OperationMetrics struct {
+	Product string
+	OpName  string
+	Bucket  string
+
+	// The time when the operation started.
+	StartTime time.Time
+
+	// The duration of the operation, including all attempts and retry delays.
+	Latency time.Duration
+
+	// The number of http attempts.
+	Attempts int
+
+	// The reasons of the retried attempts, e.g. the ServiceError.Code or ConnectionError.
+	RetryReasons []string
+
+	// The number of request body bytes sent, including the retried attempts.
+	BytesSent int64
+
+	// The http status code of the last attempt, 0 if no response is received.
+	StatusCode int
+
+	// The ServiceError.Code if the operation failed with a service error.
+	ErrorCode string
+
+	// The error of the operation.
+	Err error
+
+	// The operation is a duplicate range request sent by the HedgePolicy.
+	Hedged bool
+
+	// The operation is canceled, because the other one of the hedged requests responded first.
+	// The bytes it received which are not read by the SDK are not reported by RecordBytesReceived.
+	Abandoned bool
+}
+
+// MetricsCollector is a interface for the SDK to report operation metrics to.
+type MetricsCollector interface {
+	// RecordOperation is called when the operation returns.
+	RecordOperation(m *OperationMetrics)
+
+	// RecordBytesReceived is called once the response body of the operation is drained or closed.
+	RecordBytesReceived(m *OperationMetrics, n int64)
+}
+
+// Retry reasons which are not service error codes
+const (
+	RetryReasonConnectionError = "ConnectionError"
+	RetryReasonClientError     = "ClientError"
+)
+
+type metricsStateKey struct{}
+
+type metricsState struct {
+	attemptErrs []error
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+type countingReadCloser struct {
+	body    io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+func (r *countingReadCloser) Read(p []byte) (n int, err error) {
+	n, err = r.body.Read(p)
+	r.n += int64(n)
+	if err == io.EOF {
+		r.report()
+	}
+	return
+}
+
+func (r *countingReadCloser) Close() error {
+	r.report()
+	return r.body.Close()
+}
+
+func (r *countingReadCloser) report() {
+	r.once.Do(func() { r.onClose(r.n) })
+}
+
+func retryReason(err error) string {
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		return serr.Code
+	}
+	if (&retry.ConnectionErrorRetryable{}).IsErrorRetryable(err) {
+		return RetryReasonConnectionError
+	}
+	return RetryReasonClientError
+}
+
+func metricsMiddlewares(collector MetricsCollector, product string) []Middleware {
+	return []Middleware{
+		{
+			Name: "MetricsOperation",
+			Step: MiddlewareStepInitialize,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				m := &OperationMetrics{
+					Product:   product,
+					OpName:    mctx.Input.OpName,
+					Bucket:    ToString(mctx.Input.Bucket),
+					StartTime: time.Now(),
+				}
+
+				// track the request body without changing the caller's input
+				sent := &countingWriter{}
+				orig := mctx.Input
+				input := *orig
+				input.OpMetadata = input.OpMetadata.Clone()
+				input.OpMetadata.Add(OpMetaKeyRequestBodyTracker, sent)
+				mctx.Input = &input
+
+				state := &metricsState{}
+				err := next(context.WithValue(ctx, metricsStateKey{}, state), mctx)
+				mctx.Input = orig
+				if mctx.Output != nil {
+					mctx.Output.Input = orig
+				}
+
+				m.Latency = time.Since(m.StartTime)
+				m.Attempts = mctx.Attempt
+				m.BytesSent = sent.n
+				m.Err = err
+				for i := 0; i < len(state.attemptErrs)-1; i++ {
+					m.RetryReasons = append(m.RetryReasons, retryReason(state.attemptErrs[i]))
+				}
+				if mctx.Response != nil {
+					m.StatusCode = mctx.Response.StatusCode
+				}
+				var serr *ServiceError
+				if errors.As(err, &serr) {
+					m.ErrorCode = serr.Code
+				}
+				if ha := hedgeAttemptFrom(ctx); ha != nil {
+					m.Hedged = ha.hedged
+					m.Abandoned = atomic.LoadInt32(&ha.abandoned) == 1
+				}
+				collector.RecordOperation(m)
+
+				if mctx.Output != nil && mctx.Output.Body != nil {
+					mctx.Output.Body = &countingReadCloser{
+						body:    mctx.Output.Body,
+						onClose: func(n int64) { collector.RecordBytesReceived(m, n) },
+					}
+				}
+				return err
+			},
+		},
+		{
+			Name: "MetricsAttempt",
+			Step: MiddlewareStepBeforeSign,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				err := next(ctx, mctx)
+				if state, ok := ctx.Value(metricsStateKey{}).(*metricsState); ok {
+					state.attemptErrs = append(state.attemptErrs, err)
+				}
+				return err
+			},
+		},
+	}
+}
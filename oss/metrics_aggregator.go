@@ -0,0 +1,309 @@
+package oss
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets The default upper bounds of the latency histogram, in seconds.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type MetricsAggregatorOptions struct {
+	// The upper bounds of the latency histogram buckets in seconds, in increasing order.
+	LatencyBuckets []float64
+
+	// The prefix of the metric names, default is "oss".
+	Namespace string
+}
+
+// HistogramSnapshot is a point-in-time copy of a histogram.
+type HistogramSnapshot struct {
+	// The upper bounds of the buckets.
+	Buckets []float64
+
+	// The cumulative counts of the buckets, the last one is the +Inf bucket.
+	Counts []uint64
+
+	Sum   float64
+	Count uint64
+}
+
+// OperationStats is the aggregated metrics of an operation.
+type OperationStats struct {
+	Product string
+	OpName  string
+
+	Count         uint64
+	Attempts      uint64
+	BytesSent     int64
+	BytesReceived int64
+
+	// The number of duplicate range requests sent by the HedgePolicy.
+	Hedges uint64
+
+	// The number of retries by reason.
+	Retries map[string]uint64
+
+	// The number of failures by error code, ClientError for non service errors.
+	Errors map[string]uint64
+
+	Latency HistogramSnapshot
+}
+
+type histogram struct {
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+func (h *histogram) observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.counts[i]++
+	h.sum += v
+	h.count++
+}
+
+func (h *histogram) snapshot() HistogramSnapshot {
+	s := HistogramSnapshot{
+		Buckets: h.buckets,
+		Counts:  make([]uint64, len(h.counts)),
+		Sum:     h.sum,
+		Count:   h.count,
+	}
+	var acc uint64
+	for i, c := range h.counts {
+		acc += c
+		s.Counts[i] = acc
+	}
+	return s
+}
+
+type operationKey struct {
+	product string
+	opName  string
+}
+
+type operationStats struct {
+	count         uint64
+	attempts      uint64
+	hedges        uint64
+	bytesSent     int64
+	bytesReceived int64
+	retries       map[string]uint64
+	errors        map[string]uint64
+	latency       *histogram
+}
+
+// MetricsAggregator is a MetricsCollector which aggregates the metrics in memory.
+// It can be scraped in the Prometheus text format through its http.Handler implementation.
+type MetricsAggregator struct {
+	options MetricsAggregatorOptions
+	mu      sync.Mutex
+	stats   map[operationKey]*operationStats
+}
+
+// NewMetricsAggregator creates a new MetricsAggregator instance.
+func NewMetricsAggregator(optFns ...func(*MetricsAggregatorOptions)) *MetricsAggregator {
+	options := MetricsAggregatorOptions{
+		LatencyBuckets: DefaultLatencyBuckets,
+		Namespace:      "oss",
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	buckets := make([]float64, len(options.LatencyBuckets))
+	copy(buckets, options.LatencyBuckets)
+	sort.Float64s(buckets)
+	options.LatencyBuckets = buckets
+
+	return &MetricsAggregator{
+		options: options,
+		stats:   map[operationKey]*operationStats{},
+	}
+}
+
+func (a *MetricsAggregator) get(product, opName string) *operationStats {
+	key := operationKey{product: product, opName: opName}
+	s, ok := a.stats[key]
+	if !ok {
+		s = &operationStats{
+			retries: map[string]uint64{},
+			errors:  map[string]uint64{},
+			latency: &histogram{
+				buckets: a.options.LatencyBuckets,
+				counts:  make([]uint64, len(a.options.LatencyBuckets)+1),
+			},
+		}
+		a.stats[key] = s
+	}
+	return s
+}
+
+// RecordOperation implements MetricsCollector.
+func (a *MetricsAggregator) RecordOperation(m *OperationMetrics) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := a.get(m.Product, m.OpName)
+	s.count++
+	s.attempts += uint64(m.Attempts)
+	s.bytesSent += m.BytesSent
+	s.latency.observe(m.Latency.Seconds())
+	for _, reason := range m.RetryReasons {
+		s.retries[reason]++
+	}
+	if m.Hedged {
+		s.hedges++
+	}
+	// the abandoned one of the hedged requests is not a failure
+	if m.Err != nil && !m.Abandoned {
+		code := m.ErrorCode
+		if code == "" {
+			code = RetryReasonClientError
+		}
+		s.errors[code]++
+	}
+}
+
+// RecordBytesReceived implements MetricsCollector.
+func (a *MetricsAggregator) RecordBytesReceived(m *OperationMetrics, n int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.get(m.Product, m.OpName).bytesReceived += n
+}
+
+// Snapshot returns the aggregated metrics, sorted by product and operation name.
+func (a *MetricsAggregator) Snapshot() []OperationStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	result := make([]OperationStats, 0, len(a.stats))
+	for k, s := range a.stats {
+		stats := OperationStats{
+			Product:       k.product,
+			OpName:        k.opName,
+			Count:         s.count,
+			Attempts:      s.attempts,
+			Hedges:        s.hedges,
+			BytesSent:     s.bytesSent,
+			BytesReceived: s.bytesReceived,
+			Retries:       make(map[string]uint64, len(s.retries)),
+			Errors:        make(map[string]uint64, len(s.errors)),
+			Latency:       s.latency.snapshot(),
+		}
+		for r, n := range s.retries {
+			stats.Retries[r] = n
+		}
+		for c, n := range s.errors {
+			stats.Errors[c] = n
+		}
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Product != result[j].Product {
+			return result[i].Product < result[j].Product
+		}
+		return result[i].OpName < result[j].OpName
+	})
+	return result
+}
+
+// Reset clears the aggregated metrics.
+func (a *MetricsAggregator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stats = map[operationKey]*operationStats{}
+}
+
+// WriteTo writes the aggregated metrics in the Prometheus text exposition format.
+func (a *MetricsAggregator) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	snapshot := a.Snapshot()
+	ns := a.options.Namespace
+
+	writeHeader := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s_%s %s\n# TYPE %s_%s %s\n", ns, name, help, ns, name, typ)
+	}
+	labels := func(s *OperationStats, extra ...string) string {
+		l := fmt.Sprintf("product=%q,operation=%q", s.Product, s.OpName)
+		for i := 0; i+1 < len(extra); i += 2 {
+			l += fmt.Sprintf(",%s=%q", extra[i], extra[i+1])
+		}
+		return l
+	}
+	sortedKeys := func(m map[string]uint64) []string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	writeHeader("operation_duration_seconds", "histogram", "The latency of the operations.")
+	for i := range snapshot {
+		s := &snapshot[i]
+		h := s.Latency
+		for j, le := range h.Buckets {
+			fmt.Fprintf(&b, "%s_operation_duration_seconds_bucket{%s} %d\n", ns,
+				labels(s, "le", strconv.FormatFloat(le, 'g', -1, 64)), h.Counts[j])
+		}
+		fmt.Fprintf(&b, "%s_operation_duration_seconds_bucket{%s} %d\n", ns, labels(s, "le", "+Inf"), h.Counts[len(h.Counts)-1])
+		fmt.Fprintf(&b, "%s_operation_duration_seconds_sum{%s} %s\n", ns, labels(s), strconv.FormatFloat(h.Sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_operation_duration_seconds_count{%s} %d\n", ns, labels(s), h.Count)
+	}
+
+	writeHeader("operation_attempts_total", "counter", "The number of http attempts of the operations.")
+	for i := range snapshot {
+		fmt.Fprintf(&b, "%s_operation_attempts_total{%s} %d\n", ns, labels(&snapshot[i]), snapshot[i].Attempts)
+	}
+
+	writeHeader("operation_retries_total", "counter", "The number of retried attempts by reason.")
+	for i := range snapshot {
+		s := &snapshot[i]
+		for _, reason := range sortedKeys(s.Retries) {
+			fmt.Fprintf(&b, "%s_operation_retries_total{%s} %d\n", ns, labels(s, "reason", reason), s.Retries[reason])
+		}
+	}
+
+	writeHeader("operation_errors_total", "counter", "The number of failed operations by error code.")
+	for i := range snapshot {
+		s := &snapshot[i]
+		for _, code := range sortedKeys(s.Errors) {
+			fmt.Fprintf(&b, "%s_operation_errors_total{%s} %d\n", ns, labels(s, "code", code), s.Errors[code])
+		}
+	}
+
+	writeHeader("operation_hedges_total", "counter", "The number of hedged range requests.")
+	for i := range snapshot {
+		fmt.Fprintf(&b, "%s_operation_hedges_total{%s} %d\n", ns, labels(&snapshot[i]), snapshot[i].Hedges)
+	}
+
+	writeHeader("operation_bytes_sent_total", "counter", "The number of request body bytes sent.")
+	for i := range snapshot {
+		fmt.Fprintf(&b, "%s_operation_bytes_sent_total{%s} %d\n", ns, labels(&snapshot[i]), snapshot[i].BytesSent)
+	}
+
+	writeHeader("operation_bytes_received_total", "counter", "The number of response body bytes received.")
+	for i := range snapshot {
+		fmt.Fprintf(&b, "%s_operation_bytes_received_total{%s} %d\n", ns, labels(&snapshot[i]), snapshot[i].BytesReceived)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP implements http.Handler to expose the metrics for scraping.
+func (a *MetricsAggregator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	a.WriteTo(w)
+}
+
+var _ MetricsCollector = (*MetricsAggregator)(nil)
+var _ http.Handler = (*MetricsAggregator)(nil)
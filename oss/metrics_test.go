@@ -0,0 +1,178 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryReason(t *testing.T) {
+	assert.Equal(t, "ServiceUnavailable", retryReason(&ServiceError{Code: "ServiceUnavailable"}))
+	assert.Equal(t, RetryReasonConnectionError, retryReason(io.ErrUnexpectedEOF))
+	assert.Equal(t, RetryReasonConnectionError, retryReason(errors.New("read: connection reset by peer")))
+	assert.Equal(t, RetryReasonClientError, retryReason(errors.New("invalid argument")))
+}
+
+func TestMetricsAggregator(t *testing.T) {
+	a := NewMetricsAggregator(func(o *MetricsAggregatorOptions) {
+		o.LatencyBuckets = []float64{1, 0.1}
+	})
+
+	a.RecordOperation(&OperationMetrics{Product: "oss", OpName: "GetObject", Latency: 50 * time.Millisecond, Attempts: 1})
+	a.RecordOperation(&OperationMetrics{Product: "oss", OpName: "GetObject", Latency: 500 * time.Millisecond, Attempts: 3,
+		RetryReasons: []string{"InternalError", RetryReasonConnectionError}, Err: &ServiceError{Code: "InternalError"}, ErrorCode: "InternalError"})
+	a.RecordOperation(&OperationMetrics{Product: "oss", OpName: "PutObject", Latency: 2 * time.Second, Attempts: 1, BytesSent: 100,
+		Err: errors.New("client error")})
+	a.RecordBytesReceived(&OperationMetrics{Product: "oss", OpName: "GetObject"}, 1024)
+
+	stats := a.Snapshot()
+	assert.Len(t, stats, 2)
+
+	get := stats[0]
+	assert.Equal(t, "GetObject", get.OpName)
+	assert.Equal(t, uint64(2), get.Count)
+	assert.Equal(t, uint64(4), get.Attempts)
+	assert.Equal(t, int64(1024), get.BytesReceived)
+	assert.Equal(t, map[string]uint64{"InternalError": 1, RetryReasonConnectionError: 1}, get.Retries)
+	assert.Equal(t, map[string]uint64{"InternalError": 1}, get.Errors)
+	assert.Equal(t, []float64{0.1, 1}, get.Latency.Buckets)
+	assert.Equal(t, []uint64{1, 2, 2}, get.Latency.Counts)
+	assert.Equal(t, uint64(2), get.Latency.Count)
+	assert.InDelta(t, 0.55, get.Latency.Sum, 0.0001)
+
+	put := stats[1]
+	assert.Equal(t, "PutObject", put.OpName)
+	assert.Equal(t, int64(100), put.BytesSent)
+	assert.Equal(t, map[string]uint64{RetryReasonClientError: 1}, put.Errors)
+	assert.Equal(t, []uint64{0, 0, 1}, put.Latency.Counts)
+
+	// scrape
+	server := httptest.NewServer(a)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	text := string(data)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	assert.Contains(t, text, "# TYPE oss_operation_duration_seconds histogram\n")
+	assert.Contains(t, text, `oss_operation_duration_seconds_bucket{product="oss",operation="GetObject",le="0.1"} 1`)
+	assert.Contains(t, text, `oss_operation_duration_seconds_bucket{product="oss",operation="GetObject",le="+Inf"} 2`)
+	assert.Contains(t, text, `oss_operation_duration_seconds_count{product="oss",operation="PutObject"} 1`)
+	assert.Contains(t, text, `oss_operation_attempts_total{product="oss",operation="GetObject"} 4`)
+	assert.Contains(t, text, `oss_operation_retries_total{product="oss",operation="GetObject",reason="ConnectionError"} 1`)
+	assert.Contains(t, text, `oss_operation_errors_total{product="oss",operation="PutObject",code="ClientError"} 1`)
+	assert.Contains(t, text, `oss_operation_bytes_sent_total{product="oss",operation="PutObject"} 100`)
+	assert.Contains(t, text, `oss_operation_bytes_received_total{product="oss",operation="GetObject"} 1024`)
+
+	a.Reset()
+	assert.Len(t, a.Snapshot(), 0)
+}
+
+type stubMetricsCollector struct {
+	operations []*OperationMetrics
+	received   []int64
+}
+
+func (c *stubMetricsCollector) RecordOperation(m *OperationMetrics) {
+	c.operations = append(c.operations, m)
+}
+
+func (c *stubMetricsCollector) RecordBytesReceived(_ *OperationMetrics, n int64) {
+	c.received = append(c.received, n)
+}
+
+func TestMetricsCollector_Operation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(503)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<Error>
+				<Code>ServiceUnavailable</Code>
+				<Message>Please reduce your request rate.</Message>
+			</Error>`))
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+
+	collector := &stubMetricsCollector{}
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		})).
+		WithMetricsCollector(collector)
+
+	client := NewClient(cfg)
+
+	// upload, the first attempt is retried
+	input := &OperationInput{
+		OpName: "PutObject",
+		Method: "PUT",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   strings.NewReader("1234567890"),
+	}
+	output, err := client.InvokeOperation(context.TODO(), input)
+	assert.Nil(t, err)
+	assert.Len(t, collector.operations, 1)
+	m := collector.operations[0]
+	assert.Equal(t, "oss", m.Product)
+	assert.Equal(t, "PutObject", m.OpName)
+	assert.Equal(t, "bucket", m.Bucket)
+	assert.Equal(t, 2, m.Attempts)
+	assert.Equal(t, []string{"ServiceUnavailable"}, m.RetryReasons)
+	assert.Equal(t, int64(20), m.BytesSent)
+	assert.Equal(t, 200, m.StatusCode)
+	assert.Equal(t, "", m.ErrorCode)
+	assert.Nil(t, m.Err)
+	assert.True(t, m.Latency > 0)
+
+	// the input of the caller is not mutated
+	assert.Same(t, input, output.Input)
+	assert.False(t, input.OpMetadata.Has(OpMetaKeyRequestBodyTracker))
+
+	// received bytes are reported once the body is read
+	assert.Len(t, collector.received, 0)
+	data, err := io.ReadAll(output.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", string(data))
+	output.Body.Close()
+	assert.Equal(t, []int64{11}, collector.received)
+
+	// service error
+	atomic.StoreInt32(&requests, 0)
+	_, err = client.GetObject(context.TODO(), &GetObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}, func(o *Options) {
+		o.RetryMaxAttempts = Ptr(1)
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, collector.operations, 2)
+	m = collector.operations[1]
+	assert.Equal(t, "GetObject", m.OpName)
+	assert.Equal(t, 1, m.Attempts)
+	assert.Len(t, m.RetryReasons, 0)
+	assert.Equal(t, 503, m.StatusCode)
+	assert.Equal(t, "ServiceUnavailable", m.ErrorCode)
+	assert.NotNil(t, m.Err)
+}
@@ -0,0 +1,89 @@
+package oss
+
+import (
+	"context"
+	"net/http"
+)
+
+// MiddlewareStep identifies the stage of the request pipeline a middleware is attached to.
+type MiddlewareStep int
+
+// Enum values for MiddlewareStep
+const (
+	// MiddlewareStepInitialize wraps the whole operation, before the http request is built.
+	// The OperationInput can be modified, the OperationOutput is available after next returns.
+	MiddlewareStepInitialize MiddlewareStep = iota
+
+	// MiddlewareStepBuild runs once per operation, after the http request is built and
+	// before it is sent. The request is not signed yet.
+	MiddlewareStepBuild
+
+	// MiddlewareStepBeforeSign wraps each attempt, before the request is signed.
+	MiddlewareStepBeforeSign
+
+	// MiddlewareStepAfterSign wraps each attempt, after the request is signed and
+	// right before it is sent. The http response is available after next returns.
+	MiddlewareStepAfterSign
+
+	// MiddlewareStepDeserialize wraps the conversion of the http response into the OperationOutput.
+	MiddlewareStepDeserialize
+)
+
+// MiddlewareContext holds the state of an operation as it flows through the middleware chain.
+type MiddlewareContext struct {
+	// The operation input, available in all stages.
+	Input *OperationInput
+
+	// The http request, available from MiddlewareStepBuild.
+	// The request body is managed by the client and must not be replaced.
+	Request *http.Request
+
+	// The http response of the last attempt, available after MiddlewareStepAfterSign's next returns.
+	Response *http.Response
+
+	// The operation output, available after MiddlewareStepDeserialize's next returns.
+	Output *OperationOutput
+
+	// The current attempt number, starts from 1.
+	Attempt int
+}
+
+// MiddlewareHandler handles the operation at a specific stage.
+type MiddlewareHandler func(ctx context.Context, mctx *MiddlewareContext) error
+
+// Middleware intercepts the operation at the given stage.
+// Handle must call next to continue the chain, and may inspect or modify the MiddlewareContext
+// before and after calling it. Returning an error without calling next aborts the operation.
+type Middleware struct {
+	// Name identifies the middleware.
+	Name string
+
+	// The stage the middleware is attached to.
+	Step MiddlewareStep
+
+	// Handle is called with the context and the next handler in the chain.
+	Handle func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error
+}
+
+// WithMiddlewares appends middlewares to the client or the operation.
+// Middlewares in the same stage are invoked in the order they are added, the first one is the outermost.
+func WithMiddlewares(middlewares ...Middleware) func(*Options) {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+func handleMiddlewares(ctx context.Context, middlewares []Middleware, step MiddlewareStep, mctx *MiddlewareContext, last MiddlewareHandler) error {
+	h := last
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		m := middlewares[i]
+		if m.Step != step || m.Handle == nil {
+			continue
+		}
+		next := h
+		h = func(ctx context.Context, mctx *MiddlewareContext) error {
+			return m.Handle(ctx, mctx, next)
+		}
+	}
+	return h(ctx, mctx)
+}
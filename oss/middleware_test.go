@@ -0,0 +1,242 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleMiddlewares(t *testing.T) {
+	var trace []string
+	record := func(name string, step MiddlewareStep) Middleware {
+		return Middleware{
+			Name: name,
+			Step: step,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				trace = append(trace, name+"-in")
+				err := next(ctx, mctx)
+				trace = append(trace, name+"-out")
+				return err
+			},
+		}
+	}
+
+	middlewares := []Middleware{
+		record("a", MiddlewareStepInitialize),
+		record("b", MiddlewareStepBuild),
+		record("c", MiddlewareStepInitialize),
+		{Name: "nil-handle", Step: MiddlewareStepInitialize},
+	}
+
+	err := handleMiddlewares(context.TODO(), middlewares, MiddlewareStepInitialize, &MiddlewareContext{},
+		func(ctx context.Context, mctx *MiddlewareContext) error {
+			trace = append(trace, "last")
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a-in", "c-in", "last", "c-out", "a-out"}, trace)
+
+	// no middlewares in step
+	trace = nil
+	err = handleMiddlewares(context.TODO(), middlewares, MiddlewareStepDeserialize, &MiddlewareContext{},
+		func(ctx context.Context, mctx *MiddlewareContext) error {
+			trace = append(trace, "last")
+			return errors.New("last error")
+		})
+	assert.EqualError(t, err, "last error")
+	assert.Equal(t, []string{"last"}, trace)
+}
+
+func TestMiddlewares_Stages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "init", r.Header.Get("x-oss-meta-init"))
+		assert.Equal(t, "build", r.Header.Get("x-oss-meta-build"))
+		assert.Equal(t, "before-sign", r.Header.Get("x-before-sign"))
+		assert.Equal(t, "after-sign", r.Header.Get("x-after-sign"))
+		assert.Contains(t, r.Header.Get("Authorization"), "x-before-sign")
+		assert.NotContains(t, r.Header.Get("Authorization"), "x-after-sign")
+		w.Header().Set("x-oss-request-id", "id-1234")
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var steps []MiddlewareStep
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithAdditionalHeaders([]string{"x-before-sign", "x-after-sign"})
+
+	client := NewClient(cfg, WithMiddlewares(
+		Middleware{
+			Name: "init",
+			Step: MiddlewareStepInitialize,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				steps = append(steps, MiddlewareStepInitialize)
+				assert.Nil(t, mctx.Request)
+				mctx.Input.Headers = map[string]string{"x-oss-meta-init": "init"}
+				err := next(ctx, mctx)
+				assert.Nil(t, err)
+				assert.NotNil(t, mctx.Output)
+				assert.Equal(t, 200, mctx.Output.StatusCode)
+				return err
+			},
+		},
+		Middleware{
+			Name: "build",
+			Step: MiddlewareStepBuild,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				steps = append(steps, MiddlewareStepBuild)
+				assert.NotNil(t, mctx.Request)
+				assert.Equal(t, "", mctx.Request.Header.Get("Authorization"))
+				mctx.Request.Header.Set("x-oss-meta-build", "build")
+				return next(ctx, mctx)
+			},
+		},
+		Middleware{
+			Name: "before-sign",
+			Step: MiddlewareStepBeforeSign,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				steps = append(steps, MiddlewareStepBeforeSign)
+				assert.Equal(t, 1, mctx.Attempt)
+				mctx.Request.Header.Set("x-before-sign", "before-sign")
+				return next(ctx, mctx)
+			},
+		},
+		Middleware{
+			Name: "after-sign",
+			Step: MiddlewareStepAfterSign,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				steps = append(steps, MiddlewareStepAfterSign)
+				assert.NotEqual(t, "", mctx.Request.Header.Get("Authorization"))
+				mctx.Request.Header.Set("x-after-sign", "after-sign")
+				err := next(ctx, mctx)
+				assert.NotNil(t, mctx.Response)
+				assert.Equal(t, "id-1234", mctx.Response.Header.Get("x-oss-request-id"))
+				return err
+			},
+		},
+		Middleware{
+			Name: "deserialize",
+			Step: MiddlewareStepDeserialize,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				steps = append(steps, MiddlewareStepDeserialize)
+				assert.Nil(t, mctx.Output)
+				err := next(ctx, mctx)
+				assert.NotNil(t, mctx.Output)
+				mctx.Output.OpMetadata.Set("deserialize", true)
+				return err
+			},
+		},
+	))
+
+	output, err := client.InvokeOperation(context.TODO(), &OperationInput{
+		OpName: "HeadObject",
+		Method: "HEAD",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, true, output.OpMetadata.Get("deserialize"))
+	assert.Equal(t, []MiddlewareStep{
+		MiddlewareStepInitialize,
+		MiddlewareStepBuild,
+		MiddlewareStepBeforeSign,
+		MiddlewareStepAfterSign,
+		MiddlewareStepDeserialize,
+	}, steps)
+}
+
+func TestMiddlewares_Retry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	var lastErr error
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		}))
+
+	client := NewClient(cfg)
+	output, err := client.InvokeOperation(context.TODO(), &OperationInput{
+		OpName: "GetObject",
+		Method: "GET",
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	}, WithMiddlewares(Middleware{
+		Name: "attempt",
+		Step: MiddlewareStepAfterSign,
+		Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+			attempts = append(attempts, mctx.Attempt)
+			lastErr = next(ctx, mctx)
+			return lastErr
+		},
+	}))
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.Nil(t, lastErr)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+
+	// middlewares of the operation are not kept by the client
+	assert.Len(t, client.options.Middlewares, 0)
+}
+
+func TestMiddlewares_Abort(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL)
+
+	client := NewClient(cfg, WithMiddlewares(Middleware{
+		Name: "deny-delete",
+		Step: MiddlewareStepInitialize,
+		Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+			if strings.HasPrefix(mctx.Input.OpName, "Delete") {
+				return errors.New("delete is not allowed")
+			}
+			return next(ctx, mctx)
+		},
+	}))
+
+	_, err := client.DeleteObject(context.TODO(), &DeleteObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "operation error DeleteObject: delete is not allowed")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+	_, err = client.HeadObject(context.TODO(), &HeadObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
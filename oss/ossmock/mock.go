@@ -0,0 +1,1735 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package ossmock provides a mock implementation of oss.API which records the calls.
+package ossmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// Call is a call of an operation, the context is not recorded.
+type Call struct {
+	Operation string
+	Args      []any
+}
+
+// Client implements oss.API. Each operation calls the function field of the same name with the Func suffix,
+// an operation whose function is not set returns an error.
+type Client struct {
+	mu    sync.Mutex
+	calls []Call
+
+	AbortBucketWormFunc                         func(ctx context.Context, request *oss.AbortBucketWormRequest, optFns ...func(*oss.Options)) (*oss.AbortBucketWormResult, error)
+	AbortMultipartUploadFunc                    func(ctx context.Context, request *oss.AbortMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.AbortMultipartUploadResult, error)
+	AppendFileFunc                              func(ctx context.Context, bucket string, key string, optFns ...func(*oss.AppendOptions)) (*oss.AppendOnlyFile, error)
+	AppendObjectFunc                            func(ctx context.Context, request *oss.AppendObjectRequest, optFns ...func(*oss.Options)) (*oss.AppendObjectResult, error)
+	AsyncProcessObjectFunc                      func(ctx context.Context, request *oss.AsyncProcessObjectRequest, optFns ...func(*oss.Options)) (*oss.AsyncProcessObjectResult, error)
+	CleanRestoredObjectFunc                     func(ctx context.Context, request *oss.CleanRestoredObjectRequest, optFns ...func(*oss.Options)) (*oss.CleanRestoredObjectResult, error)
+	CloseMetaQueryFunc                          func(ctx context.Context, request *oss.CloseMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.CloseMetaQueryResult, error)
+	CompleteBucketWormFunc                      func(ctx context.Context, request *oss.CompleteBucketWormRequest, optFns ...func(*oss.Options)) (*oss.CompleteBucketWormResult, error)
+	CompleteMultipartUploadFunc                 func(ctx context.Context, request *oss.CompleteMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.CompleteMultipartUploadResult, error)
+	CopyObjectFunc                              func(ctx context.Context, request *oss.CopyObjectRequest, optFns ...func(*oss.Options)) (*oss.CopyObjectResult, error)
+	CreateAccessPointFunc                       func(ctx context.Context, request *oss.CreateAccessPointRequest, optFns ...func(*oss.Options)) (*oss.CreateAccessPointResult, error)
+	CreateAccessPointForObjectProcessFunc       func(ctx context.Context, request *oss.CreateAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.CreateAccessPointForObjectProcessResult, error)
+	CreateBucketDataRedundancyTransitionFunc    func(ctx context.Context, request *oss.CreateBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.CreateBucketDataRedundancyTransitionResult, error)
+	CreateCnameTokenFunc                        func(ctx context.Context, request *oss.CreateCnameTokenRequest, optFns ...func(*oss.Options)) (*oss.CreateCnameTokenResult, error)
+	CreateSelectObjectMetaFunc                  func(ctx context.Context, request *oss.CreateSelectObjectMetaRequest, optFns ...func(*oss.Options)) (*oss.CreateSelectObjectMetaResult, error)
+	DeleteAccessPointFunc                       func(ctx context.Context, request *oss.DeleteAccessPointRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointResult, error)
+	DeleteAccessPointForObjectProcessFunc       func(ctx context.Context, request *oss.DeleteAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointForObjectProcessResult, error)
+	DeleteAccessPointPolicyFunc                 func(ctx context.Context, request *oss.DeleteAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPolicyResult, error)
+	DeleteAccessPointPolicyForObjectProcessFunc func(ctx context.Context, request *oss.DeleteAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPolicyForObjectProcessResult, error)
+	DeleteAccessPointPublicAccessBlockFunc      func(ctx context.Context, request *oss.DeleteAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPublicAccessBlockResult, error)
+	DeleteBucketFunc                            func(ctx context.Context, request *oss.DeleteBucketRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketResult, error)
+	DeleteBucketCorsFunc                        func(ctx context.Context, request *oss.DeleteBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketCorsResult, error)
+	DeleteBucketDataRedundancyTransitionFunc    func(ctx context.Context, request *oss.DeleteBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketDataRedundancyTransitionResult, error)
+	DeleteBucketEncryptionFunc                  func(ctx context.Context, request *oss.DeleteBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketEncryptionResult, error)
+	DeleteBucketInventoryFunc                   func(ctx context.Context, request *oss.DeleteBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketInventoryResult, error)
+	DeleteBucketLifecycleFunc                   func(ctx context.Context, request *oss.DeleteBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketLifecycleResult, error)
+	DeleteBucketLoggingFunc                     func(ctx context.Context, request *oss.DeleteBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketLoggingResult, error)
+	DeleteBucketOverwriteConfigFunc             func(ctx context.Context, request *oss.DeleteBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketOverwriteConfigResult, error)
+	DeleteBucketPolicyFunc                      func(ctx context.Context, request *oss.DeleteBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketPolicyResult, error)
+	DeleteBucketPublicAccessBlockFunc           func(ctx context.Context, request *oss.DeleteBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketPublicAccessBlockResult, error)
+	DeleteBucketReplicationFunc                 func(ctx context.Context, request *oss.DeleteBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketReplicationResult, error)
+	DeleteBucketTagsFunc                        func(ctx context.Context, request *oss.DeleteBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketTagsResult, error)
+	DeleteBucketWebsiteFunc                     func(ctx context.Context, request *oss.DeleteBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketWebsiteResult, error)
+	DeleteCnameFunc                             func(ctx context.Context, request *oss.DeleteCnameRequest, optFns ...func(*oss.Options)) (*oss.DeleteCnameResult, error)
+	DeleteMultipleObjectsFunc                   func(ctx context.Context, request *oss.DeleteMultipleObjectsRequest, optFns ...func(*oss.Options)) (*oss.DeleteMultipleObjectsResult, error)
+	DeleteObjectFunc                            func(ctx context.Context, request *oss.DeleteObjectRequest, optFns ...func(*oss.Options)) (*oss.DeleteObjectResult, error)
+	DeleteObjectTaggingFunc                     func(ctx context.Context, request *oss.DeleteObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.DeleteObjectTaggingResult, error)
+	DeletePublicAccessBlockFunc                 func(ctx context.Context, request *oss.DeletePublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeletePublicAccessBlockResult, error)
+	DeleteStyleFunc                             func(ctx context.Context, request *oss.DeleteStyleRequest, optFns ...func(*oss.Options)) (*oss.DeleteStyleResult, error)
+	DeleteUserDefinedLogFieldsConfigFunc        func(ctx context.Context, request *oss.DeleteUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.DeleteUserDefinedLogFieldsConfigResult, error)
+	DescribeRegionsFunc                         func(ctx context.Context, request *oss.DescribeRegionsRequest, optFns ...func(*oss.Options)) (*oss.DescribeRegionsResult, error)
+	DoDataPipeLineActionFunc                    func(ctx context.Context, request *oss.DoDataPipeLineActionRequest, optFns ...func(*oss.Options)) (*oss.DoDataPipeLineActionResult, error)
+	DoMetaQueryFunc                             func(ctx context.Context, request *oss.DoMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.DoMetaQueryResult, error)
+	DoMetaQueryActionFunc                       func(ctx context.Context, request *oss.DoMetaQueryActionRequest, optFns ...func(*oss.Options)) (*oss.DoMetaQueryActionResult, error)
+	ExtendBucketWormFunc                        func(ctx context.Context, request *oss.ExtendBucketWormRequest, optFns ...func(*oss.Options)) (*oss.ExtendBucketWormResult, error)
+	GetAccessPointFunc                          func(ctx context.Context, request *oss.GetAccessPointRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointResult, error)
+	GetAccessPointConfigForObjectProcessFunc    func(ctx context.Context, request *oss.GetAccessPointConfigForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointConfigForObjectProcessResult, error)
+	GetAccessPointForObjectProcessFunc          func(ctx context.Context, request *oss.GetAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointForObjectProcessResult, error)
+	GetAccessPointPolicyFunc                    func(ctx context.Context, request *oss.GetAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPolicyResult, error)
+	GetAccessPointPolicyForObjectProcessFunc    func(ctx context.Context, request *oss.GetAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPolicyForObjectProcessResult, error)
+	GetAccessPointPublicAccessBlockFunc         func(ctx context.Context, request *oss.GetAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPublicAccessBlockResult, error)
+	GetBucketAccessMonitorFunc                  func(ctx context.Context, request *oss.GetBucketAccessMonitorRequest, optFns ...func(*oss.Options)) (*oss.GetBucketAccessMonitorResult, error)
+	GetBucketAclFunc                            func(ctx context.Context, request *oss.GetBucketAclRequest, optFns ...func(*oss.Options)) (*oss.GetBucketAclResult, error)
+	GetBucketArchiveDirectReadFunc              func(ctx context.Context, request *oss.GetBucketArchiveDirectReadRequest, optFns ...func(*oss.Options)) (*oss.GetBucketArchiveDirectReadResult, error)
+	GetBucketCorsFunc                           func(ctx context.Context, request *oss.GetBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.GetBucketCorsResult, error)
+	GetBucketDataRedundancyTransitionFunc       func(ctx context.Context, request *oss.GetBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.GetBucketDataRedundancyTransitionResult, error)
+	GetBucketEncryptionFunc                     func(ctx context.Context, request *oss.GetBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.GetBucketEncryptionResult, error)
+	GetBucketHttpsConfigFunc                    func(ctx context.Context, request *oss.GetBucketHttpsConfigRequest, optFns ...func(*oss.Options)) (*oss.GetBucketHttpsConfigResult, error)
+	GetBucketInfoFunc                           func(ctx context.Context, request *oss.GetBucketInfoRequest, optFns ...func(*oss.Options)) (*oss.GetBucketInfoResult, error)
+	GetBucketInventoryFunc                      func(ctx context.Context, request *oss.GetBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.GetBucketInventoryResult, error)
+	GetBucketLifecycleFunc                      func(ctx context.Context, request *oss.GetBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLifecycleResult, error)
+	GetBucketLocationFunc                       func(ctx context.Context, request *oss.GetBucketLocationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLocationResult, error)
+	GetBucketLoggingFunc                        func(ctx context.Context, request *oss.GetBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLoggingResult, error)
+	GetBucketObjectWormConfigurationFunc        func(ctx context.Context, request *oss.GetBucketObjectWormConfigurationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketObjectWormConfigurationResult, error)
+	GetBucketOverwriteConfigFunc                func(ctx context.Context, request *oss.GetBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.GetBucketOverwriteConfigResult, error)
+	GetBucketPolicyFunc                         func(ctx context.Context, request *oss.GetBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPolicyResult, error)
+	GetBucketPolicyStatusFunc                   func(ctx context.Context, request *oss.GetBucketPolicyStatusRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPolicyStatusResult, error)
+	GetBucketPublicAccessBlockFunc              func(ctx context.Context, request *oss.GetBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPublicAccessBlockResult, error)
+	GetBucketRefererFunc                        func(ctx context.Context, request *oss.GetBucketRefererRequest, optFns ...func(*oss.Options)) (*oss.GetBucketRefererResult, error)
+	GetBucketReplicationFunc                    func(ctx context.Context, request *oss.GetBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationResult, error)
+	GetBucketReplicationLocationFunc            func(ctx context.Context, request *oss.GetBucketReplicationLocationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationLocationResult, error)
+	GetBucketReplicationProgressFunc            func(ctx context.Context, request *oss.GetBucketReplicationProgressRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationProgressResult, error)
+	GetBucketRequestPaymentFunc                 func(ctx context.Context, request *oss.GetBucketRequestPaymentRequest, optFns ...func(*oss.Options)) (*oss.GetBucketRequestPaymentResult, error)
+	GetBucketResourceGroupFunc                  func(ctx context.Context, request *oss.GetBucketResourceGroupRequest, optFns ...func(*oss.Options)) (*oss.GetBucketResourceGroupResult, error)
+	GetBucketStatFunc                           func(ctx context.Context, request *oss.GetBucketStatRequest, optFns ...func(*oss.Options)) (*oss.GetBucketStatResult, error)
+	GetBucketTagsFunc                           func(ctx context.Context, request *oss.GetBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.GetBucketTagsResult, error)
+	GetBucketTransferAccelerationFunc           func(ctx context.Context, request *oss.GetBucketTransferAccelerationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketTransferAccelerationResult, error)
+	GetBucketVersioningFunc                     func(ctx context.Context, request *oss.GetBucketVersioningRequest, optFns ...func(*oss.Options)) (*oss.GetBucketVersioningResult, error)
+	GetBucketWebsiteFunc                        func(ctx context.Context, request *oss.GetBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.GetBucketWebsiteResult, error)
+	GetBucketWormFunc                           func(ctx context.Context, request *oss.GetBucketWormRequest, optFns ...func(*oss.Options)) (*oss.GetBucketWormResult, error)
+	GetCnameTokenFunc                           func(ctx context.Context, request *oss.GetCnameTokenRequest, optFns ...func(*oss.Options)) (*oss.GetCnameTokenResult, error)
+	GetMetaQueryStatusFunc                      func(ctx context.Context, request *oss.GetMetaQueryStatusRequest, optFns ...func(*oss.Options)) (*oss.GetMetaQueryStatusResult, error)
+	GetObjectFunc                               func(ctx context.Context, request *oss.GetObjectRequest, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error)
+	GetObjectAclFunc                            func(ctx context.Context, request *oss.GetObjectAclRequest, optFns ...func(*oss.Options)) (*oss.GetObjectAclResult, error)
+	GetObjectLegalHoldFunc                      func(ctx context.Context, request *oss.GetObjectLegalHoldRequest, optFns ...func(*oss.Options)) (*oss.GetObjectLegalHoldResult, error)
+	GetObjectMetaFunc                           func(ctx context.Context, request *oss.GetObjectMetaRequest, optFns ...func(*oss.Options)) (*oss.GetObjectMetaResult, error)
+	GetObjectRetentionFunc                      func(ctx context.Context, request *oss.GetObjectRetentionRequest, optFns ...func(*oss.Options)) (*oss.GetObjectRetentionResult, error)
+	GetObjectTaggingFunc                        func(ctx context.Context, request *oss.GetObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.GetObjectTaggingResult, error)
+	GetObjectToFileFunc                         func(ctx context.Context, request *oss.GetObjectRequest, filePath string, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error)
+	GetObjectToFileV2Func                       func(ctx context.Context, request *oss.GetObjectRequest, filePath string, writeBufferSize *int, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error)
+	GetPublicAccessBlockFunc                    func(ctx context.Context, request *oss.GetPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetPublicAccessBlockResult, error)
+	GetStyleFunc                                func(ctx context.Context, request *oss.GetStyleRequest, optFns ...func(*oss.Options)) (*oss.GetStyleResult, error)
+	GetSymlinkFunc                              func(ctx context.Context, request *oss.GetSymlinkRequest, optFns ...func(*oss.Options)) (*oss.GetSymlinkResult, error)
+	GetUserDefinedLogFieldsConfigFunc           func(ctx context.Context, request *oss.GetUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.GetUserDefinedLogFieldsConfigResult, error)
+	HeadObjectFunc                              func(ctx context.Context, request *oss.HeadObjectRequest, optFns ...func(*oss.Options)) (*oss.HeadObjectResult, error)
+	InitiateBucketWormFunc                      func(ctx context.Context, request *oss.InitiateBucketWormRequest, optFns ...func(*oss.Options)) (*oss.InitiateBucketWormResult, error)
+	InitiateMultipartUploadFunc                 func(ctx context.Context, request *oss.InitiateMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.InitiateMultipartUploadResult, error)
+	InvokeOperationFunc                         func(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error)
+	IsBucketExistFunc                           func(ctx context.Context, bucket string, optFns ...func(*oss.Options)) (bool, error)
+	IsObjectExistFunc                           func(ctx context.Context, bucket string, key string, optFns ...func(*oss.IsObjectExistOptions)) (bool, error)
+	ListAccessPointsFunc                        func(ctx context.Context, request *oss.ListAccessPointsRequest, optFns ...func(*oss.Options)) (*oss.ListAccessPointsResult, error)
+	ListAccessPointsForObjectProcessFunc        func(ctx context.Context, request *oss.ListAccessPointsForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.ListAccessPointsForObjectProcessResult, error)
+	ListBucketDataRedundancyTransitionFunc      func(ctx context.Context, request *oss.ListBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.ListBucketDataRedundancyTransitionResult, error)
+	ListBucketInventoryFunc                     func(ctx context.Context, request *oss.ListBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.ListBucketInventoryResult, error)
+	ListBucketsFunc                             func(ctx context.Context, request *oss.ListBucketsRequest, optFns ...func(*oss.Options)) (*oss.ListBucketsResult, error)
+	ListCloudBoxesFunc                          func(ctx context.Context, request *oss.ListCloudBoxesRequest, optFns ...func(*oss.Options)) (*oss.ListCloudBoxesResult, error)
+	ListCnameFunc                               func(ctx context.Context, request *oss.ListCnameRequest, optFns ...func(*oss.Options)) (*oss.ListCnameResult, error)
+	ListMultipartUploadsFunc                    func(ctx context.Context, request *oss.ListMultipartUploadsRequest, optFns ...func(*oss.Options)) (*oss.ListMultipartUploadsResult, error)
+	ListObjectVersionsFunc                      func(ctx context.Context, request *oss.ListObjectVersionsRequest, optFns ...func(*oss.Options)) (*oss.ListObjectVersionsResult, error)
+	ListObjectsFunc                             func(ctx context.Context, request *oss.ListObjectsRequest, optFns ...func(*oss.Options)) (*oss.ListObjectsResult, error)
+	ListObjectsV2Func                           func(ctx context.Context, request *oss.ListObjectsV2Request, optFns ...func(*oss.Options)) (*oss.ListObjectsV2Result, error)
+	ListPartsFunc                               func(ctx context.Context, request *oss.ListPartsRequest, optFns ...func(*oss.Options)) (*oss.ListPartsResult, error)
+	ListStyleFunc                               func(ctx context.Context, request *oss.ListStyleRequest, optFns ...func(*oss.Options)) (*oss.ListStyleResult, error)
+	ListUserDataRedundancyTransitionFunc        func(ctx context.Context, request *oss.ListUserDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.ListUserDataRedundancyTransitionResult, error)
+	OpenFileFunc                                func(ctx context.Context, bucket string, key string, optFns ...func(*oss.OpenOptions)) (*oss.ReadOnlyFile, error)
+	OpenMetaQueryFunc                           func(ctx context.Context, request *oss.OpenMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.OpenMetaQueryResult, error)
+	OptionObjectFunc                            func(ctx context.Context, request *oss.OptionObjectRequest, optFns ...func(*oss.Options)) (*oss.OptionObjectResult, error)
+	PostObjectFunc                              func(ctx context.Context, request *oss.PostObjectRequest, optFns ...func(*oss.Options)) (*oss.PostObjectResult, error)
+	PresignFunc                                 func(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+	PresignOperationFunc                        func(ctx context.Context, api any, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+	PresignPostObjectFunc                       func(ctx context.Context, policy *oss.PostPolicy, optFns ...func(*oss.Options)) (*oss.PresignPostObjectResult, error)
+	ProcessObjectFunc                           func(ctx context.Context, request *oss.ProcessObjectRequest, optFns ...func(*oss.Options)) (*oss.ProcessObjectResult, error)
+	PutAccessPointConfigForObjectProcessFunc    func(ctx context.Context, request *oss.PutAccessPointConfigForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointConfigForObjectProcessResult, error)
+	PutAccessPointPolicyFunc                    func(ctx context.Context, request *oss.PutAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPolicyResult, error)
+	PutAccessPointPolicyForObjectProcessFunc    func(ctx context.Context, request *oss.PutAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPolicyForObjectProcessResult, error)
+	PutAccessPointPublicAccessBlockFunc         func(ctx context.Context, request *oss.PutAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPublicAccessBlockResult, error)
+	PutBucketFunc                               func(ctx context.Context, request *oss.PutBucketRequest, optFns ...func(*oss.Options)) (*oss.PutBucketResult, error)
+	PutBucketAccessMonitorFunc                  func(ctx context.Context, request *oss.PutBucketAccessMonitorRequest, optFns ...func(*oss.Options)) (*oss.PutBucketAccessMonitorResult, error)
+	PutBucketAclFunc                            func(ctx context.Context, request *oss.PutBucketAclRequest, optFns ...func(*oss.Options)) (*oss.PutBucketAclResult, error)
+	PutBucketArchiveDirectReadFunc              func(ctx context.Context, request *oss.PutBucketArchiveDirectReadRequest, optFns ...func(*oss.Options)) (*oss.PutBucketArchiveDirectReadResult, error)
+	PutBucketCorsFunc                           func(ctx context.Context, request *oss.PutBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.PutBucketCorsResult, error)
+	PutBucketEncryptionFunc                     func(ctx context.Context, request *oss.PutBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.PutBucketEncryptionResult, error)
+	PutBucketHttpsConfigFunc                    func(ctx context.Context, request *oss.PutBucketHttpsConfigRequest, optFns ...func(*oss.Options)) (*oss.PutBucketHttpsConfigResult, error)
+	PutBucketInventoryFunc                      func(ctx context.Context, request *oss.PutBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.PutBucketInventoryResult, error)
+	PutBucketLifecycleFunc                      func(ctx context.Context, request *oss.PutBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.PutBucketLifecycleResult, error)
+	PutBucketLoggingFunc                        func(ctx context.Context, request *oss.PutBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.PutBucketLoggingResult, error)
+	PutBucketObjectWormConfigurationFunc        func(ctx context.Context, request *oss.PutBucketObjectWormConfigurationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketObjectWormConfigurationResult, error)
+	PutBucketOverwriteConfigFunc                func(ctx context.Context, request *oss.PutBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.PutBucketOverwriteConfigResult, error)
+	PutBucketPolicyFunc                         func(ctx context.Context, request *oss.PutBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.PutBucketPolicyResult, error)
+	PutBucketPublicAccessBlockFunc              func(ctx context.Context, request *oss.PutBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutBucketPublicAccessBlockResult, error)
+	PutBucketRefererFunc                        func(ctx context.Context, request *oss.PutBucketRefererRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRefererResult, error)
+	PutBucketReplicationFunc                    func(ctx context.Context, request *oss.PutBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketReplicationResult, error)
+	PutBucketRequestPaymentFunc                 func(ctx context.Context, request *oss.PutBucketRequestPaymentRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRequestPaymentResult, error)
+	PutBucketResourceGroupFunc                  func(ctx context.Context, request *oss.PutBucketResourceGroupRequest, optFns ...func(*oss.Options)) (*oss.PutBucketResourceGroupResult, error)
+	PutBucketRtcFunc                            func(ctx context.Context, request *oss.PutBucketRtcRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRtcResult, error)
+	PutBucketTagsFunc                           func(ctx context.Context, request *oss.PutBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.PutBucketTagsResult, error)
+	PutBucketTransferAccelerationFunc           func(ctx context.Context, request *oss.PutBucketTransferAccelerationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketTransferAccelerationResult, error)
+	PutBucketVersioningFunc                     func(ctx context.Context, request *oss.PutBucketVersioningRequest, optFns ...func(*oss.Options)) (*oss.PutBucketVersioningResult, error)
+	PutBucketWebsiteFunc                        func(ctx context.Context, request *oss.PutBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.PutBucketWebsiteResult, error)
+	PutCnameFunc                                func(ctx context.Context, request *oss.PutCnameRequest, optFns ...func(*oss.Options)) (*oss.PutCnameResult, error)
+	PutObjectFunc                               func(ctx context.Context, request *oss.PutObjectRequest, optFns ...func(*oss.Options)) (*oss.PutObjectResult, error)
+	PutObjectAclFunc                            func(ctx context.Context, request *oss.PutObjectAclRequest, optFns ...func(*oss.Options)) (*oss.PutObjectAclResult, error)
+	PutObjectFromFileFunc                       func(ctx context.Context, request *oss.PutObjectRequest, filePath string, optFns ...func(*oss.Options)) (*oss.PutObjectResult, error)
+	PutObjectLegalHoldFunc                      func(ctx context.Context, request *oss.PutObjectLegalHoldRequest, optFns ...func(*oss.Options)) (*oss.PutObjectLegalHoldResult, error)
+	PutObjectRetentionFunc                      func(ctx context.Context, request *oss.PutObjectRetentionRequest, optFns ...func(*oss.Options)) (*oss.PutObjectRetentionResult, error)
+	PutObjectTaggingFunc                        func(ctx context.Context, request *oss.PutObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.PutObjectTaggingResult, error)
+	PutPublicAccessBlockFunc                    func(ctx context.Context, request *oss.PutPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutPublicAccessBlockResult, error)
+	PutStyleFunc                                func(ctx context.Context, request *oss.PutStyleRequest, optFns ...func(*oss.Options)) (*oss.PutStyleResult, error)
+	PutSymlinkFunc                              func(ctx context.Context, request *oss.PutSymlinkRequest, optFns ...func(*oss.Options)) (*oss.PutSymlinkResult, error)
+	PutUserDefinedLogFieldsConfigFunc           func(ctx context.Context, request *oss.PutUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.PutUserDefinedLogFieldsConfigResult, error)
+	RestoreObjectFunc                           func(ctx context.Context, request *oss.RestoreObjectRequest, optFns ...func(*oss.Options)) (*oss.RestoreObjectResult, error)
+	SealAppendObjectFunc                        func(ctx context.Context, request *oss.SealAppendObjectRequest, optFns ...func(*oss.Options)) (*oss.SealAppendObjectResult, error)
+	SelectObjectFunc                            func(ctx context.Context, request *oss.SelectObjectRequest, optFns ...func(*oss.Options)) (*oss.SelectObjectResult, error)
+	SyncFunc                                    func(ctx context.Context, bucket string, prefix string, localDir string, direction oss.SyncDirection, optFns ...func(*oss.SyncOptions)) (*oss.SyncResult, error)
+	UploadPartFunc                              func(ctx context.Context, request *oss.UploadPartRequest, optFns ...func(*oss.Options)) (*oss.UploadPartResult, error)
+	UploadPartCopyFunc                          func(ctx context.Context, request *oss.UploadPartCopyRequest, optFns ...func(*oss.Options)) (*oss.UploadPartCopyResult, error)
+	WriteGetObjectResponseFunc                  func(ctx context.Context, request *oss.WriteGetObjectResponseRequest, optFns ...func(*oss.Options)) (*oss.WriteGetObjectResponseResult, error)
+}
+
+var _ oss.API = (*Client)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of the operation in order.
+func (m *Client) CallsOf(operation string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Client) record(operation string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Operation: operation, Args: args})
+}
+
+func notImplemented(operation string) error {
+	return fmt.Errorf("ossmock: %s is not implemented, set %sFunc", operation, operation)
+}
+
+// AbortBucketWorm records the call and calls AbortBucketWormFunc.
+func (m *Client) AbortBucketWorm(ctx context.Context, request *oss.AbortBucketWormRequest, optFns ...func(*oss.Options)) (*oss.AbortBucketWormResult, error) {
+	m.record("AbortBucketWorm", request, optFns)
+	if m.AbortBucketWormFunc == nil {
+		return nil, notImplemented("AbortBucketWorm")
+	}
+	return m.AbortBucketWormFunc(ctx, request, optFns...)
+}
+
+// AbortMultipartUpload records the call and calls AbortMultipartUploadFunc.
+func (m *Client) AbortMultipartUpload(ctx context.Context, request *oss.AbortMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.AbortMultipartUploadResult, error) {
+	m.record("AbortMultipartUpload", request, optFns)
+	if m.AbortMultipartUploadFunc == nil {
+		return nil, notImplemented("AbortMultipartUpload")
+	}
+	return m.AbortMultipartUploadFunc(ctx, request, optFns...)
+}
+
+// AppendFile records the call and calls AppendFileFunc.
+func (m *Client) AppendFile(ctx context.Context, bucket string, key string, optFns ...func(*oss.AppendOptions)) (*oss.AppendOnlyFile, error) {
+	m.record("AppendFile", bucket, key, optFns)
+	if m.AppendFileFunc == nil {
+		return nil, notImplemented("AppendFile")
+	}
+	return m.AppendFileFunc(ctx, bucket, key, optFns...)
+}
+
+// AppendObject records the call and calls AppendObjectFunc.
+func (m *Client) AppendObject(ctx context.Context, request *oss.AppendObjectRequest, optFns ...func(*oss.Options)) (*oss.AppendObjectResult, error) {
+	m.record("AppendObject", request, optFns)
+	if m.AppendObjectFunc == nil {
+		return nil, notImplemented("AppendObject")
+	}
+	return m.AppendObjectFunc(ctx, request, optFns...)
+}
+
+// AsyncProcessObject records the call and calls AsyncProcessObjectFunc.
+func (m *Client) AsyncProcessObject(ctx context.Context, request *oss.AsyncProcessObjectRequest, optFns ...func(*oss.Options)) (*oss.AsyncProcessObjectResult, error) {
+	m.record("AsyncProcessObject", request, optFns)
+	if m.AsyncProcessObjectFunc == nil {
+		return nil, notImplemented("AsyncProcessObject")
+	}
+	return m.AsyncProcessObjectFunc(ctx, request, optFns...)
+}
+
+// CleanRestoredObject records the call and calls CleanRestoredObjectFunc.
+func (m *Client) CleanRestoredObject(ctx context.Context, request *oss.CleanRestoredObjectRequest, optFns ...func(*oss.Options)) (*oss.CleanRestoredObjectResult, error) {
+	m.record("CleanRestoredObject", request, optFns)
+	if m.CleanRestoredObjectFunc == nil {
+		return nil, notImplemented("CleanRestoredObject")
+	}
+	return m.CleanRestoredObjectFunc(ctx, request, optFns...)
+}
+
+// CloseMetaQuery records the call and calls CloseMetaQueryFunc.
+func (m *Client) CloseMetaQuery(ctx context.Context, request *oss.CloseMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.CloseMetaQueryResult, error) {
+	m.record("CloseMetaQuery", request, optFns)
+	if m.CloseMetaQueryFunc == nil {
+		return nil, notImplemented("CloseMetaQuery")
+	}
+	return m.CloseMetaQueryFunc(ctx, request, optFns...)
+}
+
+// CompleteBucketWorm records the call and calls CompleteBucketWormFunc.
+func (m *Client) CompleteBucketWorm(ctx context.Context, request *oss.CompleteBucketWormRequest, optFns ...func(*oss.Options)) (*oss.CompleteBucketWormResult, error) {
+	m.record("CompleteBucketWorm", request, optFns)
+	if m.CompleteBucketWormFunc == nil {
+		return nil, notImplemented("CompleteBucketWorm")
+	}
+	return m.CompleteBucketWormFunc(ctx, request, optFns...)
+}
+
+// CompleteMultipartUpload records the call and calls CompleteMultipartUploadFunc.
+func (m *Client) CompleteMultipartUpload(ctx context.Context, request *oss.CompleteMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.CompleteMultipartUploadResult, error) {
+	m.record("CompleteMultipartUpload", request, optFns)
+	if m.CompleteMultipartUploadFunc == nil {
+		return nil, notImplemented("CompleteMultipartUpload")
+	}
+	return m.CompleteMultipartUploadFunc(ctx, request, optFns...)
+}
+
+// CopyObject records the call and calls CopyObjectFunc.
+func (m *Client) CopyObject(ctx context.Context, request *oss.CopyObjectRequest, optFns ...func(*oss.Options)) (*oss.CopyObjectResult, error) {
+	m.record("CopyObject", request, optFns)
+	if m.CopyObjectFunc == nil {
+		return nil, notImplemented("CopyObject")
+	}
+	return m.CopyObjectFunc(ctx, request, optFns...)
+}
+
+// CreateAccessPoint records the call and calls CreateAccessPointFunc.
+func (m *Client) CreateAccessPoint(ctx context.Context, request *oss.CreateAccessPointRequest, optFns ...func(*oss.Options)) (*oss.CreateAccessPointResult, error) {
+	m.record("CreateAccessPoint", request, optFns)
+	if m.CreateAccessPointFunc == nil {
+		return nil, notImplemented("CreateAccessPoint")
+	}
+	return m.CreateAccessPointFunc(ctx, request, optFns...)
+}
+
+// CreateAccessPointForObjectProcess records the call and calls CreateAccessPointForObjectProcessFunc.
+func (m *Client) CreateAccessPointForObjectProcess(ctx context.Context, request *oss.CreateAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.CreateAccessPointForObjectProcessResult, error) {
+	m.record("CreateAccessPointForObjectProcess", request, optFns)
+	if m.CreateAccessPointForObjectProcessFunc == nil {
+		return nil, notImplemented("CreateAccessPointForObjectProcess")
+	}
+	return m.CreateAccessPointForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// CreateBucketDataRedundancyTransition records the call and calls CreateBucketDataRedundancyTransitionFunc.
+func (m *Client) CreateBucketDataRedundancyTransition(ctx context.Context, request *oss.CreateBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.CreateBucketDataRedundancyTransitionResult, error) {
+	m.record("CreateBucketDataRedundancyTransition", request, optFns)
+	if m.CreateBucketDataRedundancyTransitionFunc == nil {
+		return nil, notImplemented("CreateBucketDataRedundancyTransition")
+	}
+	return m.CreateBucketDataRedundancyTransitionFunc(ctx, request, optFns...)
+}
+
+// CreateCnameToken records the call and calls CreateCnameTokenFunc.
+func (m *Client) CreateCnameToken(ctx context.Context, request *oss.CreateCnameTokenRequest, optFns ...func(*oss.Options)) (*oss.CreateCnameTokenResult, error) {
+	m.record("CreateCnameToken", request, optFns)
+	if m.CreateCnameTokenFunc == nil {
+		return nil, notImplemented("CreateCnameToken")
+	}
+	return m.CreateCnameTokenFunc(ctx, request, optFns...)
+}
+
+// CreateSelectObjectMeta records the call and calls CreateSelectObjectMetaFunc.
+func (m *Client) CreateSelectObjectMeta(ctx context.Context, request *oss.CreateSelectObjectMetaRequest, optFns ...func(*oss.Options)) (*oss.CreateSelectObjectMetaResult, error) {
+	m.record("CreateSelectObjectMeta", request, optFns)
+	if m.CreateSelectObjectMetaFunc == nil {
+		return nil, notImplemented("CreateSelectObjectMeta")
+	}
+	return m.CreateSelectObjectMetaFunc(ctx, request, optFns...)
+}
+
+// DeleteAccessPoint records the call and calls DeleteAccessPointFunc.
+func (m *Client) DeleteAccessPoint(ctx context.Context, request *oss.DeleteAccessPointRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointResult, error) {
+	m.record("DeleteAccessPoint", request, optFns)
+	if m.DeleteAccessPointFunc == nil {
+		return nil, notImplemented("DeleteAccessPoint")
+	}
+	return m.DeleteAccessPointFunc(ctx, request, optFns...)
+}
+
+// DeleteAccessPointForObjectProcess records the call and calls DeleteAccessPointForObjectProcessFunc.
+func (m *Client) DeleteAccessPointForObjectProcess(ctx context.Context, request *oss.DeleteAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointForObjectProcessResult, error) {
+	m.record("DeleteAccessPointForObjectProcess", request, optFns)
+	if m.DeleteAccessPointForObjectProcessFunc == nil {
+		return nil, notImplemented("DeleteAccessPointForObjectProcess")
+	}
+	return m.DeleteAccessPointForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// DeleteAccessPointPolicy records the call and calls DeleteAccessPointPolicyFunc.
+func (m *Client) DeleteAccessPointPolicy(ctx context.Context, request *oss.DeleteAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPolicyResult, error) {
+	m.record("DeleteAccessPointPolicy", request, optFns)
+	if m.DeleteAccessPointPolicyFunc == nil {
+		return nil, notImplemented("DeleteAccessPointPolicy")
+	}
+	return m.DeleteAccessPointPolicyFunc(ctx, request, optFns...)
+}
+
+// DeleteAccessPointPolicyForObjectProcess records the call and calls DeleteAccessPointPolicyForObjectProcessFunc.
+func (m *Client) DeleteAccessPointPolicyForObjectProcess(ctx context.Context, request *oss.DeleteAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPolicyForObjectProcessResult, error) {
+	m.record("DeleteAccessPointPolicyForObjectProcess", request, optFns)
+	if m.DeleteAccessPointPolicyForObjectProcessFunc == nil {
+		return nil, notImplemented("DeleteAccessPointPolicyForObjectProcess")
+	}
+	return m.DeleteAccessPointPolicyForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// DeleteAccessPointPublicAccessBlock records the call and calls DeleteAccessPointPublicAccessBlockFunc.
+func (m *Client) DeleteAccessPointPublicAccessBlock(ctx context.Context, request *oss.DeleteAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeleteAccessPointPublicAccessBlockResult, error) {
+	m.record("DeleteAccessPointPublicAccessBlock", request, optFns)
+	if m.DeleteAccessPointPublicAccessBlockFunc == nil {
+		return nil, notImplemented("DeleteAccessPointPublicAccessBlock")
+	}
+	return m.DeleteAccessPointPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// DeleteBucket records the call and calls DeleteBucketFunc.
+func (m *Client) DeleteBucket(ctx context.Context, request *oss.DeleteBucketRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketResult, error) {
+	m.record("DeleteBucket", request, optFns)
+	if m.DeleteBucketFunc == nil {
+		return nil, notImplemented("DeleteBucket")
+	}
+	return m.DeleteBucketFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketCors records the call and calls DeleteBucketCorsFunc.
+func (m *Client) DeleteBucketCors(ctx context.Context, request *oss.DeleteBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketCorsResult, error) {
+	m.record("DeleteBucketCors", request, optFns)
+	if m.DeleteBucketCorsFunc == nil {
+		return nil, notImplemented("DeleteBucketCors")
+	}
+	return m.DeleteBucketCorsFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketDataRedundancyTransition records the call and calls DeleteBucketDataRedundancyTransitionFunc.
+func (m *Client) DeleteBucketDataRedundancyTransition(ctx context.Context, request *oss.DeleteBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketDataRedundancyTransitionResult, error) {
+	m.record("DeleteBucketDataRedundancyTransition", request, optFns)
+	if m.DeleteBucketDataRedundancyTransitionFunc == nil {
+		return nil, notImplemented("DeleteBucketDataRedundancyTransition")
+	}
+	return m.DeleteBucketDataRedundancyTransitionFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketEncryption records the call and calls DeleteBucketEncryptionFunc.
+func (m *Client) DeleteBucketEncryption(ctx context.Context, request *oss.DeleteBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketEncryptionResult, error) {
+	m.record("DeleteBucketEncryption", request, optFns)
+	if m.DeleteBucketEncryptionFunc == nil {
+		return nil, notImplemented("DeleteBucketEncryption")
+	}
+	return m.DeleteBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketInventory records the call and calls DeleteBucketInventoryFunc.
+func (m *Client) DeleteBucketInventory(ctx context.Context, request *oss.DeleteBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketInventoryResult, error) {
+	m.record("DeleteBucketInventory", request, optFns)
+	if m.DeleteBucketInventoryFunc == nil {
+		return nil, notImplemented("DeleteBucketInventory")
+	}
+	return m.DeleteBucketInventoryFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketLifecycle records the call and calls DeleteBucketLifecycleFunc.
+func (m *Client) DeleteBucketLifecycle(ctx context.Context, request *oss.DeleteBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketLifecycleResult, error) {
+	m.record("DeleteBucketLifecycle", request, optFns)
+	if m.DeleteBucketLifecycleFunc == nil {
+		return nil, notImplemented("DeleteBucketLifecycle")
+	}
+	return m.DeleteBucketLifecycleFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketLogging records the call and calls DeleteBucketLoggingFunc.
+func (m *Client) DeleteBucketLogging(ctx context.Context, request *oss.DeleteBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketLoggingResult, error) {
+	m.record("DeleteBucketLogging", request, optFns)
+	if m.DeleteBucketLoggingFunc == nil {
+		return nil, notImplemented("DeleteBucketLogging")
+	}
+	return m.DeleteBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketOverwriteConfig records the call and calls DeleteBucketOverwriteConfigFunc.
+func (m *Client) DeleteBucketOverwriteConfig(ctx context.Context, request *oss.DeleteBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketOverwriteConfigResult, error) {
+	m.record("DeleteBucketOverwriteConfig", request, optFns)
+	if m.DeleteBucketOverwriteConfigFunc == nil {
+		return nil, notImplemented("DeleteBucketOverwriteConfig")
+	}
+	return m.DeleteBucketOverwriteConfigFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketPolicy records the call and calls DeleteBucketPolicyFunc.
+func (m *Client) DeleteBucketPolicy(ctx context.Context, request *oss.DeleteBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketPolicyResult, error) {
+	m.record("DeleteBucketPolicy", request, optFns)
+	if m.DeleteBucketPolicyFunc == nil {
+		return nil, notImplemented("DeleteBucketPolicy")
+	}
+	return m.DeleteBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketPublicAccessBlock records the call and calls DeleteBucketPublicAccessBlockFunc.
+func (m *Client) DeleteBucketPublicAccessBlock(ctx context.Context, request *oss.DeleteBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketPublicAccessBlockResult, error) {
+	m.record("DeleteBucketPublicAccessBlock", request, optFns)
+	if m.DeleteBucketPublicAccessBlockFunc == nil {
+		return nil, notImplemented("DeleteBucketPublicAccessBlock")
+	}
+	return m.DeleteBucketPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketReplication records the call and calls DeleteBucketReplicationFunc.
+func (m *Client) DeleteBucketReplication(ctx context.Context, request *oss.DeleteBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketReplicationResult, error) {
+	m.record("DeleteBucketReplication", request, optFns)
+	if m.DeleteBucketReplicationFunc == nil {
+		return nil, notImplemented("DeleteBucketReplication")
+	}
+	return m.DeleteBucketReplicationFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketTags records the call and calls DeleteBucketTagsFunc.
+func (m *Client) DeleteBucketTags(ctx context.Context, request *oss.DeleteBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketTagsResult, error) {
+	m.record("DeleteBucketTags", request, optFns)
+	if m.DeleteBucketTagsFunc == nil {
+		return nil, notImplemented("DeleteBucketTags")
+	}
+	return m.DeleteBucketTagsFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketWebsite records the call and calls DeleteBucketWebsiteFunc.
+func (m *Client) DeleteBucketWebsite(ctx context.Context, request *oss.DeleteBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.DeleteBucketWebsiteResult, error) {
+	m.record("DeleteBucketWebsite", request, optFns)
+	if m.DeleteBucketWebsiteFunc == nil {
+		return nil, notImplemented("DeleteBucketWebsite")
+	}
+	return m.DeleteBucketWebsiteFunc(ctx, request, optFns...)
+}
+
+// DeleteCname records the call and calls DeleteCnameFunc.
+func (m *Client) DeleteCname(ctx context.Context, request *oss.DeleteCnameRequest, optFns ...func(*oss.Options)) (*oss.DeleteCnameResult, error) {
+	m.record("DeleteCname", request, optFns)
+	if m.DeleteCnameFunc == nil {
+		return nil, notImplemented("DeleteCname")
+	}
+	return m.DeleteCnameFunc(ctx, request, optFns...)
+}
+
+// DeleteMultipleObjects records the call and calls DeleteMultipleObjectsFunc.
+func (m *Client) DeleteMultipleObjects(ctx context.Context, request *oss.DeleteMultipleObjectsRequest, optFns ...func(*oss.Options)) (*oss.DeleteMultipleObjectsResult, error) {
+	m.record("DeleteMultipleObjects", request, optFns)
+	if m.DeleteMultipleObjectsFunc == nil {
+		return nil, notImplemented("DeleteMultipleObjects")
+	}
+	return m.DeleteMultipleObjectsFunc(ctx, request, optFns...)
+}
+
+// DeleteObject records the call and calls DeleteObjectFunc.
+func (m *Client) DeleteObject(ctx context.Context, request *oss.DeleteObjectRequest, optFns ...func(*oss.Options)) (*oss.DeleteObjectResult, error) {
+	m.record("DeleteObject", request, optFns)
+	if m.DeleteObjectFunc == nil {
+		return nil, notImplemented("DeleteObject")
+	}
+	return m.DeleteObjectFunc(ctx, request, optFns...)
+}
+
+// DeleteObjectTagging records the call and calls DeleteObjectTaggingFunc.
+func (m *Client) DeleteObjectTagging(ctx context.Context, request *oss.DeleteObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.DeleteObjectTaggingResult, error) {
+	m.record("DeleteObjectTagging", request, optFns)
+	if m.DeleteObjectTaggingFunc == nil {
+		return nil, notImplemented("DeleteObjectTagging")
+	}
+	return m.DeleteObjectTaggingFunc(ctx, request, optFns...)
+}
+
+// DeletePublicAccessBlock records the call and calls DeletePublicAccessBlockFunc.
+func (m *Client) DeletePublicAccessBlock(ctx context.Context, request *oss.DeletePublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.DeletePublicAccessBlockResult, error) {
+	m.record("DeletePublicAccessBlock", request, optFns)
+	if m.DeletePublicAccessBlockFunc == nil {
+		return nil, notImplemented("DeletePublicAccessBlock")
+	}
+	return m.DeletePublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// DeleteStyle records the call and calls DeleteStyleFunc.
+func (m *Client) DeleteStyle(ctx context.Context, request *oss.DeleteStyleRequest, optFns ...func(*oss.Options)) (*oss.DeleteStyleResult, error) {
+	m.record("DeleteStyle", request, optFns)
+	if m.DeleteStyleFunc == nil {
+		return nil, notImplemented("DeleteStyle")
+	}
+	return m.DeleteStyleFunc(ctx, request, optFns...)
+}
+
+// DeleteUserDefinedLogFieldsConfig records the call and calls DeleteUserDefinedLogFieldsConfigFunc.
+func (m *Client) DeleteUserDefinedLogFieldsConfig(ctx context.Context, request *oss.DeleteUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.DeleteUserDefinedLogFieldsConfigResult, error) {
+	m.record("DeleteUserDefinedLogFieldsConfig", request, optFns)
+	if m.DeleteUserDefinedLogFieldsConfigFunc == nil {
+		return nil, notImplemented("DeleteUserDefinedLogFieldsConfig")
+	}
+	return m.DeleteUserDefinedLogFieldsConfigFunc(ctx, request, optFns...)
+}
+
+// DescribeRegions records the call and calls DescribeRegionsFunc.
+func (m *Client) DescribeRegions(ctx context.Context, request *oss.DescribeRegionsRequest, optFns ...func(*oss.Options)) (*oss.DescribeRegionsResult, error) {
+	m.record("DescribeRegions", request, optFns)
+	if m.DescribeRegionsFunc == nil {
+		return nil, notImplemented("DescribeRegions")
+	}
+	return m.DescribeRegionsFunc(ctx, request, optFns...)
+}
+
+// DoDataPipeLineAction records the call and calls DoDataPipeLineActionFunc.
+func (m *Client) DoDataPipeLineAction(ctx context.Context, request *oss.DoDataPipeLineActionRequest, optFns ...func(*oss.Options)) (*oss.DoDataPipeLineActionResult, error) {
+	m.record("DoDataPipeLineAction", request, optFns)
+	if m.DoDataPipeLineActionFunc == nil {
+		return nil, notImplemented("DoDataPipeLineAction")
+	}
+	return m.DoDataPipeLineActionFunc(ctx, request, optFns...)
+}
+
+// DoMetaQuery records the call and calls DoMetaQueryFunc.
+func (m *Client) DoMetaQuery(ctx context.Context, request *oss.DoMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.DoMetaQueryResult, error) {
+	m.record("DoMetaQuery", request, optFns)
+	if m.DoMetaQueryFunc == nil {
+		return nil, notImplemented("DoMetaQuery")
+	}
+	return m.DoMetaQueryFunc(ctx, request, optFns...)
+}
+
+// DoMetaQueryAction records the call and calls DoMetaQueryActionFunc.
+func (m *Client) DoMetaQueryAction(ctx context.Context, request *oss.DoMetaQueryActionRequest, optFns ...func(*oss.Options)) (*oss.DoMetaQueryActionResult, error) {
+	m.record("DoMetaQueryAction", request, optFns)
+	if m.DoMetaQueryActionFunc == nil {
+		return nil, notImplemented("DoMetaQueryAction")
+	}
+	return m.DoMetaQueryActionFunc(ctx, request, optFns...)
+}
+
+// ExtendBucketWorm records the call and calls ExtendBucketWormFunc.
+func (m *Client) ExtendBucketWorm(ctx context.Context, request *oss.ExtendBucketWormRequest, optFns ...func(*oss.Options)) (*oss.ExtendBucketWormResult, error) {
+	m.record("ExtendBucketWorm", request, optFns)
+	if m.ExtendBucketWormFunc == nil {
+		return nil, notImplemented("ExtendBucketWorm")
+	}
+	return m.ExtendBucketWormFunc(ctx, request, optFns...)
+}
+
+// GetAccessPoint records the call and calls GetAccessPointFunc.
+func (m *Client) GetAccessPoint(ctx context.Context, request *oss.GetAccessPointRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointResult, error) {
+	m.record("GetAccessPoint", request, optFns)
+	if m.GetAccessPointFunc == nil {
+		return nil, notImplemented("GetAccessPoint")
+	}
+	return m.GetAccessPointFunc(ctx, request, optFns...)
+}
+
+// GetAccessPointConfigForObjectProcess records the call and calls GetAccessPointConfigForObjectProcessFunc.
+func (m *Client) GetAccessPointConfigForObjectProcess(ctx context.Context, request *oss.GetAccessPointConfigForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointConfigForObjectProcessResult, error) {
+	m.record("GetAccessPointConfigForObjectProcess", request, optFns)
+	if m.GetAccessPointConfigForObjectProcessFunc == nil {
+		return nil, notImplemented("GetAccessPointConfigForObjectProcess")
+	}
+	return m.GetAccessPointConfigForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// GetAccessPointForObjectProcess records the call and calls GetAccessPointForObjectProcessFunc.
+func (m *Client) GetAccessPointForObjectProcess(ctx context.Context, request *oss.GetAccessPointForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointForObjectProcessResult, error) {
+	m.record("GetAccessPointForObjectProcess", request, optFns)
+	if m.GetAccessPointForObjectProcessFunc == nil {
+		return nil, notImplemented("GetAccessPointForObjectProcess")
+	}
+	return m.GetAccessPointForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// GetAccessPointPolicy records the call and calls GetAccessPointPolicyFunc.
+func (m *Client) GetAccessPointPolicy(ctx context.Context, request *oss.GetAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPolicyResult, error) {
+	m.record("GetAccessPointPolicy", request, optFns)
+	if m.GetAccessPointPolicyFunc == nil {
+		return nil, notImplemented("GetAccessPointPolicy")
+	}
+	return m.GetAccessPointPolicyFunc(ctx, request, optFns...)
+}
+
+// GetAccessPointPolicyForObjectProcess records the call and calls GetAccessPointPolicyForObjectProcessFunc.
+func (m *Client) GetAccessPointPolicyForObjectProcess(ctx context.Context, request *oss.GetAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPolicyForObjectProcessResult, error) {
+	m.record("GetAccessPointPolicyForObjectProcess", request, optFns)
+	if m.GetAccessPointPolicyForObjectProcessFunc == nil {
+		return nil, notImplemented("GetAccessPointPolicyForObjectProcess")
+	}
+	return m.GetAccessPointPolicyForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// GetAccessPointPublicAccessBlock records the call and calls GetAccessPointPublicAccessBlockFunc.
+func (m *Client) GetAccessPointPublicAccessBlock(ctx context.Context, request *oss.GetAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetAccessPointPublicAccessBlockResult, error) {
+	m.record("GetAccessPointPublicAccessBlock", request, optFns)
+	if m.GetAccessPointPublicAccessBlockFunc == nil {
+		return nil, notImplemented("GetAccessPointPublicAccessBlock")
+	}
+	return m.GetAccessPointPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// GetBucketAccessMonitor records the call and calls GetBucketAccessMonitorFunc.
+func (m *Client) GetBucketAccessMonitor(ctx context.Context, request *oss.GetBucketAccessMonitorRequest, optFns ...func(*oss.Options)) (*oss.GetBucketAccessMonitorResult, error) {
+	m.record("GetBucketAccessMonitor", request, optFns)
+	if m.GetBucketAccessMonitorFunc == nil {
+		return nil, notImplemented("GetBucketAccessMonitor")
+	}
+	return m.GetBucketAccessMonitorFunc(ctx, request, optFns...)
+}
+
+// GetBucketAcl records the call and calls GetBucketAclFunc.
+func (m *Client) GetBucketAcl(ctx context.Context, request *oss.GetBucketAclRequest, optFns ...func(*oss.Options)) (*oss.GetBucketAclResult, error) {
+	m.record("GetBucketAcl", request, optFns)
+	if m.GetBucketAclFunc == nil {
+		return nil, notImplemented("GetBucketAcl")
+	}
+	return m.GetBucketAclFunc(ctx, request, optFns...)
+}
+
+// GetBucketArchiveDirectRead records the call and calls GetBucketArchiveDirectReadFunc.
+func (m *Client) GetBucketArchiveDirectRead(ctx context.Context, request *oss.GetBucketArchiveDirectReadRequest, optFns ...func(*oss.Options)) (*oss.GetBucketArchiveDirectReadResult, error) {
+	m.record("GetBucketArchiveDirectRead", request, optFns)
+	if m.GetBucketArchiveDirectReadFunc == nil {
+		return nil, notImplemented("GetBucketArchiveDirectRead")
+	}
+	return m.GetBucketArchiveDirectReadFunc(ctx, request, optFns...)
+}
+
+// GetBucketCors records the call and calls GetBucketCorsFunc.
+func (m *Client) GetBucketCors(ctx context.Context, request *oss.GetBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.GetBucketCorsResult, error) {
+	m.record("GetBucketCors", request, optFns)
+	if m.GetBucketCorsFunc == nil {
+		return nil, notImplemented("GetBucketCors")
+	}
+	return m.GetBucketCorsFunc(ctx, request, optFns...)
+}
+
+// GetBucketDataRedundancyTransition records the call and calls GetBucketDataRedundancyTransitionFunc.
+func (m *Client) GetBucketDataRedundancyTransition(ctx context.Context, request *oss.GetBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.GetBucketDataRedundancyTransitionResult, error) {
+	m.record("GetBucketDataRedundancyTransition", request, optFns)
+	if m.GetBucketDataRedundancyTransitionFunc == nil {
+		return nil, notImplemented("GetBucketDataRedundancyTransition")
+	}
+	return m.GetBucketDataRedundancyTransitionFunc(ctx, request, optFns...)
+}
+
+// GetBucketEncryption records the call and calls GetBucketEncryptionFunc.
+func (m *Client) GetBucketEncryption(ctx context.Context, request *oss.GetBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.GetBucketEncryptionResult, error) {
+	m.record("GetBucketEncryption", request, optFns)
+	if m.GetBucketEncryptionFunc == nil {
+		return nil, notImplemented("GetBucketEncryption")
+	}
+	return m.GetBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// GetBucketHttpsConfig records the call and calls GetBucketHttpsConfigFunc.
+func (m *Client) GetBucketHttpsConfig(ctx context.Context, request *oss.GetBucketHttpsConfigRequest, optFns ...func(*oss.Options)) (*oss.GetBucketHttpsConfigResult, error) {
+	m.record("GetBucketHttpsConfig", request, optFns)
+	if m.GetBucketHttpsConfigFunc == nil {
+		return nil, notImplemented("GetBucketHttpsConfig")
+	}
+	return m.GetBucketHttpsConfigFunc(ctx, request, optFns...)
+}
+
+// GetBucketInfo records the call and calls GetBucketInfoFunc.
+func (m *Client) GetBucketInfo(ctx context.Context, request *oss.GetBucketInfoRequest, optFns ...func(*oss.Options)) (*oss.GetBucketInfoResult, error) {
+	m.record("GetBucketInfo", request, optFns)
+	if m.GetBucketInfoFunc == nil {
+		return nil, notImplemented("GetBucketInfo")
+	}
+	return m.GetBucketInfoFunc(ctx, request, optFns...)
+}
+
+// GetBucketInventory records the call and calls GetBucketInventoryFunc.
+func (m *Client) GetBucketInventory(ctx context.Context, request *oss.GetBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.GetBucketInventoryResult, error) {
+	m.record("GetBucketInventory", request, optFns)
+	if m.GetBucketInventoryFunc == nil {
+		return nil, notImplemented("GetBucketInventory")
+	}
+	return m.GetBucketInventoryFunc(ctx, request, optFns...)
+}
+
+// GetBucketLifecycle records the call and calls GetBucketLifecycleFunc.
+func (m *Client) GetBucketLifecycle(ctx context.Context, request *oss.GetBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLifecycleResult, error) {
+	m.record("GetBucketLifecycle", request, optFns)
+	if m.GetBucketLifecycleFunc == nil {
+		return nil, notImplemented("GetBucketLifecycle")
+	}
+	return m.GetBucketLifecycleFunc(ctx, request, optFns...)
+}
+
+// GetBucketLocation records the call and calls GetBucketLocationFunc.
+func (m *Client) GetBucketLocation(ctx context.Context, request *oss.GetBucketLocationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLocationResult, error) {
+	m.record("GetBucketLocation", request, optFns)
+	if m.GetBucketLocationFunc == nil {
+		return nil, notImplemented("GetBucketLocation")
+	}
+	return m.GetBucketLocationFunc(ctx, request, optFns...)
+}
+
+// GetBucketLogging records the call and calls GetBucketLoggingFunc.
+func (m *Client) GetBucketLogging(ctx context.Context, request *oss.GetBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.GetBucketLoggingResult, error) {
+	m.record("GetBucketLogging", request, optFns)
+	if m.GetBucketLoggingFunc == nil {
+		return nil, notImplemented("GetBucketLogging")
+	}
+	return m.GetBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// GetBucketObjectWormConfiguration records the call and calls GetBucketObjectWormConfigurationFunc.
+func (m *Client) GetBucketObjectWormConfiguration(ctx context.Context, request *oss.GetBucketObjectWormConfigurationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketObjectWormConfigurationResult, error) {
+	m.record("GetBucketObjectWormConfiguration", request, optFns)
+	if m.GetBucketObjectWormConfigurationFunc == nil {
+		return nil, notImplemented("GetBucketObjectWormConfiguration")
+	}
+	return m.GetBucketObjectWormConfigurationFunc(ctx, request, optFns...)
+}
+
+// GetBucketOverwriteConfig records the call and calls GetBucketOverwriteConfigFunc.
+func (m *Client) GetBucketOverwriteConfig(ctx context.Context, request *oss.GetBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.GetBucketOverwriteConfigResult, error) {
+	m.record("GetBucketOverwriteConfig", request, optFns)
+	if m.GetBucketOverwriteConfigFunc == nil {
+		return nil, notImplemented("GetBucketOverwriteConfig")
+	}
+	return m.GetBucketOverwriteConfigFunc(ctx, request, optFns...)
+}
+
+// GetBucketPolicy records the call and calls GetBucketPolicyFunc.
+func (m *Client) GetBucketPolicy(ctx context.Context, request *oss.GetBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPolicyResult, error) {
+	m.record("GetBucketPolicy", request, optFns)
+	if m.GetBucketPolicyFunc == nil {
+		return nil, notImplemented("GetBucketPolicy")
+	}
+	return m.GetBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// GetBucketPolicyStatus records the call and calls GetBucketPolicyStatusFunc.
+func (m *Client) GetBucketPolicyStatus(ctx context.Context, request *oss.GetBucketPolicyStatusRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPolicyStatusResult, error) {
+	m.record("GetBucketPolicyStatus", request, optFns)
+	if m.GetBucketPolicyStatusFunc == nil {
+		return nil, notImplemented("GetBucketPolicyStatus")
+	}
+	return m.GetBucketPolicyStatusFunc(ctx, request, optFns...)
+}
+
+// GetBucketPublicAccessBlock records the call and calls GetBucketPublicAccessBlockFunc.
+func (m *Client) GetBucketPublicAccessBlock(ctx context.Context, request *oss.GetBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetBucketPublicAccessBlockResult, error) {
+	m.record("GetBucketPublicAccessBlock", request, optFns)
+	if m.GetBucketPublicAccessBlockFunc == nil {
+		return nil, notImplemented("GetBucketPublicAccessBlock")
+	}
+	return m.GetBucketPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// GetBucketReferer records the call and calls GetBucketRefererFunc.
+func (m *Client) GetBucketReferer(ctx context.Context, request *oss.GetBucketRefererRequest, optFns ...func(*oss.Options)) (*oss.GetBucketRefererResult, error) {
+	m.record("GetBucketReferer", request, optFns)
+	if m.GetBucketRefererFunc == nil {
+		return nil, notImplemented("GetBucketReferer")
+	}
+	return m.GetBucketRefererFunc(ctx, request, optFns...)
+}
+
+// GetBucketReplication records the call and calls GetBucketReplicationFunc.
+func (m *Client) GetBucketReplication(ctx context.Context, request *oss.GetBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationResult, error) {
+	m.record("GetBucketReplication", request, optFns)
+	if m.GetBucketReplicationFunc == nil {
+		return nil, notImplemented("GetBucketReplication")
+	}
+	return m.GetBucketReplicationFunc(ctx, request, optFns...)
+}
+
+// GetBucketReplicationLocation records the call and calls GetBucketReplicationLocationFunc.
+func (m *Client) GetBucketReplicationLocation(ctx context.Context, request *oss.GetBucketReplicationLocationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationLocationResult, error) {
+	m.record("GetBucketReplicationLocation", request, optFns)
+	if m.GetBucketReplicationLocationFunc == nil {
+		return nil, notImplemented("GetBucketReplicationLocation")
+	}
+	return m.GetBucketReplicationLocationFunc(ctx, request, optFns...)
+}
+
+// GetBucketReplicationProgress records the call and calls GetBucketReplicationProgressFunc.
+func (m *Client) GetBucketReplicationProgress(ctx context.Context, request *oss.GetBucketReplicationProgressRequest, optFns ...func(*oss.Options)) (*oss.GetBucketReplicationProgressResult, error) {
+	m.record("GetBucketReplicationProgress", request, optFns)
+	if m.GetBucketReplicationProgressFunc == nil {
+		return nil, notImplemented("GetBucketReplicationProgress")
+	}
+	return m.GetBucketReplicationProgressFunc(ctx, request, optFns...)
+}
+
+// GetBucketRequestPayment records the call and calls GetBucketRequestPaymentFunc.
+func (m *Client) GetBucketRequestPayment(ctx context.Context, request *oss.GetBucketRequestPaymentRequest, optFns ...func(*oss.Options)) (*oss.GetBucketRequestPaymentResult, error) {
+	m.record("GetBucketRequestPayment", request, optFns)
+	if m.GetBucketRequestPaymentFunc == nil {
+		return nil, notImplemented("GetBucketRequestPayment")
+	}
+	return m.GetBucketRequestPaymentFunc(ctx, request, optFns...)
+}
+
+// GetBucketResourceGroup records the call and calls GetBucketResourceGroupFunc.
+func (m *Client) GetBucketResourceGroup(ctx context.Context, request *oss.GetBucketResourceGroupRequest, optFns ...func(*oss.Options)) (*oss.GetBucketResourceGroupResult, error) {
+	m.record("GetBucketResourceGroup", request, optFns)
+	if m.GetBucketResourceGroupFunc == nil {
+		return nil, notImplemented("GetBucketResourceGroup")
+	}
+	return m.GetBucketResourceGroupFunc(ctx, request, optFns...)
+}
+
+// GetBucketStat records the call and calls GetBucketStatFunc.
+func (m *Client) GetBucketStat(ctx context.Context, request *oss.GetBucketStatRequest, optFns ...func(*oss.Options)) (*oss.GetBucketStatResult, error) {
+	m.record("GetBucketStat", request, optFns)
+	if m.GetBucketStatFunc == nil {
+		return nil, notImplemented("GetBucketStat")
+	}
+	return m.GetBucketStatFunc(ctx, request, optFns...)
+}
+
+// GetBucketTags records the call and calls GetBucketTagsFunc.
+func (m *Client) GetBucketTags(ctx context.Context, request *oss.GetBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.GetBucketTagsResult, error) {
+	m.record("GetBucketTags", request, optFns)
+	if m.GetBucketTagsFunc == nil {
+		return nil, notImplemented("GetBucketTags")
+	}
+	return m.GetBucketTagsFunc(ctx, request, optFns...)
+}
+
+// GetBucketTransferAcceleration records the call and calls GetBucketTransferAccelerationFunc.
+func (m *Client) GetBucketTransferAcceleration(ctx context.Context, request *oss.GetBucketTransferAccelerationRequest, optFns ...func(*oss.Options)) (*oss.GetBucketTransferAccelerationResult, error) {
+	m.record("GetBucketTransferAcceleration", request, optFns)
+	if m.GetBucketTransferAccelerationFunc == nil {
+		return nil, notImplemented("GetBucketTransferAcceleration")
+	}
+	return m.GetBucketTransferAccelerationFunc(ctx, request, optFns...)
+}
+
+// GetBucketVersioning records the call and calls GetBucketVersioningFunc.
+func (m *Client) GetBucketVersioning(ctx context.Context, request *oss.GetBucketVersioningRequest, optFns ...func(*oss.Options)) (*oss.GetBucketVersioningResult, error) {
+	m.record("GetBucketVersioning", request, optFns)
+	if m.GetBucketVersioningFunc == nil {
+		return nil, notImplemented("GetBucketVersioning")
+	}
+	return m.GetBucketVersioningFunc(ctx, request, optFns...)
+}
+
+// GetBucketWebsite records the call and calls GetBucketWebsiteFunc.
+func (m *Client) GetBucketWebsite(ctx context.Context, request *oss.GetBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.GetBucketWebsiteResult, error) {
+	m.record("GetBucketWebsite", request, optFns)
+	if m.GetBucketWebsiteFunc == nil {
+		return nil, notImplemented("GetBucketWebsite")
+	}
+	return m.GetBucketWebsiteFunc(ctx, request, optFns...)
+}
+
+// GetBucketWorm records the call and calls GetBucketWormFunc.
+func (m *Client) GetBucketWorm(ctx context.Context, request *oss.GetBucketWormRequest, optFns ...func(*oss.Options)) (*oss.GetBucketWormResult, error) {
+	m.record("GetBucketWorm", request, optFns)
+	if m.GetBucketWormFunc == nil {
+		return nil, notImplemented("GetBucketWorm")
+	}
+	return m.GetBucketWormFunc(ctx, request, optFns...)
+}
+
+// GetCnameToken records the call and calls GetCnameTokenFunc.
+func (m *Client) GetCnameToken(ctx context.Context, request *oss.GetCnameTokenRequest, optFns ...func(*oss.Options)) (*oss.GetCnameTokenResult, error) {
+	m.record("GetCnameToken", request, optFns)
+	if m.GetCnameTokenFunc == nil {
+		return nil, notImplemented("GetCnameToken")
+	}
+	return m.GetCnameTokenFunc(ctx, request, optFns...)
+}
+
+// GetMetaQueryStatus records the call and calls GetMetaQueryStatusFunc.
+func (m *Client) GetMetaQueryStatus(ctx context.Context, request *oss.GetMetaQueryStatusRequest, optFns ...func(*oss.Options)) (*oss.GetMetaQueryStatusResult, error) {
+	m.record("GetMetaQueryStatus", request, optFns)
+	if m.GetMetaQueryStatusFunc == nil {
+		return nil, notImplemented("GetMetaQueryStatus")
+	}
+	return m.GetMetaQueryStatusFunc(ctx, request, optFns...)
+}
+
+// GetObject records the call and calls GetObjectFunc.
+func (m *Client) GetObject(ctx context.Context, request *oss.GetObjectRequest, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error) {
+	m.record("GetObject", request, optFns)
+	if m.GetObjectFunc == nil {
+		return nil, notImplemented("GetObject")
+	}
+	return m.GetObjectFunc(ctx, request, optFns...)
+}
+
+// GetObjectAcl records the call and calls GetObjectAclFunc.
+func (m *Client) GetObjectAcl(ctx context.Context, request *oss.GetObjectAclRequest, optFns ...func(*oss.Options)) (*oss.GetObjectAclResult, error) {
+	m.record("GetObjectAcl", request, optFns)
+	if m.GetObjectAclFunc == nil {
+		return nil, notImplemented("GetObjectAcl")
+	}
+	return m.GetObjectAclFunc(ctx, request, optFns...)
+}
+
+// GetObjectLegalHold records the call and calls GetObjectLegalHoldFunc.
+func (m *Client) GetObjectLegalHold(ctx context.Context, request *oss.GetObjectLegalHoldRequest, optFns ...func(*oss.Options)) (*oss.GetObjectLegalHoldResult, error) {
+	m.record("GetObjectLegalHold", request, optFns)
+	if m.GetObjectLegalHoldFunc == nil {
+		return nil, notImplemented("GetObjectLegalHold")
+	}
+	return m.GetObjectLegalHoldFunc(ctx, request, optFns...)
+}
+
+// GetObjectMeta records the call and calls GetObjectMetaFunc.
+func (m *Client) GetObjectMeta(ctx context.Context, request *oss.GetObjectMetaRequest, optFns ...func(*oss.Options)) (*oss.GetObjectMetaResult, error) {
+	m.record("GetObjectMeta", request, optFns)
+	if m.GetObjectMetaFunc == nil {
+		return nil, notImplemented("GetObjectMeta")
+	}
+	return m.GetObjectMetaFunc(ctx, request, optFns...)
+}
+
+// GetObjectRetention records the call and calls GetObjectRetentionFunc.
+func (m *Client) GetObjectRetention(ctx context.Context, request *oss.GetObjectRetentionRequest, optFns ...func(*oss.Options)) (*oss.GetObjectRetentionResult, error) {
+	m.record("GetObjectRetention", request, optFns)
+	if m.GetObjectRetentionFunc == nil {
+		return nil, notImplemented("GetObjectRetention")
+	}
+	return m.GetObjectRetentionFunc(ctx, request, optFns...)
+}
+
+// GetObjectTagging records the call and calls GetObjectTaggingFunc.
+func (m *Client) GetObjectTagging(ctx context.Context, request *oss.GetObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.GetObjectTaggingResult, error) {
+	m.record("GetObjectTagging", request, optFns)
+	if m.GetObjectTaggingFunc == nil {
+		return nil, notImplemented("GetObjectTagging")
+	}
+	return m.GetObjectTaggingFunc(ctx, request, optFns...)
+}
+
+// GetObjectToFile records the call and calls GetObjectToFileFunc.
+func (m *Client) GetObjectToFile(ctx context.Context, request *oss.GetObjectRequest, filePath string, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error) {
+	m.record("GetObjectToFile", request, filePath, optFns)
+	if m.GetObjectToFileFunc == nil {
+		return nil, notImplemented("GetObjectToFile")
+	}
+	return m.GetObjectToFileFunc(ctx, request, filePath, optFns...)
+}
+
+// GetObjectToFileV2 records the call and calls GetObjectToFileV2Func.
+func (m *Client) GetObjectToFileV2(ctx context.Context, request *oss.GetObjectRequest, filePath string, writeBufferSize *int, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error) {
+	m.record("GetObjectToFileV2", request, filePath, writeBufferSize, optFns)
+	if m.GetObjectToFileV2Func == nil {
+		return nil, notImplemented("GetObjectToFileV2")
+	}
+	return m.GetObjectToFileV2Func(ctx, request, filePath, writeBufferSize, optFns...)
+}
+
+// GetPublicAccessBlock records the call and calls GetPublicAccessBlockFunc.
+func (m *Client) GetPublicAccessBlock(ctx context.Context, request *oss.GetPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.GetPublicAccessBlockResult, error) {
+	m.record("GetPublicAccessBlock", request, optFns)
+	if m.GetPublicAccessBlockFunc == nil {
+		return nil, notImplemented("GetPublicAccessBlock")
+	}
+	return m.GetPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// GetStyle records the call and calls GetStyleFunc.
+func (m *Client) GetStyle(ctx context.Context, request *oss.GetStyleRequest, optFns ...func(*oss.Options)) (*oss.GetStyleResult, error) {
+	m.record("GetStyle", request, optFns)
+	if m.GetStyleFunc == nil {
+		return nil, notImplemented("GetStyle")
+	}
+	return m.GetStyleFunc(ctx, request, optFns...)
+}
+
+// GetSymlink records the call and calls GetSymlinkFunc.
+func (m *Client) GetSymlink(ctx context.Context, request *oss.GetSymlinkRequest, optFns ...func(*oss.Options)) (*oss.GetSymlinkResult, error) {
+	m.record("GetSymlink", request, optFns)
+	if m.GetSymlinkFunc == nil {
+		return nil, notImplemented("GetSymlink")
+	}
+	return m.GetSymlinkFunc(ctx, request, optFns...)
+}
+
+// GetUserDefinedLogFieldsConfig records the call and calls GetUserDefinedLogFieldsConfigFunc.
+func (m *Client) GetUserDefinedLogFieldsConfig(ctx context.Context, request *oss.GetUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.GetUserDefinedLogFieldsConfigResult, error) {
+	m.record("GetUserDefinedLogFieldsConfig", request, optFns)
+	if m.GetUserDefinedLogFieldsConfigFunc == nil {
+		return nil, notImplemented("GetUserDefinedLogFieldsConfig")
+	}
+	return m.GetUserDefinedLogFieldsConfigFunc(ctx, request, optFns...)
+}
+
+// HeadObject records the call and calls HeadObjectFunc.
+func (m *Client) HeadObject(ctx context.Context, request *oss.HeadObjectRequest, optFns ...func(*oss.Options)) (*oss.HeadObjectResult, error) {
+	m.record("HeadObject", request, optFns)
+	if m.HeadObjectFunc == nil {
+		return nil, notImplemented("HeadObject")
+	}
+	return m.HeadObjectFunc(ctx, request, optFns...)
+}
+
+// InitiateBucketWorm records the call and calls InitiateBucketWormFunc.
+func (m *Client) InitiateBucketWorm(ctx context.Context, request *oss.InitiateBucketWormRequest, optFns ...func(*oss.Options)) (*oss.InitiateBucketWormResult, error) {
+	m.record("InitiateBucketWorm", request, optFns)
+	if m.InitiateBucketWormFunc == nil {
+		return nil, notImplemented("InitiateBucketWorm")
+	}
+	return m.InitiateBucketWormFunc(ctx, request, optFns...)
+}
+
+// InitiateMultipartUpload records the call and calls InitiateMultipartUploadFunc.
+func (m *Client) InitiateMultipartUpload(ctx context.Context, request *oss.InitiateMultipartUploadRequest, optFns ...func(*oss.Options)) (*oss.InitiateMultipartUploadResult, error) {
+	m.record("InitiateMultipartUpload", request, optFns)
+	if m.InitiateMultipartUploadFunc == nil {
+		return nil, notImplemented("InitiateMultipartUpload")
+	}
+	return m.InitiateMultipartUploadFunc(ctx, request, optFns...)
+}
+
+// InvokeOperation records the call and calls InvokeOperationFunc.
+func (m *Client) InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error) {
+	m.record("InvokeOperation", input, optFns)
+	if m.InvokeOperationFunc == nil {
+		return nil, notImplemented("InvokeOperation")
+	}
+	return m.InvokeOperationFunc(ctx, input, optFns...)
+}
+
+// IsBucketExist records the call and calls IsBucketExistFunc.
+func (m *Client) IsBucketExist(ctx context.Context, bucket string, optFns ...func(*oss.Options)) (bool, error) {
+	m.record("IsBucketExist", bucket, optFns)
+	if m.IsBucketExistFunc == nil {
+		return false, notImplemented("IsBucketExist")
+	}
+	return m.IsBucketExistFunc(ctx, bucket, optFns...)
+}
+
+// IsObjectExist records the call and calls IsObjectExistFunc.
+func (m *Client) IsObjectExist(ctx context.Context, bucket string, key string, optFns ...func(*oss.IsObjectExistOptions)) (bool, error) {
+	m.record("IsObjectExist", bucket, key, optFns)
+	if m.IsObjectExistFunc == nil {
+		return false, notImplemented("IsObjectExist")
+	}
+	return m.IsObjectExistFunc(ctx, bucket, key, optFns...)
+}
+
+// ListAccessPoints records the call and calls ListAccessPointsFunc.
+func (m *Client) ListAccessPoints(ctx context.Context, request *oss.ListAccessPointsRequest, optFns ...func(*oss.Options)) (*oss.ListAccessPointsResult, error) {
+	m.record("ListAccessPoints", request, optFns)
+	if m.ListAccessPointsFunc == nil {
+		return nil, notImplemented("ListAccessPoints")
+	}
+	return m.ListAccessPointsFunc(ctx, request, optFns...)
+}
+
+// ListAccessPointsForObjectProcess records the call and calls ListAccessPointsForObjectProcessFunc.
+func (m *Client) ListAccessPointsForObjectProcess(ctx context.Context, request *oss.ListAccessPointsForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.ListAccessPointsForObjectProcessResult, error) {
+	m.record("ListAccessPointsForObjectProcess", request, optFns)
+	if m.ListAccessPointsForObjectProcessFunc == nil {
+		return nil, notImplemented("ListAccessPointsForObjectProcess")
+	}
+	return m.ListAccessPointsForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// ListBucketDataRedundancyTransition records the call and calls ListBucketDataRedundancyTransitionFunc.
+func (m *Client) ListBucketDataRedundancyTransition(ctx context.Context, request *oss.ListBucketDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.ListBucketDataRedundancyTransitionResult, error) {
+	m.record("ListBucketDataRedundancyTransition", request, optFns)
+	if m.ListBucketDataRedundancyTransitionFunc == nil {
+		return nil, notImplemented("ListBucketDataRedundancyTransition")
+	}
+	return m.ListBucketDataRedundancyTransitionFunc(ctx, request, optFns...)
+}
+
+// ListBucketInventory records the call and calls ListBucketInventoryFunc.
+func (m *Client) ListBucketInventory(ctx context.Context, request *oss.ListBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.ListBucketInventoryResult, error) {
+	m.record("ListBucketInventory", request, optFns)
+	if m.ListBucketInventoryFunc == nil {
+		return nil, notImplemented("ListBucketInventory")
+	}
+	return m.ListBucketInventoryFunc(ctx, request, optFns...)
+}
+
+// ListBuckets records the call and calls ListBucketsFunc.
+func (m *Client) ListBuckets(ctx context.Context, request *oss.ListBucketsRequest, optFns ...func(*oss.Options)) (*oss.ListBucketsResult, error) {
+	m.record("ListBuckets", request, optFns)
+	if m.ListBucketsFunc == nil {
+		return nil, notImplemented("ListBuckets")
+	}
+	return m.ListBucketsFunc(ctx, request, optFns...)
+}
+
+// ListCloudBoxes records the call and calls ListCloudBoxesFunc.
+func (m *Client) ListCloudBoxes(ctx context.Context, request *oss.ListCloudBoxesRequest, optFns ...func(*oss.Options)) (*oss.ListCloudBoxesResult, error) {
+	m.record("ListCloudBoxes", request, optFns)
+	if m.ListCloudBoxesFunc == nil {
+		return nil, notImplemented("ListCloudBoxes")
+	}
+	return m.ListCloudBoxesFunc(ctx, request, optFns...)
+}
+
+// ListCname records the call and calls ListCnameFunc.
+func (m *Client) ListCname(ctx context.Context, request *oss.ListCnameRequest, optFns ...func(*oss.Options)) (*oss.ListCnameResult, error) {
+	m.record("ListCname", request, optFns)
+	if m.ListCnameFunc == nil {
+		return nil, notImplemented("ListCname")
+	}
+	return m.ListCnameFunc(ctx, request, optFns...)
+}
+
+// ListMultipartUploads records the call and calls ListMultipartUploadsFunc.
+func (m *Client) ListMultipartUploads(ctx context.Context, request *oss.ListMultipartUploadsRequest, optFns ...func(*oss.Options)) (*oss.ListMultipartUploadsResult, error) {
+	m.record("ListMultipartUploads", request, optFns)
+	if m.ListMultipartUploadsFunc == nil {
+		return nil, notImplemented("ListMultipartUploads")
+	}
+	return m.ListMultipartUploadsFunc(ctx, request, optFns...)
+}
+
+// ListObjectVersions records the call and calls ListObjectVersionsFunc.
+func (m *Client) ListObjectVersions(ctx context.Context, request *oss.ListObjectVersionsRequest, optFns ...func(*oss.Options)) (*oss.ListObjectVersionsResult, error) {
+	m.record("ListObjectVersions", request, optFns)
+	if m.ListObjectVersionsFunc == nil {
+		return nil, notImplemented("ListObjectVersions")
+	}
+	return m.ListObjectVersionsFunc(ctx, request, optFns...)
+}
+
+// ListObjects records the call and calls ListObjectsFunc.
+func (m *Client) ListObjects(ctx context.Context, request *oss.ListObjectsRequest, optFns ...func(*oss.Options)) (*oss.ListObjectsResult, error) {
+	m.record("ListObjects", request, optFns)
+	if m.ListObjectsFunc == nil {
+		return nil, notImplemented("ListObjects")
+	}
+	return m.ListObjectsFunc(ctx, request, optFns...)
+}
+
+// ListObjectsV2 records the call and calls ListObjectsV2Func.
+func (m *Client) ListObjectsV2(ctx context.Context, request *oss.ListObjectsV2Request, optFns ...func(*oss.Options)) (*oss.ListObjectsV2Result, error) {
+	m.record("ListObjectsV2", request, optFns)
+	if m.ListObjectsV2Func == nil {
+		return nil, notImplemented("ListObjectsV2")
+	}
+	return m.ListObjectsV2Func(ctx, request, optFns...)
+}
+
+// ListParts records the call and calls ListPartsFunc.
+func (m *Client) ListParts(ctx context.Context, request *oss.ListPartsRequest, optFns ...func(*oss.Options)) (*oss.ListPartsResult, error) {
+	m.record("ListParts", request, optFns)
+	if m.ListPartsFunc == nil {
+		return nil, notImplemented("ListParts")
+	}
+	return m.ListPartsFunc(ctx, request, optFns...)
+}
+
+// ListStyle records the call and calls ListStyleFunc.
+func (m *Client) ListStyle(ctx context.Context, request *oss.ListStyleRequest, optFns ...func(*oss.Options)) (*oss.ListStyleResult, error) {
+	m.record("ListStyle", request, optFns)
+	if m.ListStyleFunc == nil {
+		return nil, notImplemented("ListStyle")
+	}
+	return m.ListStyleFunc(ctx, request, optFns...)
+}
+
+// ListUserDataRedundancyTransition records the call and calls ListUserDataRedundancyTransitionFunc.
+func (m *Client) ListUserDataRedundancyTransition(ctx context.Context, request *oss.ListUserDataRedundancyTransitionRequest, optFns ...func(*oss.Options)) (*oss.ListUserDataRedundancyTransitionResult, error) {
+	m.record("ListUserDataRedundancyTransition", request, optFns)
+	if m.ListUserDataRedundancyTransitionFunc == nil {
+		return nil, notImplemented("ListUserDataRedundancyTransition")
+	}
+	return m.ListUserDataRedundancyTransitionFunc(ctx, request, optFns...)
+}
+
+// OpenFile records the call and calls OpenFileFunc.
+func (m *Client) OpenFile(ctx context.Context, bucket string, key string, optFns ...func(*oss.OpenOptions)) (*oss.ReadOnlyFile, error) {
+	m.record("OpenFile", bucket, key, optFns)
+	if m.OpenFileFunc == nil {
+		return nil, notImplemented("OpenFile")
+	}
+	return m.OpenFileFunc(ctx, bucket, key, optFns...)
+}
+
+// OpenMetaQuery records the call and calls OpenMetaQueryFunc.
+func (m *Client) OpenMetaQuery(ctx context.Context, request *oss.OpenMetaQueryRequest, optFns ...func(*oss.Options)) (*oss.OpenMetaQueryResult, error) {
+	m.record("OpenMetaQuery", request, optFns)
+	if m.OpenMetaQueryFunc == nil {
+		return nil, notImplemented("OpenMetaQuery")
+	}
+	return m.OpenMetaQueryFunc(ctx, request, optFns...)
+}
+
+// OptionObject records the call and calls OptionObjectFunc.
+func (m *Client) OptionObject(ctx context.Context, request *oss.OptionObjectRequest, optFns ...func(*oss.Options)) (*oss.OptionObjectResult, error) {
+	m.record("OptionObject", request, optFns)
+	if m.OptionObjectFunc == nil {
+		return nil, notImplemented("OptionObject")
+	}
+	return m.OptionObjectFunc(ctx, request, optFns...)
+}
+
+// PostObject records the call and calls PostObjectFunc.
+func (m *Client) PostObject(ctx context.Context, request *oss.PostObjectRequest, optFns ...func(*oss.Options)) (*oss.PostObjectResult, error) {
+	m.record("PostObject", request, optFns)
+	if m.PostObjectFunc == nil {
+		return nil, notImplemented("PostObject")
+	}
+	return m.PostObjectFunc(ctx, request, optFns...)
+}
+
+// Presign records the call and calls PresignFunc.
+func (m *Client) Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error) {
+	m.record("Presign", request, optFns)
+	if m.PresignFunc == nil {
+		return nil, notImplemented("Presign")
+	}
+	return m.PresignFunc(ctx, request, optFns...)
+}
+
+// PresignOperation records the call and calls PresignOperationFunc.
+func (m *Client) PresignOperation(ctx context.Context, api any, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error) {
+	m.record("PresignOperation", api, request, optFns)
+	if m.PresignOperationFunc == nil {
+		return nil, notImplemented("PresignOperation")
+	}
+	return m.PresignOperationFunc(ctx, api, request, optFns...)
+}
+
+// PresignPostObject records the call and calls PresignPostObjectFunc.
+func (m *Client) PresignPostObject(ctx context.Context, policy *oss.PostPolicy, optFns ...func(*oss.Options)) (*oss.PresignPostObjectResult, error) {
+	m.record("PresignPostObject", policy, optFns)
+	if m.PresignPostObjectFunc == nil {
+		return nil, notImplemented("PresignPostObject")
+	}
+	return m.PresignPostObjectFunc(ctx, policy, optFns...)
+}
+
+// ProcessObject records the call and calls ProcessObjectFunc.
+func (m *Client) ProcessObject(ctx context.Context, request *oss.ProcessObjectRequest, optFns ...func(*oss.Options)) (*oss.ProcessObjectResult, error) {
+	m.record("ProcessObject", request, optFns)
+	if m.ProcessObjectFunc == nil {
+		return nil, notImplemented("ProcessObject")
+	}
+	return m.ProcessObjectFunc(ctx, request, optFns...)
+}
+
+// PutAccessPointConfigForObjectProcess records the call and calls PutAccessPointConfigForObjectProcessFunc.
+func (m *Client) PutAccessPointConfigForObjectProcess(ctx context.Context, request *oss.PutAccessPointConfigForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointConfigForObjectProcessResult, error) {
+	m.record("PutAccessPointConfigForObjectProcess", request, optFns)
+	if m.PutAccessPointConfigForObjectProcessFunc == nil {
+		return nil, notImplemented("PutAccessPointConfigForObjectProcess")
+	}
+	return m.PutAccessPointConfigForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// PutAccessPointPolicy records the call and calls PutAccessPointPolicyFunc.
+func (m *Client) PutAccessPointPolicy(ctx context.Context, request *oss.PutAccessPointPolicyRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPolicyResult, error) {
+	m.record("PutAccessPointPolicy", request, optFns)
+	if m.PutAccessPointPolicyFunc == nil {
+		return nil, notImplemented("PutAccessPointPolicy")
+	}
+	return m.PutAccessPointPolicyFunc(ctx, request, optFns...)
+}
+
+// PutAccessPointPolicyForObjectProcess records the call and calls PutAccessPointPolicyForObjectProcessFunc.
+func (m *Client) PutAccessPointPolicyForObjectProcess(ctx context.Context, request *oss.PutAccessPointPolicyForObjectProcessRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPolicyForObjectProcessResult, error) {
+	m.record("PutAccessPointPolicyForObjectProcess", request, optFns)
+	if m.PutAccessPointPolicyForObjectProcessFunc == nil {
+		return nil, notImplemented("PutAccessPointPolicyForObjectProcess")
+	}
+	return m.PutAccessPointPolicyForObjectProcessFunc(ctx, request, optFns...)
+}
+
+// PutAccessPointPublicAccessBlock records the call and calls PutAccessPointPublicAccessBlockFunc.
+func (m *Client) PutAccessPointPublicAccessBlock(ctx context.Context, request *oss.PutAccessPointPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutAccessPointPublicAccessBlockResult, error) {
+	m.record("PutAccessPointPublicAccessBlock", request, optFns)
+	if m.PutAccessPointPublicAccessBlockFunc == nil {
+		return nil, notImplemented("PutAccessPointPublicAccessBlock")
+	}
+	return m.PutAccessPointPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// PutBucket records the call and calls PutBucketFunc.
+func (m *Client) PutBucket(ctx context.Context, request *oss.PutBucketRequest, optFns ...func(*oss.Options)) (*oss.PutBucketResult, error) {
+	m.record("PutBucket", request, optFns)
+	if m.PutBucketFunc == nil {
+		return nil, notImplemented("PutBucket")
+	}
+	return m.PutBucketFunc(ctx, request, optFns...)
+}
+
+// PutBucketAccessMonitor records the call and calls PutBucketAccessMonitorFunc.
+func (m *Client) PutBucketAccessMonitor(ctx context.Context, request *oss.PutBucketAccessMonitorRequest, optFns ...func(*oss.Options)) (*oss.PutBucketAccessMonitorResult, error) {
+	m.record("PutBucketAccessMonitor", request, optFns)
+	if m.PutBucketAccessMonitorFunc == nil {
+		return nil, notImplemented("PutBucketAccessMonitor")
+	}
+	return m.PutBucketAccessMonitorFunc(ctx, request, optFns...)
+}
+
+// PutBucketAcl records the call and calls PutBucketAclFunc.
+func (m *Client) PutBucketAcl(ctx context.Context, request *oss.PutBucketAclRequest, optFns ...func(*oss.Options)) (*oss.PutBucketAclResult, error) {
+	m.record("PutBucketAcl", request, optFns)
+	if m.PutBucketAclFunc == nil {
+		return nil, notImplemented("PutBucketAcl")
+	}
+	return m.PutBucketAclFunc(ctx, request, optFns...)
+}
+
+// PutBucketArchiveDirectRead records the call and calls PutBucketArchiveDirectReadFunc.
+func (m *Client) PutBucketArchiveDirectRead(ctx context.Context, request *oss.PutBucketArchiveDirectReadRequest, optFns ...func(*oss.Options)) (*oss.PutBucketArchiveDirectReadResult, error) {
+	m.record("PutBucketArchiveDirectRead", request, optFns)
+	if m.PutBucketArchiveDirectReadFunc == nil {
+		return nil, notImplemented("PutBucketArchiveDirectRead")
+	}
+	return m.PutBucketArchiveDirectReadFunc(ctx, request, optFns...)
+}
+
+// PutBucketCors records the call and calls PutBucketCorsFunc.
+func (m *Client) PutBucketCors(ctx context.Context, request *oss.PutBucketCorsRequest, optFns ...func(*oss.Options)) (*oss.PutBucketCorsResult, error) {
+	m.record("PutBucketCors", request, optFns)
+	if m.PutBucketCorsFunc == nil {
+		return nil, notImplemented("PutBucketCors")
+	}
+	return m.PutBucketCorsFunc(ctx, request, optFns...)
+}
+
+// PutBucketEncryption records the call and calls PutBucketEncryptionFunc.
+func (m *Client) PutBucketEncryption(ctx context.Context, request *oss.PutBucketEncryptionRequest, optFns ...func(*oss.Options)) (*oss.PutBucketEncryptionResult, error) {
+	m.record("PutBucketEncryption", request, optFns)
+	if m.PutBucketEncryptionFunc == nil {
+		return nil, notImplemented("PutBucketEncryption")
+	}
+	return m.PutBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// PutBucketHttpsConfig records the call and calls PutBucketHttpsConfigFunc.
+func (m *Client) PutBucketHttpsConfig(ctx context.Context, request *oss.PutBucketHttpsConfigRequest, optFns ...func(*oss.Options)) (*oss.PutBucketHttpsConfigResult, error) {
+	m.record("PutBucketHttpsConfig", request, optFns)
+	if m.PutBucketHttpsConfigFunc == nil {
+		return nil, notImplemented("PutBucketHttpsConfig")
+	}
+	return m.PutBucketHttpsConfigFunc(ctx, request, optFns...)
+}
+
+// PutBucketInventory records the call and calls PutBucketInventoryFunc.
+func (m *Client) PutBucketInventory(ctx context.Context, request *oss.PutBucketInventoryRequest, optFns ...func(*oss.Options)) (*oss.PutBucketInventoryResult, error) {
+	m.record("PutBucketInventory", request, optFns)
+	if m.PutBucketInventoryFunc == nil {
+		return nil, notImplemented("PutBucketInventory")
+	}
+	return m.PutBucketInventoryFunc(ctx, request, optFns...)
+}
+
+// PutBucketLifecycle records the call and calls PutBucketLifecycleFunc.
+func (m *Client) PutBucketLifecycle(ctx context.Context, request *oss.PutBucketLifecycleRequest, optFns ...func(*oss.Options)) (*oss.PutBucketLifecycleResult, error) {
+	m.record("PutBucketLifecycle", request, optFns)
+	if m.PutBucketLifecycleFunc == nil {
+		return nil, notImplemented("PutBucketLifecycle")
+	}
+	return m.PutBucketLifecycleFunc(ctx, request, optFns...)
+}
+
+// PutBucketLogging records the call and calls PutBucketLoggingFunc.
+func (m *Client) PutBucketLogging(ctx context.Context, request *oss.PutBucketLoggingRequest, optFns ...func(*oss.Options)) (*oss.PutBucketLoggingResult, error) {
+	m.record("PutBucketLogging", request, optFns)
+	if m.PutBucketLoggingFunc == nil {
+		return nil, notImplemented("PutBucketLogging")
+	}
+	return m.PutBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// PutBucketObjectWormConfiguration records the call and calls PutBucketObjectWormConfigurationFunc.
+func (m *Client) PutBucketObjectWormConfiguration(ctx context.Context, request *oss.PutBucketObjectWormConfigurationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketObjectWormConfigurationResult, error) {
+	m.record("PutBucketObjectWormConfiguration", request, optFns)
+	if m.PutBucketObjectWormConfigurationFunc == nil {
+		return nil, notImplemented("PutBucketObjectWormConfiguration")
+	}
+	return m.PutBucketObjectWormConfigurationFunc(ctx, request, optFns...)
+}
+
+// PutBucketOverwriteConfig records the call and calls PutBucketOverwriteConfigFunc.
+func (m *Client) PutBucketOverwriteConfig(ctx context.Context, request *oss.PutBucketOverwriteConfigRequest, optFns ...func(*oss.Options)) (*oss.PutBucketOverwriteConfigResult, error) {
+	m.record("PutBucketOverwriteConfig", request, optFns)
+	if m.PutBucketOverwriteConfigFunc == nil {
+		return nil, notImplemented("PutBucketOverwriteConfig")
+	}
+	return m.PutBucketOverwriteConfigFunc(ctx, request, optFns...)
+}
+
+// PutBucketPolicy records the call and calls PutBucketPolicyFunc.
+func (m *Client) PutBucketPolicy(ctx context.Context, request *oss.PutBucketPolicyRequest, optFns ...func(*oss.Options)) (*oss.PutBucketPolicyResult, error) {
+	m.record("PutBucketPolicy", request, optFns)
+	if m.PutBucketPolicyFunc == nil {
+		return nil, notImplemented("PutBucketPolicy")
+	}
+	return m.PutBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// PutBucketPublicAccessBlock records the call and calls PutBucketPublicAccessBlockFunc.
+func (m *Client) PutBucketPublicAccessBlock(ctx context.Context, request *oss.PutBucketPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutBucketPublicAccessBlockResult, error) {
+	m.record("PutBucketPublicAccessBlock", request, optFns)
+	if m.PutBucketPublicAccessBlockFunc == nil {
+		return nil, notImplemented("PutBucketPublicAccessBlock")
+	}
+	return m.PutBucketPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// PutBucketReferer records the call and calls PutBucketRefererFunc.
+func (m *Client) PutBucketReferer(ctx context.Context, request *oss.PutBucketRefererRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRefererResult, error) {
+	m.record("PutBucketReferer", request, optFns)
+	if m.PutBucketRefererFunc == nil {
+		return nil, notImplemented("PutBucketReferer")
+	}
+	return m.PutBucketRefererFunc(ctx, request, optFns...)
+}
+
+// PutBucketReplication records the call and calls PutBucketReplicationFunc.
+func (m *Client) PutBucketReplication(ctx context.Context, request *oss.PutBucketReplicationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketReplicationResult, error) {
+	m.record("PutBucketReplication", request, optFns)
+	if m.PutBucketReplicationFunc == nil {
+		return nil, notImplemented("PutBucketReplication")
+	}
+	return m.PutBucketReplicationFunc(ctx, request, optFns...)
+}
+
+// PutBucketRequestPayment records the call and calls PutBucketRequestPaymentFunc.
+func (m *Client) PutBucketRequestPayment(ctx context.Context, request *oss.PutBucketRequestPaymentRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRequestPaymentResult, error) {
+	m.record("PutBucketRequestPayment", request, optFns)
+	if m.PutBucketRequestPaymentFunc == nil {
+		return nil, notImplemented("PutBucketRequestPayment")
+	}
+	return m.PutBucketRequestPaymentFunc(ctx, request, optFns...)
+}
+
+// PutBucketResourceGroup records the call and calls PutBucketResourceGroupFunc.
+func (m *Client) PutBucketResourceGroup(ctx context.Context, request *oss.PutBucketResourceGroupRequest, optFns ...func(*oss.Options)) (*oss.PutBucketResourceGroupResult, error) {
+	m.record("PutBucketResourceGroup", request, optFns)
+	if m.PutBucketResourceGroupFunc == nil {
+		return nil, notImplemented("PutBucketResourceGroup")
+	}
+	return m.PutBucketResourceGroupFunc(ctx, request, optFns...)
+}
+
+// PutBucketRtc records the call and calls PutBucketRtcFunc.
+func (m *Client) PutBucketRtc(ctx context.Context, request *oss.PutBucketRtcRequest, optFns ...func(*oss.Options)) (*oss.PutBucketRtcResult, error) {
+	m.record("PutBucketRtc", request, optFns)
+	if m.PutBucketRtcFunc == nil {
+		return nil, notImplemented("PutBucketRtc")
+	}
+	return m.PutBucketRtcFunc(ctx, request, optFns...)
+}
+
+// PutBucketTags records the call and calls PutBucketTagsFunc.
+func (m *Client) PutBucketTags(ctx context.Context, request *oss.PutBucketTagsRequest, optFns ...func(*oss.Options)) (*oss.PutBucketTagsResult, error) {
+	m.record("PutBucketTags", request, optFns)
+	if m.PutBucketTagsFunc == nil {
+		return nil, notImplemented("PutBucketTags")
+	}
+	return m.PutBucketTagsFunc(ctx, request, optFns...)
+}
+
+// PutBucketTransferAcceleration records the call and calls PutBucketTransferAccelerationFunc.
+func (m *Client) PutBucketTransferAcceleration(ctx context.Context, request *oss.PutBucketTransferAccelerationRequest, optFns ...func(*oss.Options)) (*oss.PutBucketTransferAccelerationResult, error) {
+	m.record("PutBucketTransferAcceleration", request, optFns)
+	if m.PutBucketTransferAccelerationFunc == nil {
+		return nil, notImplemented("PutBucketTransferAcceleration")
+	}
+	return m.PutBucketTransferAccelerationFunc(ctx, request, optFns...)
+}
+
+// PutBucketVersioning records the call and calls PutBucketVersioningFunc.
+func (m *Client) PutBucketVersioning(ctx context.Context, request *oss.PutBucketVersioningRequest, optFns ...func(*oss.Options)) (*oss.PutBucketVersioningResult, error) {
+	m.record("PutBucketVersioning", request, optFns)
+	if m.PutBucketVersioningFunc == nil {
+		return nil, notImplemented("PutBucketVersioning")
+	}
+	return m.PutBucketVersioningFunc(ctx, request, optFns...)
+}
+
+// PutBucketWebsite records the call and calls PutBucketWebsiteFunc.
+func (m *Client) PutBucketWebsite(ctx context.Context, request *oss.PutBucketWebsiteRequest, optFns ...func(*oss.Options)) (*oss.PutBucketWebsiteResult, error) {
+	m.record("PutBucketWebsite", request, optFns)
+	if m.PutBucketWebsiteFunc == nil {
+		return nil, notImplemented("PutBucketWebsite")
+	}
+	return m.PutBucketWebsiteFunc(ctx, request, optFns...)
+}
+
+// PutCname records the call and calls PutCnameFunc.
+func (m *Client) PutCname(ctx context.Context, request *oss.PutCnameRequest, optFns ...func(*oss.Options)) (*oss.PutCnameResult, error) {
+	m.record("PutCname", request, optFns)
+	if m.PutCnameFunc == nil {
+		return nil, notImplemented("PutCname")
+	}
+	return m.PutCnameFunc(ctx, request, optFns...)
+}
+
+// PutObject records the call and calls PutObjectFunc.
+func (m *Client) PutObject(ctx context.Context, request *oss.PutObjectRequest, optFns ...func(*oss.Options)) (*oss.PutObjectResult, error) {
+	m.record("PutObject", request, optFns)
+	if m.PutObjectFunc == nil {
+		return nil, notImplemented("PutObject")
+	}
+	return m.PutObjectFunc(ctx, request, optFns...)
+}
+
+// PutObjectAcl records the call and calls PutObjectAclFunc.
+func (m *Client) PutObjectAcl(ctx context.Context, request *oss.PutObjectAclRequest, optFns ...func(*oss.Options)) (*oss.PutObjectAclResult, error) {
+	m.record("PutObjectAcl", request, optFns)
+	if m.PutObjectAclFunc == nil {
+		return nil, notImplemented("PutObjectAcl")
+	}
+	return m.PutObjectAclFunc(ctx, request, optFns...)
+}
+
+// PutObjectFromFile records the call and calls PutObjectFromFileFunc.
+func (m *Client) PutObjectFromFile(ctx context.Context, request *oss.PutObjectRequest, filePath string, optFns ...func(*oss.Options)) (*oss.PutObjectResult, error) {
+	m.record("PutObjectFromFile", request, filePath, optFns)
+	if m.PutObjectFromFileFunc == nil {
+		return nil, notImplemented("PutObjectFromFile")
+	}
+	return m.PutObjectFromFileFunc(ctx, request, filePath, optFns...)
+}
+
+// PutObjectLegalHold records the call and calls PutObjectLegalHoldFunc.
+func (m *Client) PutObjectLegalHold(ctx context.Context, request *oss.PutObjectLegalHoldRequest, optFns ...func(*oss.Options)) (*oss.PutObjectLegalHoldResult, error) {
+	m.record("PutObjectLegalHold", request, optFns)
+	if m.PutObjectLegalHoldFunc == nil {
+		return nil, notImplemented("PutObjectLegalHold")
+	}
+	return m.PutObjectLegalHoldFunc(ctx, request, optFns...)
+}
+
+// PutObjectRetention records the call and calls PutObjectRetentionFunc.
+func (m *Client) PutObjectRetention(ctx context.Context, request *oss.PutObjectRetentionRequest, optFns ...func(*oss.Options)) (*oss.PutObjectRetentionResult, error) {
+	m.record("PutObjectRetention", request, optFns)
+	if m.PutObjectRetentionFunc == nil {
+		return nil, notImplemented("PutObjectRetention")
+	}
+	return m.PutObjectRetentionFunc(ctx, request, optFns...)
+}
+
+// PutObjectTagging records the call and calls PutObjectTaggingFunc.
+func (m *Client) PutObjectTagging(ctx context.Context, request *oss.PutObjectTaggingRequest, optFns ...func(*oss.Options)) (*oss.PutObjectTaggingResult, error) {
+	m.record("PutObjectTagging", request, optFns)
+	if m.PutObjectTaggingFunc == nil {
+		return nil, notImplemented("PutObjectTagging")
+	}
+	return m.PutObjectTaggingFunc(ctx, request, optFns...)
+}
+
+// PutPublicAccessBlock records the call and calls PutPublicAccessBlockFunc.
+func (m *Client) PutPublicAccessBlock(ctx context.Context, request *oss.PutPublicAccessBlockRequest, optFns ...func(*oss.Options)) (*oss.PutPublicAccessBlockResult, error) {
+	m.record("PutPublicAccessBlock", request, optFns)
+	if m.PutPublicAccessBlockFunc == nil {
+		return nil, notImplemented("PutPublicAccessBlock")
+	}
+	return m.PutPublicAccessBlockFunc(ctx, request, optFns...)
+}
+
+// PutStyle records the call and calls PutStyleFunc.
+func (m *Client) PutStyle(ctx context.Context, request *oss.PutStyleRequest, optFns ...func(*oss.Options)) (*oss.PutStyleResult, error) {
+	m.record("PutStyle", request, optFns)
+	if m.PutStyleFunc == nil {
+		return nil, notImplemented("PutStyle")
+	}
+	return m.PutStyleFunc(ctx, request, optFns...)
+}
+
+// PutSymlink records the call and calls PutSymlinkFunc.
+func (m *Client) PutSymlink(ctx context.Context, request *oss.PutSymlinkRequest, optFns ...func(*oss.Options)) (*oss.PutSymlinkResult, error) {
+	m.record("PutSymlink", request, optFns)
+	if m.PutSymlinkFunc == nil {
+		return nil, notImplemented("PutSymlink")
+	}
+	return m.PutSymlinkFunc(ctx, request, optFns...)
+}
+
+// PutUserDefinedLogFieldsConfig records the call and calls PutUserDefinedLogFieldsConfigFunc.
+func (m *Client) PutUserDefinedLogFieldsConfig(ctx context.Context, request *oss.PutUserDefinedLogFieldsConfigRequest, optFns ...func(*oss.Options)) (*oss.PutUserDefinedLogFieldsConfigResult, error) {
+	m.record("PutUserDefinedLogFieldsConfig", request, optFns)
+	if m.PutUserDefinedLogFieldsConfigFunc == nil {
+		return nil, notImplemented("PutUserDefinedLogFieldsConfig")
+	}
+	return m.PutUserDefinedLogFieldsConfigFunc(ctx, request, optFns...)
+}
+
+// RestoreObject records the call and calls RestoreObjectFunc.
+func (m *Client) RestoreObject(ctx context.Context, request *oss.RestoreObjectRequest, optFns ...func(*oss.Options)) (*oss.RestoreObjectResult, error) {
+	m.record("RestoreObject", request, optFns)
+	if m.RestoreObjectFunc == nil {
+		return nil, notImplemented("RestoreObject")
+	}
+	return m.RestoreObjectFunc(ctx, request, optFns...)
+}
+
+// SealAppendObject records the call and calls SealAppendObjectFunc.
+func (m *Client) SealAppendObject(ctx context.Context, request *oss.SealAppendObjectRequest, optFns ...func(*oss.Options)) (*oss.SealAppendObjectResult, error) {
+	m.record("SealAppendObject", request, optFns)
+	if m.SealAppendObjectFunc == nil {
+		return nil, notImplemented("SealAppendObject")
+	}
+	return m.SealAppendObjectFunc(ctx, request, optFns...)
+}
+
+// SelectObject records the call and calls SelectObjectFunc.
+func (m *Client) SelectObject(ctx context.Context, request *oss.SelectObjectRequest, optFns ...func(*oss.Options)) (*oss.SelectObjectResult, error) {
+	m.record("SelectObject", request, optFns)
+	if m.SelectObjectFunc == nil {
+		return nil, notImplemented("SelectObject")
+	}
+	return m.SelectObjectFunc(ctx, request, optFns...)
+}
+
+// Sync records the call and calls SyncFunc.
+func (m *Client) Sync(ctx context.Context, bucket string, prefix string, localDir string, direction oss.SyncDirection, optFns ...func(*oss.SyncOptions)) (*oss.SyncResult, error) {
+	m.record("Sync", bucket, prefix, localDir, direction, optFns)
+	if m.SyncFunc == nil {
+		return nil, notImplemented("Sync")
+	}
+	return m.SyncFunc(ctx, bucket, prefix, localDir, direction, optFns...)
+}
+
+// UploadPart records the call and calls UploadPartFunc.
+func (m *Client) UploadPart(ctx context.Context, request *oss.UploadPartRequest, optFns ...func(*oss.Options)) (*oss.UploadPartResult, error) {
+	m.record("UploadPart", request, optFns)
+	if m.UploadPartFunc == nil {
+		return nil, notImplemented("UploadPart")
+	}
+	return m.UploadPartFunc(ctx, request, optFns...)
+}
+
+// UploadPartCopy records the call and calls UploadPartCopyFunc.
+func (m *Client) UploadPartCopy(ctx context.Context, request *oss.UploadPartCopyRequest, optFns ...func(*oss.Options)) (*oss.UploadPartCopyResult, error) {
+	m.record("UploadPartCopy", request, optFns)
+	if m.UploadPartCopyFunc == nil {
+		return nil, notImplemented("UploadPartCopy")
+	}
+	return m.UploadPartCopyFunc(ctx, request, optFns...)
+}
+
+// WriteGetObjectResponse records the call and calls WriteGetObjectResponseFunc.
+func (m *Client) WriteGetObjectResponse(ctx context.Context, request *oss.WriteGetObjectResponseRequest, optFns ...func(*oss.Options)) (*oss.WriteGetObjectResponseResult, error) {
+	m.record("WriteGetObjectResponse", request, optFns)
+	if m.WriteGetObjectResponseFunc == nil {
+		return nil, notImplemented("WriteGetObjectResponse")
+	}
+	return m.WriteGetObjectResponseFunc(ctx, request, optFns...)
+}
@@ -0,0 +1,74 @@
+package ossmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RecordsCalls(t *testing.T) {
+	var c Client
+	c.GetObjectFunc = func(ctx context.Context, request *oss.GetObjectRequest, optFns ...func(*oss.Options)) (*oss.GetObjectResult, error) {
+		return &oss.GetObjectResult{ContentLength: 3}, nil
+	}
+
+	var api oss.API = &c
+	result, err := api.GetObject(context.TODO(), &oss.GetObjectRequest{
+		Bucket: oss.Ptr("bucket"),
+		Key:    oss.Ptr("key"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), result.ContentLength)
+
+	_, err = api.HeadObject(context.TODO(), &oss.HeadObjectRequest{
+		Bucket: oss.Ptr("bucket"),
+		Key:    oss.Ptr("key"),
+	})
+	assert.EqualError(t, err, "ossmock: HeadObject is not implemented, set HeadObjectFunc")
+
+	calls := c.Calls()
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "GetObject", calls[0].Operation)
+	assert.Equal(t, "key", oss.ToString(calls[0].Args[0].(*oss.GetObjectRequest).Key))
+	assert.Equal(t, "HeadObject", calls[1].Operation)
+
+	assert.Len(t, c.CallsOf("GetObject"), 1)
+	assert.Len(t, c.CallsOf("PutObject"), 0)
+
+	c.Reset()
+	assert.Len(t, c.Calls(), 0)
+}
+
+func TestClient_Paginator(t *testing.T) {
+	var c Client
+	c.ListPartsFunc = func(ctx context.Context, request *oss.ListPartsRequest, optFns ...func(*oss.Options)) (*oss.ListPartsResult, error) {
+		if request.PartNumberMarker == 0 {
+			return &oss.ListPartsResult{
+				IsTruncated:          true,
+				NextPartNumberMarker: 1,
+				Parts:                []oss.Part{{PartNumber: 1}},
+			}, nil
+		}
+		return &oss.ListPartsResult{
+			Parts: []oss.Part{{PartNumber: 2}},
+		}, nil
+	}
+
+	p := oss.NewListPartsPaginator(&c, &oss.ListPartsRequest{
+		Bucket:   oss.Ptr("bucket"),
+		Key:      oss.Ptr("key"),
+		UploadId: oss.Ptr("upload-id"),
+	})
+	var parts []int32
+	for p.HasNext() {
+		page, err := p.NextPage(context.TODO())
+		assert.Nil(t, err)
+		for _, part := range page.Parts {
+			parts = append(parts, part.PartNumber)
+		}
+	}
+	assert.Equal(t, []int32{1, 2}, parts)
+	assert.Len(t, c.CallsOf("ListParts"), 2)
+}
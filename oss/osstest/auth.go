@@ -0,0 +1,67 @@
+package osstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
+)
+
+// authenticate verifies the signature of the request with the server's credentials.
+func (s *Server) authenticate(r *request) error {
+	if s.options.AccessKeyID == "" {
+		return nil
+	}
+
+	// the form of a PostObject is signed instead of the request
+	if isPostObject(r) {
+		return nil
+	}
+
+	if !signer.IsSignedRequest(r.Request) {
+		return newError(http.StatusForbidden, "AccessDenied", "You have no right to access this object because of bucket acl.")
+	}
+
+	_, err := s.verifier().Verify(r.Context(), r.Request, r.bucket, r.key)
+	return toAPIError(err)
+}
+
+func (s *Server) verifier() *signer.Verifier {
+	return signer.NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		if accessKeyID != s.options.AccessKeyID {
+			return nil, nil
+		}
+		return &credentials.Credentials{AccessKeyID: s.options.AccessKeyID, AccessKeySecret: s.options.AccessKeySecret}, nil
+	}, func(o *signer.VerifierOptions) {
+		o.Region = s.options.Region
+		o.MaxClockSkew = MaxClockSkew
+		o.Now = s.now
+	})
+}
+
+func toAPIError(err error) error {
+	var verr *signer.VerifyError
+	if errors.As(err, &verr) {
+		return newError(verr.StatusCode, verr.Code, verr.Message)
+	}
+	return err
+}
+
+func keys(query url.Values) []string {
+	var ks []string
+	for k := range query {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func cloneQuery(query url.Values) url.Values {
+	c := url.Values{}
+	for k, v := range query {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
@@ -0,0 +1,647 @@
+package osstest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxKeys = 100
+	maxMaxKeys     = 1000
+
+	// the version id of objects stored while versioning is not enabled
+	nullVersionID = "null"
+
+	timeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+type bucket struct {
+	name         string
+	location     string
+	created      time.Time
+	acl          string
+	storageClass string
+
+	// "", "Enabled" or "Suspended"
+	versioning string
+
+	// the versions of each key, the newest one first
+	objects map[string][]*object
+	uploads map[string]*upload
+}
+
+func newBucket(name, region string, created time.Time) *bucket {
+	return &bucket{
+		name:         name,
+		location:     "oss-" + region,
+		created:      created,
+		acl:          "private",
+		storageClass: "Standard",
+		objects:      map[string][]*object{},
+		uploads:      map[string]*upload{},
+	}
+}
+
+func (b *bucket) latest(key string) *object {
+	if versions := b.objects[key]; len(versions) > 0 {
+		return versions[0]
+	}
+	return nil
+}
+
+func (b *bucket) version(key, versionID string) *object {
+	for _, obj := range b.objects[key] {
+		if obj.versionID == versionID || (versionID == nullVersionID && obj.versionID == "") {
+			return obj
+		}
+	}
+	return nil
+}
+
+func (b *bucket) sortedKeys() []string {
+	keys := make([]string, 0, len(b.objects))
+	for k := range b.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (b *bucket) isEmpty() bool {
+	return len(b.objects) == 0 && len(b.uploads) == 0
+}
+
+// putVersion stores the object as the current version of the key.
+func (s *Server) putVersion(b *bucket, obj *object) {
+	versions := b.objects[obj.key]
+	if b.versioning == "Enabled" {
+		obj.versionID = s.nextVersionID()
+		b.objects[obj.key] = append([]*object{obj}, versions...)
+		return
+	}
+
+	// the null version is overwritten
+	if b.versioning == "Suspended" {
+		obj.versionID = nullVersionID
+	}
+	kept := []*object{obj}
+	for _, v := range versions {
+		if v.versionID != "" && v.versionID != nullVersionID {
+			kept = append(kept, v)
+		}
+	}
+	b.objects[obj.key] = kept
+}
+
+// deleteVersion removes a version of the key, or the current version if versionID is empty.
+// It returns the version id and whether a delete marker is created or removed.
+func (s *Server) deleteVersion(b *bucket, key, versionID string) (string, bool, error) {
+	versions := b.objects[key]
+	if versionID != "" {
+		for i, v := range versions {
+			if v.versionID == versionID || (versionID == nullVersionID && v.versionID == "") {
+				b.objects[key] = append(versions[:i:i], versions[i+1:]...)
+				if len(b.objects[key]) == 0 {
+					delete(b.objects, key)
+				}
+				return versionID, v.deleteMarker, nil
+			}
+		}
+		if b.versioning == "" {
+			return "", false, errInvalidArgument("Invalid version id specified")
+		}
+		return versionID, false, nil
+	}
+
+	if b.versioning == "" {
+		delete(b.objects, key)
+		return "", false, nil
+	}
+
+	marker := &object{key: key, deleteMarker: true, lastModified: s.now()}
+	s.putVersion(b, marker)
+	return marker.versionID, true, nil
+}
+
+func (s *Server) getBucket(name string) (*bucket, error) {
+	b, ok := s.buckets[name]
+	if !ok {
+		return nil, errNoSuchBucket()
+	}
+	return b, nil
+}
+
+func (s *Server) serveBucket(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		switch {
+		case r.hasQuery("versioning"):
+			return s.putBucketVersioning(r)
+		case r.hasQuery("acl"):
+			return s.putBucketAcl(r)
+		case len(r.query) == 0:
+			return s.putBucket(r)
+		}
+	case http.MethodDelete:
+		if len(r.query) == 0 {
+			return s.deleteBucket(r)
+		}
+	case http.MethodPost:
+		if r.hasQuery("delete") {
+			return s.deleteMultipleObjects(r)
+		}
+		if isPostObject(r) {
+			return s.postObject(r)
+		}
+	case http.MethodGet, http.MethodHead:
+		switch {
+		case r.hasQuery("versioning"):
+			return s.getBucketVersioning(r)
+		case r.hasQuery("acl"):
+			return s.getBucketAcl(r)
+		case r.hasQuery("location"):
+			return s.getBucketLocation(r)
+		case r.hasQuery("bucketInfo"):
+			return s.getBucketInfo(r)
+		case r.hasQuery("versions"):
+			return s.listObjectVersions(r)
+		case r.hasQuery("uploads"):
+			return s.listMultipartUploads(r)
+		case r.query.Get("list-type") == "2":
+			return s.listObjectsV2(r)
+		default:
+			return s.listObjects(r)
+		}
+	}
+
+	return errNotImplemented()
+}
+
+func (s *Server) listBuckets(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := r.query.Get("prefix")
+	marker := r.query.Get("marker")
+	maxKeys, err := parseMaxKeys(r.query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		if strings.HasPrefix(name, prefix) && name > marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := &listBucketsXML{
+		Prefix:  prefix,
+		Marker:  marker,
+		MaxKeys: maxKeys,
+		Owner:   owner(),
+	}
+	if len(names) > maxKeys {
+		names = names[:maxKeys]
+		result.IsTruncated = true
+		result.NextMarker = names[len(names)-1]
+	}
+	for _, name := range names {
+		b := s.buckets[name]
+		result.Buckets = append(result.Buckets, bucketXML{
+			Name:             b.name,
+			Location:         b.location,
+			CreationDate:     b.created.Format(timeFormat),
+			ExtranetEndpoint: s.host,
+			IntranetEndpoint: s.host,
+			Region:           s.options.Region,
+			StorageClass:     b.storageClass,
+		})
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) putBucket(r *request) error {
+	if _, ok := s.buckets[r.bucket]; ok {
+		return newError(http.StatusConflict, "BucketAlreadyExists", "The requested bucket name is not available.")
+	}
+
+	b := newBucket(r.bucket, s.options.Region, s.now())
+	if acl := r.Header.Get("X-Oss-Acl"); acl != "" {
+		b.acl = acl
+	}
+	if body, err := readBody(r); err != nil {
+		return err
+	} else if len(body) > 0 {
+		var cfg struct {
+			StorageClass string `xml:"StorageClass"`
+		}
+		if err := decodeXML(body, &cfg); err != nil {
+			return err
+		}
+		if cfg.StorageClass != "" {
+			b.storageClass = cfg.StorageClass
+		}
+	}
+	s.buckets[r.bucket] = b
+
+	r.w.Header().Set("Location", "/"+r.bucket)
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) deleteBucket(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	if !b.isEmpty() {
+		return newError(http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty.")
+	}
+	delete(s.buckets, r.bucket)
+	return s.writeEmpty(r, http.StatusNoContent)
+}
+
+func (s *Server) putBucketVersioning(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	var cfg versioningXML
+	if err = readXML(r, &cfg); err != nil {
+		return err
+	}
+	switch cfg.Status {
+	case "Enabled", "Suspended":
+		b.versioning = cfg.Status
+	default:
+		return errMalformedXML()
+	}
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) getBucketVersioning(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	return s.writeXML(r, http.StatusOK, &versioningXML{Status: b.versioning})
+}
+
+func (s *Server) putBucketAcl(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	b.acl = r.Header.Get("X-Oss-Acl")
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) getBucketAcl(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	return s.writeXML(r, http.StatusOK, &accessControlPolicyXML{Owner: owner(), ACL: b.acl})
+}
+
+func (s *Server) getBucketLocation(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	return s.writeXML(r, http.StatusOK, &locationXML{Location: b.location})
+}
+
+func (s *Server) getBucketInfo(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	result := &bucketInfoXML{}
+	result.Bucket.Name = b.name
+	result.Bucket.Location = b.location
+	result.Bucket.CreationDate = b.created.Format(timeFormat)
+	result.Bucket.ExtranetEndpoint = s.host
+	result.Bucket.IntranetEndpoint = s.host
+	result.Bucket.ACL = b.acl
+	result.Bucket.Owner = owner()
+	result.Bucket.StorageClass = b.storageClass
+	result.Bucket.Versioning = b.versioning
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+// listEntry is a key or a common prefix of a listing.
+type listEntry struct {
+	key    string
+	prefix bool
+}
+
+// listKeys lists the keys after the marker in lexicographical order,
+// the keys which contain the delimiter after the prefix are rolled up into common prefixes.
+func listKeys(keys []string, prefix, delimiter, marker string, maxKeys int) (entries []listEntry, truncated bool) {
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) || key <= marker {
+			continue
+		}
+		entry := listEntry{key: key}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				entry = listEntry{key: key[:len(prefix)+i+len(delimiter)], prefix: true}
+				// the marker is inside of the common prefix which has been returned
+				if entry.key <= marker {
+					continue
+				}
+				if n := len(entries); n > 0 && entries[n-1] == entry {
+					continue
+				}
+			}
+		}
+		if len(entries) == maxKeys {
+			return entries, true
+		}
+		entries = append(entries, entry)
+	}
+	return entries, false
+}
+
+func (s *Server) currentKeys(b *bucket) []string {
+	var keys []string
+	for _, key := range b.sortedKeys() {
+		if obj := b.latest(key); obj != nil && !obj.deleteMarker {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *Server) toObjectXML(obj *object, fetchOwner bool, encode func(string) string) objectXML {
+	v := objectXML{
+		Key:          encode(obj.key),
+		LastModified: obj.lastModified.Format(timeFormat),
+		ETag:         obj.etag,
+		Type:         obj.objectType,
+		Size:         int64(len(obj.data)),
+		StorageClass: obj.storageClass,
+	}
+	if fetchOwner {
+		o := owner()
+		v.Owner = &o
+	}
+	return v
+}
+
+func (s *Server) listObjects(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	maxKeys, err := parseMaxKeys(r.query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+
+	encode := encoder(r)
+	result := &listObjectsXML{
+		Name:      b.name,
+		Prefix:    encode(r.query.Get("prefix")),
+		Marker:    encode(r.query.Get("marker")),
+		MaxKeys:   maxKeys,
+		Delimiter: encode(r.query.Get("delimiter")),
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+
+	entries, truncated := listKeys(s.currentKeys(b), r.query.Get("prefix"), r.query.Get("delimiter"), r.query.Get("marker"), maxKeys)
+	for _, e := range entries {
+		if e.prefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixXML{Prefix: encode(e.key)})
+		} else {
+			result.Contents = append(result.Contents, s.toObjectXML(b.latest(e.key), true, encode))
+		}
+	}
+	if truncated {
+		result.IsTruncated = true
+		result.NextMarker = encode(entries[len(entries)-1].key)
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) listObjectsV2(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	maxKeys, err := parseMaxKeys(r.query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+
+	// the continuation token is the last key or common prefix of the previous page
+	marker := r.query.Get("start-after")
+	token := r.query.Get("continuation-token")
+	if token != "" {
+		marker = token
+	}
+
+	encode := encoder(r)
+	result := &listObjectsV2XML{
+		Name:              b.name,
+		Prefix:            encode(r.query.Get("prefix")),
+		StartAfter:        encode(r.query.Get("start-after")),
+		ContinuationToken: encode(token),
+		MaxKeys:           maxKeys,
+		Delimiter:         encode(r.query.Get("delimiter")),
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+
+	fetchOwner := r.query.Get("fetch-owner") == "true"
+	entries, truncated := listKeys(s.currentKeys(b), r.query.Get("prefix"), r.query.Get("delimiter"), marker, maxKeys)
+	for _, e := range entries {
+		if e.prefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixXML{Prefix: encode(e.key)})
+		} else {
+			result.Contents = append(result.Contents, s.toObjectXML(b.latest(e.key), fetchOwner, encode))
+		}
+	}
+	result.KeyCount = len(entries)
+	if truncated {
+		result.IsTruncated = true
+		result.NextContinuationToken = encode(entries[len(entries)-1].key)
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) listObjectVersions(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	maxKeys, err := parseMaxKeys(r.query.Get("max-keys"))
+	if err != nil {
+		return err
+	}
+
+	prefix := r.query.Get("prefix")
+	delimiter := r.query.Get("delimiter")
+	keyMarker := r.query.Get("key-marker")
+	versionIDMarker := r.query.Get("version-id-marker")
+
+	encode := encoder(r)
+	result := &listVersionsXML{
+		Name:            b.name,
+		Prefix:          encode(prefix),
+		KeyMarker:       encode(keyMarker),
+		VersionIdMarker: versionIDMarker,
+		MaxKeys:         maxKeys,
+		Delimiter:       encode(delimiter),
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+
+	count := 0
+	lastKey, lastVersionID := "", ""
+	lastPrefix := ""
+	for _, key := range b.sortedKeys() {
+		if !strings.HasPrefix(key, prefix) || key < keyMarker {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				p := key[:len(prefix)+i+len(delimiter)]
+				if p == lastPrefix || p <= keyMarker {
+					continue
+				}
+				if count == maxKeys {
+					result.IsTruncated = true
+					break
+				}
+				lastPrefix = p
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixXML{Prefix: encode(p)})
+				lastKey, lastVersionID = p, ""
+				count++
+				continue
+			}
+		}
+
+		versions := b.objects[key]
+		skip := key == keyMarker
+		for i, obj := range versions {
+			if skip {
+				// resume after the version id marker of the same key
+				if versionIDMarker != "" && obj.versionIDOrNull() == versionIDMarker {
+					skip = false
+				}
+				continue
+			}
+			if count == maxKeys {
+				result.IsTruncated = true
+				break
+			}
+			result.Versions = append(result.Versions, s.toVersionXML(obj, i == 0, encode))
+			lastKey, lastVersionID = key, obj.versionIDOrNull()
+			count++
+		}
+		if result.IsTruncated {
+			break
+		}
+	}
+	if result.IsTruncated {
+		result.NextKeyMarker = encode(lastKey)
+		result.NextVersionIdMarker = lastVersionID
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) toVersionXML(obj *object, isLatest bool, encode func(string) string) versionXML {
+	v := versionXML{
+		Key:          encode(obj.key),
+		VersionId:    obj.versionIDOrNull(),
+		IsLatest:     isLatest,
+		LastModified: obj.lastModified.Format(timeFormat),
+		Owner:        owner(),
+	}
+	if obj.deleteMarker {
+		v.XMLName.Local = "DeleteMarker"
+		return v
+	}
+	size := int64(len(obj.data))
+	v.XMLName.Local = "Version"
+	v.ETag = obj.etag
+	v.Type = obj.objectType
+	v.Size = &size
+	v.StorageClass = obj.storageClass
+	return v
+}
+
+func (s *Server) deleteMultipleObjects(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	var req deleteXML
+	if err = readXML(r, &req); err != nil {
+		return err
+	}
+
+	encode := encoder(r)
+	result := &deleteResultXML{}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+	for _, o := range req.Objects {
+		versionID, marker, err := s.deleteVersion(b, o.Key, o.VersionId)
+		if err != nil {
+			return err
+		}
+		if req.Quiet {
+			continue
+		}
+		deleted := deletedXML{Key: encode(o.Key), VersionId: o.VersionId}
+		if marker {
+			deleted.DeleteMarker = true
+			deleted.DeleteMarkerVersionId = versionID
+		}
+		result.Deleted = append(result.Deleted, deleted)
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func parseMaxKeys(v string) (int, error) {
+	if v == "" {
+		return defaultMaxKeys, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > maxMaxKeys {
+		return 0, errInvalidArgument("Argument max-keys must be an integer between 1 and 1000.")
+	}
+	return n, nil
+}
+
+// encoder url-encodes the keys in a response when the request has encoding-type=url.
+func encoder(r *request) func(string) string {
+	if r.urlEncoded() {
+		return url.QueryEscape
+	}
+	return func(s string) string { return s }
+}
+
+func owner() ownerXML {
+	return ownerXML{ID: DefaultOwnerID, DisplayName: DefaultOwnerID}
+}
@@ -0,0 +1,381 @@
+package osstest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"hash/crc64"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+const (
+	defaultMaxParts   = 1000
+	defaultMaxUploads = 1000
+	maxPartNumber     = 10000
+)
+
+type upload struct {
+	id        string
+	key       string
+	initiated time.Time
+
+	// the attributes of the object to be created
+	template *object
+	parts    map[int32]*part
+}
+
+type part struct {
+	number       int32
+	data         []byte
+	etag         string
+	crc64        uint64
+	lastModified time.Time
+}
+
+func (u *upload) sortedParts() []*part {
+	parts := make([]*part, 0, len(u.parts))
+	for _, p := range u.parts {
+		parts = append(parts, p)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].number < parts[j].number })
+	return parts
+}
+
+func (s *Server) findUpload(r *request, b *bucket) (*upload, error) {
+	u, ok := b.uploads[r.query.Get("uploadId")]
+	if !ok || u.key != r.key {
+		return nil, errNoSuchUpload()
+	}
+	return u, nil
+}
+
+func parsePartNumber(v string) (int32, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > maxPartNumber {
+		return 0, errInvalidArgument("Part number must be an integer between 1 and 10000, inclusive.")
+	}
+	return int32(n), nil
+}
+
+func (s *Server) initiateMultipartUpload(r *request, b *bucket) error {
+	if err := s.checkForbidOverwrite(r, b); err != nil {
+		return err
+	}
+	template := newObject(r.key, nil, s.now())
+	if err := template.applyHeaders(r); err != nil {
+		return err
+	}
+	template.objectType = "Multipart"
+
+	u := &upload{
+		id:        s.nextUploadID(),
+		key:       r.key,
+		initiated: s.now(),
+		template:  template,
+		parts:     map[int32]*part{},
+	}
+	b.uploads[u.id] = u
+
+	encode := encoder(r)
+	result := &initiateMultipartUploadXML{
+		Bucket:   b.name,
+		Key:      encode(u.key),
+		UploadId: u.id,
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) storePart(u *upload, number int32, data []byte) *part {
+	sum := md5.Sum(data)
+	p := &part{
+		number:       number,
+		data:         data,
+		etag:         "\"" + strings.ToUpper(hex.EncodeToString(sum[:])) + "\"",
+		crc64:        crc64.Checksum(data, crc64Table),
+		lastModified: s.now(),
+	}
+	u.parts[number] = p
+	return p
+}
+
+func (s *Server) uploadPart(r *request, b *bucket) error {
+	u, err := s.findUpload(r, b)
+	if err != nil {
+		return err
+	}
+	number, err := parsePartNumber(r.query.Get("partNumber"))
+	if err != nil {
+		return err
+	}
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	p := s.storePart(u, number, data)
+	r.w.Header().Set("ETag", p.etag)
+	r.w.Header().Set(oss.HeaderOssCRC64, strconv.FormatUint(p.crc64, 10))
+	r.w.Header().Set("Content-MD5", md5Base64(data))
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) uploadPartCopy(r *request, b *bucket) error {
+	u, err := s.findUpload(r, b)
+	if err != nil {
+		return err
+	}
+	number, err := parsePartNumber(r.query.Get("partNumber"))
+	if err != nil {
+		return err
+	}
+	srcBucket, src, err := s.parseCopySource(r)
+	if err != nil {
+		return err
+	}
+
+	data := src.data
+	if v := r.Header.Get(oss.HeaderOssCopySourceRange); v != "" {
+		start, end, ok := parseRange(v, int64(len(src.data)))
+		if !ok {
+			return newError(http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range cannot be satisfied.")
+		}
+		data = src.data[start : end+1]
+	}
+
+	p := s.storePart(u, number, append([]byte(nil), data...))
+	r.w.Header().Set(oss.HeaderOssCRC64, strconv.FormatUint(p.crc64, 10))
+	if srcBucket.versioning != "" {
+		r.w.Header().Set("X-Oss-Copy-Source-Version-Id", src.versionIDOrNull())
+	}
+	return s.writeXML(r, http.StatusOK, &copyPartXML{
+		LastModified: p.lastModified.Format(timeFormat),
+		ETag:         p.etag,
+	})
+}
+
+func (s *Server) completeMultipartUpload(r *request, b *bucket) error {
+	u, err := s.findUpload(r, b)
+	if err != nil {
+		return err
+	}
+
+	var parts []*part
+	if strings.EqualFold(r.Header.Get("X-Oss-Complete-All"), "yes") {
+		parts = u.sortedParts()
+	} else {
+		var req completeMultipartUploadXML
+		if err = readXML(r, &req); err != nil {
+			return err
+		}
+		if len(req.Parts) == 0 {
+			return errMalformedXML()
+		}
+		for i, rp := range req.Parts {
+			if i > 0 && rp.PartNumber <= req.Parts[i-1].PartNumber {
+				return newError(http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order. Parts must be ordered by part number.")
+			}
+			p, ok := u.parts[rp.PartNumber]
+			if !ok || !etagMatch(rp.ETag, p.etag) {
+				return newError(http.StatusBadRequest, "InvalidPart", fmt.Sprintf("One or more of the specified parts could not be found or the specified entity tag might not have matched the part's entity tag, part number %d.", rp.PartNumber))
+			}
+			parts = append(parts, p)
+		}
+	}
+
+	var data []byte
+	digest := md5.New()
+	for i, p := range parts {
+		if i < len(parts)-1 && int64(len(p.data)) < s.options.MinPartSize {
+			return newError(http.StatusBadRequest, "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed size.")
+		}
+		data = append(data, p.data...)
+		sum, _ := hex.DecodeString(strings.Trim(p.etag, "\""))
+		digest.Write(sum)
+	}
+
+	if err = s.checkForbidOverwrite(r, b); err != nil {
+		return err
+	}
+
+	obj := newObject(u.key, data, s.now())
+	obj.copyAttributes(u.template)
+	obj.acl = u.template.acl
+	obj.objectType = "Multipart"
+	obj.etag = fmt.Sprintf("\"%s-%d\"", strings.ToUpper(hex.EncodeToString(digest.Sum(nil))), len(parts))
+	s.putVersion(b, obj)
+	delete(b.uploads, u.id)
+
+	h := r.w.Header()
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+
+	encode := encoder(r)
+	result := &completeMultipartUploadResultXML{
+		Location: fmt.Sprintf("%s/%s/%s", s.URL, b.name, u.key),
+		Bucket:   b.name,
+		Key:      encode(u.key),
+		ETag:     obj.etag,
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) abortMultipartUpload(r *request, b *bucket) error {
+	u, err := s.findUpload(r, b)
+	if err != nil {
+		return err
+	}
+	delete(b.uploads, u.id)
+	return s.writeEmpty(r, http.StatusNoContent)
+}
+
+func (s *Server) listParts(r *request, b *bucket) error {
+	u, err := s.findUpload(r, b)
+	if err != nil {
+		return err
+	}
+
+	maxParts := defaultMaxParts
+	if v := r.query.Get("max-parts"); v != "" {
+		if maxParts, err = strconv.Atoi(v); err != nil || maxParts < 1 || maxParts > defaultMaxParts {
+			return errInvalidArgument("Argument max-parts must be an integer between 1 and 1000.")
+		}
+	}
+	var marker int32
+	if v := r.query.Get("part-number-marker"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return errInvalidArgument("Argument part-number-marker is invalid.")
+		}
+		marker = int32(n)
+	}
+
+	encode := encoder(r)
+	result := &listPartsXML{
+		Bucket:           b.name,
+		Key:              encode(u.key),
+		UploadId:         u.id,
+		StorageClass:     u.template.storageClass,
+		PartNumberMarker: marker,
+		MaxParts:         maxParts,
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+	for _, p := range u.sortedParts() {
+		if p.number <= marker {
+			continue
+		}
+		if len(result.Parts) == maxParts {
+			result.IsTruncated = true
+			break
+		}
+		result.Parts = append(result.Parts, partXML{
+			PartNumber:    p.number,
+			LastModified:  p.lastModified.Format(timeFormat),
+			ETag:          p.etag,
+			HashCrc64ecma: strconv.FormatUint(p.crc64, 10),
+			Size:          int64(len(p.data)),
+		})
+		result.NextPartNumberMarker = p.number
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) listMultipartUploads(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+
+	maxUploads := defaultMaxUploads
+	if v := r.query.Get("max-uploads"); v != "" {
+		if maxUploads, err = strconv.Atoi(v); err != nil || maxUploads < 1 || maxUploads > defaultMaxUploads {
+			return errInvalidArgument("Argument max-uploads must be an integer between 1 and 1000.")
+		}
+	}
+	prefix := r.query.Get("prefix")
+	delimiter := r.query.Get("delimiter")
+	keyMarker := r.query.Get("key-marker")
+	uploadIDMarker := r.query.Get("upload-id-marker")
+
+	// the uploads are sorted by the key, then by the initiated time
+	var uploads []*upload
+	for _, u := range b.uploads {
+		if !strings.HasPrefix(u.key, prefix) {
+			continue
+		}
+		if u.key < keyMarker || (u.key == keyMarker && (uploadIDMarker == "" || u.id <= uploadIDMarker)) {
+			continue
+		}
+		uploads = append(uploads, u)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].key != uploads[j].key {
+			return uploads[i].key < uploads[j].key
+		}
+		return uploads[i].id < uploads[j].id
+	})
+
+	encode := encoder(r)
+	result := &listUploadsXML{
+		Bucket:         b.name,
+		KeyMarker:      encode(keyMarker),
+		UploadIdMarker: uploadIDMarker,
+		Delimiter:      encode(delimiter),
+		Prefix:         encode(prefix),
+		MaxUploads:     maxUploads,
+	}
+	if r.urlEncoded() {
+		result.EncodingType = "url"
+	}
+
+	count := 0
+	lastPrefix := ""
+	for _, u := range uploads {
+		if delimiter != "" {
+			if i := strings.Index(u.key[len(prefix):], delimiter); i >= 0 {
+				p := u.key[:len(prefix)+i+len(delimiter)]
+				if p == lastPrefix || p <= keyMarker {
+					continue
+				}
+				if count == maxUploads {
+					result.IsTruncated = true
+					break
+				}
+				lastPrefix = p
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefixXML{Prefix: encode(p)})
+				result.NextKeyMarker, result.NextUploadIdMarker = encode(p), ""
+				count++
+				continue
+			}
+		}
+		if count == maxUploads {
+			result.IsTruncated = true
+			break
+		}
+		result.Uploads = append(result.Uploads, uploadXML{
+			Key:       encode(u.key),
+			UploadId:  u.id,
+			Initiated: u.initiated.Format(timeFormat),
+		})
+		result.NextKeyMarker, result.NextUploadIdMarker = encode(u.key), u.id
+		count++
+	}
+
+	return s.writeXML(r, http.StatusOK, result)
+}
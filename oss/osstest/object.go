@@ -0,0 +1,655 @@
+package osstest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// the standard headers which are stored with the object and returned by GetObject and HeadObject
+var storedHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Expires",
+}
+
+type object struct {
+	key          string
+	versionID    string
+	deleteMarker bool
+
+	data         []byte
+	etag         string
+	crc64        uint64
+	lastModified time.Time
+	objectType   string
+	storageClass string
+	contentType  string
+	acl          string
+
+	// the standard headers and the user metadata
+	headers http.Header
+	tags    url.Values
+}
+
+func newObject(key string, data []byte, now time.Time) *object {
+	sum := md5.Sum(data)
+	return &object{
+		key:          key,
+		data:         data,
+		etag:         "\"" + strings.ToUpper(hex.EncodeToString(sum[:])) + "\"",
+		crc64:        crc64.Checksum(data, crc64Table),
+		lastModified: now,
+		objectType:   "Normal",
+		storageClass: "Standard",
+		acl:          "default",
+		headers:      http.Header{},
+		tags:         url.Values{},
+	}
+}
+
+func (o *object) versionIDOrNull() string {
+	if o.versionID == "" {
+		return nullVersionID
+	}
+	return o.versionID
+}
+
+// applyHeaders stores the content type, the standard headers, the user metadata and the tags of the request.
+func (o *object) applyHeaders(r *request) error {
+	o.contentType = r.Header.Get("Content-Type")
+	if o.contentType == "" {
+		o.contentType = "application/octet-stream"
+	}
+	for _, h := range storedHeaders {
+		if v := r.Header.Get(h); v != "" {
+			o.headers.Set(h, v)
+		}
+	}
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, oss.HeaderOssMetaPrefix) {
+			o.headers[k] = v
+		}
+	}
+	if v := r.Header.Get(oss.HeaderOssStorageClass); v != "" {
+		o.storageClass = v
+	}
+	if v := r.Header.Get(oss.HeaderOssObjectACL); v != "" {
+		o.acl = v
+	}
+	if v := r.Header.Get(oss.HeaderOssTagging); v != "" {
+		tags, err := url.ParseQuery(v)
+		if err != nil {
+			return errInvalidArgument("The tagging header is invalid.")
+		}
+		o.tags = tags
+	}
+	return nil
+}
+
+func (o *object) copyAttributes(src *object) {
+	o.contentType = src.contentType
+	o.storageClass = src.storageClass
+	o.headers = src.headers.Clone()
+	o.tags = cloneQuery(src.tags)
+}
+
+func (s *Server) writeObjectHeaders(r *request, b *bucket, obj *object) {
+	h := r.w.Header()
+	h.Set("Content-Type", obj.contentType)
+	h.Set("ETag", obj.etag)
+	h.Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+	h.Set("Accept-Ranges", "bytes")
+	h.Set(oss.HeaderOssObjectType, obj.objectType)
+	h.Set(oss.HeaderOssStorageClass, obj.storageClass)
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	if obj.objectType == "Appendable" {
+		h.Set(oss.HeaderOssNextAppendPosition, strconv.Itoa(len(obj.data)))
+	}
+	if len(obj.tags) > 0 {
+		h.Set("X-Oss-Tagging-Count", strconv.Itoa(len(obj.tags)))
+	}
+	for k, v := range obj.headers {
+		h[k] = v
+	}
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+
+	// override the response headers by the response-* parameters
+	for _, name := range []string{"Content-Type", "Content-Language", "Expires", "Cache-Control", "Content-Disposition", "Content-Encoding"} {
+		if v := r.query.Get("response-" + strings.ToLower(name)); v != "" {
+			h.Set(name, v)
+		}
+	}
+}
+
+func (s *Server) serveObject(r *request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		switch {
+		case r.hasQuery("uploadId"):
+			if r.Header.Get(oss.HeaderOssCopySource) != "" {
+				return s.uploadPartCopy(r, b)
+			}
+			return s.uploadPart(r, b)
+		case r.hasQuery("tagging"):
+			return s.putObjectTagging(r, b)
+		case r.hasQuery("acl"):
+			return s.putObjectAcl(r, b)
+		case r.Header.Get(oss.HeaderOssCopySource) != "":
+			return s.copyObject(r, b)
+		case len(r.query) == 0:
+			return s.putObject(r, b)
+		}
+	case http.MethodPost:
+		switch {
+		case r.hasQuery("uploads"):
+			return s.initiateMultipartUpload(r, b)
+		case r.hasQuery("uploadId"):
+			return s.completeMultipartUpload(r, b)
+		case r.hasQuery("append"):
+			return s.appendObject(r, b)
+		}
+	case http.MethodGet:
+		switch {
+		case r.hasQuery("uploadId"):
+			return s.listParts(r, b)
+		case r.hasQuery("tagging"):
+			return s.getObjectTagging(r, b)
+		case r.hasQuery("acl"):
+			return s.getObjectAcl(r, b)
+		default:
+			return s.getObject(r, b)
+		}
+	case http.MethodHead:
+		if r.hasQuery("objectMeta") {
+			return s.getObjectMeta(r, b)
+		}
+		return s.getObject(r, b)
+	case http.MethodDelete:
+		switch {
+		case r.hasQuery("uploadId"):
+			return s.abortMultipartUpload(r, b)
+		case r.hasQuery("tagging"):
+			return s.deleteObjectTagging(r, b)
+		default:
+			return s.deleteObject(r, b)
+		}
+	}
+
+	return errNotImplemented()
+}
+
+// findObject returns the requested version of the object, or the current version.
+func (s *Server) findObject(r *request, b *bucket) (*object, error) {
+	if versionID := r.query.Get("versionId"); versionID != "" {
+		obj := b.version(r.key, versionID)
+		if obj == nil {
+			return nil, errNoSuchVersion()
+		}
+		if obj.deleteMarker {
+			e := newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+			e.Headers = map[string]string{"X-Oss-Delete-Marker": "true", "X-Oss-Version-Id": versionID}
+			return nil, e
+		}
+		return obj, nil
+	}
+
+	obj := b.latest(r.key)
+	if obj == nil {
+		return nil, errNoSuchKey()
+	}
+	if obj.deleteMarker {
+		e := errNoSuchKey()
+		e.Headers = map[string]string{"X-Oss-Delete-Marker": "true", "X-Oss-Version-Id": obj.versionIDOrNull()}
+		return nil, e
+	}
+	return obj, nil
+}
+
+func readBody(r *request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if v := r.Header.Get("Content-Md5"); v != "" {
+		sum := md5.Sum(data)
+		if base64.StdEncoding.EncodeToString(sum[:]) != v {
+			return nil, newError(http.StatusBadRequest, "InvalidDigest", "The Content-MD5 you specified was invalid.")
+		}
+	}
+	return data, nil
+}
+
+func decodeXML(body []byte, v any) error {
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(v); err != nil {
+		return errMalformedXML()
+	}
+	return nil
+}
+
+func (s *Server) checkForbidOverwrite(r *request, b *bucket) error {
+	if !strings.EqualFold(r.Header.Get(oss.HeaderOssForbidOverWrite), "true") {
+		return nil
+	}
+	if obj := b.latest(r.key); obj != nil && !obj.deleteMarker {
+		return newError(http.StatusConflict, "FileAlreadyExists", "The object you specified already exists and can not be overwritten.")
+	}
+	return nil
+}
+
+func (s *Server) writePutResult(r *request, b *bucket, obj *object) error {
+	h := r.w.Header()
+	h.Set("ETag", obj.etag)
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	h.Set("Content-MD5", md5Base64(obj.data))
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) putObject(r *request, b *bucket) error {
+	if err := s.checkForbidOverwrite(r, b); err != nil {
+		return err
+	}
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	obj := newObject(r.key, data, s.now())
+	if err = obj.applyHeaders(r); err != nil {
+		return err
+	}
+	s.putVersion(b, obj)
+	return s.writePutResult(r, b, obj)
+}
+
+func (s *Server) appendObject(r *request, b *bucket) error {
+	position, err := strconv.ParseInt(r.query.Get("position"), 10, 64)
+	if err != nil || position < 0 {
+		return errInvalidArgument("Argument position is invalid.")
+	}
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	current := b.latest(r.key)
+	if current != nil && current.deleteMarker {
+		current = nil
+	}
+	if current != nil && current.objectType != "Appendable" {
+		return newError(http.StatusConflict, "ObjectNotAppendable", "The object is not appendable.")
+	}
+
+	var length int64
+	if current != nil {
+		length = int64(len(current.data))
+	}
+	if position != length {
+		e := newError(http.StatusConflict, "PositionNotEqualToLength", "Position is not equal to file length.")
+		e.Headers = map[string]string{oss.HeaderOssNextAppendPosition: strconv.FormatInt(length, 10)}
+		return e
+	}
+
+	var obj *object
+	if current == nil {
+		obj = newObject(r.key, data, s.now())
+		if err = obj.applyHeaders(r); err != nil {
+			return err
+		}
+		obj.objectType = "Appendable"
+		s.putVersion(b, obj)
+	} else {
+		// appending modifies the current version in place
+		obj = current
+		obj.data = append(obj.data, data...)
+		obj.crc64 = crc64.Update(obj.crc64, crc64Table, data)
+		sum := md5.Sum(obj.data)
+		obj.etag = "\"" + strings.ToUpper(hex.EncodeToString(sum[:])) + "\""
+		obj.lastModified = s.now()
+	}
+
+	h := r.w.Header()
+	h.Set(oss.HeaderOssNextAppendPosition, strconv.Itoa(len(obj.data)))
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	h.Set("ETag", obj.etag)
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+// checkConditions evaluates the If-* headers, with the given header prefix.
+func checkConditions(header http.Header, prefix string, obj *object) error {
+	failed := newError(http.StatusPreconditionFailed, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold.")
+	if v := header.Get(prefix + "If-Match"); v != "" && !etagMatch(v, obj.etag) {
+		return failed
+	}
+	if v := header.Get(prefix + "If-Unmodified-Since"); v != "" {
+		if t, err := http.ParseTime(v); err == nil && obj.lastModified.Truncate(time.Second).After(t) {
+			return failed
+		}
+	}
+	if v := header.Get(prefix + "If-None-Match"); v != "" && etagMatch(v, obj.etag) {
+		if prefix != "" {
+			return failed
+		}
+		return newError(http.StatusNotModified, "NotModified", "")
+	}
+	if v := header.Get(prefix + "If-Modified-Since"); v != "" {
+		if t, err := http.ParseTime(v); err == nil && !obj.lastModified.Truncate(time.Second).After(t) {
+			if prefix != "" {
+				return failed
+			}
+			return newError(http.StatusNotModified, "NotModified", "")
+		}
+	}
+	return nil
+}
+
+func etagMatch(condition, etag string) bool {
+	for _, v := range strings.Split(condition, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.Trim(v, "\"") == strings.Trim(etag, "\"") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRange parses "bytes=start-end", "bytes=start-" and "bytes=-suffix".
+// ok is false if the range is malformed or not satisfiable.
+func parseRange(v string, size int64) (start, end int64, ok bool) {
+	if !strings.HasPrefix(v, "bytes=") {
+		return 0, 0, false
+	}
+	spec := strings.TrimPrefix(v, "bytes=")
+	if strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	first, last, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, false
+	}
+	var err error
+	switch {
+	case first == "":
+		var n int64
+		if n, err = strconv.ParseInt(last, 10, 64); err != nil || n <= 0 {
+			return 0, 0, false
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, size - 1, size > 0
+	case last == "":
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+			return 0, 0, false
+		}
+		end = size - 1
+	default:
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+			return 0, 0, false
+		}
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end, start < size
+}
+
+func (s *Server) getObject(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	if err = checkConditions(r.Header, "", obj); err != nil {
+		if e, ok := err.(*apiError); ok && e.StatusCode == http.StatusNotModified {
+			r.w.Header().Set("ETag", obj.etag)
+			r.w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+		return err
+	}
+
+	s.writeObjectHeaders(r, b, obj)
+
+	data := obj.data
+	statusCode := http.StatusOK
+	size := int64(len(obj.data))
+	if v := r.Header.Get("Range"); v != "" {
+		start, end, ok := parseRange(v, size)
+		switch {
+		case ok:
+			data = obj.data[start : end+1]
+			statusCode = http.StatusPartialContent
+			r.w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+		case strings.EqualFold(r.Header.Get(oss.HeaderOssRangeBehavior), "standard"):
+			r.w.Header().Del(oss.HeaderOssCRC64)
+			e := newError(http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range cannot be satisfied.")
+			e.Headers = map[string]string{"Content-Range": fmt.Sprintf("bytes */%d", size)}
+			return e
+		}
+		// otherwise the invalid range is ignored and the whole object is returned
+	}
+
+	r.w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	r.w.WriteHeader(statusCode)
+	if r.Method != http.MethodHead {
+		r.w.Write(data)
+	}
+	return nil
+}
+
+func (s *Server) getObjectMeta(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	h := r.w.Header()
+	h.Set("ETag", obj.etag)
+	h.Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+	h.Set("Content-Length", strconv.Itoa(len(obj.data)))
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	r.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) deleteObject(r *request, b *bucket) error {
+	versionID, marker, err := s.deleteVersion(b, r.key, r.query.Get("versionId"))
+	if err != nil {
+		return err
+	}
+	if marker {
+		r.w.Header().Set("X-Oss-Delete-Marker", "true")
+	}
+	if versionID != "" {
+		r.w.Header().Set("X-Oss-Version-Id", versionID)
+	}
+	return s.writeEmpty(r, http.StatusNoContent)
+}
+
+// parseCopySource parses the x-oss-copy-source header, "/bucket/key?versionId=id".
+func (s *Server) parseCopySource(r *request) (*bucket, *object, error) {
+	source := r.Header.Get(oss.HeaderOssCopySource)
+	path, query, _ := strings.Cut(source, "?")
+	path, err := url.PathUnescape(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, nil, errInvalidArgument("Copy Source must mention the source bucket and key: /sourcebucket/sourcekey.")
+	}
+	bucketName, key, found := strings.Cut(path, "/")
+	if !found || key == "" {
+		return nil, nil, errInvalidArgument("Copy Source must mention the source bucket and key: /sourcebucket/sourcekey.")
+	}
+	values, _ := url.ParseQuery(query)
+
+	src, err := s.getBucket(bucketName)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj, err := s.findObject(&request{key: key, query: values}, src)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = checkConditions(r.Header, "X-Oss-Copy-Source-", obj); err != nil {
+		return nil, nil, err
+	}
+	return src, obj, nil
+}
+
+func (s *Server) copyObject(r *request, b *bucket) error {
+	if err := s.checkForbidOverwrite(r, b); err != nil {
+		return err
+	}
+	srcBucket, src, err := s.parseCopySource(r)
+	if err != nil {
+		return err
+	}
+
+	obj := newObject(r.key, append([]byte(nil), src.data...), s.now())
+	obj.copyAttributes(src)
+	if strings.EqualFold(r.Header.Get(oss.HeaderOssMetadataDirective), "REPLACE") {
+		tags := obj.tags
+		obj.headers = http.Header{}
+		if err = obj.applyHeaders(r); err != nil {
+			return err
+		}
+		obj.tags = tags
+	} else if v := r.Header.Get(oss.HeaderOssStorageClass); v != "" {
+		obj.storageClass = v
+	}
+	if strings.EqualFold(r.Header.Get(oss.HeaderOssTaggingDirective), "REPLACE") {
+		obj.tags = url.Values{}
+		if v := r.Header.Get(oss.HeaderOssTagging); v != "" {
+			if obj.tags, err = url.ParseQuery(v); err != nil {
+				return errInvalidArgument("The tagging header is invalid.")
+			}
+		}
+	}
+	s.putVersion(b, obj)
+
+	h := r.w.Header()
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	if srcBucket.versioning != "" {
+		h.Set("X-Oss-Copy-Source-Version-Id", src.versionIDOrNull())
+	}
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeXML(r, http.StatusOK, &copyObjectXML{
+		LastModified: obj.lastModified.Format(timeFormat),
+		ETag:         obj.etag,
+	})
+}
+
+func (s *Server) putObjectTagging(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	var tagging taggingXML
+	if err = readXML(r, &tagging); err != nil {
+		return err
+	}
+	tags := url.Values{}
+	for _, t := range tagging.Tags {
+		tags.Set(t.Key, t.Value)
+	}
+	obj.tags = tags
+	if b.versioning != "" {
+		r.w.Header().Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) getObjectTagging(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	result := &taggingXML{}
+	for _, k := range sortedKeys(obj.tags) {
+		result.Tags = append(result.Tags, tagXML{Key: k, Value: obj.tags.Get(k)})
+	}
+	if b.versioning != "" {
+		r.w.Header().Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeXML(r, http.StatusOK, result)
+}
+
+func (s *Server) deleteObjectTagging(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	obj.tags = url.Values{}
+	if b.versioning != "" {
+		r.w.Header().Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	return s.writeEmpty(r, http.StatusNoContent)
+}
+
+func (s *Server) putObjectAcl(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	obj.acl = r.Header.Get(oss.HeaderOssObjectACL)
+	return s.writeEmpty(r, http.StatusOK)
+}
+
+func (s *Server) getObjectAcl(r *request, b *bucket) error {
+	obj, err := s.findObject(r, b)
+	if err != nil {
+		return err
+	}
+	return s.writeXML(r, http.StatusOK, &accessControlPolicyXML{Owner: owner(), ACL: obj.acl})
+}
+
+func md5Base64(data []byte) string {
+	sum := md5.Sum(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func sortedKeys(values url.Values) []string {
+	ks := keys(values)
+	sort.Strings(ks)
+	return ks
+}
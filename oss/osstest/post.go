@@ -0,0 +1,179 @@
+package osstest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// isPostObject reports whether the request is a form upload to a bucket.
+func isPostObject(r *request) bool {
+	if r.Method != http.MethodPost || r.bucket == "" || r.key != "" || len(r.query) != 0 {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
+}
+
+// readPostForm returns the fields of the form and the content of the file, which is the last field.
+func readPostForm(r *request) (map[string]string, []byte, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, nil, errInvalidArgument("The body of the form is invalid.")
+	}
+	fields := map[string]string{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, nil, errInvalidArgument("The file field is missing.")
+		}
+		if err != nil {
+			return nil, nil, errInvalidArgument("The body of the form is invalid.")
+		}
+		value, err := io.ReadAll(part)
+		if err != nil {
+			return nil, nil, err
+		}
+		if strings.EqualFold(part.FormName(), "file") {
+			return fields, value, nil
+		}
+		fields[strings.ToLower(part.FormName())] = string(value)
+	}
+}
+
+func (s *Server) postObject(r *request) error {
+	b, err := s.getBucket(r.bucket)
+	if err != nil {
+		return err
+	}
+	fields, data, err := readPostForm(r)
+	if err != nil {
+		return err
+	}
+	key := fields["key"]
+	if key == "" {
+		return errInvalidArgument("The key field is missing.")
+	}
+
+	if s.options.AccessKeyID != "" {
+		if _, err = s.verifier().VerifyPostPolicy(r.Context(), fields); err != nil {
+			return toAPIError(err)
+		}
+		if err = checkPostPolicyConditions(fields, r.bucket, int64(len(data))); err != nil {
+			return err
+		}
+	}
+
+	status := http.StatusNoContent
+	if v := fields["success_action_status"]; v == "200" || v == "201" {
+		status, _ = strconv.Atoi(v)
+	}
+
+	obj := newObject(key, data, s.now())
+	obj.contentType = fields["content-type"]
+	if obj.contentType == "" {
+		obj.contentType = "application/octet-stream"
+	}
+	for k, v := range fields {
+		if strings.HasPrefix(k, "x-oss-meta-") {
+			obj.headers.Set(k, v)
+		}
+	}
+	s.putVersion(b, obj)
+
+	h := r.w.Header()
+	h.Set("ETag", obj.etag)
+	h.Set(oss.HeaderOssCRC64, strconv.FormatUint(obj.crc64, 10))
+	h.Set("Content-MD5", md5Base64(obj.data))
+	if b.versioning != "" {
+		h.Set("X-Oss-Version-Id", obj.versionIDOrNull())
+	}
+	if status == http.StatusCreated {
+		return s.writeXML(r, status, &postResponseXML{
+			Bucket:   b.name,
+			Location: fmt.Sprintf("http://%s.%s/%s", b.name, s.host, key),
+			Key:      key,
+			ETag:     obj.etag,
+		})
+	}
+	return s.writeEmpty(r, status)
+}
+
+func errPolicyCondition(condition any) *apiError {
+	data, _ := json.Marshal(condition)
+	return newError(http.StatusForbidden, "AccessDenied", "Invalid according to Policy: Policy Condition failed: "+string(data))
+}
+
+// checkPostPolicyConditions checks the fields of the form against the conditions of the verified policy.
+func checkPostPolicyConditions(fields map[string]string, bucket string, size int64) error {
+	data, _ := base64.StdEncoding.DecodeString(fields["policy"])
+	var policy struct {
+		Conditions []any `json:"conditions"`
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return errInvalidArgument("Invalid Policy: Invalid JSON.")
+	}
+
+	value := func(name string) string {
+		name = strings.ToLower(strings.TrimPrefix(name, "$"))
+		if name == "bucket" {
+			return bucket
+		}
+		return fields[name]
+	}
+	for _, condition := range policy.Conditions {
+		switch c := condition.(type) {
+		case map[string]any:
+			for k, v := range c {
+				if fmt.Sprint(v) != value(k) {
+					return errPolicyCondition(condition)
+				}
+			}
+		case []any:
+			if len(c) != 3 {
+				return errInvalidArgument("Invalid Policy: Invalid condition.")
+			}
+			op, _ := c[0].(string)
+			switch strings.ToLower(op) {
+			case "content-length-range":
+				min, _ := c[1].(float64)
+				max, _ := c[2].(float64)
+				if size < int64(min) {
+					return newError(http.StatusBadRequest, "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed size.")
+				}
+				if size > int64(max) {
+					return newError(http.StatusBadRequest, "EntityTooLarge", "Your proposed upload exceeds the maximum allowed size.")
+				}
+			case "eq":
+				if value(fmt.Sprint(c[1])) != fmt.Sprint(c[2]) {
+					return errPolicyCondition(condition)
+				}
+			case "starts-with":
+				if !strings.HasPrefix(value(fmt.Sprint(c[1])), fmt.Sprint(c[2])) {
+					return errPolicyCondition(condition)
+				}
+			case "in":
+				values, _ := c[2].([]any)
+				matched := false
+				for _, v := range values {
+					matched = matched || fmt.Sprint(v) == value(fmt.Sprint(c[1]))
+				}
+				if !matched {
+					return errPolicyCondition(condition)
+				}
+			default:
+				return errInvalidArgument("Invalid Policy: Invalid condition operator " + op + ".")
+			}
+		default:
+			return errInvalidArgument("Invalid Policy: Invalid condition.")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+// Package osstest provides an in-memory OSS server for tests.
+//
+// The server speaks enough of the OSS protocol to exercise the Client and the
+// transfer managers (Uploader, Downloader, Copier and ReadOnlyFile) end to end
+// without network access or a real bucket:
+//
+//	srv := osstest.NewServer()
+//	defer srv.Close()
+//	client := oss.NewClient(srv.Config())
+package osstest
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+)
+
+const (
+	// DefaultRegion The region reported by the server when Options.Region is not set.
+	DefaultRegion = "cn-hangzhou"
+
+	// DefaultOwnerID The owner of the buckets and objects.
+	DefaultOwnerID = "osstest"
+
+	// MaxClockSkew The maximum allowed difference between the request time and the server time.
+	MaxClockSkew = 15 * time.Minute
+)
+
+type Options struct {
+	// The region of the server, it is checked against the scope of V4 signatures.
+	Region string
+
+	// The AccessKey pair that requests must be signed with.
+	// If AccessKeyID is empty, the server accepts anonymous requests and does not verify signatures.
+	AccessKeyID     string
+	AccessKeySecret string
+
+	// The minimum size of each part except the last one when completing a multipart upload.
+	// OSS requires 100 KiB, the default 0 disables the check so tests can use tiny parts.
+	MinPartSize int64
+
+	// Now returns the current time of the server, defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server is an in-memory OSS server backed by an httptest.Server.
+type Server struct {
+	// URL is the endpoint of the server, in the form http://ipaddr:port with no trailing slash.
+	URL string
+
+	options Options
+	ts      *httptest.Server
+	host    string
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+
+	requestSeq uint64
+	versionSeq uint64
+	uploadSeq  uint64
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer(optFns ...func(*Options)) *Server {
+	options := Options{
+		Region: DefaultRegion,
+		Now:    time.Now,
+	}
+
+	for _, fn := range optFns {
+		fn(&options)
+	}
+
+	if options.Region == "" {
+		options.Region = DefaultRegion
+	}
+
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	s := &Server{
+		options: options,
+		buckets: map[string]*bucket{},
+	}
+	s.ts = httptest.NewServer(http.HandlerFunc(s.ServeHTTP))
+	s.URL = s.ts.URL
+	if u, err := url.Parse(s.URL); err == nil {
+		s.host = u.Host
+	}
+
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests on this server have completed.
+func (s *Server) Close() {
+	s.ts.Close()
+}
+
+// Config returns a client configuration which points to the server,
+// with the server's region and credentials.
+func (s *Server) Config() *oss.Config {
+	var provider credentials.CredentialsProvider
+	if s.options.AccessKeyID != "" {
+		provider = credentials.NewStaticCredentialsProvider(s.options.AccessKeyID, s.options.AccessKeySecret)
+	} else {
+		provider = credentials.NewAnonymousCredentialsProvider()
+	}
+
+	return oss.LoadDefaultConfig().
+		WithCredentialsProvider(provider).
+		WithRegion(s.options.Region).
+		WithEndpoint(s.URL)
+}
+
+// CreateBucket creates a bucket without going through the HTTP API.
+func (s *Server) CreateBucket(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.buckets[name]; ok {
+		return fmt.Errorf("bucket %s already exists", name)
+	}
+	s.buckets[name] = newBucket(name, s.options.Region, s.now())
+	return nil
+}
+
+// PutObject stores an object without going through the HTTP API.
+func (s *Server) PutObject(bucketName, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		return fmt.Errorf("bucket %s does not exist", bucketName)
+	}
+	obj := newObject(key, data, s.now())
+	obj.contentType = "application/octet-stream"
+	s.putVersion(b, obj)
+	return nil
+}
+
+// Object returns a copy of the data of the current version of the object.
+func (s *Server) Object(bucketName, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		return nil, false
+	}
+	obj := b.latest(key)
+	if obj == nil || obj.deleteMarker {
+		return nil, false
+	}
+	return append([]byte(nil), obj.data...), true
+}
+
+// Keys returns the keys of the current objects in the bucket in lexicographical order.
+func (s *Server) Keys(bucketName string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		return nil
+	}
+	var keys []string
+	for _, key := range b.sortedKeys() {
+		if obj := b.latest(key); obj != nil && !obj.deleteMarker {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *Server) now() time.Time {
+	return s.options.Now().UTC()
+}
+
+func (s *Server) nextRequestID() string {
+	return fmt.Sprintf("%024X", atomic.AddUint64(&s.requestSeq, 1))
+}
+
+func (s *Server) nextVersionID() string {
+	return fmt.Sprintf("CAEQ%016d", atomic.AddUint64(&s.versionSeq, 1))
+}
+
+func (s *Server) nextUploadID() string {
+	return fmt.Sprintf("%032X", atomic.AddUint64(&s.uploadSeq, 1))
+}
+
+// request is the parsed incoming request.
+type request struct {
+	*http.Request
+	w         http.ResponseWriter
+	requestID string
+	bucket    string
+	key       string
+	query     url.Values
+}
+
+func (r *request) hasQuery(name string) bool {
+	_, ok := r.query[name]
+	return ok
+}
+
+func (r *request) urlEncoded() bool {
+	return strings.EqualFold(r.query.Get("encoding-type"), "url")
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, hr *http.Request) {
+	r := &request{
+		Request:   hr,
+		w:         w,
+		requestID: s.nextRequestID(),
+		query:     hr.URL.Query(),
+	}
+	r.bucket, r.key = s.resolveBucketKey(hr)
+
+	w.Header().Set("Server", "AliyunOSS")
+	w.Header().Set("Date", s.now().Format(http.TimeFormat))
+	w.Header().Set(oss.HeaderOssRequestID, r.requestID)
+
+	if err := s.authenticate(r); err != nil {
+		s.writeError(r, err)
+		return
+	}
+
+	var err error
+	switch {
+	case r.bucket == "":
+		err = s.serveService(r)
+	case r.key == "":
+		err = s.serveBucket(r)
+	default:
+		err = s.serveObject(r)
+	}
+
+	if err != nil {
+		s.writeError(r, err)
+	}
+}
+
+// resolveBucketKey supports both the path style (/bucket/key) and the virtual hosted style (bucket.host/key).
+func (s *Server) resolveBucketKey(r *http.Request) (string, string) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if host := r.Host; host != s.host && strings.HasSuffix(host, "."+s.host) {
+		return strings.TrimSuffix(host, "."+s.host), path
+	}
+	bucket, key, _ := strings.Cut(path, "/")
+	return bucket, key
+}
+
+// apiError is an error response of the server.
+type apiError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	EC         string
+
+	// extra response headers
+	Headers map[string]string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func newError(statusCode int, code, message string) *apiError {
+	return &apiError{StatusCode: statusCode, Code: code, Message: message}
+}
+
+func errNoSuchBucket() *apiError {
+	return newError(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+}
+
+func errNoSuchKey() *apiError {
+	return newError(http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+}
+
+func errNoSuchUpload() *apiError {
+	return newError(http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed.")
+}
+
+func errNoSuchVersion() *apiError {
+	return newError(http.StatusNotFound, "NoSuchVersion", "The specified version does not exist.")
+}
+
+func errInvalidArgument(message string) *apiError {
+	return newError(http.StatusBadRequest, "InvalidArgument", message)
+}
+
+func errMalformedXML() *apiError {
+	return newError(http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.")
+}
+
+func errNotImplemented() *apiError {
+	return newError(http.StatusNotImplemented, "NotImplemented", "The operation is not supported by osstest.")
+}
+
+func (s *Server) writeError(r *request, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = newError(http.StatusInternalServerError, "InternalError", err.Error())
+	}
+
+	body, _ := xml.Marshal(&errorXML{
+		Code:      e.Code,
+		Message:   e.Message,
+		RequestId: r.requestID,
+		HostId:    r.Host,
+		EC:        e.EC,
+	})
+	body = append([]byte(xml.Header), body...)
+
+	h := r.w.Header()
+	for k, v := range e.Headers {
+		h.Set(k, v)
+	}
+	if e.EC != "" {
+		h.Set(oss.HeaderOssEC, e.EC)
+	}
+	h.Set("Content-Type", "application/xml")
+	if r.Method == http.MethodHead {
+		// HEAD responses have no body, OSS returns the error in the x-oss-err header instead
+		h.Set(oss.HeaderOssERR, base64.StdEncoding.EncodeToString(body))
+		r.w.WriteHeader(e.StatusCode)
+		return
+	}
+	h.Set("Content-Length", fmt.Sprint(len(body)))
+	r.w.WriteHeader(e.StatusCode)
+	r.w.Write(body)
+}
+
+func (s *Server) writeXML(r *request, statusCode int, v any) error {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	body = append([]byte(xml.Header), body...)
+	r.w.Header().Set("Content-Type", "application/xml")
+	r.w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	r.w.WriteHeader(statusCode)
+	_, err = r.w.Write(body)
+	return err
+}
+
+func (s *Server) writeEmpty(r *request, statusCode int) error {
+	r.w.Header().Set("Content-Length", "0")
+	r.w.WriteHeader(statusCode)
+	return nil
+}
+
+func readXML(r *request, v any) error {
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return decodeXML(body, v)
+}
+
+func (s *Server) serveService(r *request) error {
+	if r.Method != http.MethodGet {
+		return newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+	return s.listBuckets(r)
+}
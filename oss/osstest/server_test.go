@@ -0,0 +1,601 @@
+package osstest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccessKeyID     = "ak"
+	testAccessKeySecret = "sk"
+	testBucket          = "bucket"
+)
+
+func newTestServer(t *testing.T, optFns ...func(*Options)) (*Server, *oss.Client) {
+	fns := append([]func(*Options){func(o *Options) {
+		o.AccessKeyID = testAccessKeyID
+		o.AccessKeySecret = testAccessKeySecret
+	}}, optFns...)
+	srv := NewServer(fns...)
+	t.Cleanup(srv.Close)
+	assert.Nil(t, srv.CreateBucket(testBucket))
+	return srv, oss.NewClient(srv.Config())
+}
+
+func serviceError(t *testing.T, err error) *oss.ServiceError {
+	t.Helper()
+	var serr *oss.ServiceError
+	if !errors.As(err, &serr) {
+		t.Fatalf("expect a service error, got %v", err)
+	}
+	return serr
+}
+
+func TestServer_Bucket(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.TODO()
+
+	_, err := client.PutBucket(ctx, &oss.PutBucketRequest{Bucket: oss.Ptr("bucket-2")})
+	assert.Nil(t, err)
+
+	_, err = client.PutBucket(ctx, &oss.PutBucketRequest{Bucket: oss.Ptr("bucket-2")})
+	assert.Equal(t, "BucketAlreadyExists", serviceError(t, err).Code)
+
+	exist, err := client.IsBucketExist(ctx, "bucket-2")
+	assert.Nil(t, err)
+	assert.True(t, exist)
+
+	exist, err = client.IsBucketExist(ctx, "bucket-3")
+	assert.Nil(t, err)
+	assert.False(t, exist)
+
+	var names []string
+	p := client.NewListBucketsPaginator(&oss.ListBucketsRequest{MaxKeys: 1})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx)
+		assert.Nil(t, err)
+		for _, b := range page.Buckets {
+			names = append(names, oss.ToString(b.Name))
+		}
+	}
+	assert.Equal(t, []string{"bucket", "bucket-2"}, names)
+
+	_, err = client.PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr("bucket-2"), Key: oss.Ptr("key"), Body: strings.NewReader("hi")})
+	assert.Nil(t, err)
+
+	_, err = client.DeleteBucket(ctx, &oss.DeleteBucketRequest{Bucket: oss.Ptr("bucket-2")})
+	assert.Equal(t, "BucketNotEmpty", serviceError(t, err).Code)
+
+	_, err = client.DeleteObject(ctx, &oss.DeleteObjectRequest{Bucket: oss.Ptr("bucket-2"), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+
+	_, err = client.DeleteBucket(ctx, &oss.DeleteBucketRequest{Bucket: oss.Ptr("bucket-2")})
+	assert.Nil(t, err)
+
+	_, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr("bucket-2"), Key: oss.Ptr("key")})
+	assert.Equal(t, "NoSuchBucket", serviceError(t, err).Code)
+}
+
+func TestServer_Object(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.TODO()
+	data := []byte("hello osstest")
+
+	putResult, err := client.PutObject(ctx, &oss.PutObjectRequest{
+		Bucket:      oss.Ptr(testBucket),
+		Key:         oss.Ptr("dir/key+with space"),
+		Body:        bytes.NewReader(data),
+		ContentType: oss.Ptr("text/plain"),
+		Metadata:    map[string]string{"owner": "test"},
+		Tagging:     oss.Ptr("k1=v1&k2=v2"),
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, oss.ToString(putResult.ETag))
+	assert.NotEmpty(t, oss.ToString(putResult.HashCRC64))
+
+	getResult, err := client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space")})
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(getResult.Body)
+	getResult.Body.Close()
+	assert.Equal(t, data, body)
+	assert.Equal(t, "text/plain", oss.ToString(getResult.ContentType))
+	assert.Equal(t, "test", getResult.Metadata["owner"])
+	assert.Equal(t, int32(2), getResult.TaggingCount)
+	assert.Equal(t, putResult.ETag, getResult.ETag)
+	assert.Equal(t, putResult.HashCRC64, getResult.HashCRC64)
+
+	getResult, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space"), Range: oss.Ptr("bytes=6-")})
+	assert.Nil(t, err)
+	body, _ = io.ReadAll(getResult.Body)
+	getResult.Body.Close()
+	assert.Equal(t, 206, getResult.StatusCode)
+	assert.Equal(t, "osstest", string(body))
+	assert.Equal(t, "bytes 6-12/13", oss.ToString(getResult.ContentRange))
+
+	getResult, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space"), Range: oss.Ptr("bytes=100-")})
+	assert.Nil(t, err)
+	getResult.Body.Close()
+	assert.Equal(t, 200, getResult.StatusCode)
+
+	_, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space"), Range: oss.Ptr("bytes=100-"), RangeBehavior: oss.Ptr("standard")})
+	assert.Equal(t, "InvalidRange", serviceError(t, err).Code)
+
+	headResult, err := client.HeadObject(ctx, &oss.HeadObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(data)), headResult.ContentLength)
+	assert.Equal(t, "Normal", oss.ToString(headResult.ObjectType))
+
+	_, err = client.HeadObject(ctx, &oss.HeadObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space"), IfMatch: oss.Ptr("\"invalid\"")})
+	assert.Equal(t, "PreconditionFailed", serviceError(t, err).Code)
+
+	metaResult, err := client.GetObjectMeta(ctx, &oss.GetObjectMetaRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(data)), metaResult.ContentLength)
+
+	_, err = client.PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space"), Body: strings.NewReader("x"), ForbidOverwrite: oss.Ptr("true")})
+	assert.Equal(t, "FileAlreadyExists", serviceError(t, err).Code)
+
+	_, err = client.DeleteObject(ctx, &oss.DeleteObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space")})
+	assert.Nil(t, err)
+
+	exist, err := client.IsObjectExist(ctx, testBucket, "dir/key+with space")
+	assert.Nil(t, err)
+	assert.False(t, exist)
+
+	_, err = client.HeadObject(ctx, &oss.HeadObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("dir/key+with space")})
+	serr := serviceError(t, err)
+	assert.Equal(t, 404, serr.StatusCode)
+	assert.Equal(t, "NoSuchKey", serr.Code)
+}
+
+func TestServer_Signature(t *testing.T) {
+	srv, _ := newTestServer(t)
+	ctx := context.TODO()
+	assert.Nil(t, srv.PutObject(testBucket, "key", []byte("data")))
+
+	for _, version := range []oss.SignatureVersionType{oss.SignatureVersionV1, oss.SignatureVersionV4} {
+		client := oss.NewClient(srv.Config().WithSignatureVersion(version))
+
+		_, err := client.PutObjectTagging(ctx, &oss.PutObjectTaggingRequest{
+			Bucket:  oss.Ptr(testBucket),
+			Key:     oss.Ptr("key"),
+			Tagging: &oss.Tagging{TagSet: &oss.TagSet{Tags: []oss.Tag{{Key: oss.Ptr("k"), Value: oss.Ptr("v")}}}},
+		})
+		assert.Nil(t, err)
+
+		_, err = client.ListObjectsV2(ctx, &oss.ListObjectsV2Request{Bucket: oss.Ptr(testBucket), Prefix: oss.Ptr("k")})
+		assert.Nil(t, err)
+
+		presignResult, err := client.Presign(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+		assert.Nil(t, err)
+		resp, err := http.Get(presignResult.URL)
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "data", string(body))
+
+		// the presigned signature covers the key
+		resp, err = http.Get(strings.Replace(presignResult.URL, "/key?", "/key2?", 1))
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 403, resp.StatusCode)
+
+		client = oss.NewClient(srv.Config().
+			WithSignatureVersion(version).
+			WithCredentialsProvider(credentials.NewStaticCredentialsProvider(testAccessKeyID, "invalid")))
+		_, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+		assert.Equal(t, "SignatureDoesNotMatch", serviceError(t, err).Code)
+
+		client = oss.NewClient(srv.Config().
+			WithSignatureVersion(version).
+			WithCredentialsProvider(credentials.NewStaticCredentialsProvider("invalid", testAccessKeySecret)))
+		_, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+		assert.Equal(t, "InvalidAccessKeyId", serviceError(t, err).Code)
+	}
+
+	resp, err := http.Get(srv.URL + "/" + testBucket + "/key")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 403, resp.StatusCode)
+
+	// anonymous access
+	anonymous := NewServer()
+	defer anonymous.Close()
+	assert.Nil(t, anonymous.CreateBucket(testBucket))
+	_, err = oss.NewClient(anonymous.Config()).PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key"), Body: strings.NewReader("data")})
+	assert.Nil(t, err)
+}
+
+func TestServer_PostObject(t *testing.T) {
+	srv, _ := newTestServer(t)
+	ctx := context.TODO()
+
+	for _, version := range []oss.SignatureVersionType{oss.SignatureVersionV1, oss.SignatureVersionV4} {
+		client := oss.NewClient(srv.Config().WithSignatureVersion(version))
+		newPolicy := func() *oss.PostPolicy {
+			return oss.NewPostPolicy(time.Now().Add(time.Hour)).
+				WithBucket(testBucket).
+				WithKeyStartsWith("user/").
+				WithContentLengthRange(1, 10).
+				WithCondition("eq", "x-oss-meta-owner", "alice")
+		}
+
+		result, err := client.PostObject(ctx, &oss.PostObjectRequest{
+			Bucket: oss.Ptr(testBucket),
+			Key:    oss.Ptr("user/a.txt"),
+			Policy: newPolicy().WithSuccessActionStatus(201).WithContentType("text/plain"),
+			Body:   strings.NewReader("hello"),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 201, result.StatusCode)
+		assert.Contains(t, oss.ToString(result.Location), "user/a.txt")
+		assert.NotEmpty(t, oss.ToString(result.ETag))
+		data, ok := srv.Object(testBucket, "user/a.txt")
+		assert.True(t, ok)
+		assert.Equal(t, "hello", string(data))
+		head, err := client.HeadObject(ctx, &oss.HeadObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("user/a.txt")})
+		assert.Nil(t, err)
+		assert.Equal(t, "text/plain", oss.ToString(head.ContentType))
+		assert.Equal(t, "alice", head.Metadata["owner"])
+
+		// the conditions of the policy
+		_, err = client.PostObject(ctx, &oss.PostObjectRequest{
+			Bucket: oss.Ptr(testBucket),
+			Key:    oss.Ptr("other/a.txt"),
+			Policy: newPolicy(),
+			Body:   strings.NewReader("hello"),
+		})
+		assert.Equal(t, "AccessDenied", serviceError(t, err).Code)
+
+		_, err = client.PostObject(ctx, &oss.PostObjectRequest{
+			Bucket: oss.Ptr(testBucket),
+			Key:    oss.Ptr("user/b.txt"),
+			Policy: newPolicy(),
+			Body:   strings.NewReader("hello world"),
+		})
+		assert.Equal(t, "EntityTooLarge", serviceError(t, err).Code)
+
+		_, err = client.PostObject(ctx, &oss.PostObjectRequest{
+			Bucket: oss.Ptr(testBucket),
+			Key:    oss.Ptr("user/b.txt"),
+			Policy: newPolicy(),
+			Fields: map[string]string{"x-oss-meta-owner": "bob"},
+			Body:   strings.NewReader("hello"),
+		})
+		assert.Equal(t, "AccessDenied", serviceError(t, err).Code)
+
+		_, err = client.PostObject(ctx, &oss.PostObjectRequest{
+			Bucket: oss.Ptr(testBucket),
+			Key:    oss.Ptr("user/b.txt"),
+			Policy: oss.NewPostPolicy(time.Now().Add(-time.Minute)),
+			Body:   strings.NewReader("hello"),
+		})
+		assert.Equal(t, "AccessDenied", serviceError(t, err).Code)
+		assert.Contains(t, serviceError(t, err).Message, "Policy expired")
+
+		// a form posted by a browser
+		presignResult, err := client.PresignPostObject(ctx, newPolicy())
+		assert.Nil(t, err)
+		assert.Equal(t, "alice", presignResult.Fields["x-oss-meta-owner"])
+		post := func(fields map[string]string, key, content string) (int, string) {
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			w.WriteField("key", key)
+			for k, v := range fields {
+				w.WriteField(k, v)
+			}
+			fw, _ := w.CreateFormFile("file", "b.txt")
+			fw.Write([]byte(content))
+			w.Close()
+			resp, err := http.Post(presignResult.URL, w.FormDataContentType(), &body)
+			assert.Nil(t, err)
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return resp.StatusCode, string(data)
+		}
+		status, _ := post(presignResult.Fields, "user/b.txt", "world")
+		assert.Equal(t, 204, status)
+		data, _ = srv.Object(testBucket, "user/b.txt")
+		assert.Equal(t, "world", string(data))
+
+		tampered := map[string]string{}
+		for k, v := range presignResult.Fields {
+			tampered[k] = v
+		}
+		tampered["policy"] = base64.StdEncoding.EncodeToString([]byte(`{"expiration":"2100-01-01T00:00:00.000Z","conditions":[]}`))
+		status, body := post(tampered, "other/b.txt", "world")
+		assert.Equal(t, 403, status)
+		assert.Contains(t, body, "<Code>SignatureDoesNotMatch</Code>")
+	}
+}
+
+func TestServer_ListObjects(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.TODO()
+	keys := []string{"a", "b/1", "b/2", "c/1", "c/2/3", "d%e f", "x"}
+	for _, key := range keys {
+		assert.Nil(t, srv.PutObject(testBucket, key, []byte(key)))
+	}
+	assert.Equal(t, keys, srv.Keys(testBucket))
+
+	var contents, prefixes []string
+	p := client.NewListObjectsPaginator(&oss.ListObjectsRequest{Bucket: oss.Ptr(testBucket), Delimiter: oss.Ptr("/"), MaxKeys: 2})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(page.Contents)+len(page.CommonPrefixes), 2)
+		for _, o := range page.Contents {
+			contents = append(contents, oss.ToString(o.Key))
+		}
+		for _, cp := range page.CommonPrefixes {
+			prefixes = append(prefixes, oss.ToString(cp.Prefix))
+		}
+	}
+	assert.Equal(t, []string{"a", "d%e f", "x"}, contents)
+	assert.Equal(t, []string{"b/", "c/"}, prefixes)
+
+	contents, prefixes = nil, nil
+	p2 := client.NewListObjectsV2Paginator(&oss.ListObjectsV2Request{Bucket: oss.Ptr(testBucket), Prefix: oss.Ptr("c/"), Delimiter: oss.Ptr("/"), MaxKeys: 1})
+	for p2.HasNext() {
+		page, err := p2.NextPage(ctx)
+		assert.Nil(t, err)
+		for _, o := range page.Contents {
+			contents = append(contents, oss.ToString(o.Key))
+			assert.Equal(t, int64(len(*o.Key)), o.Size)
+		}
+		for _, cp := range page.CommonPrefixes {
+			prefixes = append(prefixes, oss.ToString(cp.Prefix))
+		}
+	}
+	assert.Equal(t, []string{"c/1"}, contents)
+	assert.Equal(t, []string{"c/2/"}, prefixes)
+
+	result, err := client.ListObjectsV2(ctx, &oss.ListObjectsV2Request{Bucket: oss.Ptr(testBucket), StartAfter: oss.Ptr("c/2/3")})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.KeyCount)
+	assert.Equal(t, "d%e f", oss.ToString(result.Contents[0].Key))
+
+	delResult, err := client.DeleteMultipleObjects(ctx, &oss.DeleteMultipleObjectsRequest{
+		Bucket:  oss.Ptr(testBucket),
+		Objects: []oss.DeleteObject{{Key: oss.Ptr("a")}, {Key: oss.Ptr("d%e f")}},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, delResult.DeletedObjects, 2)
+	assert.Equal(t, "d%e f", oss.ToString(delResult.DeletedObjects[1].Key))
+	assert.Equal(t, []string{"b/1", "b/2", "c/1", "c/2/3", "x"}, srv.Keys(testBucket))
+}
+
+func TestServer_AppendAndCopyObject(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.TODO()
+
+	result, err := client.AppendObject(ctx, &oss.AppendObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("append"), Position: oss.Ptr(int64(0)), Body: strings.NewReader("hello ")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), result.NextPosition)
+
+	result, err = client.AppendObject(ctx, &oss.AppendObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("append"), Position: oss.Ptr(int64(6)), Body: strings.NewReader("world"), InitHashCRC64: result.HashCRC64})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), result.NextPosition)
+
+	_, err = client.AppendObject(ctx, &oss.AppendObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("append"), Position: oss.Ptr(int64(0)), Body: strings.NewReader("x")})
+	serr := serviceError(t, err)
+	assert.Equal(t, "PositionNotEqualToLength", serr.Code)
+	assert.Equal(t, "11", serr.Headers.Get(oss.HeaderOssNextAppendPosition))
+
+	data, ok := srv.Object(testBucket, "append")
+	assert.True(t, ok)
+	assert.Equal(t, "hello world", string(data))
+
+	_, err = client.CopyObject(ctx, &oss.CopyObjectRequest{
+		Bucket:            oss.Ptr(testBucket),
+		Key:               oss.Ptr("copy/target"),
+		SourceKey:         oss.Ptr("append"),
+		MetadataDirective: oss.Ptr("REPLACE"),
+		Metadata:          map[string]string{"copied": "yes"},
+	})
+	assert.Nil(t, err)
+
+	headResult, err := client.HeadObject(ctx, &oss.HeadObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("copy/target")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), headResult.ContentLength)
+	assert.Equal(t, "yes", headResult.Metadata["copied"])
+	assert.Equal(t, "Normal", oss.ToString(headResult.ObjectType))
+
+	_, err = client.AppendObject(ctx, &oss.AppendObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("copy/target"), Position: oss.Ptr(int64(11)), Body: strings.NewReader("x")})
+	assert.Equal(t, "ObjectNotAppendable", serviceError(t, err).Code)
+
+	_, err = client.CopyObject(ctx, &oss.CopyObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("copy/target"), SourceKey: oss.Ptr("missing")})
+	assert.Equal(t, "NoSuchKey", serviceError(t, err).Code)
+}
+
+func TestServer_Multipart(t *testing.T) {
+	srv, client := newTestServer(t, func(o *Options) { o.MinPartSize = 4 })
+	ctx := context.TODO()
+	assert.Nil(t, srv.PutObject(testBucket, "source", []byte("0123456789")))
+
+	initResult, err := client.InitiateMultipartUpload(ctx, &oss.InitiateMultipartUploadRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part")})
+	assert.Nil(t, err)
+	assert.Equal(t, "multi part", oss.ToString(initResult.Key))
+
+	part1, err := client.UploadPart(ctx, &oss.UploadPartRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: 1, Body: strings.NewReader("abcd")})
+	assert.Nil(t, err)
+
+	part2, err := client.UploadPartCopy(ctx, &oss.UploadPartCopyRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: 2, SourceKey: oss.Ptr("source"), Range: oss.Ptr("bytes=2-5")})
+	assert.Nil(t, err)
+
+	part3, err := client.UploadPart(ctx, &oss.UploadPartRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: 3, Body: strings.NewReader("z")})
+	assert.Nil(t, err)
+
+	partsResult, err := client.ListParts(ctx, &oss.ListPartsRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, MaxParts: 2})
+	assert.Nil(t, err)
+	assert.True(t, partsResult.IsTruncated)
+	assert.Len(t, partsResult.Parts, 2)
+	assert.Equal(t, int64(4), partsResult.Parts[1].Size)
+	assert.Equal(t, int32(2), partsResult.NextPartNumberMarker)
+
+	uploadsResult, err := client.ListMultipartUploads(ctx, &oss.ListMultipartUploadsRequest{Bucket: oss.Ptr(testBucket)})
+	assert.Nil(t, err)
+	assert.Len(t, uploadsResult.Uploads, 1)
+	assert.Equal(t, "multi part", oss.ToString(uploadsResult.Uploads[0].Key))
+
+	_, err = client.CompleteMultipartUpload(ctx, &oss.CompleteMultipartUploadRequest{
+		Bucket:   oss.Ptr(testBucket),
+		Key:      oss.Ptr("multi part"),
+		UploadId: initResult.UploadId,
+		CompleteMultipartUpload: &oss.CompleteMultipartUpload{Parts: []oss.UploadPart{
+			{PartNumber: 1, ETag: part1.ETag},
+			{PartNumber: 2, ETag: oss.Ptr("\"invalid\"")},
+		}},
+	})
+	assert.Equal(t, "InvalidPart", serviceError(t, err).Code)
+
+	_, err = client.CompleteMultipartUpload(ctx, &oss.CompleteMultipartUploadRequest{
+		Bucket:   oss.Ptr(testBucket),
+		Key:      oss.Ptr("multi part"),
+		UploadId: initResult.UploadId,
+		CompleteMultipartUpload: &oss.CompleteMultipartUpload{Parts: []oss.UploadPart{
+			{PartNumber: 3, ETag: part3.ETag},
+		}},
+	})
+	assert.Nil(t, err)
+	_, err = client.UploadPart(ctx, &oss.UploadPartRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: 1, Body: strings.NewReader("abcd")})
+	assert.Equal(t, "NoSuchUpload", serviceError(t, err).Code)
+
+	// complete with parts smaller than MinPartSize
+	initResult, err = client.InitiateMultipartUpload(ctx, &oss.InitiateMultipartUploadRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part")})
+	assert.Nil(t, err)
+	for i, body := range []string{"a", "b"} {
+		_, err = client.UploadPart(ctx, &oss.UploadPartRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: int32(i + 1), Body: strings.NewReader(body)})
+		assert.Nil(t, err)
+	}
+	_, err = client.CompleteMultipartUpload(ctx, &oss.CompleteMultipartUploadRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, CompleteAll: oss.Ptr("yes")})
+	assert.Equal(t, "EntityTooSmall", serviceError(t, err).Code)
+	_, err = client.AbortMultipartUpload(ctx, &oss.AbortMultipartUploadRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId})
+	assert.Nil(t, err)
+
+	// complete the first upload again with the valid parts
+	initResult, err = client.InitiateMultipartUpload(ctx, &oss.InitiateMultipartUploadRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part")})
+	assert.Nil(t, err)
+	var parts []oss.UploadPart
+	for i, body := range []string{"abcd", "2345", "z"} {
+		result, err := client.UploadPart(ctx, &oss.UploadPartRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("multi part"), UploadId: initResult.UploadId, PartNumber: int32(i + 1), Body: strings.NewReader(body)})
+		assert.Nil(t, err)
+		parts = append(parts, oss.UploadPart{PartNumber: int32(i + 1), ETag: result.ETag})
+	}
+	assert.Equal(t, part2.ETag, parts[1].ETag)
+	completeResult, err := client.CompleteMultipartUpload(ctx, &oss.CompleteMultipartUploadRequest{
+		Bucket:                  oss.Ptr(testBucket),
+		Key:                     oss.Ptr("multi part"),
+		UploadId:                initResult.UploadId,
+		CompleteMultipartUpload: &oss.CompleteMultipartUpload{Parts: parts},
+	})
+	assert.Nil(t, err)
+	assert.True(t, strings.HasSuffix(oss.ToString(completeResult.ETag), "-3\""))
+
+	data, _ := srv.Object(testBucket, "multi part")
+	assert.Equal(t, "abcd2345z", string(data))
+}
+
+func TestServer_Versioning(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.TODO()
+
+	_, err := client.PutBucketVersioning(ctx, &oss.PutBucketVersioningRequest{
+		Bucket:                  oss.Ptr(testBucket),
+		VersioningConfiguration: &oss.VersioningConfiguration{Status: oss.VersionEnabled},
+	})
+	assert.Nil(t, err)
+
+	versioning, err := client.GetBucketVersioning(ctx, &oss.GetBucketVersioningRequest{Bucket: oss.Ptr(testBucket)})
+	assert.Nil(t, err)
+	assert.Equal(t, "Enabled", oss.ToString(versioning.VersionStatus))
+
+	v1, err := client.PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key"), Body: strings.NewReader("v1")})
+	assert.Nil(t, err)
+	v2, err := client.PutObject(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key"), Body: strings.NewReader("v2")})
+	assert.Nil(t, err)
+	assert.NotEqual(t, oss.ToString(v1.VersionId), oss.ToString(v2.VersionId))
+
+	delResult, err := client.DeleteObject(ctx, &oss.DeleteObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+	assert.True(t, delResult.DeleteMarker)
+
+	_, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Equal(t, "NoSuchKey", serviceError(t, err).Code)
+
+	getResult, err := client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key"), VersionId: v1.VersionId})
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(getResult.Body)
+	getResult.Body.Close()
+	assert.Equal(t, "v1", string(body))
+	assert.Equal(t, v1.VersionId, getResult.VersionId)
+
+	listResult, err := client.ListObjectVersions(ctx, &oss.ListObjectVersionsRequest{Bucket: oss.Ptr(testBucket)})
+	assert.Nil(t, err)
+	assert.Len(t, listResult.ObjectDeleteMarkers, 1)
+	assert.True(t, listResult.ObjectDeleteMarkers[0].IsLatest)
+	assert.Len(t, listResult.ObjectVersions, 2)
+	assert.Equal(t, v2.VersionId, listResult.ObjectVersions[0].VersionId)
+
+	var mixed []string
+	p := client.NewListObjectVersionsPaginator(&oss.ListObjectVersionsRequest{Bucket: oss.Ptr(testBucket), IsMix: true, MaxKeys: 1})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx)
+		assert.Nil(t, err)
+		for _, v := range page.ObjectVersionsDeleteMarkers {
+			mixed = append(mixed, oss.ToString(v.VersionId))
+		}
+	}
+	assert.Equal(t, []string{oss.ToString(delResult.VersionId), oss.ToString(v2.VersionId), oss.ToString(v1.VersionId)}, mixed)
+
+	// remove the delete marker to restore the previous version
+	_, err = client.DeleteObject(ctx, &oss.DeleteObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key"), VersionId: delResult.VersionId})
+	assert.Nil(t, err)
+	getResult, err = client.GetObject(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+	body, _ = io.ReadAll(getResult.Body)
+	getResult.Body.Close()
+	assert.Equal(t, "v2", string(body))
+}
+
+func TestServer_Tagging(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.TODO()
+	assert.Nil(t, srv.PutObject(testBucket, "key", []byte("data")))
+
+	_, err := client.PutObjectTagging(ctx, &oss.PutObjectTaggingRequest{
+		Bucket: oss.Ptr(testBucket),
+		Key:    oss.Ptr("key"),
+		Tagging: &oss.Tagging{TagSet: &oss.TagSet{Tags: []oss.Tag{
+			{Key: oss.Ptr("k2"), Value: oss.Ptr("v2")},
+			{Key: oss.Ptr("k1"), Value: oss.Ptr("v1")},
+		}}},
+	})
+	assert.Nil(t, err)
+
+	result, err := client.GetObjectTagging(ctx, &oss.GetObjectTaggingRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+	assert.Len(t, result.Tags, 2)
+	assert.Equal(t, "k1", oss.ToString(result.Tags[0].Key))
+	assert.Equal(t, "v1", oss.ToString(result.Tags[0].Value))
+
+	_, err = client.DeleteObjectTagging(ctx, &oss.DeleteObjectTaggingRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+
+	result, err = client.GetObjectTagging(ctx, &oss.GetObjectTaggingRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("key")})
+	assert.Nil(t, err)
+	assert.Len(t, result.Tags, 0)
+}
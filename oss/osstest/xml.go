@@ -0,0 +1,269 @@
+package osstest
+
+import (
+	"encoding/xml"
+)
+
+// The wire formats of the responses and request bodies.
+// The result types of the oss package carry response metadata, so the server uses its own types.
+
+type errorXML struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	RequestId string   `xml:"RequestId"`
+	HostId    string   `xml:"HostId"`
+	EC        string   `xml:"EC,omitempty"`
+}
+
+type ownerXML struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type bucketXML struct {
+	Name             string `xml:"Name"`
+	Location         string `xml:"Location"`
+	CreationDate     string `xml:"CreationDate"`
+	ExtranetEndpoint string `xml:"ExtranetEndpoint"`
+	IntranetEndpoint string `xml:"IntranetEndpoint"`
+	Region           string `xml:"Region"`
+	StorageClass     string `xml:"StorageClass"`
+}
+
+type listBucketsXML struct {
+	XMLName     xml.Name    `xml:"ListAllMyBucketsResult"`
+	Prefix      string      `xml:"Prefix,omitempty"`
+	Marker      string      `xml:"Marker,omitempty"`
+	MaxKeys     int         `xml:"MaxKeys,omitempty"`
+	IsTruncated bool        `xml:"IsTruncated"`
+	NextMarker  string      `xml:"NextMarker,omitempty"`
+	Owner       ownerXML    `xml:"Owner"`
+	Buckets     []bucketXML `xml:"Buckets>Bucket"`
+}
+
+type bucketInfoXML struct {
+	XMLName xml.Name `xml:"BucketInfo"`
+	Bucket  struct {
+		Name             string   `xml:"Name"`
+		Location         string   `xml:"Location"`
+		CreationDate     string   `xml:"CreationDate"`
+		ExtranetEndpoint string   `xml:"ExtranetEndpoint"`
+		IntranetEndpoint string   `xml:"IntranetEndpoint"`
+		ACL              string   `xml:"AccessControlList>Grant"`
+		Owner            ownerXML `xml:"Owner"`
+		StorageClass     string   `xml:"StorageClass"`
+		Versioning       string   `xml:"Versioning,omitempty"`
+	} `xml:"Bucket"`
+}
+
+type accessControlPolicyXML struct {
+	XMLName xml.Name `xml:"AccessControlPolicy"`
+	Owner   ownerXML `xml:"Owner"`
+	ACL     string   `xml:"AccessControlList>Grant"`
+}
+
+type locationXML struct {
+	XMLName  xml.Name `xml:"LocationConstraint"`
+	Location string   `xml:",chardata"`
+}
+
+type versioningXML struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+type objectXML struct {
+	Key          string    `xml:"Key"`
+	LastModified string    `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Type         string    `xml:"Type"`
+	Size         int64     `xml:"Size"`
+	StorageClass string    `xml:"StorageClass"`
+	Owner        *ownerXML `xml:"Owner,omitempty"`
+}
+
+type commonPrefixXML struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type listObjectsXML struct {
+	XMLName        xml.Name          `xml:"ListBucketResult"`
+	Name           string            `xml:"Name"`
+	Prefix         string            `xml:"Prefix"`
+	Marker         string            `xml:"Marker"`
+	MaxKeys        int               `xml:"MaxKeys"`
+	Delimiter      string            `xml:"Delimiter"`
+	IsTruncated    bool              `xml:"IsTruncated"`
+	NextMarker     string            `xml:"NextMarker,omitempty"`
+	EncodingType   string            `xml:"EncodingType,omitempty"`
+	Contents       []objectXML       `xml:"Contents"`
+	CommonPrefixes []commonPrefixXML `xml:"CommonPrefixes"`
+}
+
+type listObjectsV2XML struct {
+	XMLName               xml.Name          `xml:"ListBucketResult"`
+	Name                  string            `xml:"Name"`
+	Prefix                string            `xml:"Prefix"`
+	StartAfter            string            `xml:"StartAfter,omitempty"`
+	ContinuationToken     string            `xml:"ContinuationToken,omitempty"`
+	MaxKeys               int               `xml:"MaxKeys"`
+	Delimiter             string            `xml:"Delimiter"`
+	IsTruncated           bool              `xml:"IsTruncated"`
+	NextContinuationToken string            `xml:"NextContinuationToken,omitempty"`
+	EncodingType          string            `xml:"EncodingType,omitempty"`
+	KeyCount              int               `xml:"KeyCount"`
+	Contents              []objectXML       `xml:"Contents"`
+	CommonPrefixes        []commonPrefixXML `xml:"CommonPrefixes"`
+}
+
+// versionXML is either a <Version> or a <DeleteMarker>, which are interleaved in the key order.
+type versionXML struct {
+	XMLName      xml.Name
+	Key          string   `xml:"Key"`
+	VersionId    string   `xml:"VersionId"`
+	IsLatest     bool     `xml:"IsLatest"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag,omitempty"`
+	Type         string   `xml:"Type,omitempty"`
+	Size         *int64   `xml:"Size,omitempty"`
+	StorageClass string   `xml:"StorageClass,omitempty"`
+	Owner        ownerXML `xml:"Owner"`
+}
+
+type listVersionsXML struct {
+	XMLName             xml.Name          `xml:"ListVersionsResult"`
+	Name                string            `xml:"Name"`
+	Prefix              string            `xml:"Prefix"`
+	KeyMarker           string            `xml:"KeyMarker"`
+	VersionIdMarker     string            `xml:"VersionIdMarker"`
+	MaxKeys             int               `xml:"MaxKeys"`
+	Delimiter           string            `xml:"Delimiter"`
+	IsTruncated         bool              `xml:"IsTruncated"`
+	NextKeyMarker       string            `xml:"NextKeyMarker,omitempty"`
+	NextVersionIdMarker string            `xml:"NextVersionIdMarker,omitempty"`
+	EncodingType        string            `xml:"EncodingType,omitempty"`
+	Versions            []versionXML      `xml:""`
+	CommonPrefixes      []commonPrefixXML `xml:"CommonPrefixes"`
+}
+
+type copyObjectXML struct {
+	XMLName      xml.Name `xml:"CopyObjectResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+type copyPartXML struct {
+	XMLName      xml.Name `xml:"CopyPartResult"`
+	LastModified string   `xml:"LastModified"`
+	ETag         string   `xml:"ETag"`
+}
+
+type initiateMultipartUploadXML struct {
+	XMLName      xml.Name `xml:"InitiateMultipartUploadResult"`
+	Bucket       string   `xml:"Bucket"`
+	Key          string   `xml:"Key"`
+	UploadId     string   `xml:"UploadId"`
+	EncodingType string   `xml:"EncodingType,omitempty"`
+}
+
+type completeMultipartUploadXML struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []struct {
+		PartNumber int32  `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResultXML struct {
+	XMLName      xml.Name `xml:"CompleteMultipartUploadResult"`
+	EncodingType string   `xml:"EncodingType,omitempty"`
+	Location     string   `xml:"Location"`
+	Bucket       string   `xml:"Bucket"`
+	Key          string   `xml:"Key"`
+	ETag         string   `xml:"ETag"`
+}
+
+type partXML struct {
+	PartNumber    int32  `xml:"PartNumber"`
+	LastModified  string `xml:"LastModified"`
+	ETag          string `xml:"ETag"`
+	HashCrc64ecma string `xml:"HashCrc64ecma"`
+	Size          int64  `xml:"Size"`
+}
+
+type listPartsXML struct {
+	XMLName              xml.Name  `xml:"ListPartsResult"`
+	EncodingType         string    `xml:"EncodingType,omitempty"`
+	Bucket               string    `xml:"Bucket"`
+	Key                  string    `xml:"Key"`
+	UploadId             string    `xml:"UploadId"`
+	StorageClass         string    `xml:"StorageClass"`
+	PartNumberMarker     int32     `xml:"PartNumberMarker"`
+	NextPartNumberMarker int32     `xml:"NextPartNumberMarker"`
+	MaxParts             int       `xml:"MaxParts"`
+	IsTruncated          bool      `xml:"IsTruncated"`
+	Parts                []partXML `xml:"Part"`
+}
+
+type uploadXML struct {
+	Key       string `xml:"Key"`
+	UploadId  string `xml:"UploadId"`
+	Initiated string `xml:"Initiated"`
+}
+
+type listUploadsXML struct {
+	XMLName            xml.Name          `xml:"ListMultipartUploadsResult"`
+	EncodingType       string            `xml:"EncodingType,omitempty"`
+	Bucket             string            `xml:"Bucket"`
+	KeyMarker          string            `xml:"KeyMarker"`
+	UploadIdMarker     string            `xml:"UploadIdMarker"`
+	NextKeyMarker      string            `xml:"NextKeyMarker"`
+	NextUploadIdMarker string            `xml:"NextUploadIdMarker"`
+	Delimiter          string            `xml:"Delimiter"`
+	Prefix             string            `xml:"Prefix"`
+	MaxUploads         int               `xml:"MaxUploads"`
+	IsTruncated        bool              `xml:"IsTruncated"`
+	Uploads            []uploadXML       `xml:"Upload"`
+	CommonPrefixes     []commonPrefixXML `xml:"CommonPrefixes"`
+}
+
+type tagXML struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type taggingXML struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Tags    []tagXML `xml:"TagSet>Tag"`
+}
+
+type deleteXML struct {
+	XMLName xml.Name `xml:"Delete"`
+	Quiet   bool     `xml:"Quiet"`
+	Objects []struct {
+		Key       string `xml:"Key"`
+		VersionId string `xml:"VersionId"`
+	} `xml:"Object"`
+}
+
+type deletedXML struct {
+	Key                   string `xml:"Key"`
+	VersionId             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionId string `xml:"DeleteMarkerVersionId,omitempty"`
+}
+
+type deleteResultXML struct {
+	XMLName      xml.Name     `xml:"DeleteResult"`
+	EncodingType string       `xml:"EncodingType,omitempty"`
+	Deleted      []deletedXML `xml:"Deleted"`
+}
+
+type postResponseXML struct {
+	XMLName  xml.Name `xml:"PostResponse"`
+	Bucket   string   `xml:"Bucket"`
+	Location string   `xml:"Location"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
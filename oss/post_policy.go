@@ -0,0 +1,144 @@
+package oss
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const postPolicyTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// PostPolicy is the policy of a form upload (PostObject), it limits the fields of the forms which are signed with it.
+// The fields which are set to exact values by the policy are added to the form by PresignPostObject and PostObject.
+type PostPolicy struct {
+	// The time after which the forms are rejected.
+	Expiration time.Time
+
+	// The conditions, each of them is a map of one field, such as {"bucket": "examplebucket"},
+	// or a list of an operator, a field and values, such as ["starts-with", "$key", "user/"].
+	Conditions []any
+
+	bucket string
+	fields map[string]string
+}
+
+// NewPostPolicy returns a policy which expires at the expiration.
+func NewPostPolicy(expiration time.Time) *PostPolicy {
+	return &PostPolicy{
+		Expiration: expiration,
+		fields:     map[string]string{},
+	}
+}
+
+// WithBucket limits the bucket of the forms.
+func (p *PostPolicy) WithBucket(bucket string) *PostPolicy {
+	p.bucket = bucket
+	p.Conditions = append(p.Conditions, map[string]string{"bucket": bucket})
+	return p
+}
+
+// WithKey limits the key of the object to the value.
+func (p *PostPolicy) WithKey(key string) *PostPolicy {
+	return p.WithCondition("eq", "key", key)
+}
+
+// WithKeyStartsWith limits the key of the object to the ones which start with the prefix.
+func (p *PostPolicy) WithKeyStartsWith(prefix string) *PostPolicy {
+	return p.WithCondition("starts-with", "key", prefix)
+}
+
+// WithContentLengthRange limits the size of the file, in bytes.
+func (p *PostPolicy) WithContentLengthRange(min, max int64) *PostPolicy {
+	p.Conditions = append(p.Conditions, []any{"content-length-range", min, max})
+	return p
+}
+
+// WithContentType limits the Content-Type of the object to the value.
+func (p *PostPolicy) WithContentType(contentType string) *PostPolicy {
+	return p.WithCondition("eq", "Content-Type", contentType)
+}
+
+// WithContentTypeStartsWith limits the Content-Type of the object to the ones which start with the prefix, such as "image/".
+func (p *PostPolicy) WithContentTypeStartsWith(prefix string) *PostPolicy {
+	return p.WithCondition("starts-with", "Content-Type", prefix)
+}
+
+// WithSuccessActionStatus sets the status code of the successful uploads, which is 200, 201 or 204.
+func (p *PostPolicy) WithSuccessActionStatus(status int) *PostPolicy {
+	return p.WithCondition("eq", "success_action_status", strconv.Itoa(status))
+}
+
+// WithCallback sets the base64 encoded callback, the same as PutObjectRequest.Callback.
+func (p *PostPolicy) WithCallback(callback string) *PostPolicy {
+	return p.WithCondition("eq", "callback", callback)
+}
+
+// WithCondition adds a condition of the operator "eq", "starts-with" or "in" on the field, such as x-oss-meta-*.
+// The fields of the "eq" conditions are added to the forms.
+func (p *PostPolicy) WithCondition(operator, field string, values ...string) *PostPolicy {
+	condition := []any{operator, "$" + strings.TrimPrefix(field, "$")}
+	switch {
+	case operator == "in":
+		condition = append(condition, values)
+	case len(values) > 0:
+		condition = append(condition, values[0])
+	default:
+		condition = append(condition, "")
+	}
+	if operator == "eq" {
+		if p.fields == nil {
+			p.fields = map[string]string{}
+		}
+		p.fields[strings.TrimPrefix(field, "$")] = condition[2].(string)
+	}
+	p.Conditions = append(p.Conditions, condition)
+	return p
+}
+
+// Bucket returns the bucket of the policy, which is set by WithBucket.
+func (p *PostPolicy) Bucket() string {
+	return p.bucket
+}
+
+// Fields returns the form fields which are set to exact values by the policy.
+func (p *PostPolicy) Fields() map[string]string {
+	fields := make(map[string]string, len(p.fields))
+	for k, v := range p.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// Encode returns the base64 encoded JSON of the policy, with the extra "eq" conditions of the fields.
+func (p *PostPolicy) Encode(fields map[string]string) (string, error) {
+	conditions := append([]any(nil), p.Conditions...)
+	for _, k := range sortedStringKeys(fields) {
+		conditions = append(conditions, map[string]string{k: fields[k]})
+	}
+	if conditions == nil {
+		conditions = []any{}
+	}
+	data, err := json.Marshal(struct {
+		Expiration string `json:"expiration"`
+		Conditions []any  `json:"conditions"`
+	}{
+		Expiration: p.Expiration.UTC().Format(postPolicyTimeFormat),
+		Conditions: conditions,
+	})
+	if err != nil {
+		return "", &SerializationError{Err: err}
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
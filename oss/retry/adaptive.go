@@ -0,0 +1,389 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRetryQuota       = 500
+	DefaultRetryCost        = 5
+	DefaultRetryTimeoutCost = 10
+	DefaultNoRetryIncrement = 1
+
+	// DefaultMinSendRate The minimum send rate in requests per second when throttled.
+	DefaultMinSendRate = 0.5
+
+	// DefaultMaxRetryAfter The maximum delay honored from the Retry-After header.
+	DefaultMaxRetryAfter = 5 * time.Minute
+)
+
+// DefaultThrottleErrorCodes The service error codes which indicate the request is throttled.
+var DefaultThrottleErrorCodes = []string{
+	"QpsLimitExceeded",
+	"Throttling",
+	"RequestRateExceeded",
+	"SlowDown",
+}
+
+// DefaultThrottleStatusCodes The http status codes which indicate the request is throttled.
+var DefaultThrottleStatusCodes = []int{
+	429, // Too Many Requests
+	503, // Service Unavailable
+}
+
+// ErrRetryQuotaExceeded is returned by Adaptive.RetryDelay when the retry quota is exhausted.
+var ErrRetryQuotaExceeded = errors.New("retry quota exceeded")
+
+type AdaptiveOptions struct {
+	RetryOptions
+
+	// The capacity of the retry token bucket shared by all requests of the retryer.
+	RetryQuota int
+
+	// The tokens consumed by a retry.
+	RetryCost int
+
+	// The tokens consumed by a retry of a timeout error.
+	RetryTimeoutCost int
+
+	// The tokens refilled by a request which succeeds without retry.
+	NoRetryIncrement int
+
+	// The service error codes which indicate the request is throttled.
+	ThrottleErrorCodes []string
+
+	// The http status codes which indicate the request is throttled.
+	ThrottleStatusCodes []int
+
+	// The minimum send rate in requests per second when throttled.
+	MinSendRate float64
+
+	// The maximum delay honored from the Retry-After header, it is not limited by MaxBackoff.
+	MaxRetryAfter time.Duration
+}
+
+// Adaptive is a retryer which limits the retries by a shared retry quota,
+// and throttles the send rate on the client side when the service reports throttling.
+// It also honors the Retry-After header of the response.
+type Adaptive struct {
+	standard *Standard
+	options  AdaptiveOptions
+
+	mu      sync.Mutex
+	quota   int
+	limiter *sendRateLimiter
+}
+
+func NewAdaptive(fnOpts ...func(*AdaptiveOptions)) *Adaptive {
+	o := AdaptiveOptions{
+		RetryOptions: RetryOptions{
+			MaxAttempts:     DefaultMaxAttempts,
+			MaxBackoff:      DefaultMaxBackoff,
+			BaseDelay:       DefaultBaseDelay,
+			ErrorRetryables: DefaultErrorRetryables,
+		},
+		RetryQuota:          DefaultRetryQuota,
+		RetryCost:           DefaultRetryCost,
+		RetryTimeoutCost:    DefaultRetryTimeoutCost,
+		NoRetryIncrement:    DefaultNoRetryIncrement,
+		ThrottleErrorCodes:  DefaultThrottleErrorCodes,
+		ThrottleStatusCodes: DefaultThrottleStatusCodes,
+		MinSendRate:         DefaultMinSendRate,
+		MaxRetryAfter:       DefaultMaxRetryAfter,
+	}
+
+	for _, fn := range fnOpts {
+		fn(&o)
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if o.MinSendRate <= 0 {
+		o.MinSendRate = DefaultMinSendRate
+	}
+
+	if o.MaxRetryAfter <= 0 {
+		o.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	ro := o.RetryOptions
+	return &Adaptive{
+		standard: NewStandard(func(r *RetryOptions) { *r = ro }),
+		options:  o,
+		quota:    o.RetryQuota,
+		limiter:  newSendRateLimiter(o.MinSendRate, time.Now),
+	}
+}
+
+func (a *Adaptive) MaxAttempts() int {
+	return a.standard.MaxAttempts()
+}
+
+func (a *Adaptive) IsErrorRetryable(err error) bool {
+	return a.standard.IsErrorRetryable(err)
+}
+
+// RetryDelay consumes the retry tokens of the error, it returns ErrRetryQuotaExceeded if not enough tokens left.
+// The delay is the larger one of the backoff delay and the Retry-After header,
+// the backoff delay is no more than MaxBackoff, and the Retry-After header is no more than MaxRetryAfter.
+func (a *Adaptive) RetryDelay(attempt int, err error) (time.Duration, error) {
+	cost := a.retryCost(err)
+
+	a.mu.Lock()
+	if a.quota < cost {
+		a.mu.Unlock()
+		return 0, fmt.Errorf("%w, available %d, cost %d", ErrRetryQuotaExceeded, a.quota, cost)
+	}
+	a.quota -= cost
+	a.mu.Unlock()
+
+	delay, derr := a.standard.RetryDelay(attempt, err)
+	if derr != nil {
+		return delay, derr
+	}
+
+	if delay > a.options.MaxBackoff {
+		delay = a.options.MaxBackoff
+	}
+
+	if retryAfter, ok := retryAfterDelay(err, time.Now()); ok {
+		if retryAfter > a.options.MaxRetryAfter {
+			retryAfter = a.options.MaxRetryAfter
+		}
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	return delay, nil
+}
+
+// BeforeAttempt blocks until the send rate limiter allows the attempt.
+func (a *Adaptive) BeforeAttempt(ctx context.Context) error {
+	return a.limiter.acquire(ctx)
+}
+
+// AfterAttempt refills the retry quota on success and updates the send rate by the result.
+// A successful retry refunds the tokens consumed by the retry of retriedErr.
+func (a *Adaptive) AfterAttempt(attempt int, err error, retriedErr error) {
+	if err == nil {
+		refill := a.options.NoRetryIncrement
+		if attempt > 1 {
+			refill = a.retryCost(retriedErr)
+		}
+		a.mu.Lock()
+		a.quota += refill
+		if a.quota > a.options.RetryQuota {
+			a.quota = a.options.RetryQuota
+		}
+		a.mu.Unlock()
+	}
+
+	a.limiter.update(a.isThrottleError(err))
+}
+
+// AvailableQuota returns the tokens left in the retry quota.
+func (a *Adaptive) AvailableQuota() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.quota
+}
+
+func (a *Adaptive) retryCost(err error) int {
+	if isTimeoutError(err) {
+		return a.options.RetryTimeoutCost
+	}
+	return a.options.RetryCost
+}
+
+func (a *Adaptive) isThrottleError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var ec interface{ ErrorCode() string }
+	if errors.As(err, &ec) {
+		for _, code := range a.options.ThrottleErrorCodes {
+			if ec.ErrorCode() == code {
+				return true
+			}
+		}
+	}
+
+	var sc interface{ HttpStatusCode() int }
+	if errors.As(err, &sc) {
+		for _, code := range a.options.ThrottleStatusCodes {
+			if sc.HttpStatusCode() == code {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isTimeoutError(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+func retryAfterDelay(err error, now time.Time) (time.Duration, bool) {
+	var v interface{ HttpHeaders() http.Header }
+	if !errors.As(err, &v) || v.HttpHeaders() == nil {
+		return 0, false
+	}
+
+	value := v.HttpHeaders().Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sendRateLimiter is a token bucket whose fill rate is cut down multiplicatively on throttling
+// and increases linearly over time afterwards.
+// It does not limit the send rate until the first throttling error, and stops limiting once recovered.
+type sendRateLimiter struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	enabled    bool
+	minRate    float64
+	fillRate   float64
+	tokens     float64
+	lastRefill time.Time
+
+	// the measured send rate
+	measuredRate float64
+	measureStart time.Time
+	measureCount int
+
+	// the send rate when last throttled, and the rate after the decrease
+	throttledRate float64
+	throttledTime time.Time
+	baseRate      float64
+}
+
+const (
+	// the fill rate is multiplied by it when throttled
+	sendRateBeta = 0.7
+
+	// the fill rate increases by this ratio of the throttled rate per second
+	sendRateIncrease = 0.1
+)
+
+func newSendRateLimiter(minRate float64, now func() time.Time) *sendRateLimiter {
+	return &sendRateLimiter{
+		now:     now,
+		minRate: minRate,
+	}
+}
+
+func (l *sendRateLimiter) refill(now time.Time) {
+	if !l.lastRefill.IsZero() {
+		l.tokens += now.Sub(l.lastRefill).Seconds() * l.fillRate
+		if capacity := math.Max(l.fillRate, 1); l.tokens > capacity {
+			l.tokens = capacity
+		}
+	}
+	l.lastRefill = now
+}
+
+func (l *sendRateLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if !l.enabled {
+			l.mu.Unlock()
+			return nil
+		}
+		l.refill(l.now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.fillRate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *sendRateLimiter) update(throttled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	// measure the send rate in one second windows
+	if l.measureStart.IsZero() {
+		l.measureStart = now
+	} else if elapsed := now.Sub(l.measureStart).Seconds(); elapsed >= 1 {
+		l.measuredRate = float64(l.measureCount) / elapsed
+		l.measureStart = now
+		l.measureCount = 0
+	}
+	l.measureCount++
+
+	if !throttled && !l.enabled {
+		return
+	}
+
+	l.refill(now)
+
+	if throttled {
+		rate := l.fillRate
+		if !l.enabled {
+			rate = math.Max(l.measuredRate, l.minRate)
+			l.enabled = true
+		}
+		l.throttledRate = rate
+		l.throttledTime = now
+		l.baseRate = math.Max(rate*sendRateBeta, l.minRate)
+		l.fillRate = l.baseRate
+		return
+	}
+
+	l.fillRate = l.baseRate + l.throttledRate*sendRateIncrease*now.Sub(l.throttledTime).Seconds()
+
+	// recovered, stop limiting the send rate
+	if l.fillRate >= 2*l.throttledRate {
+		l.enabled = false
+		l.tokens = 0
+		l.lastRefill = time.Time{}
+	}
+}
+
+var _ Retryer = (*Adaptive)(nil)
+var _ AttemptHandler = (*Adaptive)(nil)
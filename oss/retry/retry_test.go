@@ -1,9 +1,11 @@
 package retry
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -190,3 +192,213 @@ func TestStandard(t *testing.T) {
 		assert.False(t, r.IsErrorRetryable(errors.New(pattern)))
 	}
 }
+
+type serviceError struct {
+	StatusCode int
+	Code       string
+	Headers    http.Header
+}
+
+func (e *serviceError) Error() string {
+	return "service error"
+}
+
+func (e *serviceError) HttpStatusCode() int {
+	return e.StatusCode
+}
+
+func (e *serviceError) ErrorCode() string {
+	return e.Code
+}
+
+func (e *serviceError) HttpHeaders() http.Header {
+	return e.Headers
+}
+
+func TestAdaptive(t *testing.T) {
+	r := NewAdaptive()
+	assert.NotNil(t, r)
+	assert.Equal(t, DefaultMaxAttempts, r.MaxAttempts())
+	assert.Equal(t, DefaultRetryQuota, r.AvailableQuota())
+
+	assert.False(t, r.IsErrorRetryable(nil))
+	assert.True(t, r.IsErrorRetryable(&statusCodeError{StatusCode: 500}))
+	assert.False(t, r.IsErrorRetryable(&statusCodeError{StatusCode: 403}))
+
+	// no limit before throttled
+	assert.Nil(t, r.BeforeAttempt(context.Background()))
+
+	r = NewAdaptive(func(o *AdaptiveOptions) {
+		o.MaxAttempts = 5
+		o.Backoff = NewFixedDelayBackoff(time.Second)
+		o.RetryQuota = 12
+	})
+	assert.Equal(t, 5, r.MaxAttempts())
+
+	// consume retry cost
+	delay, err := r.RetryDelay(2, &statusCodeError{StatusCode: 500})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, delay)
+	assert.Equal(t, 7, r.AvailableQuota())
+
+	// timeout cost
+	_, err = r.RetryDelay(2, &net.DNSError{IsTimeout: true})
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrRetryQuotaExceeded))
+	assert.Equal(t, 7, r.AvailableQuota())
+
+	_, err = r.RetryDelay(2, &statusCodeError{StatusCode: 500})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, r.AvailableQuota())
+
+	// quota exhausted
+	_, err = r.RetryDelay(2, &statusCodeError{StatusCode: 500})
+	assert.True(t, errors.Is(err, ErrRetryQuotaExceeded))
+
+	// refill on success
+	r.AfterAttempt(1, nil, nil)
+	assert.Equal(t, 3, r.AvailableQuota())
+	r.AfterAttempt(2, nil, &statusCodeError{StatusCode: 500})
+	assert.Equal(t, 8, r.AvailableQuota())
+
+	// no refill on error
+	r.AfterAttempt(1, &statusCodeError{StatusCode: 500}, nil)
+	assert.Equal(t, 8, r.AvailableQuota())
+	r.AfterAttempt(2, &statusCodeError{StatusCode: 500}, &statusCodeError{StatusCode: 500})
+	assert.Equal(t, 8, r.AvailableQuota())
+
+	r.AfterAttempt(3, nil, &statusCodeError{StatusCode: 500})
+	assert.Equal(t, 12, r.AvailableQuota())
+
+	// refund the timeout cost consumed by the retry
+	_, err = r.RetryDelay(2, &net.DNSError{IsTimeout: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, r.AvailableQuota())
+	r.AfterAttempt(2, nil, &net.DNSError{IsTimeout: true})
+	assert.Equal(t, 12, r.AvailableQuota())
+}
+
+func TestAdaptive_RetryAfter(t *testing.T) {
+	r := NewAdaptive(func(o *AdaptiveOptions) {
+		o.Backoff = NewFixedDelayBackoff(time.Second)
+		o.MaxBackoff = 10 * time.Second
+	})
+
+	delay, err := r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"3"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3*time.Second, delay)
+
+	// backoff is larger
+	delay, err = r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"0"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, delay)
+
+	// not limited by max backoff
+	delay, err = r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"60"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 60*time.Second, delay)
+
+	// no more than max retry after
+	delay, err = r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"3600"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultMaxRetryAfter, delay)
+
+	r = NewAdaptive(func(o *AdaptiveOptions) {
+		o.Backoff = NewFixedDelayBackoff(time.Second)
+		o.MaxRetryAfter = 2 * time.Second
+	})
+	delay, err = r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"60"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Second, delay)
+
+	// invalid
+	delay, err = r.RetryDelay(2, &serviceError{StatusCode: 503, Headers: http.Header{"Retry-After": {"abc"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, delay)
+
+	// http date
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d, ok := retryAfterDelay(&serviceError{Headers: http.Header{"Retry-After": {now.Add(5 * time.Second).Format(http.TimeFormat)}}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	d, ok = retryAfterDelay(&serviceError{Headers: http.Header{"Retry-After": {now.Add(-5 * time.Second).Format(http.TimeFormat)}}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	_, ok = retryAfterDelay(&serviceError{}, now)
+	assert.False(t, ok)
+
+	_, ok = retryAfterDelay(errors.New("error"), now)
+	assert.False(t, ok)
+}
+
+func TestAdaptive_ThrottleError(t *testing.T) {
+	r := NewAdaptive()
+	assert.False(t, r.isThrottleError(nil))
+	assert.True(t, r.isThrottleError(&serviceError{StatusCode: 503, Code: "ServiceUnavailable"}))
+	assert.True(t, r.isThrottleError(&serviceError{StatusCode: 429}))
+	assert.True(t, r.isThrottleError(&serviceError{StatusCode: 400, Code: "QpsLimitExceeded"}))
+	assert.False(t, r.isThrottleError(&serviceError{StatusCode: 500, Code: "InternalError"}))
+	assert.False(t, r.isThrottleError(io.ErrUnexpectedEOF))
+
+	r = NewAdaptive(func(o *AdaptiveOptions) {
+		o.ThrottleErrorCodes = []string{"CustomThrottle"}
+		o.ThrottleStatusCodes = nil
+	})
+	assert.True(t, r.isThrottleError(&serviceError{StatusCode: 400, Code: "CustomThrottle"}))
+	assert.False(t, r.isThrottleError(&serviceError{StatusCode: 503}))
+}
+
+func TestSendRateLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newSendRateLimiter(0.5, func() time.Time { return now })
+
+	// disabled before throttled
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, l.acquire(context.Background()))
+		l.update(false)
+		now = now.Add(100 * time.Millisecond)
+	}
+	assert.False(t, l.enabled)
+	assert.InDelta(t, 10, l.measuredRate, 0.5)
+
+	// throttled, the rate is cut down
+	l.update(true)
+	assert.True(t, l.enabled)
+	assert.InDelta(t, 10, l.throttledRate, 0.5)
+	assert.InDelta(t, 7, l.fillRate, 0.5)
+
+	// tokens are consumed
+	now = now.Add(time.Second)
+	for i := 0; i < int(l.fillRate); i++ {
+		assert.Nil(t, l.acquire(context.Background()))
+	}
+
+	// blocks until canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.acquire(ctx))
+
+	// throttled again
+	l.update(true)
+	assert.InDelta(t, 4.9, l.fillRate, 0.5)
+
+	// increases over time
+	now = now.Add(5 * time.Second)
+	l.update(false)
+	assert.True(t, l.enabled)
+	assert.InDelta(t, 4.9+7*0.1*5, l.fillRate, 0.5)
+
+	// recovered
+	now = now.Add(60 * time.Second)
+	l.update(false)
+	assert.False(t, l.enabled)
+	assert.Nil(t, l.acquire(context.Background()))
+
+	// min rate
+	l = newSendRateLimiter(0.5, func() time.Time { return now })
+	l.update(true)
+	assert.True(t, l.enabled)
+	assert.Equal(t, 0.5, l.fillRate)
+}
@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -11,6 +12,16 @@ type Retryer interface {
 	RetryDelay(attempt int, opErr error) (time.Duration, error)
 }
 
+// AttemptHandler is an optional interface of Retryer, the client calls it around every http attempt.
+type AttemptHandler interface {
+	// BeforeAttempt is called before every attempt, it may block to limit the send rate.
+	BeforeAttempt(ctx context.Context) error
+
+	// AfterAttempt is called with the attempt number and the result of every attempt,
+	// retriedErr is the error of the previous attempt which caused this retry, nil for the first attempt.
+	AfterAttempt(attempt int, err error, retriedErr error)
+}
+
 type NopRetryer struct{}
 
 func (NopRetryer) IsErrorRetryable(error) bool { return false }
@@ -0,0 +1,29 @@
+package oss_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/osstest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBucket = "bucket"
+
+func newTestServer(t *testing.T, optFns ...func(*osstest.Options)) (*osstest.Server, *oss.Client) {
+	fns := append([]func(*osstest.Options){func(o *osstest.Options) {
+		o.AccessKeyID = "ak"
+		o.AccessKeySecret = "sk"
+	}}, optFns...)
+	srv := osstest.NewServer(fns...)
+	t.Cleanup(srv.Close)
+	assert.Nil(t, srv.CreateBucket(testBucket))
+	return srv, oss.NewClient(srv.Config())
+}
+
+func randomData(n int) []byte {
+	data := make([]byte, n)
+	rand.Read(data)
+	return data
+}
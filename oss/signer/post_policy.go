@@ -0,0 +1,112 @@
+package signer
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+The policy of a form upload (PostObject) is signed instead of the request.
+The signature is computed on the base64 encoded policy, which is the StringToSign.
+
+	SignerV1: Signature = base64(hmac-sha1(AccessKeySecret, Policy))
+	SignerV4: Signature = hex(hmac-sha256(SigningKey, Policy))
+
+The form fields of the V4 credentials must also be conditions of the policy, so that they are signed.
+*/
+
+const (
+	postPolicyField = "policy"
+)
+
+func checkPostPolicyContext(signingCtx *SigningContext) error {
+	if signingCtx == nil {
+		return fmt.Errorf("SigningContext is null.")
+	}
+	if signingCtx.Credentials == nil || !signingCtx.Credentials.HasKeys() {
+		return fmt.Errorf("SigningContext.Credentials is null or empty.")
+	}
+	if signingCtx.Time.IsZero() {
+		return fmt.Errorf("SigningContext.Time is zero.")
+	}
+	return nil
+}
+
+// PostPolicyConditions returns the form fields which must be conditions of the policy, there are none for V1.
+func (*SignerV1) PostPolicyConditions(signingCtx *SigningContext) (map[string]string, error) {
+	if err := checkPostPolicyContext(signingCtx); err != nil {
+		return nil, err
+	}
+	return map[string]string{}, nil
+}
+
+// SignPostPolicy returns the form fields of the base64 encoded policy and its signature.
+func (s *SignerV1) SignPostPolicy(signingCtx *SigningContext, policy string) (map[string]string, error) {
+	if err := checkPostPolicyContext(signingCtx); err != nil {
+		return nil, err
+	}
+	cred := signingCtx.Credentials
+	signature := s.calcSignature(cred.AccessKeySecret, policy)
+	signingCtx.StringToSign = policy
+	signingCtx.Signature = signature
+
+	fields := map[string]string{
+		postPolicyField:  policy,
+		accessKeyIdQuery: cred.AccessKeyID,
+		signatureQuery:   signature,
+	}
+	if cred.SecurityToken != "" {
+		fields[securityTokenHeader] = cred.SecurityToken
+	}
+	return fields, nil
+}
+
+// PostPolicyConditions returns the form fields which must be conditions of the policy,
+// they are the signature version, the credential, the date and the security token.
+func (*SignerV4) PostPolicyConditions(signingCtx *SigningContext) (map[string]string, error) {
+	if err := checkPostPolicyContext(signingCtx); err != nil {
+		return nil, err
+	}
+	cred := signingCtx.Credentials
+	utcTime := signingCtx.Time.UTC()
+	scope := buildScope(utcTime.Format(iso8601DateFormat), toString(signingCtx.Region), toString(signingCtx.Product))
+
+	fields := map[string]string{
+		"x-oss-signature-version": algorithmV4,
+		"x-oss-credential":        cred.AccessKeyID + "/" + scope,
+		ossDateHeader:             utcTime.Format(iso8601DatetimeFormat),
+	}
+	if cred.SecurityToken != "" {
+		fields[securityTokenHeader] = cred.SecurityToken
+	}
+	return fields, nil
+}
+
+// SignPostPolicy returns the form fields of the base64 encoded policy, the conditions and the signature.
+func (s *SignerV4) SignPostPolicy(signingCtx *SigningContext, policy string) (map[string]string, error) {
+	fields, err := s.PostPolicyConditions(signingCtx)
+	if err != nil {
+		return nil, err
+	}
+	date := signingCtx.Time.UTC().Format(iso8601DateFormat)
+	signature := s.calcSignature(signingCtx.Credentials.AccessKeySecret, date, toString(signingCtx.Region), toString(signingCtx.Product), policy)
+	signingCtx.StringToSign = policy
+	signingCtx.Signature = signature
+
+	fields[postPolicyField] = policy
+	fields["x-oss-signature"] = signature
+	return fields, nil
+}
+
+// lookupField returns the value of a form field, the names of the fields are case insensitive.
+func lookupField(fields map[string]string, name string) string {
+	if v, ok := fields[name]; ok {
+		return v
+	}
+	for k, v := range fields {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
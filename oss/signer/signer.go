@@ -36,6 +36,9 @@ type SigningContext struct {
 	SignedHeaders map[string]string
 	StringToSign  string
 
+	// The signature of the request in the authorization header, which is the seed of a streaming payload.
+	Signature string
+
 	// for test
 	signTime *time.Time
 }
@@ -1,9 +1,15 @@
 package signer
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -909,3 +915,470 @@ func TestSignerVectorV4BuildBucketArn(t *testing.T) {
 	arn = buildArnUri(signCtx, signer.AccountId)
 	assert.Equal(t, "/acs:ossvector:cn-hangzhou:"+accountId+":bucket/key-1/key-2", arn)
 }
+
+// decodeStreamingPayload decodes the signed chunks and verifies the signatures with a new ChunkSigner.
+func decodeStreamingPayload(t *testing.T, payload []byte, chunkSigner *ChunkSigner) ([]byte, map[string]string) {
+	var data []byte
+	r := bufio.NewReader(bytes.NewReader(payload))
+	for {
+		line, err := r.ReadString('\n')
+		assert.Nil(t, err)
+		line = strings.TrimSuffix(line, "\r\n")
+		sizeHex, signature, ok := strings.Cut(line, ";chunk-signature=")
+		assert.True(t, ok, line)
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		assert.Nil(t, err)
+		chunk := make([]byte, size)
+		_, err = io.ReadFull(r, chunk)
+		assert.Nil(t, err)
+		assert.Equal(t, chunkSigner.SignChunk(chunk), signature)
+		if size == 0 {
+			break
+		}
+		data = append(data, chunk...)
+		crlf := make([]byte, 2)
+		io.ReadFull(r, crlf)
+		assert.Equal(t, "\r\n", string(crlf))
+	}
+
+	trailer := map[string]string{}
+	var canonical string
+	for {
+		line, err := r.ReadString('\n')
+		assert.Nil(t, err)
+		line = strings.TrimSuffix(line, "\r\n")
+		if line == "" {
+			break
+		}
+		k, v, _ := strings.Cut(line, ":")
+		if k == "x-oss-trailer-signature" {
+			assert.Equal(t, chunkSigner.SignTrailer([]byte(canonical)), v)
+			continue
+		}
+		canonical += line + "\n"
+		trailer[k] = v
+	}
+	_, err := r.ReadByte()
+	assert.Equal(t, io.EOF, err)
+	return data, trailer
+}
+
+func TestV4StreamingPayload(t *testing.T) {
+	provider := credentials.NewStaticCredentialsProvider("ak", "sk")
+	cred, _ := provider.GetCredentials(context.TODO())
+	signTime, _ := http.ParseTime("Thu, 19 Dec 2024 12:00:00 GMT")
+
+	newSigningContext := func() *SigningContext {
+		request, _ := http.NewRequest("PUT", "http://bucket.oss-cn-hangzhou.aliyuncs.com/key", nil)
+		request.Header = http.Header{}
+		request.Header.Set("x-oss-content-sha256", StreamingPayloadTrailer)
+		request.Header.Set("x-oss-trailer", TrailerKeys("X-Oss-Hash-Crc64ecma"))
+		return &SigningContext{
+			Product:     ptr("oss"),
+			Region:      ptr("cn-hangzhou"),
+			Bucket:      ptr("bucket"),
+			Key:         ptr("key"),
+			Request:     request,
+			Credentials: &cred,
+			Time:        signTime,
+		}
+	}
+
+	signer := &SignerV4{}
+	signCtx := newSigningContext()
+	_, err := NewChunkSigner(signCtx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "SigningContext is not signed.")
+
+	assert.Nil(t, signer.Sign(context.TODO(), signCtx))
+	assert.Equal(t, StreamingPayloadTrailer, signCtx.Request.Header.Get("x-oss-content-sha256"))
+	assert.Equal(t, "x-oss-hash-crc64ecma", signCtx.Request.Header.Get("x-oss-trailer"))
+	assert.NotEmpty(t, signCtx.Signature)
+	assert.True(t, strings.HasSuffix(signCtx.Request.Header.Get("Authorization"), ",Signature="+signCtx.Signature))
+
+	// the chunks and the trailing headers
+	data := "hello world, streaming payload"
+	chunkSigner, err := NewChunkSigner(signCtx)
+	assert.Nil(t, err)
+	payload, err := io.ReadAll(NewStreamingPayloadReader(strings.NewReader(data), chunkSigner, 8, func() map[string]string {
+		return map[string]string{"X-Oss-Hash-Crc64ecma": "12345"}
+	}))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(payload), "8;chunk-signature="))
+	assert.True(t, strings.HasSuffix(string(payload), "\r\n\r\n"))
+	verifier, _ := NewChunkSigner(signCtx)
+	decoded, trailer := decodeStreamingPayload(t, payload, verifier)
+	assert.Equal(t, data, string(decoded))
+	assert.Equal(t, map[string]string{"x-oss-hash-crc64ecma": "12345"}, trailer)
+
+	// the same inputs, the same signatures
+	chunkSigner, _ = NewChunkSigner(signCtx)
+	payload1, err := io.ReadAll(NewStreamingPayloadReader(strings.NewReader(data), chunkSigner, 8, func() map[string]string {
+		return map[string]string{"X-Oss-Hash-Crc64ecma": "12345"}
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, payload, payload1)
+
+	// the signatures of the following chunks are chained from a changed chunk
+	chunkSigner, _ = NewChunkSigner(signCtx)
+	changed, err := io.ReadAll(NewStreamingPayloadReader(strings.NewReader("hello World, streaming payload"), chunkSigner, 8, nil))
+	assert.Nil(t, err)
+	lines := strings.Split(string(payload), "\r\n")
+	changedLines := strings.Split(string(changed), "\r\n")
+	assert.NotEqual(t, lines[0], changedLines[0])
+	assert.Equal(t, lines[3], changedLines[3])
+	assert.NotEqual(t, lines[2], changedLines[2])
+
+	// an empty body without trailing headers
+	signCtx = newSigningContext()
+	signCtx.Request.Header.Set("x-oss-content-sha256", StreamingPayload)
+	assert.Nil(t, signer.Sign(context.TODO(), signCtx))
+	chunkSigner, _ = NewChunkSigner(signCtx)
+	payload, err = io.ReadAll(NewStreamingPayloadReader(strings.NewReader(""), chunkSigner, 0, nil))
+	assert.Nil(t, err)
+	verifier, _ = NewChunkSigner(signCtx)
+	decoded, trailer = decodeStreamingPayload(t, payload, verifier)
+	assert.Empty(t, decoded)
+	assert.Empty(t, trailer)
+
+	// a body of the exact chunk size
+	chunkSigner, _ = NewChunkSigner(signCtx)
+	payload, err = io.ReadAll(NewStreamingPayloadReader(strings.NewReader(data[:10]), chunkSigner, 10, nil))
+	assert.Nil(t, err)
+	verifier, _ = NewChunkSigner(signCtx)
+	decoded, _ = decodeStreamingPayload(t, payload, verifier)
+	assert.Equal(t, data[:10], string(decoded))
+
+	// the other payloads are not signed
+	signCtx = newSigningContext()
+	signCtx.Request.Header.Set("x-oss-content-sha256", "abc")
+	assert.Nil(t, signer.Sign(context.TODO(), signCtx))
+	assert.Equal(t, "UNSIGNED-PAYLOAD", signCtx.Request.Header.Get("x-oss-content-sha256"))
+}
+
+func TestVerifier(t *testing.T) {
+	cred := credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}
+	stsCred := credentials.Credentials{AccessKeyID: "sts-ak", AccessKeySecret: "sts-sk", SecurityToken: "token"}
+	lookup := func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		switch accessKeyID {
+		case cred.AccessKeyID:
+			return &cred, nil
+		case stsCred.AccessKeyID:
+			return &stsCred, nil
+		}
+		return nil, nil
+	}
+	now, _ := http.ParseTime("Thu, 19 Dec 2024 12:00:00 GMT")
+	verifier := NewVerifier(lookup, func(o *VerifierOptions) {
+		o.Region = "cn-hangzhou"
+		o.Now = func() time.Time { return now }
+	})
+
+	newSigningContext := func(c *credentials.Credentials, query string) *SigningContext {
+		request, _ := http.NewRequest("PUT", "http://bucket.oss-cn-hangzhou.aliyuncs.com/1234%2B-/123/1.txt?"+query, nil)
+		request.Header = http.Header{}
+		request.Header.Set("x-oss-meta-a", "value")
+		request.Header.Set("Content-Type", "text/plain")
+		request.Header.Set("abc", "value")
+		return &SigningContext{
+			Product:     ptr("oss"),
+			Region:      ptr("cn-hangzhou"),
+			Bucket:      ptr("bucket"),
+			Key:         ptr("1234+-/123/1.txt"),
+			Request:     request,
+			Credentials: c,
+			Time:        now,
+		}
+	}
+	verify := func(signCtx *SigningContext) (*SigningContext, error) {
+		return verifier.Verify(context.TODO(), signCtx.Request, "bucket", "1234+-/123/1.txt")
+	}
+	assertCode := func(code string, err error) {
+		t.Helper()
+		verr, ok := err.(*VerifyError)
+		assert.True(t, ok, "%v", err)
+		if ok {
+			assert.Equal(t, code, verr.Code)
+			assert.Equal(t, 403, verr.StatusCode)
+		}
+	}
+
+	// not signed
+	_, err := verify(newSigningContext(&cred, ""))
+	assertCode("AccessDenied", err)
+
+	// V1 in the authorization header, with or without sub resources
+	for _, subResource := range [][]string{nil, {"acl"}} {
+		signCtx := newSigningContext(&cred, "acl&x-oss-process=abc")
+		signCtx.SubResource = subResource
+		assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+		verified, err := verify(signCtx)
+		assert.Nil(t, err)
+		assert.Equal(t, "ak", verified.Credentials.AccessKeyID)
+		assert.Equal(t, signCtx.StringToSign, verified.StringToSign)
+	}
+	signCtx := newSigningContext(&cred, "")
+	assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+	signCtx.Request.Header.Set("x-oss-meta-a", "changed")
+	_, err = verify(signCtx)
+	assertCode("SignatureDoesNotMatch", err)
+
+	signCtx = newSigningContext(&cred, "")
+	signCtx.Time = now.Add(-16 * time.Minute)
+	assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("RequestTimeTooSkewed", err)
+
+	signCtx = newSigningContext(&credentials.Credentials{AccessKeyID: "invalid", AccessKeySecret: "sk"}, "")
+	assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("InvalidAccessKeyId", err)
+
+	// V1 in the query
+	signCtx = newSigningContext(&stsCred, "")
+	signCtx.AuthMethodQuery = true
+	signCtx.Time = now.Add(time.Minute)
+	assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+	verified, err := verify(signCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, "sts-ak", verified.Credentials.AccessKeyID)
+	assert.True(t, verified.AuthMethodQuery)
+
+	signCtx = newSigningContext(&cred, "")
+	signCtx.AuthMethodQuery = true
+	signCtx.Time = now.Add(-time.Second)
+	assert.Nil(t, (&SignerV1{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("AccessDenied", err)
+	assert.Contains(t, err.Error(), "Request has expired.")
+
+	// V4 in the authorization header
+	signCtx = newSigningContext(&stsCred, "param1=value1&%2Bparam2=&x-oss-process=a%20b")
+	signCtx.AdditionalHeaders = []string{"abc"}
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	assert.Contains(t, signCtx.Request.Header.Get("Authorization"), "AdditionalHeaders=abc")
+	verified, err = verify(signCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, signCtx.Signature, verified.Signature)
+	assert.Equal(t, []string{"abc"}, verified.AdditionalHeaders)
+
+	signCtx.Request.Header.Set("abc", "changed")
+	_, err = verify(signCtx)
+	assertCode("SignatureDoesNotMatch", err)
+	signCtx.Request.Header.Del("abc")
+	_, err = verify(signCtx)
+	assert.Equal(t, 400, err.(*VerifyError).StatusCode)
+	assert.Equal(t, "InvalidArgument", err.(*VerifyError).Code)
+
+	signCtx = newSigningContext(&stsCred, "")
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	signCtx.Request.Header.Set("x-oss-security-token", "invalid")
+	_, err = verify(signCtx)
+	assertCode("AccessDenied", err)
+
+	signCtx = newSigningContext(&cred, "")
+	signCtx.Region = ptr("cn-beijing")
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assert.Equal(t, "InvalidArgument", err.(*VerifyError).Code)
+	assert.Contains(t, err.Error(), "Invalid region")
+
+	signCtx = newSigningContext(&cred, "")
+	signCtx.Time = now.Add(16 * time.Minute)
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("RequestTimeTooSkewed", err)
+
+	signCtx = newSigningContext(&credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "invalid"}, "")
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("SignatureDoesNotMatch", err)
+
+	// V4 in the query
+	signTime := now.Add(-time.Minute)
+	signCtx = newSigningContext(&stsCred, "param1=value1&%2Bparam2=&%7Cparam3=a+b")
+	signCtx.AuthMethodQuery = true
+	signCtx.AdditionalHeaders = []string{"abc"}
+	signCtx.signTime = &signTime
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	verified, err = verify(signCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, signTime, verified.Time)
+	assert.Equal(t, signCtx.Request.URL.Query().Get("x-oss-signature"), verified.Signature)
+
+	query := signCtx.Request.URL.Query()
+	query.Set("param1", "changed")
+	signCtx.Request.URL.RawQuery = query.Encode()
+	_, err = verify(signCtx)
+	assertCode("SignatureDoesNotMatch", err)
+
+	signCtx = newSigningContext(&cred, "")
+	signCtx.AuthMethodQuery = true
+	signCtx.Time = now.Add(-time.Second)
+	signCtx.signTime = &signTime
+	assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+	_, err = verify(signCtx)
+	assertCode("AccessDenied", err)
+	assert.Contains(t, err.Error(), "Request has expired.")
+}
+
+func TestStreamingPayloadDecoder(t *testing.T) {
+	cred := credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}
+	now, _ := http.ParseTime("Thu, 19 Dec 2024 12:00:00 GMT")
+	verifier := NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		return &cred, nil
+	}, func(o *VerifierOptions) {
+		o.Now = func() time.Time { return now }
+	})
+	data := strings.Repeat("0123456789", 100)
+
+	encode := func() (*http.Request, []byte) {
+		request, _ := http.NewRequest("PUT", "http://bucket.oss-cn-hangzhou.aliyuncs.com/key", nil)
+		request.Header = http.Header{}
+		request.Header.Set("x-oss-content-sha256", StreamingPayloadTrailer)
+		request.Header.Set("x-oss-trailer", TrailerKeys("X-Oss-Hash-Crc64ecma"))
+		signCtx := &SigningContext{
+			Product:     ptr("oss"),
+			Region:      ptr("cn-hangzhou"),
+			Bucket:      ptr("bucket"),
+			Key:         ptr("key"),
+			Request:     request,
+			Credentials: &cred,
+			Time:        now,
+		}
+		assert.Nil(t, (&SignerV4{}).Sign(context.TODO(), signCtx))
+		chunkSigner, _ := NewChunkSigner(signCtx)
+		payload, err := io.ReadAll(NewStreamingPayloadReader(strings.NewReader(data), chunkSigner, 64, func() map[string]string {
+			return map[string]string{"X-Oss-Hash-Crc64ecma": "12345"}
+		}))
+		assert.Nil(t, err)
+		return request, payload
+	}
+
+	request, payload := encode()
+	signingCtx, err := verifier.Verify(context.TODO(), request, "bucket", "key")
+	assert.Nil(t, err)
+	decoder, err := NewStreamingPayloadDecoder(bytes.NewReader(payload), signingCtx)
+	assert.Nil(t, err)
+	decoded, err := io.ReadAll(decoder)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(decoded))
+	assert.Equal(t, map[string]string{"x-oss-hash-crc64ecma": "12345"}, decoder.Trailer())
+
+	// a changed chunk
+	changed := bytes.Replace(payload, []byte("0123"), []byte("3210"), 1)
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(changed), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+
+	// a changed trailer
+	changed = bytes.Replace(payload, []byte("12345"), []byte("54321"), 1)
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(changed), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+
+	// a stripped trailer
+	i := bytes.Index(payload, []byte("x-oss-hash-crc64ecma:"))
+	assert.True(t, i > 0)
+	stripped := append(append([]byte{}, payload[:i]...), "\r\n"...)
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(stripped), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+
+	// a stripped trailer signature
+	j := bytes.Index(payload, []byte("x-oss-trailer-signature:"))
+	assert.True(t, j > i)
+	stripped = append(append([]byte{}, payload[:j]...), "\r\n"...)
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(stripped), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+
+	// a declared trailing header is missing
+	signingCtx.Request.Header.Set("x-oss-trailer", TrailerKeys("X-Oss-Hash-Crc64ecma", "X-Oss-Meta-Checksum"))
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(payload), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+	signingCtx.Request.Header.Set("x-oss-trailer", TrailerKeys("X-Oss-Hash-Crc64ecma"))
+
+	// a truncated payload
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(payload[:100]), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	// an oversized and a negative chunk size
+	for _, size := range []string{"7fffffffffffffff", "801", "-1"} {
+		changed = append([]byte(size), payload[bytes.IndexByte(payload, ';'):]...)
+		decoder, _ = NewVerifier(nil, func(o *VerifierOptions) { o.MaxChunkSize = 2048 }).NewStreamingPayloadDecoder(bytes.NewReader(changed), signingCtx)
+		_, err = io.ReadAll(decoder)
+		assert.Equal(t, "InvalidArgument", err.(*VerifyError).Code)
+	}
+	decoder, _ = NewStreamingPayloadDecoder(bytes.NewReader(append([]byte("7fffffffffffffff"), payload[bytes.IndexByte(payload, ';'):]...)), signingCtx)
+	_, err = io.ReadAll(decoder)
+	assert.Equal(t, "InvalidArgument", err.(*VerifyError).Code)
+
+	// not a streaming payload
+	signingCtx.Request.Header.Set("x-oss-content-sha256", "UNSIGNED-PAYLOAD")
+	_, err = NewStreamingPayloadDecoder(bytes.NewReader(payload), signingCtx)
+	assert.NotNil(t, err)
+}
+
+func TestPostPolicySignature(t *testing.T) {
+	cred := credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}
+	now, _ := http.ParseTime("Thu, 19 Dec 2024 12:00:00 GMT")
+	verifier := NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		if accessKeyID != "ak" {
+			return nil, nil
+		}
+		return &cred, nil
+	}, func(o *VerifierOptions) {
+		o.Region = "cn-hangzhou"
+		o.Now = func() time.Time { return now }
+	})
+	policy := func(expiration string) string {
+		return base64.StdEncoding.EncodeToString([]byte(`{"expiration":"` + expiration + `","conditions":[]}`))
+	}
+
+	// v4
+	signCtx := &SigningContext{Product: ptr("oss"), Region: ptr("cn-hangzhou"), Credentials: &cred, Time: now}
+	conditions, err := (&SignerV4{}).PostPolicyConditions(signCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"x-oss-signature-version": "OSS4-HMAC-SHA256",
+		"x-oss-credential":        "ak/20241219/cn-hangzhou/oss/aliyun_v4_request",
+		"x-oss-date":              "20241219T120000Z",
+	}, conditions)
+	fields, err := (&SignerV4{}).SignPostPolicy(signCtx, policy("2024-12-19T13:00:00.000Z"))
+	assert.Nil(t, err)
+	assert.Equal(t, signCtx.Signature, fields["x-oss-signature"])
+	assert.Equal(t, fields["policy"], signCtx.StringToSign)
+	verified, err := verifier.VerifyPostPolicy(context.TODO(), fields)
+	assert.Nil(t, err)
+	assert.Equal(t, "ak", verified.Credentials.AccessKeyID)
+
+	fields["policy"] = policy("2024-12-19T14:00:00.000Z")
+	_, err = verifier.VerifyPostPolicy(context.TODO(), fields)
+	assert.Equal(t, "SignatureDoesNotMatch", err.(*VerifyError).Code)
+
+	// v1, the names of the fields are case insensitive
+	signCtx = &SigningContext{Credentials: &cred, Time: now}
+	fields, err = (&SignerV1{}).SignPostPolicy(signCtx, policy("2024-12-19T11:00:00.000Z"))
+	assert.Nil(t, err)
+	assert.Equal(t, "ak", fields["OSSAccessKeyId"])
+	_, err = verifier.VerifyPostPolicy(context.TODO(), map[string]string{
+		"policy":         fields["policy"],
+		"ossaccesskeyid": fields["OSSAccessKeyId"],
+		"signature":      fields["Signature"],
+	})
+	assert.Equal(t, "AccessDenied", err.(*VerifyError).Code)
+	assert.Contains(t, err.Error(), "Policy expired.")
+
+	fields["OSSAccessKeyId"] = "invalid"
+	_, err = verifier.VerifyPostPolicy(context.TODO(), fields)
+	assert.Equal(t, "InvalidAccessKeyId", err.(*VerifyError).Code)
+
+	_, err = verifier.VerifyPostPolicy(context.TODO(), map[string]string{"key": "a.txt"})
+	assert.Equal(t, "AccessDenied", err.(*VerifyError).Code)
+
+	_, err = (&SignerV4{}).SignPostPolicy(&SigningContext{Credentials: &cred}, "")
+	assert.Contains(t, err.Error(), "SigningContext.Time is zero.")
+}
@@ -162,6 +162,12 @@ func (*SignerV1) calcStringToSign(date string, signingCtx *SigningContext) strin
 	return stringToSign
 }
 
+func (*SignerV1) calcSignature(sk, stringToSign string) string {
+	h := hmac.New(func() hash.Hash { return sha1.New() }, []byte(sk))
+	io.WriteString(h, stringToSign)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
 func (s *SignerV1) authHeader(ctx context.Context, signingCtx *SigningContext) error {
 	request := signingCtx.Request
 	cred := signingCtx.Credentials
@@ -183,11 +189,7 @@ func (s *SignerV1) authHeader(ctx context.Context, signingCtx *SigningContext) e
 	signingCtx.StringToSign = stringToSign
 
 	// Signature
-	h := hmac.New(func() hash.Hash { return sha1.New() }, []byte(cred.AccessKeySecret))
-	if _, err := io.WriteString(h, stringToSign); err != nil {
-		return err
-	}
-	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	signature := s.calcSignature(cred.AccessKeySecret, stringToSign)
 
 	// Authorization header
 	request.Header.Set(authorizationHeader, fmt.Sprintf("OSS %s:%s", cred.AccessKeyID, signature))
@@ -217,11 +219,7 @@ func (s *SignerV1) authQuery(ctx context.Context, signingCtx *SigningContext) er
 	signingCtx.StringToSign = stringToSign
 
 	// Signature
-	h := hmac.New(func() hash.Hash { return sha1.New() }, []byte(cred.AccessKeySecret))
-	if _, err := io.WriteString(h, stringToSign); err != nil {
-		return err
-	}
-	signature := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	signature := s.calcSignature(cred.AccessKeySecret, stringToSign)
 
 	// Authorization query
 	query.Add(expiresQuery, datetime)
@@ -213,6 +213,14 @@ func buildScope(date, region, product string) string {
 }
 
 func (s *SignerV4) calcSignature(sk, date, region, product, stringToSign string) string {
+	h := hmac.New(func() hash.Hash { return sha256.New() }, signingKeyV4(sk, date, region, product))
+	io.WriteString(h, stringToSign)
+	signature := hex.EncodeToString(h.Sum(nil))
+
+	return signature
+}
+
+func signingKeyV4(sk, date, region, product string) []byte {
 	hmacHash := func() hash.Hash { return sha256.New() }
 
 	signingKey := "aliyun_v4" + sk
@@ -231,13 +239,7 @@ func (s *SignerV4) calcSignature(sk, date, region, product, stringToSign string)
 
 	h4 := hmac.New(hmacHash, h3Key)
 	io.WriteString(h4, "aliyun_v4_request")
-	h4Key := h4.Sum(nil)
-
-	h := hmac.New(hmacHash, h4Key)
-	io.WriteString(h, stringToSign)
-	signature := hex.EncodeToString(h.Sum(nil))
-
-	return signature
+	return h4.Sum(nil)
 }
 
 func (s *SignerV4) authHeader(ctx context.Context, signingCtx *SigningContext) error {
@@ -260,7 +262,9 @@ func (s *SignerV4) authHeader(ctx context.Context, signingCtx *SigningContext) e
 	}
 
 	// Other Headers
-	request.Header.Set(contentSha256Header, unsignedPayload)
+	if !IsStreamingPayload(request.Header.Get(contentSha256Header)) {
+		request.Header.Set(contentSha256Header, unsignedPayload)
+	}
 
 	// Scope
 	region := toString(signingCtx.Region)
@@ -278,6 +282,7 @@ func (s *SignerV4) authHeader(ctx context.Context, signingCtx *SigningContext) e
 
 	// Signature
 	signature := s.calcSignature(cred.AccessKeySecret, date, region, product, stringToSign)
+	signingCtx.Signature = signature
 
 	// credential
 	var buf strings.Builder
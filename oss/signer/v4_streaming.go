@@ -0,0 +1,227 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The streaming payload is EXPERIMENTAL, it is not part of the documented OSS API, and may be rejected by OSS.
+// The encoding follows the streaming upload of the AWS Signature Version 4 with the OSS4 algorithm names,
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html.
+const (
+	// StreamingPayload is the x-oss-content-sha256 of a payload which is signed chunk by chunk.
+	StreamingPayload = "STREAMING-OSS4-HMAC-SHA256-PAYLOAD"
+
+	// StreamingPayloadTrailer is the same as StreamingPayload, and the payload ends with signed trailing headers.
+	StreamingPayloadTrailer = "STREAMING-OSS4-HMAC-SHA256-PAYLOAD-TRAILER"
+
+	// DefaultStreamingChunkSize is the size of the chunks of a streaming payload, except the last one.
+	DefaultStreamingChunkSize = 64 * 1024
+
+	algorithmV4Payload = "OSS4-HMAC-SHA256-PAYLOAD"
+	algorithmV4Trailer = "OSS4-HMAC-SHA256-TRAILER"
+
+	chunkSignatureKey   = "chunk-signature"
+	trailerSignatureKey = "x-oss-trailer-signature"
+	trailerHeader       = "x-oss-trailer"
+)
+
+func IsStreamingPayload(contentSha256 string) bool {
+	return contentSha256 == StreamingPayload || contentSha256 == StreamingPayloadTrailer
+}
+
+/*
+ChunkSigner signs the chunks of a streaming payload. The signature of a chunk is chained from the previous one,
+the first chunk is chained from the signature of the request, which is the seed signature.
+
+	StringToSign of a chunk
+	"OSS4-HMAC-SHA256-PAYLOAD" + "\n" +
+	TimeStamp + "\n" +
+	Scope + "\n" +
+	PreviousSignature + "\n" +
+	Hex(SHA256Hash(ChunkData))
+
+	StringToSign of the trailing headers
+	"OSS4-HMAC-SHA256-TRAILER" + "\n" +
+	TimeStamp + "\n" +
+	Scope + "\n" +
+	PreviousSignature + "\n" +
+	Hex(SHA256Hash(CanonicalTrailingHeaders))
+*/
+type ChunkSigner struct {
+	key       []byte
+	datetime  string
+	scope     string
+	signature string
+}
+
+// NewChunkSigner returns a ChunkSigner from the SigningContext which is signed by SignerV4 in the authorization header.
+func NewChunkSigner(signingCtx *SigningContext) (*ChunkSigner, error) {
+	if signingCtx == nil || signingCtx.Signature == "" {
+		return nil, fmt.Errorf("SigningContext is not signed.")
+	}
+	if signingCtx.Credentials == nil || !signingCtx.Credentials.HasKeys() {
+		return nil, fmt.Errorf("SigningContext.Credentials is null or empty.")
+	}
+	utcTime := signingCtx.Time.UTC()
+	date := utcTime.Format(iso8601DateFormat)
+	region := toString(signingCtx.Region)
+	product := toString(signingCtx.Product)
+	return &ChunkSigner{
+		key:       signingKeyV4(signingCtx.Credentials.AccessKeySecret, date, region, product),
+		datetime:  utcTime.Format(iso8601DatetimeFormat),
+		scope:     buildScope(date, region, product),
+		signature: signingCtx.Signature,
+	}, nil
+}
+
+// SignChunk returns the signature of the chunk, an empty chunk ends the payload.
+func (s *ChunkSigner) SignChunk(chunk []byte) string {
+	return s.sign(algorithmV4Payload, chunk)
+}
+
+// SignTrailer returns the signature of the canonical trailing headers.
+func (s *ChunkSigner) SignTrailer(trailer []byte) string {
+	return s.sign(algorithmV4Trailer, trailer)
+}
+
+func (s *ChunkSigner) sign(algorithm string, data []byte) string {
+	hashValue := sha256.Sum256(data)
+	stringToSign := algorithm + "\n" +
+		s.datetime + "\n" +
+		s.scope + "\n" +
+		s.signature + "\n" +
+		hex.EncodeToString(hashValue[:])
+
+	h := hmac.New(sha256.New, s.key)
+	io.WriteString(h, stringToSign)
+	s.signature = hex.EncodeToString(h.Sum(nil))
+	return s.signature
+}
+
+// canonicalTrailer returns the trailing headers as "key:value\n", sorted by the lower case keys.
+func canonicalTrailer(trailer map[string]string) []byte {
+	var keys []string
+	values := make(map[string]string, len(trailer))
+	for k, v := range trailer {
+		lowK := strings.ToLower(k)
+		keys = append(keys, lowK)
+		values[lowK] = strings.TrimSpace(v)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(k)
+		buf.WriteString(":")
+		buf.WriteString(values[k])
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// TrailerKeys returns the value of the x-oss-trailer header which declares the trailing headers.
+func TrailerKeys(keys ...string) string {
+	lowKeys := make([]string, len(keys))
+	for i, k := range keys {
+		lowKeys[i] = strings.ToLower(k)
+	}
+	sort.Strings(lowKeys)
+	return strings.Join(lowKeys, ",")
+}
+
+/*
+StreamingPayloadReader encodes a payload into signed chunks:
+
+	hex(size);chunk-signature=signature\r\n
+	data\r\n
+	...
+	0;chunk-signature=signature\r\n
+	key:value\r\n
+	x-oss-trailer-signature:signature\r\n
+	\r\n
+
+The trailing headers are present only if the trailer function is set, they are computed after the payload is read,
+so that they can carry the checksums of the payload.
+*/
+type StreamingPayloadReader struct {
+	body      io.Reader
+	signer    *ChunkSigner
+	chunkSize int
+	trailer   func() map[string]string
+
+	chunk []byte
+	buf   bytes.Buffer
+	done  bool
+	err   error
+}
+
+// NewStreamingPayloadReader returns a StreamingPayloadReader, chunkSize <= 0 means DefaultStreamingChunkSize.
+func NewStreamingPayloadReader(body io.Reader, signer *ChunkSigner, chunkSize int, trailer func() map[string]string) *StreamingPayloadReader {
+	if chunkSize <= 0 {
+		chunkSize = DefaultStreamingChunkSize
+	}
+	return &StreamingPayloadReader{
+		body:      body,
+		signer:    signer,
+		chunkSize: chunkSize,
+		trailer:   trailer,
+	}
+}
+
+func (r *StreamingPayloadReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.encodeChunk()
+	}
+	return r.buf.Read(p)
+}
+
+func (r *StreamingPayloadReader) encodeChunk() error {
+	if r.chunk == nil {
+		r.chunk = make([]byte, r.chunkSize)
+	}
+	n, err := io.ReadFull(r.body, r.chunk)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	if n > 0 {
+		r.writeChunk(r.chunk[:n])
+	}
+	if err == nil {
+		return nil
+	}
+
+	// the final chunk and the trailing headers
+	r.writeChunk(nil)
+	if r.trailer != nil {
+		trailer := canonicalTrailer(r.trailer())
+		r.buf.Write(bytes.ReplaceAll(trailer, []byte("\n"), []byte("\r\n")))
+		r.buf.WriteString(trailerSignatureKey + ":" + r.signer.SignTrailer(trailer) + "\r\n")
+	}
+	r.buf.WriteString("\r\n")
+	r.done = true
+	return nil
+}
+
+func (r *StreamingPayloadReader) writeChunk(data []byte) {
+	r.buf.WriteString(strconv.FormatInt(int64(len(data)), 16))
+	r.buf.WriteString(";" + chunkSignatureKey + "=")
+	r.buf.WriteString(r.signer.SignChunk(data))
+	r.buf.WriteString("\r\n")
+	if len(data) > 0 {
+		r.buf.Write(data)
+		r.buf.WriteString("\r\n")
+	}
+}
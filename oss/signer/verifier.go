@@ -0,0 +1,646 @@
+package signer
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+)
+
+const (
+	authV1Prefix = "OSS "
+	authV4Prefix = algorithmV4 + " "
+
+	defaultMaxClockSkew = 15 * time.Minute
+
+	defaultMaxChunkSize = 8 * 1024 * 1024
+)
+
+// VerifyError is returned when a request fails the verification, its code and status code are the same as OSS.
+type VerifyError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func errAccessDenied(message string) *VerifyError {
+	return &VerifyError{StatusCode: http.StatusForbidden, Code: "AccessDenied", Message: message}
+}
+
+func errInvalidAccessKeyId() *VerifyError {
+	return &VerifyError{StatusCode: http.StatusForbidden, Code: "InvalidAccessKeyId", Message: "The OSS Access Key Id you provided does not exist in our records."}
+}
+
+func errSignatureDoesNotMatch() *VerifyError {
+	return &VerifyError{StatusCode: http.StatusForbidden, Code: "SignatureDoesNotMatch", Message: "The request signature we calculated does not match the signature you provided. Check your key and signing method."}
+}
+
+func errRequestTimeTooSkewed() *VerifyError {
+	return &VerifyError{StatusCode: http.StatusForbidden, Code: "RequestTimeTooSkewed", Message: "The difference between the request time and the current time is too large."}
+}
+
+func errInvalidArgument(message string) *VerifyError {
+	return &VerifyError{StatusCode: http.StatusBadRequest, Code: "InvalidArgument", Message: message}
+}
+
+// CredentialsLookupFunc returns the credentials of the access key id, or nil if it does not exist.
+// If the credentials have a security token, the request must carry the same one.
+type CredentialsLookupFunc func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error)
+
+type VerifierOptions struct {
+	// The product of the V4 signatures, the default is "oss".
+	Product string
+
+	// The region of the V4 signatures, it is not checked if empty.
+	Region string
+
+	// The maximum difference between the request time and the current time, the default is 15 minutes.
+	MaxClockSkew time.Duration
+
+	// Now returns the current time, the default is time.Now.
+	Now func() time.Time
+
+	// The maximum size of the chunks of the streaming payloads, the default is 8 MiB.
+	// A chunk is buffered to verify its signature, the larger ones are rejected.
+	MaxChunkSize int64
+}
+
+// Verifier verifies the V1 and V4 signatures of the requests, in the authorization header or in the query.
+// It computes the signatures with the same canonicalization as SignerV1 and SignerV4.
+type Verifier struct {
+	lookup  CredentialsLookupFunc
+	options VerifierOptions
+}
+
+func NewVerifier(lookup CredentialsLookupFunc, optFns ...func(*VerifierOptions)) *Verifier {
+	options := VerifierOptions{
+		Product:      "oss",
+		MaxClockSkew: defaultMaxClockSkew,
+		Now:          time.Now,
+		MaxChunkSize: defaultMaxChunkSize,
+	}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if options.MaxChunkSize <= 0 {
+		options.MaxChunkSize = defaultMaxChunkSize
+	}
+	return &Verifier{
+		lookup:  lookup,
+		options: options,
+	}
+}
+
+// IsSignedRequest reports whether the request carries a V1 or V4 signature.
+func IsSignedRequest(r *http.Request) bool {
+	auth := r.Header.Get(authorizationHeader)
+	if strings.HasPrefix(auth, authV1Prefix) || strings.HasPrefix(auth, authV4Prefix) {
+		return true
+	}
+	query := r.URL.Query()
+	return query.Get("x-oss-signature-version") == algorithmV4 || query.Has(signatureQuery)
+}
+
+/*
+Verify verifies the signature of the request on the bucket and the key, which are empty if not present.
+It returns the SigningContext of the request, whose Credentials are the credentials of the signer,
+and whose Signature is the seed of a streaming payload.
+The errors of the verification are *VerifyError.
+
+The sub resources of V1 signatures depend on the operations, so the signature is computed with the parameters
+which are always signed first, then with all the parameters.
+*/
+func (v *Verifier) Verify(ctx context.Context, r *http.Request, bucket, key string) (*SigningContext, error) {
+	signingCtx := &SigningContext{
+		Product: &v.options.Product,
+	}
+	if bucket != "" {
+		signingCtx.Bucket = &bucket
+	}
+	if key != "" {
+		signingCtx.Key = &key
+	}
+
+	auth := r.Header.Get(authorizationHeader)
+	query := r.URL.Query()
+	var err error
+	switch {
+	case strings.HasPrefix(auth, authV1Prefix):
+		err = v.verifyV1Header(ctx, r, signingCtx, strings.TrimPrefix(auth, authV1Prefix))
+	case strings.HasPrefix(auth, authV4Prefix):
+		err = v.verifyV4Header(ctx, r, signingCtx, strings.TrimPrefix(auth, authV4Prefix))
+	case query.Get("x-oss-signature-version") == algorithmV4:
+		err = v.verifyV4Query(ctx, r, signingCtx, query)
+	case query.Has(signatureQuery):
+		err = v.verifyV1Query(ctx, r, signingCtx, query)
+	default:
+		err = errAccessDenied("The request is not signed.")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signingCtx, nil
+}
+
+func (v *Verifier) credentials(ctx context.Context, accessKeyID, securityToken string) (*credentials.Credentials, error) {
+	if accessKeyID == "" {
+		return nil, errInvalidAccessKeyId()
+	}
+	cred, err := v.lookup(ctx, accessKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if cred == nil || !cred.HasKeys() {
+		return nil, errInvalidAccessKeyId()
+	}
+	if cred.SecurityToken != "" && !hmac.Equal([]byte(cred.SecurityToken), []byte(securityToken)) {
+		return nil, errAccessDenied("The security token you provided is invalid.")
+	}
+	if cred.Expires != nil && !cred.Expires.After(v.options.Now()) {
+		return nil, errAccessDenied("The security token you provided has expired.")
+	}
+	return cred, nil
+}
+
+func (v *Verifier) checkClockSkew(t time.Time) error {
+	if d := v.options.Now().Sub(t); d > v.options.MaxClockSkew || d < -v.options.MaxClockSkew {
+		return errRequestTimeTooSkewed()
+	}
+	return nil
+}
+
+// cloneRequest returns a copy of the request for canonicalization,
+// the headers which the http server moves out of the header map are restored.
+func cloneRequest(r *http.Request) *http.Request {
+	req := *r
+	req.Header = r.Header.Clone()
+	if req.Header.Get("Host") == "" && r.Host != "" {
+		req.Header.Set("Host", r.Host)
+	}
+	if r.ContentLength > 0 && req.Header.Get("Content-Length") == "" {
+		req.Header.Set("Content-Length", strconv.FormatInt(r.ContentLength, 10))
+	}
+	u := *r.URL
+	req.URL = &u
+	return &req
+}
+
+func equalSignature(a, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
+
+func (v *Verifier) verifyV1Header(ctx context.Context, r *http.Request, signingCtx *SigningContext, credential string) error {
+	accessKeyID, signature, _ := strings.Cut(credential, ":")
+	cred, err := v.credentials(ctx, accessKeyID, r.Header.Get(securityTokenHeader))
+	if err != nil {
+		return err
+	}
+
+	datetime := r.Header.Get(dateHeader)
+	date, err := http.ParseTime(datetime)
+	if err != nil {
+		return errAccessDenied("OSS authentication requires a valid Date.")
+	}
+	if err = v.checkClockSkew(date); err != nil {
+		return err
+	}
+
+	signingCtx.Request = cloneRequest(r)
+	signingCtx.Credentials = cred
+	signingCtx.Time = date
+	return v.verifyV1(signingCtx, datetime, signature, signingCtx.Request.URL.Query())
+}
+
+func (v *Verifier) verifyV1Query(ctx context.Context, r *http.Request, signingCtx *SigningContext, query url.Values) error {
+	cred, err := v.credentials(ctx, query.Get(accessKeyIdQuery), query.Get(securityTokenQuery))
+	if err != nil {
+		return err
+	}
+
+	datetime := query.Get(expiresQuery)
+	expires, err := strconv.ParseInt(datetime, 10, 64)
+	if err != nil {
+		return errAccessDenied("Invalid Expires.")
+	}
+	if v.options.Now().Unix() > expires {
+		return errAccessDenied("Request has expired.")
+	}
+
+	signature := query.Get(signatureQuery)
+	query.Del(signatureQuery)
+	query.Del(expiresQuery)
+	query.Del(accessKeyIdQuery)
+
+	signingCtx.Request = cloneRequest(r)
+	signingCtx.Request.URL.RawQuery = query.Encode()
+	signingCtx.Credentials = cred
+	signingCtx.Time = time.Unix(expires, 0)
+	signingCtx.AuthMethodQuery = true
+	return v.verifyV1(signingCtx, datetime, signature, query)
+}
+
+func (v *Verifier) verifyV1(signingCtx *SigningContext, datetime, signature string, query url.Values) error {
+	s := &SignerV1{}
+	var all []string
+	for k := range query {
+		all = append(all, k)
+	}
+	for _, subResource := range [][]string{nil, all} {
+		signingCtx.SubResource = subResource
+		stringToSign := s.calcStringToSign(datetime, signingCtx)
+		if equalSignature(s.calcSignature(signingCtx.Credentials.AccessKeySecret, stringToSign), signature) {
+			signingCtx.StringToSign = stringToSign
+			signingCtx.Signature = signature
+			return nil
+		}
+	}
+	return errSignatureDoesNotMatch()
+}
+
+// parseV4Credential parses "accessKeyId/date/region/product/aliyun_v4_request".
+func (v *Verifier) parseV4Credential(credential string, datetime time.Time) (accessKeyID, region string, err error) {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[4] != "aliyun_v4_request" {
+		return "", "", errInvalidArgument("Invalid credential in the V4 signature.")
+	}
+	if parts[1] != datetime.UTC().Format(iso8601DateFormat) {
+		return "", "", errInvalidArgument("Invalid date in the V4 signature, expected " + datetime.UTC().Format(iso8601DateFormat) + ".")
+	}
+	if v.options.Region != "" && parts[2] != v.options.Region {
+		return "", "", errInvalidArgument("Invalid region in the V4 signature, expected " + v.options.Region + ".")
+	}
+	if parts[3] != v.options.Product {
+		return "", "", errInvalidArgument("Invalid product in the V4 signature, expected " + v.options.Product + ".")
+	}
+	return parts[0], parts[2], nil
+}
+
+func (v *Verifier) verifyV4Header(ctx context.Context, r *http.Request, signingCtx *SigningContext, auth string) error {
+	var credential, additionalHeaders, signature string
+	for _, item := range strings.Split(auth, ",") {
+		k, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch k {
+		case "Credential":
+			credential = val
+		case "AdditionalHeaders":
+			additionalHeaders = val
+		case "Signature":
+			signature = val
+		}
+	}
+
+	date, err := time.Parse(iso8601DatetimeFormat, r.Header.Get(ossDateHeader))
+	if err != nil {
+		return errAccessDenied("OSS V4 authentication requires a valid x-oss-date.")
+	}
+	accessKeyID, region, err := v.parseV4Credential(credential, date)
+	if err != nil {
+		return err
+	}
+	cred, err := v.credentials(ctx, accessKeyID, r.Header.Get(securityTokenHeader))
+	if err != nil {
+		return err
+	}
+	if err = v.checkClockSkew(date); err != nil {
+		return err
+	}
+
+	signingCtx.Request = cloneRequest(r)
+	signingCtx.Region = &region
+	signingCtx.Credentials = cred
+	signingCtx.Time = date
+	return v.verifyV4(signingCtx, additionalHeaders, signature)
+}
+
+func (v *Verifier) verifyV4Query(ctx context.Context, r *http.Request, signingCtx *SigningContext, query url.Values) error {
+	date, err := time.Parse(iso8601DatetimeFormat, query.Get("x-oss-date"))
+	if err != nil {
+		return errAccessDenied("Invalid x-oss-date.")
+	}
+	expires, err := strconv.ParseInt(query.Get("x-oss-expires"), 10, 64)
+	if err != nil || expires <= 0 {
+		return errAccessDenied("Invalid x-oss-expires.")
+	}
+	accessKeyID, region, err := v.parseV4Credential(query.Get("x-oss-credential"), date)
+	if err != nil {
+		return err
+	}
+	cred, err := v.credentials(ctx, accessKeyID, query.Get("x-oss-security-token"))
+	if err != nil {
+		return err
+	}
+	if v.options.Now().After(date.Add(time.Duration(expires) * time.Second)) {
+		return errAccessDenied("Request has expired.")
+	}
+	if date.Sub(v.options.Now()) > v.options.MaxClockSkew {
+		return errRequestTimeTooSkewed()
+	}
+
+	signature := query.Get("x-oss-signature")
+	query.Del("x-oss-signature")
+	signingCtx.Request = cloneRequest(r)
+	signingCtx.Request.URL.RawQuery = query.Encode()
+	signingCtx.Region = &region
+	signingCtx.Credentials = cred
+	signingCtx.Time = date
+	signingCtx.AuthMethodQuery = true
+	return v.verifyV4(signingCtx, query.Get("x-oss-additional-headers"), signature)
+}
+
+func (v *Verifier) verifyV4(signingCtx *SigningContext, additionalHeaders, signature string) error {
+	var headers []string
+	if additionalHeaders != "" {
+		headers = strings.Split(additionalHeaders, ";")
+	}
+	for _, h := range headers {
+		if signingCtx.Request.Header.Get(h) == "" {
+			return errInvalidArgument("The additional header " + h + " is not present.")
+		}
+	}
+	signingCtx.AdditionalHeaders = headers
+
+	s := &SignerV4{}
+	date := signingCtx.Time.UTC().Format(iso8601DateFormat)
+	region := toString(signingCtx.Region)
+	scope := buildScope(date, region, v.options.Product)
+	canonicalRequest := s.calcCanonicalRequest(signingCtx, headers)
+	stringToSign := s.calcStringToSign(signingCtx.Time.UTC().Format(iso8601DatetimeFormat), scope, canonicalRequest)
+	if !equalSignature(s.calcSignature(signingCtx.Credentials.AccessKeySecret, date, region, v.options.Product, stringToSign), signature) {
+		return errSignatureDoesNotMatch()
+	}
+	signingCtx.StringToSign = stringToSign
+	signingCtx.Signature = signature
+	return nil
+}
+
+/*
+VerifyPostPolicy verifies the signature of a form upload, and the expiration of its policy.
+The fields are the form fields except the file, the conditions of the policy are not checked.
+It returns the SigningContext of the form, whose StringToSign is the policy.
+*/
+func (v *Verifier) VerifyPostPolicy(ctx context.Context, fields map[string]string) (*SigningContext, error) {
+	policy := lookupField(fields, postPolicyField)
+	if policy == "" {
+		return nil, errAccessDenied("The form is not signed.")
+	}
+
+	signingCtx := &SigningContext{
+		Product:      &v.options.Product,
+		StringToSign: policy,
+	}
+	var signature, expected string
+	switch {
+	case lookupField(fields, "x-oss-signature-version") == algorithmV4:
+		date, err := time.Parse(iso8601DatetimeFormat, lookupField(fields, ossDateHeader))
+		if err != nil {
+			return nil, errInvalidArgument("Invalid x-oss-date.")
+		}
+		accessKeyID, region, err := v.parseV4Credential(lookupField(fields, "x-oss-credential"), date)
+		if err != nil {
+			return nil, err
+		}
+		if signingCtx.Credentials, err = v.credentials(ctx, accessKeyID, lookupField(fields, securityTokenHeader)); err != nil {
+			return nil, err
+		}
+		signingCtx.Region = &region
+		signingCtx.Time = date
+		signature = lookupField(fields, "x-oss-signature")
+		expected = (&SignerV4{}).calcSignature(signingCtx.Credentials.AccessKeySecret, date.UTC().Format(iso8601DateFormat), region, v.options.Product, policy)
+	case lookupField(fields, accessKeyIdQuery) != "":
+		var err error
+		if signingCtx.Credentials, err = v.credentials(ctx, lookupField(fields, accessKeyIdQuery), lookupField(fields, securityTokenHeader)); err != nil {
+			return nil, err
+		}
+		signature = lookupField(fields, signatureQuery)
+		expected = (&SignerV1{}).calcSignature(signingCtx.Credentials.AccessKeySecret, policy)
+	default:
+		return nil, errAccessDenied("The form is not signed.")
+	}
+	if !equalSignature(expected, signature) {
+		return nil, errSignatureDoesNotMatch()
+	}
+	signingCtx.Signature = signature
+
+	data, err := base64.StdEncoding.DecodeString(policy)
+	if err != nil {
+		return nil, errInvalidArgument("Invalid Policy: Invalid Simple-Base64 value-string.")
+	}
+	var doc struct {
+		Expiration string `json:"expiration"`
+	}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, errInvalidArgument("Invalid Policy: Invalid JSON.")
+	}
+	expiration, err := time.Parse(time.RFC3339, doc.Expiration)
+	if err != nil {
+		return nil, errInvalidArgument("Invalid Policy: Invalid 'expiration' value.")
+	}
+	if !v.options.Now().Before(expiration) {
+		return nil, errAccessDenied("Invalid according to Policy: Policy expired.")
+	}
+	return signingCtx, nil
+}
+
+/*
+StreamingPayloadDecoder decodes the payload which is encoded by StreamingPayloadReader,
+and verifies the signatures of the chunks and the trailing headers.
+If the payload is StreamingPayloadTrailer, the trailer signature and the headers declared by x-oss-trailer are required.
+It returns a *VerifyError if a signature does not match.
+*/
+type StreamingPayloadDecoder struct {
+	r            *bufio.Reader
+	signer       *ChunkSigner
+	maxChunkSize int64
+	remain       int64
+	trailer      map[string]string
+	trailerKeys  []string // the trailing headers declared by x-oss-trailer, nil if the payload has no trailer
+	done         bool
+	err          error
+}
+
+// NewStreamingPayloadDecoder returns a decoder of the body, the signingCtx is returned by Verifier.Verify.
+// The chunks larger than 8 MiB are rejected.
+func NewStreamingPayloadDecoder(body io.Reader, signingCtx *SigningContext) (*StreamingPayloadDecoder, error) {
+	return newStreamingPayloadDecoder(body, signingCtx, defaultMaxChunkSize)
+}
+
+// NewStreamingPayloadDecoder returns a decoder of the body, the chunks larger than MaxChunkSize are rejected.
+func (v *Verifier) NewStreamingPayloadDecoder(body io.Reader, signingCtx *SigningContext) (*StreamingPayloadDecoder, error) {
+	return newStreamingPayloadDecoder(body, signingCtx, v.options.MaxChunkSize)
+}
+
+func newStreamingPayloadDecoder(body io.Reader, signingCtx *SigningContext, maxChunkSize int64) (*StreamingPayloadDecoder, error) {
+	if signingCtx == nil || signingCtx.AuthMethodQuery || signingCtx.Request == nil ||
+		!IsStreamingPayload(signingCtx.Request.Header.Get(contentSha256Header)) {
+		return nil, fmt.Errorf("The request does not have a streaming payload.")
+	}
+	chunkSigner, err := NewChunkSigner(signingCtx)
+	if err != nil {
+		return nil, err
+	}
+	var trailerKeys []string
+	if signingCtx.Request.Header.Get(contentSha256Header) == StreamingPayloadTrailer {
+		trailerKeys = []string{}
+		for _, k := range strings.Split(signingCtx.Request.Header.Get(trailerHeader), ",") {
+			if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+				trailerKeys = append(trailerKeys, k)
+			}
+		}
+	}
+	return &StreamingPayloadDecoder{
+		r:            bufio.NewReader(body),
+		signer:       chunkSigner,
+		maxChunkSize: maxChunkSize,
+		trailer:      map[string]string{},
+		trailerKeys:  trailerKeys,
+	}, nil
+}
+
+// Trailer returns the trailing headers, whose keys are in lower case, after the payload is read.
+func (d *StreamingPayloadDecoder) Trailer() map[string]string {
+	return d.trailer
+}
+
+func (d *StreamingPayloadDecoder) Read(p []byte) (int, error) {
+	for d.remain == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.err = d.nextChunk()
+	}
+	if int64(len(p)) > d.remain {
+		p = p[:d.remain]
+	}
+	n, err := d.r.Read(p)
+	d.remain -= int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if d.remain == 0 && err == nil {
+		err = d.readCRLF()
+	}
+	if err != nil {
+		d.err = err
+	}
+	return n, err
+}
+
+func (d *StreamingPayloadDecoder) readLine() (string, error) {
+	line, err := d.r.ReadString('\n')
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(line, "\r\n") {
+		return "", errInvalidArgument("Invalid chunk encoding.")
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+func (d *StreamingPayloadDecoder) readCRLF() error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+	if line != "" {
+		return errInvalidArgument("Invalid chunk encoding.")
+	}
+	return nil
+}
+
+// nextChunk reads the header of the next chunk, the whole chunk is buffered to verify its signature.
+func (d *StreamingPayloadDecoder) nextChunk() error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+	sizeHex, signature, ok := strings.Cut(line, ";"+chunkSignatureKey+"=")
+	if !ok {
+		return errInvalidArgument("Invalid chunk encoding.")
+	}
+	size, err := strconv.ParseInt(sizeHex, 16, 64)
+	if err != nil || size < 0 {
+		return errInvalidArgument("Invalid chunk size.")
+	}
+	if size > d.maxChunkSize {
+		return errInvalidArgument("Chunk size exceeds the maximum allowed size.")
+	}
+	chunk, err := d.r.Peek(int(size))
+	if err == bufio.ErrBufferFull {
+		// the chunk is larger than the buffer
+		d.r = bufio.NewReaderSize(d.r, int(size))
+		chunk, err = d.r.Peek(int(size))
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if !equalSignature(d.signer.SignChunk(chunk), signature) {
+		return errSignatureDoesNotMatch()
+	}
+	if size > 0 {
+		d.remain = size
+		return nil
+	}
+	return d.readTrailer()
+}
+
+func (d *StreamingPayloadDecoder) readTrailer() error {
+	var canonical strings.Builder
+	var signature string
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			break
+		}
+		k, val, _ := strings.Cut(line, ":")
+		if k == trailerSignatureKey {
+			signature = val
+			continue
+		}
+		canonical.WriteString(line + "\n")
+		d.trailer[strings.ToLower(k)] = val
+	}
+	// the trailer must not be stripped from a payload which is signed with it
+	if d.trailerKeys != nil {
+		if signature == "" {
+			return errSignatureDoesNotMatch()
+		}
+		for _, k := range d.trailerKeys {
+			if _, ok := d.trailer[k]; !ok {
+				return errSignatureDoesNotMatch()
+			}
+		}
+	}
+	if canonical.Len() > 0 || signature != "" {
+		if !equalSignature(d.signer.SignTrailer([]byte(canonical.String())), signature) {
+			return errSignatureDoesNotMatch()
+		}
+	}
+	d.done = true
+	return nil
+}
@@ -0,0 +1,49 @@
+package oss
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
+)
+
+// canStreamPayload reports whether the request is signed by SignerV4 in the authorization header.
+func (c *Client) canStreamPayload(opts *Options, signingCtx *signer.SigningContext) bool {
+	if _, ok := c.options.Signer.(*signer.SignerV4); !ok || signingCtx.AuthMethodQuery {
+		return false
+	}
+	_, anonymous := opts.CredentialsProvider.(*credentials.AnonymousCredentialsProvider)
+	return !anonymous
+}
+
+// setStreamingPayloadHeaders declares the streaming payload, which is sent with the chunked transfer encoding.
+func setStreamingPayloadHeaders(request *http.Request, length int64) {
+	request.Header.Set(HeaderOssContentSha256, signer.StreamingPayloadTrailer)
+	request.Header.Set(HeaderOssTrailer, signer.TrailerKeys(HeaderOssCRC64))
+	if length >= 0 {
+		request.Header.Set(HeaderOssDecodedContentLength, strconv.FormatInt(length, 10))
+	}
+	request.Header.Del(HTTPHeaderContentLength)
+	request.ContentLength = -1
+}
+
+// streamingPayloadRequest returns a shallow copy of the signed request, whose body is encoded into signed chunks.
+// The request itself keeps the original body, so that it can be reset and signed again by the next attempt.
+func streamingPayloadRequest(request *http.Request, signingCtx *signer.SigningContext) (*http.Request, error) {
+	chunkSigner, err := signer.NewChunkSigner(signingCtx)
+	if err != nil {
+		return nil, err
+	}
+	crc := NewCRC64(0)
+	body := signer.NewStreamingPayloadReader(io.TeeReader(request.Body, crc), chunkSigner, 0, func() map[string]string {
+		return map[string]string{HeaderOssCRC64: fmt.Sprint(crc.Sum64())}
+	})
+	req := *request
+	req.Body = io.NopCloser(body)
+	req.ContentLength = -1
+	req.GetBody = nil
+	return &req, nil
+}
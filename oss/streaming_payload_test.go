@@ -0,0 +1,231 @@
+package oss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedStreamingRequest struct {
+	headers  http.Header
+	length   int64
+	encoding []string
+	data     string
+	trailer  map[string]string
+	err      error
+}
+
+func withStreamingPayloadSign(o *Options) {
+	o.FeatureFlags |= FeatureExperimentalStreamingPayloadSign
+}
+
+// decodeStreamingPayloadRequest verifies the request, then decodes the chunks and verifies their signatures.
+func decodeStreamingPayloadRequest(r *http.Request, cred *credentials.Credentials) (string, map[string]string, error) {
+	verifier := signer.NewVerifier(func(ctx context.Context, accessKeyID string) (*credentials.Credentials, error) {
+		return cred, nil
+	}, func(o *signer.VerifierOptions) {
+		o.Region = "cn-hangzhou"
+	})
+	signingCtx, err := verifier.Verify(r.Context(), r, "bucket", "key")
+	if err != nil {
+		return "", nil, err
+	}
+	decoder, err := verifier.NewStreamingPayloadDecoder(r.Body, signingCtx)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := io.ReadAll(decoder)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(data), decoder.Trailer(), nil
+}
+
+func testSetupStreamingPayloadServer(t *testing.T, cred *credentials.Credentials, failFirst bool) (*httptest.Server, func() []receivedStreamingRequest) {
+	var mu sync.Mutex
+	var requests []receivedStreamingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := receivedStreamingRequest{headers: r.Header.Clone(), length: r.ContentLength, encoding: r.TransferEncoding}
+		if signer.IsStreamingPayload(r.Header.Get(HeaderOssContentSha256)) {
+			req.data, req.trailer, req.err = decodeStreamingPayloadRequest(r, cred)
+		} else {
+			data, _ := io.ReadAll(r.Body)
+			req.data = string(data)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		n := len(requests)
+		mu.Unlock()
+
+		if failFirst && n == 1 {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(500)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>InternalError</Code><Message>Please try again.</Message></Error>`))
+			return
+		}
+		h := NewCRC64(0)
+		h.Write([]byte(req.data))
+		w.Header().Set(HeaderOssCRC64, fmt.Sprint(h.Sum64()))
+		w.Header().Set("X-Oss-Next-Append-Position", fmt.Sprint(len(req.data)))
+		w.WriteHeader(200)
+	}))
+	return server, func() []receivedStreamingRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestStreamingPayload(t *testing.T) {
+	cred := credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}
+	server, requests := testSetupStreamingPayloadServer(t, &cred, false)
+	defer server.Close()
+
+	data := strings.Repeat("0123456789", 10000)
+	crc := NewCRC64(0)
+	crc.Write([]byte(data))
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cred.AccessKeyID, cred.AccessKeySecret)).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithUsePathStyle(true)
+	client := NewClient(cfg, withStreamingPayloadSign)
+	ctx := context.Background()
+
+	// unknown length
+	_, err := client.PutObject(ctx, &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs := requests()
+	assert.Len(t, reqs, 1)
+	assert.Nil(t, reqs[0].err)
+	assert.Equal(t, data, reqs[0].data)
+	assert.Equal(t, int64(-1), reqs[0].length)
+	assert.Equal(t, []string{"chunked"}, reqs[0].encoding)
+	assert.Equal(t, signer.StreamingPayloadTrailer, reqs[0].headers.Get(HeaderOssContentSha256))
+	assert.Equal(t, "x-oss-hash-crc64ecma", reqs[0].headers.Get(HeaderOssTrailer))
+	assert.Equal(t, "", reqs[0].headers.Get(HeaderOssDecodedContentLength))
+	assert.Equal(t, map[string]string{"x-oss-hash-crc64ecma": fmt.Sprint(crc.Sum64())}, reqs[0].trailer)
+
+	// not seekable, with the content length
+	_, err = client.UploadPart(ctx, &UploadPartRequest{
+		Bucket:        Ptr("bucket"),
+		Key:           Ptr("key"),
+		UploadId:      Ptr("upload-id"),
+		PartNumber:    1,
+		ContentLength: Ptr(int64(len(data))),
+		Body:          io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs = requests()
+	assert.Len(t, reqs, 2)
+	assert.Nil(t, reqs[1].err)
+	assert.Equal(t, data, reqs[1].data)
+	assert.Equal(t, fmt.Sprint(len(data)), reqs[1].headers.Get(HeaderOssDecodedContentLength))
+
+	_, err = client.AppendObject(ctx, &AppendObjectRequest{
+		Bucket:   Ptr("bucket"),
+		Key:      Ptr("key"),
+		Position: Ptr(int64(0)),
+		Body:     io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs = requests()
+	assert.Len(t, reqs, 3)
+	assert.Nil(t, reqs[2].err)
+	assert.Equal(t, data, reqs[2].data)
+
+	// a seekable body of known length is not streamed
+	_, err = client.PutObject(ctx, &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   strings.NewReader(data),
+	})
+	assert.Nil(t, err)
+	reqs = requests()
+	assert.Len(t, reqs, 4)
+	assert.Equal(t, "UNSIGNED-PAYLOAD", reqs[3].headers.Get(HeaderOssContentSha256))
+	assert.Equal(t, int64(len(data)), reqs[3].length)
+	assert.Equal(t, data, reqs[3].data)
+
+	// the presigned requests and the signature version 1 are not streamed
+	_, err = client.PutObject(ctx, &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   io.MultiReader(strings.NewReader(data)),
+	}, func(o *Options) {
+		o.AuthMethod = Ptr(AuthMethodQuery)
+	})
+	assert.Nil(t, err)
+	v1Cfg := cfg.Copy()
+	v1Client := NewClient(v1Cfg.WithSignatureVersion(SignatureVersionV1), withStreamingPayloadSign)
+	_, err = v1Client.PutObject(ctx, &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs = requests()
+	assert.Len(t, reqs, 6)
+	for _, req := range reqs[4:] {
+		assert.False(t, signer.IsStreamingPayload(req.headers.Get(HeaderOssContentSha256)))
+		assert.Equal(t, data, req.data)
+	}
+
+	// disabled by default
+	client = NewClient(cfg)
+	_, err = client.PutObject(ctx, &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs = requests()
+	assert.Len(t, reqs, 7)
+	assert.Equal(t, "UNSIGNED-PAYLOAD", reqs[6].headers.Get(HeaderOssContentSha256))
+}
+
+func TestStreamingPayload_Retry(t *testing.T) {
+	cred := credentials.Credentials{AccessKeyID: "ak", AccessKeySecret: "sk"}
+	server, requests := testSetupStreamingPayloadServer(t, &cred, true)
+	defer server.Close()
+
+	data := strings.Repeat("0123456789", 1000)
+	client := NewClient(LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cred.AccessKeyID, cred.AccessKeySecret)).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithUsePathStyle(true).
+		WithReplayBufferSize(1024*1024).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		})), withStreamingPayloadSign)
+
+	// each attempt is signed again, and the body is replayed
+	_, err := client.PutObject(context.Background(), &PutObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+		Body:   io.MultiReader(strings.NewReader(data)),
+	})
+	assert.Nil(t, err)
+	reqs := requests()
+	assert.Len(t, reqs, 2)
+	for _, req := range reqs {
+		assert.Nil(t, req.err)
+		assert.Equal(t, data, req.data)
+		assert.Equal(t, signer.StreamingPayloadTrailer, req.headers.Get(HeaderOssContentSha256))
+	}
+}
@@ -0,0 +1,560 @@
+package oss
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncDirection The direction of the sync
+type SyncDirection int
+
+// Enum values for SyncDirection
+const (
+	// SyncUpload updates the objects under the prefix with the files in the local directory.
+	SyncUpload SyncDirection = iota
+
+	// SyncDownload updates the files in the local directory with the objects under the prefix.
+	SyncDownload
+)
+
+// SyncAction The action of the sync on a file or an object
+type SyncAction string
+
+// Enum values for SyncAction
+const (
+	SyncActionUpload   SyncAction = "upload"
+	SyncActionDownload SyncAction = "download"
+	SyncActionDelete   SyncAction = "delete"
+	SyncActionSkip     SyncAction = "skip"
+)
+
+// The user metadata which stores the modification time of the uploaded file, in Unix seconds.
+const syncMtimeMetadata = "mtime"
+
+// The reasons of the actions of the sync
+const (
+	syncReasonNew            = "new"
+	syncReasonSizeChanged    = "size changed"
+	syncReasonMtimeChanged   = "modification time changed"
+	syncReasonContentChanged = "content changed"
+	syncReasonUnchanged      = "unchanged"
+	syncReasonExtraneous     = "extraneous"
+)
+
+// The maximum number of the objects deleted by a DeleteMultipleObjects request.
+const deleteMultipleObjectsSize = 1000
+
+type SyncOptions struct {
+	// The patterns of the files and the objects to sync, in the syntax of path.Match.
+	// A pattern with a "/" matches the relative path, otherwise it matches the base name.
+	// All the files and the objects are synced by default.
+	Include []string
+
+	// The patterns of the files and the objects not to sync, they take precedence over Include.
+	// The excluded ones are neither transferred nor deleted.
+	Exclude []string
+
+	// Specifies whether to delete the objects or the files which do not exist in the source.
+	Delete bool
+
+	// Specifies whether to plan the actions only, nothing is transferred or deleted.
+	DryRun bool
+
+	// Specifies whether to compare the CRC-64 of the files and the objects of the same size,
+	// even if their modification times are the same.
+	Checksum bool
+
+	// The maximum number of requests in flight, which is shared by the files and their parts.
+	// The default is the ParallelNum of the uploader or the downloader.
+	ParallelNum int
+
+	// The upload bandwidth limit in kBytes/s for all the requests of the sync, 0 is unlimited.
+	UploadBandwidthlimit int64
+
+	// The download bandwidth limit in kBytes/s for all the requests of the sync, 0 is unlimited.
+	DownloadBandwidthlimit int64
+
+	// The options of the requests to list, check and delete the objects.
+	ClientOptions []func(*Options)
+
+	// The options of the uploads of the files.
+	UploaderOptions []func(*UploaderOptions)
+
+	// The options of the downloads of the objects.
+	DownloaderOptions []func(*DownloaderOptions)
+}
+
+type SyncEntry struct {
+	// The action on the file or the object.
+	Action SyncAction
+
+	// The reason of the action, such as "new", "size changed" or "unchanged".
+	Reason string
+
+	// The local path of the file, empty for the objects to delete.
+	Path string
+
+	// The key of the object, empty for the files to delete.
+	Key string
+
+	// The size of the source, or the size of the file or the object to delete.
+	Size int64
+
+	// The error of the action, nil if it succeeds.
+	Err error
+}
+
+// String returns the plan of the entry, such as "upload /local/dir/a.txt -> prefix/a.txt (new)".
+func (e SyncEntry) String() string {
+	var s string
+	switch {
+	case e.Action == SyncActionUpload:
+		s = fmt.Sprintf("%v %v -> %v", e.Action, e.Path, e.Key)
+	case e.Action == SyncActionDownload:
+		s = fmt.Sprintf("%v %v -> %v", e.Action, e.Key, e.Path)
+	case e.Path != "":
+		s = fmt.Sprintf("%v %v", e.Action, e.Path)
+	default:
+		s = fmt.Sprintf("%v %v", e.Action, e.Key)
+	}
+	if e.Reason != "" {
+		s += " (" + e.Reason + ")"
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+type SyncResult struct {
+	// The entries of the files and the objects, in the lexical order of their relative paths.
+	Entries []SyncEntry
+
+	// The number of the entries of each action, or the planned ones in a dry run.
+	// The failed entries are only counted in Failed.
+	Uploaded   int
+	Downloaded int
+	Deleted    int
+	Skipped    int
+	Failed     int
+}
+
+// Sync makes the objects under the prefix the same as the files in the local directory, or the other way around,
+// like rsync. The files and the objects are compared by their sizes, modification times and CRC-64,
+// only the changed ones are transferred, and the extraneous ones are deleted if the Delete option is set.
+// The modification time of an uploaded file is stored in its user metadata "mtime", and the one of a downloaded file
+// is set to the time of its object. The failures of the entries are reported in the result instead of stopping the others.
+// The returned error is only for the arguments, the local directory and the listing of the objects.
+func (c *Client) Sync(ctx context.Context, bucket, prefix, localDir string, direction SyncDirection, optFns ...func(*SyncOptions)) (*SyncResult, error) {
+	options := SyncOptions{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if bucket == "" {
+		return nil, NewErrParamRequired("bucket")
+	}
+	if localDir == "" {
+		return nil, NewErrParamRequired("localDir")
+	}
+	if direction != SyncUpload && direction != SyncDownload {
+		return nil, NewErrParamInvalid("direction")
+	}
+	if err := checkPatterns(options.Include, "options.Include"); err != nil {
+		return nil, err
+	}
+	if err := checkPatterns(options.Exclude, "options.Exclude"); err != nil {
+		return nil, err
+	}
+	localDir, err := filepath.Abs(localDir)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := options.ClientOptions
+	uploaderOptions := options.UploaderOptions
+	downloaderOptions := options.DownloaderOptions
+	if options.UploadBandwidthlimit > 0 || options.DownloadBandwidthlimit > 0 {
+		// the token buckets are shared by all the requests of the sync
+		bw := WithBwTokenBuckets(NewBwTokenBuckets(options.UploadBandwidthlimit, options.DownloadBandwidthlimit))
+		clientOptions = append(clientOptions[:len(clientOptions):len(clientOptions)], bw)
+		uploaderOptions = append(uploaderOptions[:len(uploaderOptions):len(uploaderOptions)], func(uo *UploaderOptions) {
+			uo.ClientOptions = append(uo.ClientOptions[:len(uo.ClientOptions):len(uo.ClientOptions)], bw)
+		})
+		downloaderOptions = append(downloaderOptions[:len(downloaderOptions):len(downloaderOptions)], func(do *DownloaderOptions) {
+			do.ClientOptions = append(do.ClientOptions[:len(do.ClientOptions):len(do.ClientOptions)], bw)
+		})
+	}
+	s := &syncer{
+		client:        c,
+		bucket:        bucket,
+		options:       &options,
+		clientOptions: clientOptions,
+		uploader:      c.NewUploader(uploaderOptions...),
+		downloader:    c.NewDownloader(downloaderOptions...),
+	}
+	if options.ParallelNum <= 0 {
+		if direction == SyncUpload {
+			options.ParallelNum = s.uploader.options.ParallelNum
+		} else {
+			options.ParallelNum = s.downloader.options.ParallelNum
+		}
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = DefaultParallel
+	}
+	s.budget = newTransferBudget(options.ParallelNum)
+
+	files, err := s.listFiles(localDir, direction)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.listObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	items := s.plan(localDir, prefix, direction, files, objects)
+
+	// compare the files and the objects of the same size
+	s.forEach(ctx, items, func(item *syncItem) {
+		if item.entry.Action == SyncActionSkip && item.entry.Reason == "" {
+			s.compare(ctx, item, direction)
+		}
+	})
+
+	if !options.DryRun {
+		s.forEach(ctx, items, func(item *syncItem) {
+			switch item.entry.Action {
+			case SyncActionUpload:
+				s.upload(ctx, item)
+			case SyncActionDownload:
+				s.download(ctx, item)
+			case SyncActionDelete:
+				if direction == SyncDownload {
+					item.entry.Err = os.Remove(item.entry.Path)
+				}
+			}
+		})
+		if direction == SyncUpload {
+			s.deleteObjects(ctx, items)
+		}
+	}
+
+	result := &SyncResult{}
+	for _, item := range items {
+		result.Entries = append(result.Entries, *item.entry)
+		switch {
+		case item.entry.Err != nil:
+			result.Failed++
+		case item.entry.Action == SyncActionUpload:
+			result.Uploaded++
+		case item.entry.Action == SyncActionDownload:
+			result.Downloaded++
+		case item.entry.Action == SyncActionDelete:
+			result.Deleted++
+		default:
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+type syncer struct {
+	client        *Client
+	bucket        string
+	options       *SyncOptions
+	clientOptions []func(*Options)
+	uploader      *Uploader
+	downloader    *Downloader
+	budget        transferBudget
+}
+
+type syncFile struct {
+	path  string
+	size  int64
+	mtime time.Time
+	err   error
+}
+
+type syncItem struct {
+	entry  *SyncEntry
+	file   *syncFile
+	object *ObjectProperties
+
+	// the modification time of the object, which is stored in its metadata, or its last modified time
+	mtime time.Time
+}
+
+// listFiles lists the files in the local directory by their relative paths.
+// The local directory of a download may not exist, it is created when the objects are downloaded.
+func (s *syncer) listFiles(localDir string, direction SyncDirection) (map[string]*syncFile, error) {
+	files := map[string]*syncFile{}
+	info, err := os.Stat(localDir)
+	if err != nil {
+		if direction == SyncDownload && os.IsNotExist(err) {
+			return files, nil
+		}
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%v is not a directory", localDir)
+	}
+	err = walkLocalFiles(localDir, s.options.Include, s.options.Exclude, func(filePath, relPath string, err error) {
+		file := &syncFile{path: filePath, err: err}
+		if err == nil {
+			var info os.FileInfo
+			if info, file.err = os.Stat(filePath); file.err == nil {
+				file.size = info.Size()
+				file.mtime = info.ModTime()
+			}
+		}
+		files[relPath] = file
+	})
+	return files, err
+}
+
+// listObjects lists the objects under the prefix by their keys relative to the prefix, the directory markers are skipped.
+func (s *syncer) listObjects(ctx context.Context, prefix string) (map[string]*ObjectProperties, error) {
+	objects := map[string]*ObjectProperties{}
+	p := s.client.NewListObjectsV2Paginator(&ListObjectsV2Request{
+		Bucket: Ptr(s.bucket),
+		Prefix: Ptr(prefix),
+	})
+	for p.HasNext() {
+		page, err := p.NextPage(ctx, s.clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Contents {
+			key := ToString(page.Contents[i].Key)
+			relKey := strings.TrimPrefix(key, prefix)
+			if strings.HasSuffix(key, "/") || !isIncluded(s.options.Include, s.options.Exclude, relKey) {
+				continue
+			}
+			objects[relKey] = &page.Contents[i]
+		}
+	}
+	return objects, nil
+}
+
+// plan decides the actions by the existences and the sizes of the files and the objects.
+// The files and the objects of the same size are left to be compared, as the skipped ones without a reason.
+func (s *syncer) plan(localDir, prefix string, direction SyncDirection, files map[string]*syncFile, objects map[string]*ObjectProperties) []*syncItem {
+	var relPaths []string
+	for relPath := range files {
+		relPaths = append(relPaths, relPath)
+	}
+	for relKey := range objects {
+		if _, ok := files[relKey]; !ok {
+			relPaths = append(relPaths, relKey)
+		}
+	}
+	sort.Strings(relPaths)
+
+	var items []*syncItem
+	for _, relPath := range relPaths {
+		file, object := files[relPath], objects[relPath]
+		item := &syncItem{file: file, object: object, entry: &SyncEntry{Key: prefix + relPath}}
+		if object != nil && object.LastModified != nil {
+			item.mtime = *object.LastModified
+		}
+		if file != nil {
+			item.entry.Path = file.path
+		} else {
+			item.entry.Path, item.entry.Err = localFilePath(localDir, relPath)
+		}
+
+		transfer, source, target := SyncActionUpload, file != nil, object != nil
+		if direction == SyncDownload {
+			transfer, source, target = SyncActionDownload, object != nil, file != nil
+		}
+		switch {
+		case file != nil && file.err != nil:
+			item.entry.Action, item.entry.Err = transfer, file.err
+		case !source:
+			// the extraneous file or object
+			if !s.options.Delete {
+				continue
+			}
+			item.entry.Action, item.entry.Reason = SyncActionDelete, syncReasonExtraneous
+		case !target:
+			item.entry.Action, item.entry.Reason = transfer, syncReasonNew
+		case file.size != object.Size:
+			item.entry.Action, item.entry.Reason = transfer, syncReasonSizeChanged
+		case !s.options.Checksum && file.mtime.Unix() == item.mtime.Unix():
+			item.entry.Action, item.entry.Reason = SyncActionSkip, syncReasonUnchanged
+		default:
+			item.entry.Action = SyncActionSkip
+		}
+		// the entry of a deletion has only the path or the key to delete
+		switch {
+		case item.entry.Action == SyncActionDelete && direction == SyncUpload:
+			item.entry.Path, item.entry.Size = "", object.Size
+		case item.entry.Action == SyncActionDelete:
+			item.entry.Key, item.entry.Size = "", file.size
+		case file != nil && direction == SyncUpload:
+			item.entry.Size = file.size
+		case object != nil:
+			item.entry.Size = object.Size
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// compare decides the action of the file and the object of the same size. They are the same if the modification time
+// of the file is the one stored in the metadata of the object, otherwise their CRC-64 are compared.
+func (s *syncer) compare(ctx context.Context, item *syncItem, direction SyncDirection) {
+	transfer := SyncActionUpload
+	if direction == SyncDownload {
+		transfer = SyncActionDownload
+	}
+	item.entry.Action = transfer
+	if item.entry.Err = s.budget.acquire(ctx); item.entry.Err != nil {
+		return
+	}
+	head, err := s.client.HeadObject(ctx, &HeadObjectRequest{
+		Bucket: Ptr(s.bucket),
+		Key:    Ptr(item.entry.Key),
+	}, s.clientOptions...)
+	s.budget.release()
+	if err != nil {
+		item.entry.Err = err
+		return
+	}
+	if mtime, ok := syncMtime(head.Metadata[syncMtimeMetadata]); ok {
+		item.mtime = mtime
+	}
+	if !s.options.Checksum && item.file.mtime.Unix() == item.mtime.Unix() {
+		item.entry.Action, item.entry.Reason = SyncActionSkip, syncReasonUnchanged
+		return
+	}
+	if head.HashCRC64 == nil {
+		item.entry.Reason = syncReasonMtimeChanged
+		return
+	}
+	crc, err := fileCRC64(item.file.path)
+	if err != nil {
+		item.entry.Err = err
+		return
+	}
+	if fmt.Sprint(crc) == ToString(head.HashCRC64) {
+		item.entry.Action, item.entry.Reason = SyncActionSkip, syncReasonUnchanged
+		return
+	}
+	item.entry.Reason = syncReasonContentChanged
+}
+
+// syncMtime parses the modification time stored in the user metadata.
+func syncMtime(value string) (time.Time, bool) {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func (s *syncer) upload(ctx context.Context, item *syncItem) {
+	request := &PutObjectRequest{
+		Bucket:   Ptr(s.bucket),
+		Key:      Ptr(item.entry.Key),
+		Metadata: map[string]string{syncMtimeMetadata: strconv.FormatInt(item.file.mtime.Unix(), 10)},
+	}
+	_, item.entry.Err = s.uploader.uploadFile(ctx, request, item.file.path, s.budget)
+}
+
+func (s *syncer) download(ctx context.Context, item *syncItem) {
+	if item.entry.Err = os.MkdirAll(filepath.Dir(item.entry.Path), 0755); item.entry.Err != nil {
+		return
+	}
+	request := &GetObjectRequest{
+		Bucket: Ptr(s.bucket),
+		Key:    Ptr(item.entry.Key),
+	}
+	result, err := s.downloader.downloadFile(ctx, request, item.entry.Path, s.budget)
+	if item.entry.Err = err; err != nil {
+		return
+	}
+	if mtime, ok := syncMtime(result.headers.Get("x-oss-meta-" + syncMtimeMetadata)); ok {
+		item.mtime = mtime
+	}
+	if !item.mtime.IsZero() {
+		item.entry.Err = os.Chtimes(item.entry.Path, item.mtime, item.mtime)
+	}
+}
+
+// deleteObjects deletes the extraneous objects by DeleteMultipleObjects, 1000 objects a request.
+func (s *syncer) deleteObjects(ctx context.Context, items []*syncItem) {
+	var batch []*syncItem
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		defer func() { batch = batch[:0] }()
+		objects := make([]ObjectIdentifier, 0, len(batch))
+		for _, item := range batch {
+			objects = append(objects, ObjectIdentifier{Key: Ptr(item.entry.Key)})
+		}
+		var result *DeleteMultipleObjectsResult
+		err := s.budget.acquire(ctx)
+		if err == nil {
+			result, err = s.client.DeleteMultipleObjects(ctx, &DeleteMultipleObjectsRequest{
+				Bucket: Ptr(s.bucket),
+				Delete: &Delete{Objects: objects},
+			}, s.clientOptions...)
+			s.budget.release()
+		}
+		deleted := map[string]bool{}
+		if result != nil {
+			for _, info := range result.DeletedObjects {
+				deleted[ToString(info.Key)] = true
+			}
+		}
+		for _, item := range batch {
+			if err != nil {
+				item.entry.Err = err
+			} else if !deleted[item.entry.Key] {
+				item.entry.Err = fmt.Errorf("the object %v is not deleted", item.entry.Key)
+			}
+		}
+	}
+	for _, item := range items {
+		if item.entry.Action == SyncActionDelete && item.entry.Err == nil {
+			batch = append(batch, item)
+			if len(batch) == deleteMultipleObjectsSize {
+				flush()
+			}
+		}
+	}
+	flush()
+}
+
+// forEach calls fn with the items without errors in parallel, the items are failed once the context is done.
+func (s *syncer) forEach(ctx context.Context, items []*syncItem, fn func(*syncItem)) {
+	ch := make(chan *syncItem)
+	var wg sync.WaitGroup
+	for i := 0; i < s.options.ParallelNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range ch {
+				if item.entry.Err = ctx.Err(); item.entry.Err == nil {
+					fn(item)
+				}
+			}
+		}()
+	}
+	for _, item := range items {
+		if item.entry.Err == nil {
+			ch <- item
+		}
+	}
+	close(ch)
+	wg.Wait()
+}
@@ -0,0 +1,240 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync_Upload(t *testing.T) {
+	store := newTestObjectStore()
+	server := testSetupObjectStoreServer(t, store)
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	dir := t.TempDir()
+	testCreateFiles(t, dir, map[string]string{
+		"a.txt":     "hello a",
+		"b.txt":     "hello b",
+		"sub/c.txt": "hello c",
+		"skip.tmp":  "tmp",
+	})
+	// the files are not modified at the time of the objects
+	mtime := time.Now().Add(-time.Hour)
+	for _, name := range []string{"a.txt", "b.txt", "sub/c.txt"} {
+		assert.Nil(t, os.Chtimes(filepath.Join(dir, name), mtime, mtime))
+	}
+	store.put("bucket/dir/b.txt", []byte("hello B"), nil)
+	store.put("bucket/dir/old.txt", []byte("old"), nil)
+	store.put("bucket/dir/keep.tmp", []byte("keep"), nil)
+
+	// dry run
+	result, err := client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Exclude = []string{"*.tmp"}
+		o.Delete = true
+		o.DryRun = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Uploaded)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 0, result.Failed)
+	assert.Len(t, result.Entries, 4)
+	assert.Equal(t, "upload "+filepath.Join(dir, "a.txt")+" -> dir/a.txt (new)", result.Entries[0].String())
+	assert.Equal(t, SyncActionUpload, result.Entries[1].Action)
+	assert.Equal(t, "content changed", result.Entries[1].Reason)
+	assert.Equal(t, "delete dir/old.txt (extraneous)", result.Entries[2].String())
+	assert.Equal(t, int64(3), result.Entries[2].Size)
+	assert.Equal(t, "dir/sub/c.txt", result.Entries[3].Key)
+	assert.Equal(t, []string{"bucket/dir/b.txt", "bucket/dir/keep.tmp", "bucket/dir/old.txt"}, store.keys())
+
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Exclude = []string{"*.tmp"}
+		o.Delete = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Uploaded)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, []string{"bucket/dir/a.txt", "bucket/dir/b.txt", "bucket/dir/keep.tmp", "bucket/dir/sub/c.txt"}, store.keys())
+	data, _ := store.get("bucket/dir/b.txt")
+	assert.Equal(t, "hello b", string(data))
+	info, err := os.Stat(filepath.Join(dir, "b.txt"))
+	assert.Nil(t, err)
+	store.mu.Lock()
+	assert.Equal(t, strconv.FormatInt(info.ModTime().Unix(), 10), store.metadata["bucket/dir/b.txt"].Get("x-oss-meta-mtime"))
+	store.mu.Unlock()
+
+	// the unchanged files are skipped by their modification times
+	heads := store.count("HEAD")
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Exclude = []string{"*.tmp"}
+		o.Delete = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Skipped)
+	assert.Equal(t, 0, result.Uploaded+result.Deleted+result.Failed)
+	assert.Equal(t, "skip "+filepath.Join(dir, "a.txt")+" (unchanged)", result.Entries[0].String())
+	assert.Equal(t, heads+3, store.count("HEAD"))
+
+	// the content is changed without changing the size and the modification time
+	testCreateFiles(t, dir, map[string]string{"a.txt": "HELLO A"})
+	assert.Nil(t, os.Chtimes(filepath.Join(dir, "a.txt"), mtime, mtime))
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Include = []string{"a.txt"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Skipped)
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Include = []string{"a.txt"}
+		o.Checksum = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Uploaded)
+	assert.Equal(t, "content changed", result.Entries[0].Reason)
+	data, _ = store.get("bucket/dir/a.txt")
+	assert.Equal(t, "HELLO A", string(data))
+
+	// the modification time is changed without changing the content
+	mtime = mtime.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(filepath.Join(dir, "a.txt"), mtime, mtime))
+	heads = store.count("HEAD")
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Include = []string{"a.txt"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, heads+1, store.count("HEAD"))
+
+	// the failures do not stop the others
+	store.failKeys["bucket/dir/sub/c.txt"] = true
+	testCreateFiles(t, dir, map[string]string{"a.txt": "hello a, changed", "sub/c.txt": "hello c, changed"})
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncUpload, func(o *SyncOptions) {
+		o.Exclude = []string{"*.tmp"}
+		o.UploadBandwidthlimit = 1024
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Uploaded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 1, result.Skipped)
+	var serr *ServiceError
+	assert.ErrorAs(t, result.Entries[2].Err, &serr)
+	assert.Equal(t, "AccessDenied", serr.Code)
+	data, _ = store.get("bucket/dir/a.txt")
+	assert.Equal(t, "hello a, changed", string(data))
+}
+
+func TestSync_Download(t *testing.T) {
+	store := newTestObjectStore()
+	server := testSetupObjectStoreServer(t, store)
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	mtime := time.Unix(time.Now().Add(-time.Hour).Unix(), 0)
+	store.put("bucket/dir/a.txt", []byte("hello a"), nil)
+	store.put("bucket/dir/sub/", nil, nil)
+	store.put("bucket/dir/sub/b.txt", []byte("hello b"), map[string][]string{
+		"X-Oss-Meta-Mtime": {strconv.FormatInt(mtime.Unix(), 10)},
+	})
+	dir := t.TempDir()
+	testCreateFiles(t, dir, map[string]string{
+		"a.txt":     "HELLO A",
+		"extra.txt": "extra",
+	})
+	assert.Nil(t, os.Chtimes(filepath.Join(dir, "a.txt"), mtime, mtime))
+
+	// the local directory is created
+	newDir := filepath.Join(t.TempDir(), "new")
+	result, err := client.Sync(context.TODO(), "bucket", "dir/", newDir, SyncDownload)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Downloaded)
+	assert.FileExists(t, filepath.Join(newDir, "sub", "b.txt"))
+
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncDownload, func(o *SyncOptions) {
+		o.Delete = true
+		o.DryRun = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Downloaded)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, "download dir/a.txt -> "+filepath.Join(dir, "a.txt")+" (content changed)", result.Entries[0].String())
+	assert.Equal(t, "delete "+filepath.Join(dir, "extra.txt")+" (extraneous)", result.Entries[1].String())
+	assert.Equal(t, "new", result.Entries[2].Reason)
+	assert.FileExists(t, filepath.Join(dir, "extra.txt"))
+
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncDownload, func(o *SyncOptions) {
+		o.Delete = true
+		o.DownloadBandwidthlimit = 1024
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Downloaded)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 0, result.Failed)
+	data, _ := os.ReadFile(filepath.Join(dir, "a.txt"))
+	assert.Equal(t, "hello a", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "extra.txt"))
+	info, err := os.Stat(filepath.Join(dir, "sub", "b.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, mtime.Unix(), info.ModTime().Unix())
+	info, err = os.Stat(filepath.Join(dir, "a.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, store.modTime("bucket/dir/a.txt").Unix(), info.ModTime().Unix())
+
+	// only the file whose modification time is in the metadata is checked
+	heads := store.count("HEAD")
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncDownload, func(o *SyncOptions) {
+		o.Delete = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 0, result.Downloaded+result.Deleted+result.Failed)
+	assert.Equal(t, heads+1, store.count("HEAD"))
+
+	// the keys out of the directory are rejected
+	store.put("bucket/dir/../evil.txt", []byte("evil"), nil)
+	result, err = client.Sync(context.TODO(), "bucket", "dir/", dir, SyncDownload)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Entries[0].Err.Error(), "is out of the directory")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "evil.txt"))
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = client.Sync(ctx, "bucket", "dir/", dir, SyncDownload)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSync_ArgumentCheck(t *testing.T) {
+	client := NewClient(LoadDefaultConfig())
+	dir := t.TempDir()
+
+	_, err := client.Sync(context.TODO(), "", "", dir, SyncUpload)
+	assert.Contains(t, err.Error(), "missing required field, bucket")
+
+	_, err = client.Sync(context.TODO(), "bucket", "", "", SyncUpload)
+	assert.Contains(t, err.Error(), "missing required field, localDir")
+
+	_, err = client.Sync(context.TODO(), "bucket", "", dir, SyncDirection(2))
+	assert.Contains(t, err.Error(), "invalid field, direction")
+
+	_, err = client.Sync(context.TODO(), "bucket", "", dir, SyncUpload, func(o *SyncOptions) {
+		o.Exclude = []string{"["}
+	})
+	assert.Contains(t, err.Error(), "invalid field, options.Exclude")
+
+	_, err = client.Sync(context.TODO(), "bucket", "", filepath.Join(dir, "none"), SyncUpload)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSyncEntry_String(t *testing.T) {
+	assert.Equal(t, "upload a.txt -> dir/a.txt (new)", SyncEntry{Action: SyncActionUpload, Reason: "new", Path: "a.txt", Key: "dir/a.txt"}.String())
+	assert.Equal(t, "download dir/a.txt -> a.txt", SyncEntry{Action: SyncActionDownload, Path: "a.txt", Key: "dir/a.txt"}.String())
+	assert.Equal(t, "delete dir/a.txt (extraneous): denied", SyncEntry{Action: SyncActionDelete, Reason: "extraneous", Key: "dir/a.txt", Err: errors.New("denied")}.String())
+	assert.Equal(t, "skip a.txt (unchanged)", SyncEntry{Action: SyncActionSkip, Reason: "unchanged", Path: "a.txt", Key: "dir/a.txt"}.String())
+}
@@ -0,0 +1,110 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// TablesAPI lists the operations of TablesClient, so that callers can depend on an interface,
+// decorate it or replace it with a mock in tests.
+type TablesAPI interface {
+	// CreateNamespace Creates a namespace.
+	CreateNamespace(ctx context.Context, request *CreateNamespaceRequest, optFns ...func(*oss.Options)) (*CreateNamespaceResult, error)
+
+	// CreateTable Creates a table.
+	CreateTable(ctx context.Context, request *CreateTableRequest, optFns ...func(*oss.Options)) (*CreateTableResult, error)
+
+	// CreateTableBucket Creates a table bucket.
+	CreateTableBucket(ctx context.Context, request *CreateTableBucketRequest, optFns ...func(*oss.Options)) (*CreateTableBucketResult, error)
+
+	// DeleteNamespace Deletes a namespace.
+	DeleteNamespace(ctx context.Context, request *DeleteNamespaceRequest, optFns ...func(*oss.Options)) (*DeleteNamespaceResult, error)
+
+	// DeleteTable Deletes a table.
+	DeleteTable(ctx context.Context, request *DeleteTableRequest, optFns ...func(*oss.Options)) (*DeleteTableResult, error)
+
+	// DeleteTableBucket Deletes a table bucket.
+	DeleteTableBucket(ctx context.Context, request *DeleteTableBucketRequest, optFns ...func(*oss.Options)) (*DeleteTableBucketResult, error)
+
+	// DeleteTableBucketEncryption Deletes encryption rules for a bucket.
+	DeleteTableBucketEncryption(ctx context.Context, request *DeleteTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*DeleteTableBucketEncryptionResult, error)
+
+	// DeleteTableBucketPolicy Deletes a policy for a table bucket.
+	DeleteTableBucketPolicy(ctx context.Context, request *DeleteTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*DeleteTableBucketPolicyResult, error)
+
+	// DeleteTablePolicy delete a table policy.
+	DeleteTablePolicy(ctx context.Context, request *DeleteTablePolicyRequest, optFns ...func(*oss.Options)) (*DeleteTablePolicyResult, error)
+
+	// GetNamespace Queries information about a table bucket.
+	GetNamespace(ctx context.Context, request *GetNamespaceRequest, optFns ...func(*oss.Options)) (*GetNamespaceResult, error)
+
+	// GetTable Queries information about a table.
+	GetTable(ctx context.Context, request *GetTableRequest, optFns ...func(*oss.Options)) (*GetTableResult, error)
+
+	// GetTableBucket Queries information about a table bucket.
+	GetTableBucket(ctx context.Context, request *GetTableBucketRequest, optFns ...func(*oss.Options)) (*GetTableBucketResult, error)
+
+	// GetTableBucketEncryption Queries the encryption rules configured for a bucket.
+	GetTableBucketEncryption(ctx context.Context, request *GetTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*GetTableBucketEncryptionResult, error)
+
+	// GetTableBucketMaintenanceConfiguration Queries the maintenance config of a bucket.
+	GetTableBucketMaintenanceConfiguration(ctx context.Context, request *GetTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*GetTableBucketMaintenanceConfigurationResult, error)
+
+	// GetTableBucketPolicy Queries the policies configured for a table bucket.
+	GetTableBucketPolicy(ctx context.Context, request *GetTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*GetTableBucketPolicyResult, error)
+
+	// GetTableEncryption Queries the encryption rules configured for a table.
+	GetTableEncryption(ctx context.Context, request *GetTableEncryptionRequest, optFns ...func(*oss.Options)) (*GetTableEncryptionResult, error)
+
+	// GetTableMaintenanceConfiguration Queries the maintenance config of a table.
+	GetTableMaintenanceConfiguration(ctx context.Context, request *GetTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*GetTableMaintenanceConfigurationResult, error)
+
+	// GetTableMaintenanceJobStatus Queries the table maintenance job status of a table.
+	GetTableMaintenanceJobStatus(ctx context.Context, request *GetTableMaintenanceJobStatusRequest, optFns ...func(*oss.Options)) (*GetTableMaintenanceJobStatusResult, error)
+
+	// GetTableMetadataLocation Queries the metadata location of a table.
+	GetTableMetadataLocation(ctx context.Context, request *GetTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*GetTableMetadataLocationResult, error)
+
+	// GetTablePolicy Queries a table policy.
+	GetTablePolicy(ctx context.Context, request *GetTablePolicyRequest, optFns ...func(*oss.Options)) (*GetTablePolicyResult, error)
+
+	InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error)
+
+	// ListNamespaces Lists vector buckets that belong to the current account.
+	ListNamespaces(ctx context.Context, request *ListNamespacesRequest, optFns ...func(*oss.Options)) (*ListNamespacesResult, error)
+
+	// ListTableBuckets Lists table buckets that belong to the current account.
+	ListTableBuckets(ctx context.Context, request *ListTableBucketsRequest, optFns ...func(*oss.Options)) (*ListTableBucketsResult, error)
+
+	// ListTables Lists table s that belong to the current account.
+	ListTables(ctx context.Context, request *ListTablesRequest, optFns ...func(*oss.Options)) (*ListTablesResult, error)
+
+	// Presign generates the pre-signed URL of the request of an operation, such as *GetTableRequest.
+	Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+
+	// PutTableBucketEncryption Configures encryption rules for a bucket.
+	PutTableBucketEncryption(ctx context.Context, request *PutTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*PutTableBucketEncryptionResult, error)
+
+	// PutTableBucketMaintenanceConfiguration set maintenance config for the table bucket.
+	PutTableBucketMaintenanceConfiguration(ctx context.Context, request *PutTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*PutTableBucketMaintenanceConfigurationResult, error)
+
+	// PutTableBucketPolicy Configures a policy for a table bucket.
+	PutTableBucketPolicy(ctx context.Context, request *PutTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*PutTableBucketPolicyResult, error)
+
+	// PutTableMaintenanceConfiguration set maintenance config for the table.
+	PutTableMaintenanceConfiguration(ctx context.Context, request *PutTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*PutTableMaintenanceConfigurationResult, error)
+
+	// PutTablePolicy create a table policy.
+	PutTablePolicy(ctx context.Context, request *PutTablePolicyRequest, optFns ...func(*oss.Options)) (*PutTablePolicyResult, error)
+
+	// RenameTable Rename a table .
+	RenameTable(ctx context.Context, request *RenameTableRequest, optFns ...func(*oss.Options)) (*RenameTableResult, error)
+
+	// UpdateTableMetadataLocation Update the metadata location of a table.
+	UpdateTableMetadataLocation(ctx context.Context, request *UpdateTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*UpdateTableMetadataLocationResult, error)
+}
+
+var _ TablesAPI = (*TablesClient)(nil)
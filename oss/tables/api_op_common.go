@@ -9,3 +9,8 @@ import (
 func (c *TablesClient) InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error) {
 	return c.clientImpl.InvokeOperation(ctx, input, optFns...)
 }
+
+// Presign generates the pre-signed URL of the request of an operation, such as *GetTableRequest.
+func (c *TablesClient) Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error) {
+	return c.clientImpl.PresignOperation(ctx, c, request, optFns...)
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
 )
 
+//go:generate go run ../internal/apigen -type TablesClient -interface TablesAPI -mock tablesmock
+
 type TablesClient struct {
 	clientImpl *oss.Client
 }
@@ -312,6 +312,57 @@ func TestTablesInvokeOperation_Error(t *testing.T) {
 	}
 }
 
+func TestTablesInvokeOperation_Middlewares(t *testing.T) {
+	server := testSetupMockServer(t, 200, map[string]string{"x-oss-request-id": "534B371674E88A4D8906****"}, nil,
+		func(t *testing.T, r *http.Request) {
+			assert.Equal(t, "value", r.Header.Get("x-oss-meta-injected"))
+		})
+	defer server.Close()
+
+	var steps []oss.MiddlewareStep
+	record := func(step oss.MiddlewareStep) oss.Middleware {
+		return oss.Middleware{
+			Name: "record",
+			Step: step,
+			Handle: func(ctx context.Context, mctx *oss.MiddlewareContext, next oss.MiddlewareHandler) error {
+				steps = append(steps, step)
+				if step == oss.MiddlewareStepBuild {
+					mctx.Request.Header.Set("x-oss-meta-injected", "value")
+				}
+				return next(ctx, mctx)
+			},
+		}
+	}
+
+	cfg := oss.LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL)
+
+	client := NewTablesClient(cfg, oss.WithMiddlewares(
+		record(oss.MiddlewareStepInitialize),
+		record(oss.MiddlewareStepBuild),
+		record(oss.MiddlewareStepBeforeSign),
+		record(oss.MiddlewareStepAfterSign),
+		record(oss.MiddlewareStepDeserialize),
+	))
+
+	output, err := client.InvokeOperation(context.TODO(), &oss.OperationInput{
+		OpName: "GetTableBucket",
+		Method: "GET",
+		Bucket: oss.Ptr("bucket"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "534B371674E88A4D8906****", output.Headers.Get("x-oss-request-id"))
+	assert.Equal(t, []oss.MiddlewareStep{
+		oss.MiddlewareStepInitialize,
+		oss.MiddlewareStepBuild,
+		oss.MiddlewareStepBeforeSign,
+		oss.MiddlewareStepAfterSign,
+		oss.MiddlewareStepDeserialize,
+	}, steps)
+}
+
 var testMockCreateTableBucketSuccessCases = []struct {
 	StatusCode     int
 	Headers        map[string]string
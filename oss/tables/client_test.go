@@ -2,12 +2,14 @@ package tables
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -725,3 +727,31 @@ func TestResolveTablesUserAgent(t *testing.T) {
 	updateUserAgent(cfg)
 	assert.Equal(t, oss.ToString(cfg.UserAgent), "tables-client/my-user-agent")
 }
+
+func TestPresign(t *testing.T) {
+	client := NewTablesClient(oss.LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+		WithRegion("cn-beijing"))
+
+	result, err := client.Presign(context.TODO(), &GetTableRequest{
+		TableBucketARN: oss.Ptr("acs:osstables:cn-beijing:1234567890:bucket/demo-bucket"),
+		Namespace:      oss.Ptr("ns"),
+		Name:           oss.Ptr("table"),
+	}, oss.PresignExpires(10*time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "GET", result.Method)
+	assert.Contains(t, result.URL, "https://demo-bucket-1234567890.cn-beijing.oss-tables.aliyuncs.com/get-table?")
+	assert.Contains(t, result.URL, "namespace=ns")
+	assert.Contains(t, result.URL, "name=table")
+	assert.Contains(t, result.URL, "x-oss-signature-version=OSS4-HMAC-SHA256")
+	assert.Contains(t, result.URL, "x-oss-signature=")
+	assert.NotEmpty(t, result.Expiration)
+
+	// the request is validated by the operation
+	_, err = client.Presign(context.TODO(), &GetTableRequest{})
+	assert.NotNil(t, err)
+
+	_, err = client.Presign(context.TODO(), &GetTableResult{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "request *tables.GetTableResult")
+}
@@ -0,0 +1,386 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package tablesmock provides a mock implementation of tables.TablesAPI which records the calls.
+package tablesmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/tables"
+)
+
+// Call is a call of an operation, the context is not recorded.
+type Call struct {
+	Operation string
+	Args      []any
+}
+
+// TablesClient implements tables.TablesAPI. Each operation calls the function field of the same name with the Func suffix,
+// an operation whose function is not set returns an error.
+type TablesClient struct {
+	mu    sync.Mutex
+	calls []Call
+
+	CreateNamespaceFunc                        func(ctx context.Context, request *tables.CreateNamespaceRequest, optFns ...func(*oss.Options)) (*tables.CreateNamespaceResult, error)
+	CreateTableFunc                            func(ctx context.Context, request *tables.CreateTableRequest, optFns ...func(*oss.Options)) (*tables.CreateTableResult, error)
+	CreateTableBucketFunc                      func(ctx context.Context, request *tables.CreateTableBucketRequest, optFns ...func(*oss.Options)) (*tables.CreateTableBucketResult, error)
+	DeleteNamespaceFunc                        func(ctx context.Context, request *tables.DeleteNamespaceRequest, optFns ...func(*oss.Options)) (*tables.DeleteNamespaceResult, error)
+	DeleteTableFunc                            func(ctx context.Context, request *tables.DeleteTableRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableResult, error)
+	DeleteTableBucketFunc                      func(ctx context.Context, request *tables.DeleteTableBucketRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketResult, error)
+	DeleteTableBucketEncryptionFunc            func(ctx context.Context, request *tables.DeleteTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketEncryptionResult, error)
+	DeleteTableBucketPolicyFunc                func(ctx context.Context, request *tables.DeleteTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketPolicyResult, error)
+	DeleteTablePolicyFunc                      func(ctx context.Context, request *tables.DeleteTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.DeleteTablePolicyResult, error)
+	GetNamespaceFunc                           func(ctx context.Context, request *tables.GetNamespaceRequest, optFns ...func(*oss.Options)) (*tables.GetNamespaceResult, error)
+	GetTableFunc                               func(ctx context.Context, request *tables.GetTableRequest, optFns ...func(*oss.Options)) (*tables.GetTableResult, error)
+	GetTableBucketFunc                         func(ctx context.Context, request *tables.GetTableBucketRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketResult, error)
+	GetTableBucketEncryptionFunc               func(ctx context.Context, request *tables.GetTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketEncryptionResult, error)
+	GetTableBucketMaintenanceConfigurationFunc func(ctx context.Context, request *tables.GetTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketMaintenanceConfigurationResult, error)
+	GetTableBucketPolicyFunc                   func(ctx context.Context, request *tables.GetTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketPolicyResult, error)
+	GetTableEncryptionFunc                     func(ctx context.Context, request *tables.GetTableEncryptionRequest, optFns ...func(*oss.Options)) (*tables.GetTableEncryptionResult, error)
+	GetTableMaintenanceConfigurationFunc       func(ctx context.Context, request *tables.GetTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.GetTableMaintenanceConfigurationResult, error)
+	GetTableMaintenanceJobStatusFunc           func(ctx context.Context, request *tables.GetTableMaintenanceJobStatusRequest, optFns ...func(*oss.Options)) (*tables.GetTableMaintenanceJobStatusResult, error)
+	GetTableMetadataLocationFunc               func(ctx context.Context, request *tables.GetTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*tables.GetTableMetadataLocationResult, error)
+	GetTablePolicyFunc                         func(ctx context.Context, request *tables.GetTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.GetTablePolicyResult, error)
+	InvokeOperationFunc                        func(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error)
+	ListNamespacesFunc                         func(ctx context.Context, request *tables.ListNamespacesRequest, optFns ...func(*oss.Options)) (*tables.ListNamespacesResult, error)
+	ListTableBucketsFunc                       func(ctx context.Context, request *tables.ListTableBucketsRequest, optFns ...func(*oss.Options)) (*tables.ListTableBucketsResult, error)
+	ListTablesFunc                             func(ctx context.Context, request *tables.ListTablesRequest, optFns ...func(*oss.Options)) (*tables.ListTablesResult, error)
+	PresignFunc                                func(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+	PutTableBucketEncryptionFunc               func(ctx context.Context, request *tables.PutTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketEncryptionResult, error)
+	PutTableBucketMaintenanceConfigurationFunc func(ctx context.Context, request *tables.PutTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketMaintenanceConfigurationResult, error)
+	PutTableBucketPolicyFunc                   func(ctx context.Context, request *tables.PutTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketPolicyResult, error)
+	PutTableMaintenanceConfigurationFunc       func(ctx context.Context, request *tables.PutTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.PutTableMaintenanceConfigurationResult, error)
+	PutTablePolicyFunc                         func(ctx context.Context, request *tables.PutTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.PutTablePolicyResult, error)
+	RenameTableFunc                            func(ctx context.Context, request *tables.RenameTableRequest, optFns ...func(*oss.Options)) (*tables.RenameTableResult, error)
+	UpdateTableMetadataLocationFunc            func(ctx context.Context, request *tables.UpdateTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*tables.UpdateTableMetadataLocationResult, error)
+}
+
+var _ tables.TablesAPI = (*TablesClient)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *TablesClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of the operation in order.
+func (m *TablesClient) CallsOf(operation string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls.
+func (m *TablesClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *TablesClient) record(operation string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Operation: operation, Args: args})
+}
+
+func notImplemented(operation string) error {
+	return fmt.Errorf("tablesmock: %s is not implemented, set %sFunc", operation, operation)
+}
+
+// CreateNamespace records the call and calls CreateNamespaceFunc.
+func (m *TablesClient) CreateNamespace(ctx context.Context, request *tables.CreateNamespaceRequest, optFns ...func(*oss.Options)) (*tables.CreateNamespaceResult, error) {
+	m.record("CreateNamespace", request, optFns)
+	if m.CreateNamespaceFunc == nil {
+		return nil, notImplemented("CreateNamespace")
+	}
+	return m.CreateNamespaceFunc(ctx, request, optFns...)
+}
+
+// CreateTable records the call and calls CreateTableFunc.
+func (m *TablesClient) CreateTable(ctx context.Context, request *tables.CreateTableRequest, optFns ...func(*oss.Options)) (*tables.CreateTableResult, error) {
+	m.record("CreateTable", request, optFns)
+	if m.CreateTableFunc == nil {
+		return nil, notImplemented("CreateTable")
+	}
+	return m.CreateTableFunc(ctx, request, optFns...)
+}
+
+// CreateTableBucket records the call and calls CreateTableBucketFunc.
+func (m *TablesClient) CreateTableBucket(ctx context.Context, request *tables.CreateTableBucketRequest, optFns ...func(*oss.Options)) (*tables.CreateTableBucketResult, error) {
+	m.record("CreateTableBucket", request, optFns)
+	if m.CreateTableBucketFunc == nil {
+		return nil, notImplemented("CreateTableBucket")
+	}
+	return m.CreateTableBucketFunc(ctx, request, optFns...)
+}
+
+// DeleteNamespace records the call and calls DeleteNamespaceFunc.
+func (m *TablesClient) DeleteNamespace(ctx context.Context, request *tables.DeleteNamespaceRequest, optFns ...func(*oss.Options)) (*tables.DeleteNamespaceResult, error) {
+	m.record("DeleteNamespace", request, optFns)
+	if m.DeleteNamespaceFunc == nil {
+		return nil, notImplemented("DeleteNamespace")
+	}
+	return m.DeleteNamespaceFunc(ctx, request, optFns...)
+}
+
+// DeleteTable records the call and calls DeleteTableFunc.
+func (m *TablesClient) DeleteTable(ctx context.Context, request *tables.DeleteTableRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableResult, error) {
+	m.record("DeleteTable", request, optFns)
+	if m.DeleteTableFunc == nil {
+		return nil, notImplemented("DeleteTable")
+	}
+	return m.DeleteTableFunc(ctx, request, optFns...)
+}
+
+// DeleteTableBucket records the call and calls DeleteTableBucketFunc.
+func (m *TablesClient) DeleteTableBucket(ctx context.Context, request *tables.DeleteTableBucketRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketResult, error) {
+	m.record("DeleteTableBucket", request, optFns)
+	if m.DeleteTableBucketFunc == nil {
+		return nil, notImplemented("DeleteTableBucket")
+	}
+	return m.DeleteTableBucketFunc(ctx, request, optFns...)
+}
+
+// DeleteTableBucketEncryption records the call and calls DeleteTableBucketEncryptionFunc.
+func (m *TablesClient) DeleteTableBucketEncryption(ctx context.Context, request *tables.DeleteTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketEncryptionResult, error) {
+	m.record("DeleteTableBucketEncryption", request, optFns)
+	if m.DeleteTableBucketEncryptionFunc == nil {
+		return nil, notImplemented("DeleteTableBucketEncryption")
+	}
+	return m.DeleteTableBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// DeleteTableBucketPolicy records the call and calls DeleteTableBucketPolicyFunc.
+func (m *TablesClient) DeleteTableBucketPolicy(ctx context.Context, request *tables.DeleteTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.DeleteTableBucketPolicyResult, error) {
+	m.record("DeleteTableBucketPolicy", request, optFns)
+	if m.DeleteTableBucketPolicyFunc == nil {
+		return nil, notImplemented("DeleteTableBucketPolicy")
+	}
+	return m.DeleteTableBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// DeleteTablePolicy records the call and calls DeleteTablePolicyFunc.
+func (m *TablesClient) DeleteTablePolicy(ctx context.Context, request *tables.DeleteTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.DeleteTablePolicyResult, error) {
+	m.record("DeleteTablePolicy", request, optFns)
+	if m.DeleteTablePolicyFunc == nil {
+		return nil, notImplemented("DeleteTablePolicy")
+	}
+	return m.DeleteTablePolicyFunc(ctx, request, optFns...)
+}
+
+// GetNamespace records the call and calls GetNamespaceFunc.
+func (m *TablesClient) GetNamespace(ctx context.Context, request *tables.GetNamespaceRequest, optFns ...func(*oss.Options)) (*tables.GetNamespaceResult, error) {
+	m.record("GetNamespace", request, optFns)
+	if m.GetNamespaceFunc == nil {
+		return nil, notImplemented("GetNamespace")
+	}
+	return m.GetNamespaceFunc(ctx, request, optFns...)
+}
+
+// GetTable records the call and calls GetTableFunc.
+func (m *TablesClient) GetTable(ctx context.Context, request *tables.GetTableRequest, optFns ...func(*oss.Options)) (*tables.GetTableResult, error) {
+	m.record("GetTable", request, optFns)
+	if m.GetTableFunc == nil {
+		return nil, notImplemented("GetTable")
+	}
+	return m.GetTableFunc(ctx, request, optFns...)
+}
+
+// GetTableBucket records the call and calls GetTableBucketFunc.
+func (m *TablesClient) GetTableBucket(ctx context.Context, request *tables.GetTableBucketRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketResult, error) {
+	m.record("GetTableBucket", request, optFns)
+	if m.GetTableBucketFunc == nil {
+		return nil, notImplemented("GetTableBucket")
+	}
+	return m.GetTableBucketFunc(ctx, request, optFns...)
+}
+
+// GetTableBucketEncryption records the call and calls GetTableBucketEncryptionFunc.
+func (m *TablesClient) GetTableBucketEncryption(ctx context.Context, request *tables.GetTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketEncryptionResult, error) {
+	m.record("GetTableBucketEncryption", request, optFns)
+	if m.GetTableBucketEncryptionFunc == nil {
+		return nil, notImplemented("GetTableBucketEncryption")
+	}
+	return m.GetTableBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// GetTableBucketMaintenanceConfiguration records the call and calls GetTableBucketMaintenanceConfigurationFunc.
+func (m *TablesClient) GetTableBucketMaintenanceConfiguration(ctx context.Context, request *tables.GetTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketMaintenanceConfigurationResult, error) {
+	m.record("GetTableBucketMaintenanceConfiguration", request, optFns)
+	if m.GetTableBucketMaintenanceConfigurationFunc == nil {
+		return nil, notImplemented("GetTableBucketMaintenanceConfiguration")
+	}
+	return m.GetTableBucketMaintenanceConfigurationFunc(ctx, request, optFns...)
+}
+
+// GetTableBucketPolicy records the call and calls GetTableBucketPolicyFunc.
+func (m *TablesClient) GetTableBucketPolicy(ctx context.Context, request *tables.GetTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.GetTableBucketPolicyResult, error) {
+	m.record("GetTableBucketPolicy", request, optFns)
+	if m.GetTableBucketPolicyFunc == nil {
+		return nil, notImplemented("GetTableBucketPolicy")
+	}
+	return m.GetTableBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// GetTableEncryption records the call and calls GetTableEncryptionFunc.
+func (m *TablesClient) GetTableEncryption(ctx context.Context, request *tables.GetTableEncryptionRequest, optFns ...func(*oss.Options)) (*tables.GetTableEncryptionResult, error) {
+	m.record("GetTableEncryption", request, optFns)
+	if m.GetTableEncryptionFunc == nil {
+		return nil, notImplemented("GetTableEncryption")
+	}
+	return m.GetTableEncryptionFunc(ctx, request, optFns...)
+}
+
+// GetTableMaintenanceConfiguration records the call and calls GetTableMaintenanceConfigurationFunc.
+func (m *TablesClient) GetTableMaintenanceConfiguration(ctx context.Context, request *tables.GetTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.GetTableMaintenanceConfigurationResult, error) {
+	m.record("GetTableMaintenanceConfiguration", request, optFns)
+	if m.GetTableMaintenanceConfigurationFunc == nil {
+		return nil, notImplemented("GetTableMaintenanceConfiguration")
+	}
+	return m.GetTableMaintenanceConfigurationFunc(ctx, request, optFns...)
+}
+
+// GetTableMaintenanceJobStatus records the call and calls GetTableMaintenanceJobStatusFunc.
+func (m *TablesClient) GetTableMaintenanceJobStatus(ctx context.Context, request *tables.GetTableMaintenanceJobStatusRequest, optFns ...func(*oss.Options)) (*tables.GetTableMaintenanceJobStatusResult, error) {
+	m.record("GetTableMaintenanceJobStatus", request, optFns)
+	if m.GetTableMaintenanceJobStatusFunc == nil {
+		return nil, notImplemented("GetTableMaintenanceJobStatus")
+	}
+	return m.GetTableMaintenanceJobStatusFunc(ctx, request, optFns...)
+}
+
+// GetTableMetadataLocation records the call and calls GetTableMetadataLocationFunc.
+func (m *TablesClient) GetTableMetadataLocation(ctx context.Context, request *tables.GetTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*tables.GetTableMetadataLocationResult, error) {
+	m.record("GetTableMetadataLocation", request, optFns)
+	if m.GetTableMetadataLocationFunc == nil {
+		return nil, notImplemented("GetTableMetadataLocation")
+	}
+	return m.GetTableMetadataLocationFunc(ctx, request, optFns...)
+}
+
+// GetTablePolicy records the call and calls GetTablePolicyFunc.
+func (m *TablesClient) GetTablePolicy(ctx context.Context, request *tables.GetTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.GetTablePolicyResult, error) {
+	m.record("GetTablePolicy", request, optFns)
+	if m.GetTablePolicyFunc == nil {
+		return nil, notImplemented("GetTablePolicy")
+	}
+	return m.GetTablePolicyFunc(ctx, request, optFns...)
+}
+
+// InvokeOperation records the call and calls InvokeOperationFunc.
+func (m *TablesClient) InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error) {
+	m.record("InvokeOperation", input, optFns)
+	if m.InvokeOperationFunc == nil {
+		return nil, notImplemented("InvokeOperation")
+	}
+	return m.InvokeOperationFunc(ctx, input, optFns...)
+}
+
+// ListNamespaces records the call and calls ListNamespacesFunc.
+func (m *TablesClient) ListNamespaces(ctx context.Context, request *tables.ListNamespacesRequest, optFns ...func(*oss.Options)) (*tables.ListNamespacesResult, error) {
+	m.record("ListNamespaces", request, optFns)
+	if m.ListNamespacesFunc == nil {
+		return nil, notImplemented("ListNamespaces")
+	}
+	return m.ListNamespacesFunc(ctx, request, optFns...)
+}
+
+// ListTableBuckets records the call and calls ListTableBucketsFunc.
+func (m *TablesClient) ListTableBuckets(ctx context.Context, request *tables.ListTableBucketsRequest, optFns ...func(*oss.Options)) (*tables.ListTableBucketsResult, error) {
+	m.record("ListTableBuckets", request, optFns)
+	if m.ListTableBucketsFunc == nil {
+		return nil, notImplemented("ListTableBuckets")
+	}
+	return m.ListTableBucketsFunc(ctx, request, optFns...)
+}
+
+// ListTables records the call and calls ListTablesFunc.
+func (m *TablesClient) ListTables(ctx context.Context, request *tables.ListTablesRequest, optFns ...func(*oss.Options)) (*tables.ListTablesResult, error) {
+	m.record("ListTables", request, optFns)
+	if m.ListTablesFunc == nil {
+		return nil, notImplemented("ListTables")
+	}
+	return m.ListTablesFunc(ctx, request, optFns...)
+}
+
+// Presign records the call and calls PresignFunc.
+func (m *TablesClient) Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error) {
+	m.record("Presign", request, optFns)
+	if m.PresignFunc == nil {
+		return nil, notImplemented("Presign")
+	}
+	return m.PresignFunc(ctx, request, optFns...)
+}
+
+// PutTableBucketEncryption records the call and calls PutTableBucketEncryptionFunc.
+func (m *TablesClient) PutTableBucketEncryption(ctx context.Context, request *tables.PutTableBucketEncryptionRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketEncryptionResult, error) {
+	m.record("PutTableBucketEncryption", request, optFns)
+	if m.PutTableBucketEncryptionFunc == nil {
+		return nil, notImplemented("PutTableBucketEncryption")
+	}
+	return m.PutTableBucketEncryptionFunc(ctx, request, optFns...)
+}
+
+// PutTableBucketMaintenanceConfiguration records the call and calls PutTableBucketMaintenanceConfigurationFunc.
+func (m *TablesClient) PutTableBucketMaintenanceConfiguration(ctx context.Context, request *tables.PutTableBucketMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketMaintenanceConfigurationResult, error) {
+	m.record("PutTableBucketMaintenanceConfiguration", request, optFns)
+	if m.PutTableBucketMaintenanceConfigurationFunc == nil {
+		return nil, notImplemented("PutTableBucketMaintenanceConfiguration")
+	}
+	return m.PutTableBucketMaintenanceConfigurationFunc(ctx, request, optFns...)
+}
+
+// PutTableBucketPolicy records the call and calls PutTableBucketPolicyFunc.
+func (m *TablesClient) PutTableBucketPolicy(ctx context.Context, request *tables.PutTableBucketPolicyRequest, optFns ...func(*oss.Options)) (*tables.PutTableBucketPolicyResult, error) {
+	m.record("PutTableBucketPolicy", request, optFns)
+	if m.PutTableBucketPolicyFunc == nil {
+		return nil, notImplemented("PutTableBucketPolicy")
+	}
+	return m.PutTableBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// PutTableMaintenanceConfiguration records the call and calls PutTableMaintenanceConfigurationFunc.
+func (m *TablesClient) PutTableMaintenanceConfiguration(ctx context.Context, request *tables.PutTableMaintenanceConfigurationRequest, optFns ...func(*oss.Options)) (*tables.PutTableMaintenanceConfigurationResult, error) {
+	m.record("PutTableMaintenanceConfiguration", request, optFns)
+	if m.PutTableMaintenanceConfigurationFunc == nil {
+		return nil, notImplemented("PutTableMaintenanceConfiguration")
+	}
+	return m.PutTableMaintenanceConfigurationFunc(ctx, request, optFns...)
+}
+
+// PutTablePolicy records the call and calls PutTablePolicyFunc.
+func (m *TablesClient) PutTablePolicy(ctx context.Context, request *tables.PutTablePolicyRequest, optFns ...func(*oss.Options)) (*tables.PutTablePolicyResult, error) {
+	m.record("PutTablePolicy", request, optFns)
+	if m.PutTablePolicyFunc == nil {
+		return nil, notImplemented("PutTablePolicy")
+	}
+	return m.PutTablePolicyFunc(ctx, request, optFns...)
+}
+
+// RenameTable records the call and calls RenameTableFunc.
+func (m *TablesClient) RenameTable(ctx context.Context, request *tables.RenameTableRequest, optFns ...func(*oss.Options)) (*tables.RenameTableResult, error) {
+	m.record("RenameTable", request, optFns)
+	if m.RenameTableFunc == nil {
+		return nil, notImplemented("RenameTable")
+	}
+	return m.RenameTableFunc(ctx, request, optFns...)
+}
+
+// UpdateTableMetadataLocation records the call and calls UpdateTableMetadataLocationFunc.
+func (m *TablesClient) UpdateTableMetadataLocation(ctx context.Context, request *tables.UpdateTableMetadataLocationRequest, optFns ...func(*oss.Options)) (*tables.UpdateTableMetadataLocationResult, error) {
+	m.record("UpdateTableMetadataLocation", request, optFns)
+	if m.UpdateTableMetadataLocationFunc == nil {
+		return nil, notImplemented("UpdateTableMetadataLocation")
+	}
+	return m.UpdateTableMetadataLocationFunc(ctx, request, optFns...)
+}
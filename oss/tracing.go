@@ -0,0 +1,118 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SpanAttribute is a key-value pair attached to a span.
+type SpanAttribute struct {
+	Key   string
+	Value any
+}
+
+// Span represents a single traced unit of work.
+type Span interface {
+	// SetAttributes sets the attributes of the span.
+	SetAttributes(attrs ...SpanAttribute)
+
+	// RecordError records the error and marks the span as failed.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// Tracer is a interface for the SDK to create spans with.
+// The SDK creates a span for every operation, and a child span for every http attempt of the operation.
+type Tracer interface {
+	// Start creates a span and a context containing the newly-created span.
+	Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span)
+
+	// Inject writes the trace context of ctx into the http header,
+	// e.g. the W3C traceparent and tracestate headers.
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Attribute keys of the spans created by the SDK
+const (
+	SpanAttrRPCSystem      = "rpc.system"
+	SpanAttrRPCService     = "rpc.service"
+	SpanAttrRPCMethod      = "rpc.method"
+	SpanAttrBucket         = "oss.bucket"
+	SpanAttrKey            = "oss.key"
+	SpanAttrAttempt        = "oss.attempt"
+	SpanAttrRequestID      = "oss.request_id"
+	SpanAttrErrorCode      = "oss.error_code"
+	SpanAttrHTTPMethod     = "http.request.method"
+	SpanAttrHTTPStatusCode = "http.response.status_code"
+	SpanAttrServerAddress  = "server.address"
+)
+
+func tracingMiddlewares(tracer Tracer, product string) []Middleware {
+	return []Middleware{
+		{
+			Name: "TracingOperation",
+			Step: MiddlewareStepInitialize,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				attrs := []SpanAttribute{
+					{Key: SpanAttrRPCSystem, Value: "oss"},
+					{Key: SpanAttrRPCService, Value: product},
+					{Key: SpanAttrRPCMethod, Value: mctx.Input.OpName},
+				}
+				if mctx.Input.Bucket != nil {
+					attrs = append(attrs, SpanAttribute{Key: SpanAttrBucket, Value: *mctx.Input.Bucket})
+				}
+				if mctx.Input.Key != nil {
+					attrs = append(attrs, SpanAttribute{Key: SpanAttrKey, Value: *mctx.Input.Key})
+				}
+				ctx, span := tracer.Start(ctx, fmt.Sprintf("%s.%s", product, mctx.Input.OpName), attrs...)
+				defer span.End()
+
+				err := next(ctx, mctx)
+				endSpan(span, mctx.Response, err)
+				return err
+			},
+		},
+		{
+			Name: "TracingAttempt",
+			Step: MiddlewareStepBeforeSign,
+			Handle: func(ctx context.Context, mctx *MiddlewareContext, next MiddlewareHandler) error {
+				ctx, span := tracer.Start(ctx, mctx.Request.Method,
+					SpanAttribute{Key: SpanAttrHTTPMethod, Value: mctx.Request.Method},
+					SpanAttribute{Key: SpanAttrServerAddress, Value: mctx.Request.URL.Host},
+					SpanAttribute{Key: SpanAttrAttempt, Value: mctx.Attempt},
+				)
+				defer span.End()
+
+				mctx.Request = mctx.Request.WithContext(ctx)
+				tracer.Inject(ctx, mctx.Request.Header)
+
+				err := next(ctx, mctx)
+				endSpan(span, mctx.Response, err)
+				return err
+			},
+		},
+	}
+}
+
+func endSpan(span Span, response *http.Response, err error) {
+	if response != nil {
+		span.SetAttributes(SpanAttribute{Key: SpanAttrHTTPStatusCode, Value: response.StatusCode})
+		if id := response.Header.Get(HeaderOssRequestID); id != "" {
+			span.SetAttributes(SpanAttribute{Key: SpanAttrRequestID, Value: id})
+		}
+	}
+
+	if err == nil {
+		return
+	}
+
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		span.SetAttributes(SpanAttribute{Key: SpanAttrErrorCode, Value: serr.Code})
+	}
+	span.RecordError(err)
+}
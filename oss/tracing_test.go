@@ -0,0 +1,155 @@
+package oss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubSpanKey struct{}
+
+type stubSpan struct {
+	id     int
+	parent int
+	name   string
+	attrs  map[string]any
+	err    error
+	ended  bool
+}
+
+func (s *stubSpan) SetAttributes(attrs ...SpanAttribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *stubSpan) RecordError(err error) { s.err = err }
+
+func (s *stubSpan) End() { s.ended = true }
+
+type stubTracer struct {
+	mu    sync.Mutex
+	spans []*stubSpan
+}
+
+func (t *stubTracer) Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &stubSpan{id: len(t.spans) + 1, name: name, attrs: map[string]any{}}
+	if parent, ok := ctx.Value(stubSpanKey{}).(*stubSpan); ok {
+		span.parent = parent.id
+	}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, stubSpanKey{}, span), span
+}
+
+func (t *stubTracer) Inject(ctx context.Context, header http.Header) {
+	if span, ok := ctx.Value(stubSpanKey{}).(*stubSpan); ok {
+		header.Set("traceparent", fmt.Sprintf("00-trace-%d-01", span.id))
+	}
+}
+
+func TestTracing_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "00-trace-2-01", r.Header.Get("traceparent"))
+		w.Header().Set("x-oss-request-id", "id-1234")
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	tracer := &stubTracer{}
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithTracer(tracer)
+
+	client := NewClient(cfg)
+	_, err := client.HeadObject(context.TODO(), &HeadObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, tracer.spans, 2)
+
+	op := tracer.spans[0]
+	assert.Equal(t, "oss.HeadObject", op.name)
+	assert.Equal(t, 0, op.parent)
+	assert.True(t, op.ended)
+	assert.Nil(t, op.err)
+	assert.Equal(t, "oss", op.attrs[SpanAttrRPCSystem])
+	assert.Equal(t, "HeadObject", op.attrs[SpanAttrRPCMethod])
+	assert.Equal(t, "bucket", op.attrs[SpanAttrBucket])
+	assert.Equal(t, "key", op.attrs[SpanAttrKey])
+	assert.Equal(t, 200, op.attrs[SpanAttrHTTPStatusCode])
+	assert.Equal(t, "id-1234", op.attrs[SpanAttrRequestID])
+
+	attempt := tracer.spans[1]
+	assert.Equal(t, "HEAD", attempt.name)
+	assert.Equal(t, op.id, attempt.parent)
+	assert.True(t, attempt.ended)
+	assert.Equal(t, 1, attempt.attrs[SpanAttrAttempt])
+	assert.Equal(t, 200, attempt.attrs[SpanAttrHTTPStatusCode])
+	assert.Equal(t, "id-1234", attempt.attrs[SpanAttrRequestID])
+}
+
+func TestTracing_Retry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		assert.Equal(t, fmt.Sprintf("00-trace-%d-01", n+1), r.Header.Get("traceparent"))
+		w.Header().Set("x-oss-request-id", fmt.Sprintf("id-%d", n))
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(503)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+			<Error>
+				<Code>ServiceUnavailable</Code>
+				<Message>Please reduce your request rate.</Message>
+			</Error>`))
+	}))
+	defer server.Close()
+
+	tracer := &stubTracer{}
+	cfg := LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithRetryMaxAttempts(2).
+		WithRetryer(retry.NewStandard(func(ro *retry.RetryOptions) {
+			ro.Backoff = retry.NewFixedDelayBackoff(0)
+		})).
+		WithTracer(tracer)
+
+	client := NewClient(cfg)
+	_, err := client.GetObject(context.TODO(), &GetObjectRequest{
+		Bucket: Ptr("bucket"),
+		Key:    Ptr("key"),
+	})
+	assert.NotNil(t, err)
+	assert.Len(t, tracer.spans, 3)
+
+	op := tracer.spans[0]
+	assert.Equal(t, "oss.GetObject", op.name)
+	assert.NotNil(t, op.err)
+	assert.Equal(t, "ServiceUnavailable", op.attrs[SpanAttrErrorCode])
+	assert.Equal(t, 503, op.attrs[SpanAttrHTTPStatusCode])
+	assert.Equal(t, "id-2", op.attrs[SpanAttrRequestID])
+
+	for i, attempt := range tracer.spans[1:] {
+		assert.Equal(t, op.id, attempt.parent)
+		assert.True(t, attempt.ended)
+		assert.NotNil(t, attempt.err)
+		assert.Equal(t, i+1, attempt.attrs[SpanAttrAttempt])
+		assert.Equal(t, "ServiceUnavailable", attempt.attrs[SpanAttrErrorCode])
+		assert.Equal(t, fmt.Sprintf("id-%d", i+1), attempt.attrs[SpanAttrRequestID])
+	}
+}
@@ -0,0 +1,102 @@
+package oss
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// transferBudget limits the number of requests in flight, which is shared by the transfers of a directory.
+// A nil budget is unlimited.
+type transferBudget chan struct{}
+
+func newTransferBudget(n int) transferBudget {
+	return make(transferBudget, n)
+}
+
+func (b transferBudget) acquire(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	select {
+	case b <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b transferBudget) release() {
+	if b != nil {
+		<-b
+	}
+}
+
+// checkPatterns returns an error if any of the patterns is malformed.
+func checkPatterns(patterns []string, field string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return NewErrParamInvalid(field)
+		}
+	}
+	return nil
+}
+
+// matchPatterns reports whether the relative path, which uses "/" as the separator, matches any of the patterns.
+// A pattern with a "/" matches the whole path, otherwise it matches the base name.
+func matchPatterns(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		name := relPath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(relPath)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isIncluded reports whether the relative path is selected by the include and exclude patterns.
+func isIncluded(include, exclude []string, relPath string) bool {
+	if matchPatterns(exclude, relPath) {
+		return false
+	}
+	return len(include) == 0 || matchPatterns(include, relPath)
+}
+
+// walkLocalFiles walks the files in the local directory and its sub directories which are selected by the patterns,
+// in the lexical order. fn is called with the path of each file and its path relative to the directory,
+// which uses "/" as the separator, or with the error of the file. The excluded sub directories are skipped,
+// and the symbolic links to files are walked while the ones to directories are not followed.
+func walkLocalFiles(localDir string, include, exclude []string, fn func(filePath, relPath string, err error)) error {
+	return filepath.WalkDir(localDir, func(filePath string, d fs.DirEntry, err error) error {
+		rel, _ := filepath.Rel(localDir, filePath)
+		rel = filepath.ToSlash(rel)
+		if err != nil {
+			if filePath == localDir {
+				return err
+			}
+			fn(filePath, rel, err)
+			return nil
+		}
+		if d.IsDir() {
+			if filePath != localDir && matchPatterns(exclude, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			if info, err := os.Stat(filePath); err != nil || !info.Mode().IsRegular() {
+				return nil
+			}
+		}
+		if isIncluded(include, exclude, rel) {
+			fn(filePath, rel, nil)
+		}
+		return nil
+	})
+}
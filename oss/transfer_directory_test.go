@@ -0,0 +1,339 @@
+package oss
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+// testObjectStore is an in-memory bucket for the tests of the directory transfers, its keys are "bucket/key".
+type testObjectStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	metadata map[string]http.Header
+	parts    map[string]map[int][]byte
+	modTimes map[string]time.Time
+	failKeys map[string]bool
+	requests map[string]int
+
+	inFlight    int32
+	maxInFlight int32
+}
+
+func newTestObjectStore() *testObjectStore {
+	return &testObjectStore{
+		objects:  map[string][]byte{},
+		metadata: map[string]http.Header{},
+		parts:    map[string]map[int][]byte{},
+		modTimes: map[string]time.Time{},
+		failKeys: map[string]bool{},
+		requests: map[string]int{},
+	}
+}
+
+func (s *testObjectStore) put(key string, data []byte, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	s.modTimes[key] = time.Now().UTC()
+	meta := http.Header{}
+	for k, v := range header {
+		if strings.HasPrefix(strings.ToLower(k), "x-oss-meta-") {
+			meta[k] = v
+		}
+	}
+	s.metadata[key] = meta
+}
+
+func (s *testObjectStore) get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	return data, ok
+}
+
+func (s *testObjectStore) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	delete(s.metadata, key)
+	delete(s.modTimes, key)
+}
+
+func (s *testObjectStore) modTime(key string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.modTimes[key]
+}
+
+func (s *testObjectStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *testObjectStore) count(op string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[op]
+}
+
+func testWriteObjectHeaders(w http.ResponseWriter, data []byte) {
+	hash := NewCRC64(0)
+	hash.Write(data)
+	sum := md5.Sum(data)
+	w.Header().Set(HeaderOssCRC64, fmt.Sprint(hash.Sum64()))
+	w.Header().Set(HTTPHeaderETag, fmt.Sprintf("\"%s\"", strings.ToUpper(hex.EncodeToString(sum[:]))))
+}
+
+func testSetupObjectStoreServer(t *testing.T, store *testObjectStore) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&store.inFlight, 1)
+		defer atomic.AddInt32(&store.inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&store.maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&store.maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		query := r.URL.Query()
+		op := r.Method
+		switch {
+		case query.Has("uploads"):
+			op = "InitiateMultipartUpload"
+		case query.Has("uploadId") && r.Method == "PUT":
+			op = "UploadPart"
+		case query.Has("uploadId") && r.Method == "POST":
+			op = "CompleteMultipartUpload"
+		case query.Has("uploadId") && r.Method == "DELETE":
+			op = "AbortMultipartUpload"
+		case query.Has("delete") && r.Method == "POST":
+			op = "DeleteMultipleObjects"
+		}
+		store.mu.Lock()
+		store.requests[op]++
+		fail := store.failKeys[key]
+		store.mu.Unlock()
+
+		if fail && r.Method != "HEAD" {
+			w.Header().Set(HTTPHeaderContentType, "application/xml")
+			w.WriteHeader(403)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>denied</Message><RequestId>id</RequestId></Error>`))
+			return
+		}
+
+		switch op {
+		case "HEAD":
+			data, ok := store.get(key)
+			if !ok {
+				w.WriteHeader(404)
+				return
+			}
+			store.mu.Lock()
+			for k, v := range store.metadata[key] {
+				w.Header()[k] = v
+			}
+			store.mu.Unlock()
+			testWriteObjectHeaders(w, data)
+			w.Header().Set(HTTPHeaderContentLength, fmt.Sprint(len(data)))
+			w.Header().Set(HTTPHeaderLastModified, store.modTime(key).Format(http.TimeFormat))
+			w.WriteHeader(200)
+		case "PUT":
+			data, err := io.ReadAll(r.Body)
+			assert.Nil(t, err)
+			store.put(key, data, r.Header)
+			testWriteObjectHeaders(w, data)
+			w.WriteHeader(200)
+		case "InitiateMultipartUpload":
+			store.mu.Lock()
+			store.parts[key] = map[int][]byte{}
+			store.metadata[key+"?uploads"] = r.Header.Clone()
+			store.mu.Unlock()
+			w.Header().Set(HTTPHeaderContentType, "application/xml")
+			w.WriteHeader(200)
+			w.Write([]byte(fmt.Sprintf(`<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`, key)))
+		case "UploadPart":
+			data, err := io.ReadAll(r.Body)
+			assert.Nil(t, err)
+			num, _ := strconv.Atoi(query.Get("partNumber"))
+			store.mu.Lock()
+			store.parts[key][num] = data
+			store.mu.Unlock()
+			testWriteObjectHeaders(w, data)
+			w.WriteHeader(200)
+		case "CompleteMultipartUpload":
+			store.mu.Lock()
+			parts := store.parts[key]
+			header := store.metadata[key+"?uploads"]
+			delete(store.parts, key)
+			delete(store.metadata, key+"?uploads")
+			store.mu.Unlock()
+			nums := make([]int, 0, len(parts))
+			for num := range parts {
+				nums = append(nums, num)
+			}
+			sort.Ints(nums)
+			var data []byte
+			for _, num := range nums {
+				data = append(data, parts[num]...)
+			}
+			store.put(key, data, header)
+			testWriteObjectHeaders(w, data)
+			w.Header().Set(HTTPHeaderContentType, "application/xml")
+			w.WriteHeader(200)
+			w.Write([]byte(fmt.Sprintf(`<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><ETag>etag</ETag></CompleteMultipartUploadResult>`, key)))
+		case "AbortMultipartUpload":
+			w.WriteHeader(204)
+		case "DeleteMultipleObjects":
+			var body struct {
+				Objects []struct {
+					Key string `xml:"Key"`
+				} `xml:"Object"`
+			}
+			data, err := io.ReadAll(r.Body)
+			assert.Nil(t, err)
+			assert.Nil(t, xml.Unmarshal(data, &body))
+			var buf strings.Builder
+			buf.WriteString("<DeleteResult>")
+			for _, object := range body.Objects {
+				store.delete(key + object.Key)
+				buf.WriteString(fmt.Sprintf("<Deleted><Key>%s</Key></Deleted>", object.Key))
+			}
+			buf.WriteString("</DeleteResult>")
+			w.Header().Set(HTTPHeaderContentType, "application/xml")
+			w.WriteHeader(200)
+			w.Write([]byte(buf.String()))
+		case "GET":
+			if query.Has("list-type") {
+				testListObjectsV2(w, store, query)
+				return
+			}
+			data, ok := store.get(key)
+			if !ok {
+				w.Header().Set(HTTPHeaderContentType, "application/xml")
+				w.WriteHeader(404)
+				w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>not found</Message><RequestId>id</RequestId></Error>`))
+				return
+			}
+			testWriteObjectHeaders(w, data)
+			status := 200
+			if httpRange, err := ParseRange(r.Header.Get("Range")); err == nil && httpRange != nil {
+				end := int64(len(data))
+				if httpRange.Count > 0 && httpRange.Offset+httpRange.Count < end {
+					end = httpRange.Offset + httpRange.Count
+				}
+				w.Header().Set(HTTPHeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", httpRange.Offset, end-1, len(data)))
+				data = data[httpRange.Offset:end]
+				status = 206
+			}
+			w.Header().Set(HTTPHeaderContentLength, fmt.Sprint(len(data)))
+			w.WriteHeader(status)
+			w.Write(data)
+		default:
+			assert.Fail(t, "not support", r.Method+" "+r.URL.String())
+		}
+	}))
+}
+
+// testListObjectsV2 lists the objects of the bucket, 2 objects a page.
+func testListObjectsV2(w http.ResponseWriter, store *testObjectStore, query url.Values) {
+	var buf strings.Builder
+	buf.WriteString("<ListBucketResult><Name>bucket</Name>")
+	prefix := "bucket/" + query.Get("prefix")
+	token := query.Get("continuation-token")
+	count := 0
+	truncated := false
+	for _, key := range store.keys() {
+		if !strings.HasPrefix(key, prefix) || (token != "" && key < token) {
+			continue
+		}
+		if count == 2 {
+			truncated = true
+			buf.WriteString(fmt.Sprintf("<NextContinuationToken>%s</NextContinuationToken>", key))
+			break
+		}
+		data, _ := store.get(key)
+		buf.WriteString(fmt.Sprintf("<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified></Contents>",
+			strings.TrimPrefix(key, "bucket/"), len(data), store.modTime(key).Format("2006-01-02T15:04:05.000Z")))
+		count++
+	}
+	buf.WriteString(fmt.Sprintf("<IsTruncated>%v</IsTruncated></ListBucketResult>", truncated))
+	w.Header().Set(HTTPHeaderContentType, "application/xml")
+	w.WriteHeader(200)
+	w.Write([]byte(buf.String()))
+}
+
+func testNewObjectStoreClient(server *httptest.Server) *Client {
+	return NewClient(LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewAnonymousCredentialsProvider()).
+		WithRegion("cn-hangzhou").
+		WithEndpoint(server.URL).
+		WithUsePathStyle(true).
+		WithReadWriteTimeout(300 * time.Second))
+}
+
+func testCreateFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		assert.Nil(t, os.WriteFile(filePath, []byte(content), 0644))
+	}
+}
+
+func TestMatchPatterns(t *testing.T) {
+	assert.True(t, matchPatterns([]string{"*.txt"}, "a.txt"))
+	assert.True(t, matchPatterns([]string{"*.txt"}, "sub/dir/a.txt"))
+	assert.False(t, matchPatterns([]string{"*.txt"}, "a.jpg"))
+	assert.True(t, matchPatterns([]string{"sub/*"}, "sub/a.jpg"))
+	assert.False(t, matchPatterns([]string{"sub/*"}, "sub/dir/a.jpg"))
+	assert.False(t, matchPatterns([]string{"sub/*"}, "other/sub/a.jpg"))
+	assert.False(t, matchPatterns(nil, "a.txt"))
+
+	assert.True(t, isIncluded(nil, nil, "a.txt"))
+	assert.True(t, isIncluded([]string{"*.txt"}, nil, "a.txt"))
+	assert.False(t, isIncluded([]string{"*.txt"}, []string{"a.*"}, "a.txt"))
+	assert.False(t, isIncluded([]string{"*.jpg"}, nil, "a.txt"))
+
+	assert.Nil(t, checkPatterns([]string{"*.txt", "sub/?.jpg"}, "Include"))
+	assert.Contains(t, checkPatterns([]string{"["}, "Include").Error(), "invalid field, Include")
+}
+
+func TestTransferBudget(t *testing.T) {
+	var budget transferBudget
+	assert.Nil(t, budget.acquire(context.TODO()))
+	budget.release()
+
+	budget = newTransferBudget(1)
+	assert.Nil(t, budget.acquire(context.TODO()))
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, budget.acquire(ctx))
+	budget.release()
+	assert.Nil(t, budget.acquire(context.TODO()))
+}
@@ -0,0 +1,60 @@
+package oss_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/osstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferManagers_Server(t *testing.T) {
+	srv, client := newTestServer(t, func(o *osstest.Options) { o.MinPartSize = oss.MinPartSize })
+	ctx := context.TODO()
+	data := randomData(350 * 1024)
+
+	uploader := client.NewUploader(func(uo *oss.UploaderOptions) { uo.PartSize = oss.MinPartSize })
+	uploadResult, err := uploader.UploadFrom(ctx, &oss.PutObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("uploaded")}, bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, oss.ToString(uploadResult.UploadId))
+	stored, _ := srv.Object(testBucket, "uploaded")
+	assert.Equal(t, data, stored)
+
+	copier := client.NewCopier(func(co *oss.CopierOptions) {
+		co.PartSize = oss.MinPartSize
+		co.MultipartCopyThreshold = oss.MinPartSize
+	})
+	_, err = copier.Copy(ctx, &oss.CopyObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("copied"), SourceKey: oss.Ptr("uploaded")})
+	assert.Nil(t, err)
+	stored, _ = srv.Object(testBucket, "copied")
+	assert.Equal(t, data, stored)
+
+	filePath := filepath.Join(t.TempDir(), "downloaded")
+	downloader := client.NewDownloader(func(do *oss.DownloaderOptions) { do.PartSize = 64 * 1024 })
+	downloadResult, err := downloader.DownloadFile(ctx, &oss.GetObjectRequest{Bucket: oss.Ptr(testBucket), Key: oss.Ptr("copied")}, filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(data)), downloadResult.Written)
+	downloaded, err := os.ReadFile(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, data, downloaded)
+
+	for _, prefetch := range []bool{false, true} {
+		f, err := client.OpenFile(ctx, testBucket, "copied", func(oo *oss.OpenOptions) {
+			oo.EnablePrefetch = prefetch
+			oo.ChunkSize = 32 * 1024
+			oo.PrefetchThreshold = 0
+		})
+		assert.Nil(t, err)
+		_, err = f.Seek(100*1024, io.SeekStart)
+		assert.Nil(t, err)
+		got, err := io.ReadAll(f)
+		assert.Nil(t, err)
+		assert.Equal(t, data[100*1024:], got)
+		assert.Nil(t, f.Close())
+	}
+}
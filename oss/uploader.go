@@ -110,11 +110,16 @@ func (u *Uploader) UploadFrom(ctx context.Context, request *PutObjectRequest, bo
 }
 
 func (u *Uploader) UploadFile(ctx context.Context, request *PutObjectRequest, filePath string, optFns ...func(*UploaderOptions)) (*UploadResult, error) {
+	return u.uploadFile(ctx, request, filePath, nil, optFns...)
+}
+
+func (u *Uploader) uploadFile(ctx context.Context, request *PutObjectRequest, filePath string, budget transferBudget, optFns ...func(*UploaderOptions)) (*UploadResult, error) {
 	// Uploader wrapper
 	delegate, err := u.newDelegate(ctx, request, optFns...)
 	if err != nil {
 		return nil, err
 	}
+	delegate.budget = budget
 
 	// Source
 	if err = delegate.checkSource(filePath); err != nil {
@@ -170,6 +175,9 @@ type uploaderDelegate struct {
 	partPool byteSlicePool
 
 	checkpoint *uploadCheckpoint
+
+	// the requests in flight shared with other uploads, nil if unlimited
+	budget transferBudget
 }
 
 type uploadIdInfo struct {
@@ -419,7 +427,11 @@ func (u *uploaderDelegate) singlePart() (*UploadResult, error) {
 		request.ContentType = u.getContentType()
 	}
 
+	if err := u.budget.acquire(u.context); err != nil {
+		return nil, u.wrapErr("", err)
+	}
 	result, err := u.client.PutObject(u.context, request, u.options.ClientOptions...)
+	u.budget.release()
 
 	if err != nil {
 		return nil, u.wrapErr("", err)
@@ -573,8 +585,7 @@ func (u *uploaderDelegate) multiPart() (*UploadResult, error) {
 			}
 
 			if getErrFn() == nil {
-				upResult, err := u.client.UploadPart(
-					u.context,
+				upResult, err := u.uploadPart(
 					&UploadPartRequest{
 						Bucket:              u.request.Bucket,
 						Key:                 u.request.Key,
@@ -583,8 +594,7 @@ func (u *uploaderDelegate) multiPart() (*UploadResult, error) {
 						Body:                data.body,
 						CSEMultiPartContext: uploadIdInfo.cseContext,
 						RequestPayer:        u.request.RequestPayer,
-					},
-					u.options.ClientOptions...)
+					})
 				//fmt.Printf("UploadPart result: %#v, %#v\n", upResult, err)
 
 				if err == nil {
@@ -699,6 +709,15 @@ func (u *uploaderDelegate) multiPart() (*UploadResult, error) {
 	}, nil
 }
 
+// uploadPart uploads a part within the budget of the requests in flight.
+func (u *uploaderDelegate) uploadPart(request *UploadPartRequest) (*UploadPartResult, error) {
+	if err := u.budget.acquire(u.context); err != nil {
+		return nil, err
+	}
+	defer u.budget.release()
+	return u.client.UploadPart(u.context, request, u.options.ClientOptions...)
+}
+
 func (u *uploaderDelegate) getUploadId() (info uploadIdInfo, err error) {
 	if u.uploadId != "" {
 		return uploadIdInfo{
@@ -0,0 +1,225 @@
+package oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type UploadDirectoryOptions struct {
+	// The patterns of the files to upload, in the syntax of path.Match, such as "*.jpg" or "logs/*.log".
+	// A pattern with a "/" matches the path relative to the directory, which uses "/" as the separator,
+	// otherwise it matches the base name. All the files are uploaded by default.
+	Include []string
+
+	// The patterns of the files and the sub directories not to upload, they take precedence over Include.
+	Exclude []string
+
+	// KeyFn maps the relative path of a file to the key of its object.
+	// The default is the prefix followed by the relative path.
+	KeyFn func(relPath string) string
+
+	// The maximum number of requests in flight, which is shared by the files and their parts.
+	// The default is the ParallelNum of the uploader.
+	ParallelNum int
+
+	// Specifies whether to skip the files whose objects exist with the same size and CRC-64.
+	SkipUnchanged bool
+
+	// RequestFn customizes the request of each file, such as its metadata, ACL or ProgressFn.
+	RequestFn func(request *PutObjectRequest, filePath string)
+
+	// The options of the uploads of the files.
+	UploaderOptions []func(*UploaderOptions)
+}
+
+type UploadDirectoryFileResult struct {
+	// The local path of the file.
+	Path string
+
+	// The key of the object.
+	Key string
+
+	// Specifies whether the file is skipped because its object is unchanged.
+	Skipped bool
+
+	// The result of the upload, nil if the file is skipped or fails.
+	Result *UploadResult
+
+	// The error of the file, nil if it succeeds.
+	Err error
+}
+
+type UploadDirectoryResult struct {
+	// The results of the files, in the lexical order of their paths.
+	Files []UploadDirectoryFileResult
+
+	// The number of the files which are uploaded, skipped and failed.
+	Uploaded int
+	Skipped  int
+	Failed   int
+}
+
+// UploadDirectory uploads the files in the local directory and its sub directories to the objects under the prefix.
+// The files are uploaded in parallel, and the failures of the files are reported in the result
+// instead of stopping the others. The returned error is only for the arguments and the directory itself.
+func (u *Uploader) UploadDirectory(ctx context.Context, bucket, prefix, localDir string, optFns ...func(*UploadDirectoryOptions)) (*UploadDirectoryResult, error) {
+	options := UploadDirectoryOptions{}
+	for _, fn := range optFns {
+		fn(&options)
+	}
+	if bucket == "" {
+		return nil, NewErrParamRequired("bucket")
+	}
+	if localDir == "" {
+		return nil, NewErrParamRequired("localDir")
+	}
+	if err := checkPatterns(options.Include, "options.Include"); err != nil {
+		return nil, err
+	}
+	if err := checkPatterns(options.Exclude, "options.Exclude"); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(localDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%v is not a directory", localDir)
+	}
+
+	uploaderOptions := u.options
+	for _, fn := range options.UploaderOptions {
+		fn(&uploaderOptions)
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = uploaderOptions.ParallelNum
+	}
+	if options.ParallelNum <= 0 {
+		options.ParallelNum = DefaultUploadParallel
+	}
+	keyFn := options.KeyFn
+	if keyFn == nil {
+		keyFn = func(relPath string) string { return prefix + relPath }
+	}
+
+	result := &UploadDirectoryResult{}
+	err = walkLocalFiles(localDir, options.Include, options.Exclude, func(filePath, relPath string, err error) {
+		if err != nil {
+			result.Files = append(result.Files, UploadDirectoryFileResult{Path: filePath, Err: err})
+			return
+		}
+		result.Files = append(result.Files, UploadDirectoryFileResult{Path: filePath, Key: keyFn(relPath)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	budget := newTransferBudget(options.ParallelNum)
+	files := make(chan *UploadDirectoryFileResult)
+	var wg sync.WaitGroup
+	for i := 0; i < options.ParallelNum; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range files {
+				u.uploadDirectoryFile(ctx, bucket, file, &options, &uploaderOptions, budget)
+			}
+		}()
+	}
+	for i := range result.Files {
+		if result.Files[i].Err == nil {
+			files <- &result.Files[i]
+		}
+	}
+	close(files)
+	wg.Wait()
+
+	for _, file := range result.Files {
+		switch {
+		case file.Err != nil:
+			result.Failed++
+		case file.Skipped:
+			result.Skipped++
+		default:
+			result.Uploaded++
+		}
+	}
+	return result, nil
+}
+
+func (u *Uploader) uploadDirectoryFile(ctx context.Context, bucket string, file *UploadDirectoryFileResult,
+	options *UploadDirectoryOptions, uploaderOptions *UploaderOptions, budget transferBudget) {
+	if file.Err = ctx.Err(); file.Err != nil {
+		return
+	}
+	if options.SkipUnchanged {
+		file.Skipped, file.Err = u.isFileUnchanged(ctx, bucket, file, uploaderOptions.ClientOptions, budget)
+		if file.Skipped || file.Err != nil {
+			return
+		}
+	}
+	request := &PutObjectRequest{
+		Bucket: Ptr(bucket),
+		Key:    Ptr(file.Key),
+	}
+	if options.RequestFn != nil {
+		options.RequestFn(request, file.Path)
+	}
+	file.Result, file.Err = u.uploadFile(ctx, request, file.Path, budget, options.UploaderOptions...)
+}
+
+// isFileUnchanged reports whether the object of the file exists with the same size and CRC-64.
+func (u *Uploader) isFileUnchanged(ctx context.Context, bucket string, file *UploadDirectoryFileResult,
+	clientOptions []func(*Options), budget transferBudget) (bool, error) {
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return false, err
+	}
+	if err = budget.acquire(ctx); err != nil {
+		return false, err
+	}
+	head, err := u.client.HeadObject(ctx, &HeadObjectRequest{Bucket: Ptr(bucket), Key: Ptr(file.Key)}, clientOptions...)
+	budget.release()
+	if err != nil {
+		if isNoSuchKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if head.ContentLength != info.Size() || head.HashCRC64 == nil {
+		return false, nil
+	}
+	crc, err := fileCRC64(file.Path)
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprint(crc) == ToString(head.HashCRC64), nil
+}
+
+// isNoSuchKeyError reports whether the error is that the object does not exist.
+func isNoSuchKeyError(err error) bool {
+	var serr *ServiceError
+	if errors.As(err, &serr) {
+		return serr.Code == "NoSuchKey" ||
+			// error code not in response header
+			(serr.StatusCode == 404 && serr.Code == "BadErrorResponse")
+	}
+	return false
+}
+
+func fileCRC64(filePath string) (uint64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	hash := NewCRC64(0)
+	if _, err = io.Copy(hash, file); err != nil {
+		return 0, err
+	}
+	return hash.Sum64(), nil
+}
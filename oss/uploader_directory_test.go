@@ -0,0 +1,133 @@
+package oss
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadDirectory(t *testing.T) {
+	store := newTestObjectStore()
+	server := testSetupObjectStoreServer(t, store)
+	defer server.Close()
+	client := testNewObjectStoreClient(server)
+
+	dir := t.TempDir()
+	big := strings.Repeat("0123456789", 30*1024)
+	testCreateFiles(t, dir, map[string]string{
+		"a.txt":          "hello a",
+		"b.jpg":          "hello b",
+		"big.bin":        big,
+		"sub/c.txt":      "hello c",
+		"sub/d.log":      "hello d",
+		"skip/e.txt":     "hello e",
+		"sub/skip/f.txt": "hello f",
+	})
+
+	uploader := client.NewUploader(func(uo *UploaderOptions) {
+		uo.PartSize = 100 * 1024
+	})
+	var requests int32
+	result, err := uploader.UploadDirectory(context.TODO(), "bucket", "backup/", dir, func(o *UploadDirectoryOptions) {
+		o.Include = []string{"*.txt", "*.bin", "sub/*"}
+		o.Exclude = []string{"skip"}
+		o.ParallelNum = 2
+		o.RequestFn = func(request *PutObjectRequest, filePath string) {
+			atomic.AddInt32(&requests, 1)
+			request.Metadata = map[string]string{"name": filepath.Base(filePath)}
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, result.Uploaded)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, int32(4), requests)
+	assert.Len(t, result.Files, 4)
+	assert.Equal(t, filepath.Join(dir, "a.txt"), result.Files[0].Path)
+	assert.Equal(t, "backup/a.txt", result.Files[0].Key)
+	assert.NotNil(t, result.Files[0].Result)
+	assert.Equal(t, []string{"bucket/backup/a.txt", "bucket/backup/big.bin", "bucket/backup/sub/c.txt", "bucket/backup/sub/d.log"}, store.keys())
+	data, _ := store.get("bucket/backup/big.bin")
+	assert.Equal(t, big, string(data))
+	assert.Equal(t, 3, store.count("UploadPart"))
+	assert.Equal(t, "big.bin", store.metadata["bucket/backup/big.bin"].Get("x-oss-meta-name"))
+	// the budget is shared by the files and the parts
+	assert.LessOrEqual(t, atomic.LoadInt32(&store.maxInFlight), int32(2))
+
+	// skip the unchanged files
+	testCreateFiles(t, dir, map[string]string{"sub/c.txt": "hello c, changed"})
+	puts := store.count("PUT")
+	result, err = uploader.UploadDirectory(context.TODO(), "bucket", "backup/", dir, func(o *UploadDirectoryOptions) {
+		o.Include = []string{"*.txt", "*.bin", "sub/*"}
+		o.Exclude = []string{"skip"}
+		o.SkipUnchanged = true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Uploaded)
+	assert.Equal(t, 3, result.Skipped)
+	assert.True(t, result.Files[0].Skipped)
+	assert.Nil(t, result.Files[0].Result)
+	assert.False(t, result.Files[2].Skipped)
+	assert.Equal(t, puts+1, store.count("PUT"))
+	data, _ = store.get("bucket/backup/sub/c.txt")
+	assert.Equal(t, "hello c, changed", string(data))
+
+	// the failures do not stop the others
+	store.failKeys["bucket/keys/a.txt"] = true
+	result, err = uploader.UploadDirectory(context.TODO(), "bucket", "", dir, func(o *UploadDirectoryOptions) {
+		o.Include = []string{"a.txt", "b.jpg"}
+		o.KeyFn = func(relPath string) string { return "keys/" + relPath }
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Uploaded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "keys/a.txt", result.Files[0].Key)
+	var serr *ServiceError
+	assert.ErrorAs(t, result.Files[0].Err, &serr)
+	assert.Equal(t, "AccessDenied", serr.Code)
+	assert.Nil(t, result.Files[1].Err)
+	_, ok := store.get("bucket/keys/b.jpg")
+	assert.True(t, ok)
+
+	// canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	result, err = uploader.UploadDirectory(ctx, "bucket", "canceled/", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, result.Failed)
+	assert.ErrorIs(t, result.Files[0].Err, context.Canceled)
+}
+
+func TestUploadDirectory_ArgumentCheck(t *testing.T) {
+	uploader := NewUploader(&Client{})
+	dir := t.TempDir()
+
+	_, err := uploader.UploadDirectory(context.TODO(), "", "", dir)
+	assert.Contains(t, err.Error(), "missing required field, bucket")
+
+	_, err = uploader.UploadDirectory(context.TODO(), "bucket", "", "")
+	assert.Contains(t, err.Error(), "missing required field, localDir")
+
+	_, err = uploader.UploadDirectory(context.TODO(), "bucket", "", dir, func(o *UploadDirectoryOptions) {
+		o.Exclude = []string{"["}
+	})
+	assert.Contains(t, err.Error(), "invalid field, options.Exclude")
+
+	_, err = uploader.UploadDirectory(context.TODO(), "bucket", "", filepath.Join(dir, "none"))
+	assert.True(t, os.IsNotExist(err))
+
+	filePath := filepath.Join(dir, "file")
+	assert.Nil(t, os.WriteFile(filePath, []byte("data"), 0644))
+	_, err = uploader.UploadDirectory(context.TODO(), "bucket", "", filePath)
+	assert.Contains(t, err.Error(), "is not a directory")
+
+	// empty directory
+	result, err := uploader.UploadDirectory(context.TODO(), "bucket", "", t.TempDir())
+	assert.Nil(t, err)
+	assert.Empty(t, result.Files)
+}
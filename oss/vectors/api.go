@@ -0,0 +1,77 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package vectors
+
+import (
+	"context"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+)
+
+// VectorsAPI lists the operations of VectorsClient, so that callers can depend on an interface,
+// decorate it or replace it with a mock in tests.
+type VectorsAPI interface {
+	// DeleteBucketLogging Disables the logging feature for a vector bucket.
+	DeleteBucketLogging(ctx context.Context, request *DeleteBucketLoggingRequest, optFns ...func(*oss.Options)) (*DeleteBucketLoggingResult, error)
+
+	// DeleteBucketPolicy Deletes a policy for a vector bucket.
+	DeleteBucketPolicy(ctx context.Context, request *DeleteBucketPolicyRequest, optFns ...func(*oss.Options)) (*DeleteBucketPolicyResult, error)
+
+	// DeleteVectorBucket Deletes a vector bucket.
+	DeleteVectorBucket(ctx context.Context, request *DeleteVectorBucketRequest, optFns ...func(*oss.Options)) (*DeleteVectorBucketResult, error)
+
+	// DeleteVectorIndex Deletes a vector index.
+	DeleteVectorIndex(ctx context.Context, request *DeleteVectorIndexRequest, optFns ...func(*oss.Options)) (*DeleteVectorIndexResult, error)
+
+	// DeleteVectors Deletes a vector.
+	DeleteVectors(ctx context.Context, request *DeleteVectorsRequest, optFns ...func(*oss.Options)) (*DeleteVectorsResult, error)
+
+	// GetBucketLogging Queries the configurations of access log collection of a vector bucket.
+	GetBucketLogging(ctx context.Context, request *GetBucketLoggingRequest, optFns ...func(*oss.Options)) (*GetBucketLoggingResult, error)
+
+	// GetBucketPolicy Queries the policies configured for a vector bucket.
+	GetBucketPolicy(ctx context.Context, request *GetBucketPolicyRequest, optFns ...func(*oss.Options)) (*GetBucketPolicyResult, error)
+
+	// GetVectorBucket Queries information about a bucket.
+	GetVectorBucket(ctx context.Context, request *GetVectorBucketRequest, optFns ...func(*oss.Options)) (*GetVectorBucketResult, error)
+
+	// GetVectorIndex Get a vector Index.
+	GetVectorIndex(ctx context.Context, request *GetVectorIndexRequest, optFns ...func(*oss.Options)) (*GetVectorIndexResult, error)
+
+	// GetVectors Get a vector.
+	GetVectors(ctx context.Context, request *GetVectorsRequest, optFns ...func(*oss.Options)) (*GetVectorsResult, error)
+
+	InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error)
+
+	// ListVectorBuckets Lists vector buckets that belong to the current account.
+	ListVectorBuckets(ctx context.Context, request *ListVectorBucketsRequest, optFns ...func(*oss.Options)) (*ListVectorBucketsResult, error)
+
+	// ListVectorIndexes Lists vector indexes that belong to the current account.
+	ListVectorIndexes(ctx context.Context, request *ListVectorIndexesRequest, optFns ...func(*oss.Options)) (*ListVectorIndexesResult, error)
+
+	// ListVectors Lists vectors that belong to the current account.
+	ListVectors(ctx context.Context, request *ListVectorsRequest, optFns ...func(*oss.Options)) (*ListVectorsResult, error)
+
+	// Presign generates the pre-signed URL of the request of an operation, such as *GetVectorBucketRequest.
+	Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+
+	// PutBucketLogging Enables logging for a vector bucket.
+	PutBucketLogging(ctx context.Context, request *PutBucketLoggingRequest, optFns ...func(*oss.Options)) (*PutBucketLoggingResult, error)
+
+	// PutBucketPolicy Configures a policy for a vector bucket.
+	PutBucketPolicy(ctx context.Context, request *PutBucketPolicyRequest, optFns ...func(*oss.Options)) (*PutBucketPolicyResult, error)
+
+	// PutVectorBucket Creates a vector bucket.
+	PutVectorBucket(ctx context.Context, request *PutVectorBucketRequest, optFns ...func(*oss.Options)) (*PutVectorBucketResult, error)
+
+	// PutVectorIndex Creates a vector Index.
+	PutVectorIndex(ctx context.Context, request *PutVectorIndexRequest, optFns ...func(*oss.Options)) (*PutVectorIndexResult, error)
+
+	// PutVectors Creates a vector.
+	PutVectors(ctx context.Context, request *PutVectorsRequest, optFns ...func(*oss.Options)) (*PutVectorsResult, error)
+
+	// QueryVectors Query a vector.
+	QueryVectors(ctx context.Context, request *QueryVectorsRequest, optFns ...func(*oss.Options)) (*QueryVectorsResult, error)
+}
+
+var _ VectorsAPI = (*VectorsClient)(nil)
@@ -12,6 +12,8 @@ import (
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/signer"
 )
 
+//go:generate go run ../internal/apigen -type VectorsClient -interface VectorsAPI -mock vectorsmock
+
 type VectorsClient struct {
 	clientImpl *oss.Client
 }
@@ -2,12 +2,14 @@ package vectors
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/credentials"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -725,3 +727,25 @@ func TestResolveVectorsUserAgent(t *testing.T) {
 	updateUserAgent(cfg)
 	assert.Equal(t, oss.ToString(cfg.UserAgent), "vectors-client/my-user-agent")
 }
+
+func TestPresign(t *testing.T) {
+	client := NewVectorsClient(oss.LoadDefaultConfig().
+		WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ak", "sk")).
+		WithRegion("cn-beijing").
+		WithAccountId("1234567890"))
+
+	result, err := client.Presign(context.TODO(), &GetVectorBucketRequest{
+		Bucket: oss.Ptr("demo-bucket"),
+	}, oss.PresignExpires(10*time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "GET", result.Method)
+	assert.Contains(t, result.URL, "https://demo-bucket-1234567890.cn-beijing.oss-vectors.aliyuncs.com/?")
+	assert.Contains(t, result.URL, "bucketInfo")
+	assert.Contains(t, result.URL, "x-oss-signature-version=OSS4-HMAC-SHA256")
+	assert.Contains(t, result.URL, "x-oss-signature=")
+	assert.NotEmpty(t, result.Expiration)
+
+	_, err = client.Presign(context.TODO(), &GetVectorBucketResult{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "request *vectors.GetVectorBucketResult")
+}
@@ -0,0 +1,276 @@
+// Code generated by apigen. DO NOT EDIT.
+
+// Package vectorsmock provides a mock implementation of vectors.VectorsAPI which records the calls.
+package vectorsmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss"
+	"github.com/aliyun/alibabacloud-oss-go-sdk-v2/oss/vectors"
+)
+
+// Call is a call of an operation, the context is not recorded.
+type Call struct {
+	Operation string
+	Args      []any
+}
+
+// VectorsClient implements vectors.VectorsAPI. Each operation calls the function field of the same name with the Func suffix,
+// an operation whose function is not set returns an error.
+type VectorsClient struct {
+	mu    sync.Mutex
+	calls []Call
+
+	DeleteBucketLoggingFunc func(ctx context.Context, request *vectors.DeleteBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.DeleteBucketLoggingResult, error)
+	DeleteBucketPolicyFunc  func(ctx context.Context, request *vectors.DeleteBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.DeleteBucketPolicyResult, error)
+	DeleteVectorBucketFunc  func(ctx context.Context, request *vectors.DeleteVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorBucketResult, error)
+	DeleteVectorIndexFunc   func(ctx context.Context, request *vectors.DeleteVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorIndexResult, error)
+	DeleteVectorsFunc       func(ctx context.Context, request *vectors.DeleteVectorsRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorsResult, error)
+	GetBucketLoggingFunc    func(ctx context.Context, request *vectors.GetBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.GetBucketLoggingResult, error)
+	GetBucketPolicyFunc     func(ctx context.Context, request *vectors.GetBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.GetBucketPolicyResult, error)
+	GetVectorBucketFunc     func(ctx context.Context, request *vectors.GetVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorBucketResult, error)
+	GetVectorIndexFunc      func(ctx context.Context, request *vectors.GetVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorIndexResult, error)
+	GetVectorsFunc          func(ctx context.Context, request *vectors.GetVectorsRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorsResult, error)
+	InvokeOperationFunc     func(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error)
+	ListVectorBucketsFunc   func(ctx context.Context, request *vectors.ListVectorBucketsRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorBucketsResult, error)
+	ListVectorIndexesFunc   func(ctx context.Context, request *vectors.ListVectorIndexesRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorIndexesResult, error)
+	ListVectorsFunc         func(ctx context.Context, request *vectors.ListVectorsRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorsResult, error)
+	PresignFunc             func(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error)
+	PutBucketLoggingFunc    func(ctx context.Context, request *vectors.PutBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.PutBucketLoggingResult, error)
+	PutBucketPolicyFunc     func(ctx context.Context, request *vectors.PutBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.PutBucketPolicyResult, error)
+	PutVectorBucketFunc     func(ctx context.Context, request *vectors.PutVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorBucketResult, error)
+	PutVectorIndexFunc      func(ctx context.Context, request *vectors.PutVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorIndexResult, error)
+	PutVectorsFunc          func(ctx context.Context, request *vectors.PutVectorsRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorsResult, error)
+	QueryVectorsFunc        func(ctx context.Context, request *vectors.QueryVectorsRequest, optFns ...func(*oss.Options)) (*vectors.QueryVectorsResult, error)
+}
+
+var _ vectors.VectorsAPI = (*VectorsClient)(nil)
+
+// Calls returns the recorded calls in order.
+func (m *VectorsClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsOf returns the recorded calls of the operation in order.
+func (m *VectorsClient) CallsOf(operation string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset clears the recorded calls.
+func (m *VectorsClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *VectorsClient) record(operation string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Operation: operation, Args: args})
+}
+
+func notImplemented(operation string) error {
+	return fmt.Errorf("vectorsmock: %s is not implemented, set %sFunc", operation, operation)
+}
+
+// DeleteBucketLogging records the call and calls DeleteBucketLoggingFunc.
+func (m *VectorsClient) DeleteBucketLogging(ctx context.Context, request *vectors.DeleteBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.DeleteBucketLoggingResult, error) {
+	m.record("DeleteBucketLogging", request, optFns)
+	if m.DeleteBucketLoggingFunc == nil {
+		return nil, notImplemented("DeleteBucketLogging")
+	}
+	return m.DeleteBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// DeleteBucketPolicy records the call and calls DeleteBucketPolicyFunc.
+func (m *VectorsClient) DeleteBucketPolicy(ctx context.Context, request *vectors.DeleteBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.DeleteBucketPolicyResult, error) {
+	m.record("DeleteBucketPolicy", request, optFns)
+	if m.DeleteBucketPolicyFunc == nil {
+		return nil, notImplemented("DeleteBucketPolicy")
+	}
+	return m.DeleteBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// DeleteVectorBucket records the call and calls DeleteVectorBucketFunc.
+func (m *VectorsClient) DeleteVectorBucket(ctx context.Context, request *vectors.DeleteVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorBucketResult, error) {
+	m.record("DeleteVectorBucket", request, optFns)
+	if m.DeleteVectorBucketFunc == nil {
+		return nil, notImplemented("DeleteVectorBucket")
+	}
+	return m.DeleteVectorBucketFunc(ctx, request, optFns...)
+}
+
+// DeleteVectorIndex records the call and calls DeleteVectorIndexFunc.
+func (m *VectorsClient) DeleteVectorIndex(ctx context.Context, request *vectors.DeleteVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorIndexResult, error) {
+	m.record("DeleteVectorIndex", request, optFns)
+	if m.DeleteVectorIndexFunc == nil {
+		return nil, notImplemented("DeleteVectorIndex")
+	}
+	return m.DeleteVectorIndexFunc(ctx, request, optFns...)
+}
+
+// DeleteVectors records the call and calls DeleteVectorsFunc.
+func (m *VectorsClient) DeleteVectors(ctx context.Context, request *vectors.DeleteVectorsRequest, optFns ...func(*oss.Options)) (*vectors.DeleteVectorsResult, error) {
+	m.record("DeleteVectors", request, optFns)
+	if m.DeleteVectorsFunc == nil {
+		return nil, notImplemented("DeleteVectors")
+	}
+	return m.DeleteVectorsFunc(ctx, request, optFns...)
+}
+
+// GetBucketLogging records the call and calls GetBucketLoggingFunc.
+func (m *VectorsClient) GetBucketLogging(ctx context.Context, request *vectors.GetBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.GetBucketLoggingResult, error) {
+	m.record("GetBucketLogging", request, optFns)
+	if m.GetBucketLoggingFunc == nil {
+		return nil, notImplemented("GetBucketLogging")
+	}
+	return m.GetBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// GetBucketPolicy records the call and calls GetBucketPolicyFunc.
+func (m *VectorsClient) GetBucketPolicy(ctx context.Context, request *vectors.GetBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.GetBucketPolicyResult, error) {
+	m.record("GetBucketPolicy", request, optFns)
+	if m.GetBucketPolicyFunc == nil {
+		return nil, notImplemented("GetBucketPolicy")
+	}
+	return m.GetBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// GetVectorBucket records the call and calls GetVectorBucketFunc.
+func (m *VectorsClient) GetVectorBucket(ctx context.Context, request *vectors.GetVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorBucketResult, error) {
+	m.record("GetVectorBucket", request, optFns)
+	if m.GetVectorBucketFunc == nil {
+		return nil, notImplemented("GetVectorBucket")
+	}
+	return m.GetVectorBucketFunc(ctx, request, optFns...)
+}
+
+// GetVectorIndex records the call and calls GetVectorIndexFunc.
+func (m *VectorsClient) GetVectorIndex(ctx context.Context, request *vectors.GetVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorIndexResult, error) {
+	m.record("GetVectorIndex", request, optFns)
+	if m.GetVectorIndexFunc == nil {
+		return nil, notImplemented("GetVectorIndex")
+	}
+	return m.GetVectorIndexFunc(ctx, request, optFns...)
+}
+
+// GetVectors records the call and calls GetVectorsFunc.
+func (m *VectorsClient) GetVectors(ctx context.Context, request *vectors.GetVectorsRequest, optFns ...func(*oss.Options)) (*vectors.GetVectorsResult, error) {
+	m.record("GetVectors", request, optFns)
+	if m.GetVectorsFunc == nil {
+		return nil, notImplemented("GetVectors")
+	}
+	return m.GetVectorsFunc(ctx, request, optFns...)
+}
+
+// InvokeOperation records the call and calls InvokeOperationFunc.
+func (m *VectorsClient) InvokeOperation(ctx context.Context, input *oss.OperationInput, optFns ...func(*oss.Options)) (*oss.OperationOutput, error) {
+	m.record("InvokeOperation", input, optFns)
+	if m.InvokeOperationFunc == nil {
+		return nil, notImplemented("InvokeOperation")
+	}
+	return m.InvokeOperationFunc(ctx, input, optFns...)
+}
+
+// ListVectorBuckets records the call and calls ListVectorBucketsFunc.
+func (m *VectorsClient) ListVectorBuckets(ctx context.Context, request *vectors.ListVectorBucketsRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorBucketsResult, error) {
+	m.record("ListVectorBuckets", request, optFns)
+	if m.ListVectorBucketsFunc == nil {
+		return nil, notImplemented("ListVectorBuckets")
+	}
+	return m.ListVectorBucketsFunc(ctx, request, optFns...)
+}
+
+// ListVectorIndexes records the call and calls ListVectorIndexesFunc.
+func (m *VectorsClient) ListVectorIndexes(ctx context.Context, request *vectors.ListVectorIndexesRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorIndexesResult, error) {
+	m.record("ListVectorIndexes", request, optFns)
+	if m.ListVectorIndexesFunc == nil {
+		return nil, notImplemented("ListVectorIndexes")
+	}
+	return m.ListVectorIndexesFunc(ctx, request, optFns...)
+}
+
+// ListVectors records the call and calls ListVectorsFunc.
+func (m *VectorsClient) ListVectors(ctx context.Context, request *vectors.ListVectorsRequest, optFns ...func(*oss.Options)) (*vectors.ListVectorsResult, error) {
+	m.record("ListVectors", request, optFns)
+	if m.ListVectorsFunc == nil {
+		return nil, notImplemented("ListVectors")
+	}
+	return m.ListVectorsFunc(ctx, request, optFns...)
+}
+
+// Presign records the call and calls PresignFunc.
+func (m *VectorsClient) Presign(ctx context.Context, request any, optFns ...func(*oss.PresignOptions)) (*oss.PresignResult, error) {
+	m.record("Presign", request, optFns)
+	if m.PresignFunc == nil {
+		return nil, notImplemented("Presign")
+	}
+	return m.PresignFunc(ctx, request, optFns...)
+}
+
+// PutBucketLogging records the call and calls PutBucketLoggingFunc.
+func (m *VectorsClient) PutBucketLogging(ctx context.Context, request *vectors.PutBucketLoggingRequest, optFns ...func(*oss.Options)) (*vectors.PutBucketLoggingResult, error) {
+	m.record("PutBucketLogging", request, optFns)
+	if m.PutBucketLoggingFunc == nil {
+		return nil, notImplemented("PutBucketLogging")
+	}
+	return m.PutBucketLoggingFunc(ctx, request, optFns...)
+}
+
+// PutBucketPolicy records the call and calls PutBucketPolicyFunc.
+func (m *VectorsClient) PutBucketPolicy(ctx context.Context, request *vectors.PutBucketPolicyRequest, optFns ...func(*oss.Options)) (*vectors.PutBucketPolicyResult, error) {
+	m.record("PutBucketPolicy", request, optFns)
+	if m.PutBucketPolicyFunc == nil {
+		return nil, notImplemented("PutBucketPolicy")
+	}
+	return m.PutBucketPolicyFunc(ctx, request, optFns...)
+}
+
+// PutVectorBucket records the call and calls PutVectorBucketFunc.
+func (m *VectorsClient) PutVectorBucket(ctx context.Context, request *vectors.PutVectorBucketRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorBucketResult, error) {
+	m.record("PutVectorBucket", request, optFns)
+	if m.PutVectorBucketFunc == nil {
+		return nil, notImplemented("PutVectorBucket")
+	}
+	return m.PutVectorBucketFunc(ctx, request, optFns...)
+}
+
+// PutVectorIndex records the call and calls PutVectorIndexFunc.
+func (m *VectorsClient) PutVectorIndex(ctx context.Context, request *vectors.PutVectorIndexRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorIndexResult, error) {
+	m.record("PutVectorIndex", request, optFns)
+	if m.PutVectorIndexFunc == nil {
+		return nil, notImplemented("PutVectorIndex")
+	}
+	return m.PutVectorIndexFunc(ctx, request, optFns...)
+}
+
+// PutVectors records the call and calls PutVectorsFunc.
+func (m *VectorsClient) PutVectors(ctx context.Context, request *vectors.PutVectorsRequest, optFns ...func(*oss.Options)) (*vectors.PutVectorsResult, error) {
+	m.record("PutVectors", request, optFns)
+	if m.PutVectorsFunc == nil {
+		return nil, notImplemented("PutVectors")
+	}
+	return m.PutVectorsFunc(ctx, request, optFns...)
+}
+
+// QueryVectors records the call and calls QueryVectorsFunc.
+func (m *VectorsClient) QueryVectors(ctx context.Context, request *vectors.QueryVectorsRequest, optFns ...func(*oss.Options)) (*vectors.QueryVectorsResult, error) {
+	m.record("QueryVectors", request, optFns)
+	if m.QueryVectorsFunc == nil {
+		return nil, notImplemented("QueryVectors")
+	}
+	return m.QueryVectorsFunc(ctx, request, optFns...)
+}